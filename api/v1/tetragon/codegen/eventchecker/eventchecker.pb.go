@@ -282,8 +282,12 @@ func CheckerFromEvent(event Event) (EventChecker, error) {
 		return NewProcessLoaderChecker("").FromProcessLoader(ev), nil
 	case *tetragon.RateLimitInfo:
 		return NewRateLimitInfoChecker("").FromRateLimitInfo(ev), nil
+	case *tetragon.LostEvents:
+		return NewLostEventsChecker("").FromLostEvents(ev), nil
 	case *tetragon.ProcessThrottle:
 		return NewProcessThrottleChecker("").FromProcessThrottle(ev), nil
+	case *tetragon.ProcessUpdate:
+		return NewProcessUpdateChecker("").FromProcessUpdate(ev), nil
 
 	default:
 		return nil, fmt.Errorf("Unhandled event type %T", event)
@@ -346,8 +350,12 @@ func EventFromResponse(response *tetragon.GetEventsResponse) (Event, error) {
 		return ev.ProcessLoader, nil
 	case *tetragon.GetEventsResponse_RateLimitInfo:
 		return ev.RateLimitInfo, nil
+	case *tetragon.GetEventsResponse_LostEvents:
+		return ev.LostEvents, nil
 	case *tetragon.GetEventsResponse_ProcessThrottle:
 		return ev.ProcessThrottle, nil
+	case *tetragon.GetEventsResponse_ProcessUpdate:
+		return ev.ProcessUpdate, nil
 
 	default:
 		return nil, fmt.Errorf("Unknown event type %T", response.Event)
@@ -2328,6 +2336,130 @@ func (checker *RateLimitInfoChecker) FromRateLimitInfo(event *tetragon.RateLimit
 	return checker
 }
 
+// LostEventsChecker implements a checker struct to check a LostEvents event
+type LostEventsChecker struct {
+	CheckerName string  `json:"checkerName"`
+	RingBuffer  *uint64 `json:"ringBuffer,omitempty"`
+	EventCache  *uint64 `json:"eventCache,omitempty"`
+	RateLimit   *uint64 `json:"rateLimit,omitempty"`
+	SlowClient  *uint64 `json:"slowClient,omitempty"`
+}
+
+// CheckEvent checks a single event and implements the EventChecker interface
+func (checker *LostEventsChecker) CheckEvent(event Event) error {
+	if ev, ok := event.(*tetragon.LostEvents); ok {
+		return checker.Check(ev)
+	}
+	return fmt.Errorf("%s: %T is not a LostEvents event", CheckerLogPrefix(checker), event)
+}
+
+// CheckResponse checks a single gRPC response and implements the EventChecker interface
+func (checker *LostEventsChecker) CheckResponse(response *tetragon.GetEventsResponse) error {
+	event, err := EventFromResponse(response)
+	if err != nil {
+		return err
+	}
+	return checker.CheckEvent(event)
+}
+
+// NewLostEventsChecker creates a new LostEventsChecker
+func NewLostEventsChecker(name string) *LostEventsChecker {
+	return &LostEventsChecker{CheckerName: name}
+}
+
+// Get the name associated with the checker
+func (checker *LostEventsChecker) GetCheckerName() string {
+	return checker.CheckerName
+}
+
+// Get the type of the checker as a string
+func (checker *LostEventsChecker) GetCheckerType() string {
+	return "LostEventsChecker"
+}
+
+// Check checks a LostEvents event
+func (checker *LostEventsChecker) Check(event *tetragon.LostEvents) error {
+	if event == nil {
+		return fmt.Errorf("%s: LostEvents event is nil", CheckerLogPrefix(checker))
+	}
+
+	fieldChecks := func() error {
+		if checker.RingBuffer != nil {
+			if *checker.RingBuffer != event.RingBuffer {
+				return fmt.Errorf("RingBuffer has value %d which does not match expected value %d", event.RingBuffer, *checker.RingBuffer)
+			}
+		}
+		if checker.EventCache != nil {
+			if *checker.EventCache != event.EventCache {
+				return fmt.Errorf("EventCache has value %d which does not match expected value %d", event.EventCache, *checker.EventCache)
+			}
+		}
+		if checker.RateLimit != nil {
+			if *checker.RateLimit != event.RateLimit {
+				return fmt.Errorf("RateLimit has value %d which does not match expected value %d", event.RateLimit, *checker.RateLimit)
+			}
+		}
+		if checker.SlowClient != nil {
+			if *checker.SlowClient != event.SlowClient {
+				return fmt.Errorf("SlowClient has value %d which does not match expected value %d", event.SlowClient, *checker.SlowClient)
+			}
+		}
+		return nil
+	}
+	if err := fieldChecks(); err != nil {
+		return fmt.Errorf("%s: %w", CheckerLogPrefix(checker), err)
+	}
+	return nil
+}
+
+// WithRingBuffer adds a RingBuffer check to the LostEventsChecker
+func (checker *LostEventsChecker) WithRingBuffer(check uint64) *LostEventsChecker {
+	checker.RingBuffer = &check
+	return checker
+}
+
+// WithEventCache adds a EventCache check to the LostEventsChecker
+func (checker *LostEventsChecker) WithEventCache(check uint64) *LostEventsChecker {
+	checker.EventCache = &check
+	return checker
+}
+
+// WithRateLimit adds a RateLimit check to the LostEventsChecker
+func (checker *LostEventsChecker) WithRateLimit(check uint64) *LostEventsChecker {
+	checker.RateLimit = &check
+	return checker
+}
+
+// WithSlowClient adds a SlowClient check to the LostEventsChecker
+func (checker *LostEventsChecker) WithSlowClient(check uint64) *LostEventsChecker {
+	checker.SlowClient = &check
+	return checker
+}
+
+//FromLostEvents populates the LostEventsChecker using data from a LostEvents event
+func (checker *LostEventsChecker) FromLostEvents(event *tetragon.LostEvents) *LostEventsChecker {
+	if event == nil {
+		return checker
+	}
+	{
+		val := event.RingBuffer
+		checker.RingBuffer = &val
+	}
+	{
+		val := event.EventCache
+		checker.EventCache = &val
+	}
+	{
+		val := event.RateLimit
+		checker.RateLimit = &val
+	}
+	{
+		val := event.SlowClient
+		checker.SlowClient = &val
+	}
+	return checker
+}
+
 // ProcessThrottleChecker implements a checker struct to check a ProcessThrottle event
 type ProcessThrottleChecker struct {
 	CheckerName string                       `json:"checkerName"`
@@ -2415,6 +2547,96 @@ func (checker *ProcessThrottleChecker) FromProcessThrottle(event *tetragon.Proce
 	return checker
 }
 
+// ProcessUpdateChecker implements a checker struct to check a ProcessUpdate event
+type ProcessUpdateChecker struct {
+	CheckerName string          `json:"checkerName"`
+	Process     *ProcessChecker `json:"process,omitempty"`
+	Parent      *ProcessChecker `json:"parent,omitempty"`
+}
+
+// CheckEvent checks a single event and implements the EventChecker interface
+func (checker *ProcessUpdateChecker) CheckEvent(event Event) error {
+	if ev, ok := event.(*tetragon.ProcessUpdate); ok {
+		return checker.Check(ev)
+	}
+	return fmt.Errorf("%s: %T is not a ProcessUpdate event", CheckerLogPrefix(checker), event)
+}
+
+// CheckResponse checks a single gRPC response and implements the EventChecker interface
+func (checker *ProcessUpdateChecker) CheckResponse(response *tetragon.GetEventsResponse) error {
+	event, err := EventFromResponse(response)
+	if err != nil {
+		return err
+	}
+	return checker.CheckEvent(event)
+}
+
+// NewProcessUpdateChecker creates a new ProcessUpdateChecker
+func NewProcessUpdateChecker(name string) *ProcessUpdateChecker {
+	return &ProcessUpdateChecker{CheckerName: name}
+}
+
+// Get the name associated with the checker
+func (checker *ProcessUpdateChecker) GetCheckerName() string {
+	return checker.CheckerName
+}
+
+// Get the type of the checker as a string
+func (checker *ProcessUpdateChecker) GetCheckerType() string {
+	return "ProcessUpdateChecker"
+}
+
+// Check checks a ProcessUpdate event
+func (checker *ProcessUpdateChecker) Check(event *tetragon.ProcessUpdate) error {
+	if event == nil {
+		return fmt.Errorf("%s: ProcessUpdate event is nil", CheckerLogPrefix(checker))
+	}
+
+	fieldChecks := func() error {
+		if checker.Process != nil {
+			if err := checker.Process.Check(event.Process); err != nil {
+				return fmt.Errorf("Process check failed: %w", err)
+			}
+		}
+		if checker.Parent != nil {
+			if err := checker.Parent.Check(event.Parent); err != nil {
+				return fmt.Errorf("Parent check failed: %w", err)
+			}
+		}
+		return nil
+	}
+	if err := fieldChecks(); err != nil {
+		return fmt.Errorf("%s: %w", CheckerLogPrefix(checker), err)
+	}
+	return nil
+}
+
+// WithProcess adds a Process check to the ProcessUpdateChecker
+func (checker *ProcessUpdateChecker) WithProcess(check *ProcessChecker) *ProcessUpdateChecker {
+	checker.Process = check
+	return checker
+}
+
+// WithParent adds a Parent check to the ProcessUpdateChecker
+func (checker *ProcessUpdateChecker) WithParent(check *ProcessChecker) *ProcessUpdateChecker {
+	checker.Parent = check
+	return checker
+}
+
+//FromProcessUpdate populates the ProcessUpdateChecker using data from a ProcessUpdate event
+func (checker *ProcessUpdateChecker) FromProcessUpdate(event *tetragon.ProcessUpdate) *ProcessUpdateChecker {
+	if event == nil {
+		return checker
+	}
+	if event.Process != nil {
+		checker.Process = NewProcessChecker().FromProcess(event.Process)
+	}
+	if event.Parent != nil {
+		checker.Parent = NewProcessChecker().FromProcess(event.Parent)
+	}
+	return checker
+}
+
 // ImageChecker implements a checker struct to check a Image field
 type ImageChecker struct {
 	Id   *stringmatcher.StringMatcher `json:"id,omitempty"`
@@ -149,7 +149,9 @@ type eventCheckerHelper struct {
 	Test              *eventchecker.TestChecker              `json:"test,omitempty"`
 	ProcessLoader     *eventchecker.ProcessLoaderChecker     `json:"loader,omitempty"`
 	RateLimitInfo     *eventchecker.RateLimitInfoChecker     `json:"rateLimitInfo,omitempty"`
+	LostEvents        *eventchecker.LostEventsChecker        `json:"lostEvents,omitempty"`
 	ProcessThrottle   *eventchecker.ProcessThrottleChecker   `json:"throttle,omitempty"`
+	ProcessUpdate     *eventchecker.ProcessUpdateChecker     `json:"update,omitempty"`
 }
 
 // EventChecker is a wrapper around the EventChecker interface to help unmarshaling
@@ -218,12 +220,24 @@ func (checker *EventChecker) UnmarshalJSON(b []byte) error {
 		}
 		eventChecker = helper.RateLimitInfo
 	}
+	if helper.LostEvents != nil {
+		if eventChecker != nil {
+			return fmt.Errorf("EventChecker: cannot define more than one checker, got %T but already had %T", helper.LostEvents, eventChecker)
+		}
+		eventChecker = helper.LostEvents
+	}
 	if helper.ProcessThrottle != nil {
 		if eventChecker != nil {
 			return fmt.Errorf("EventChecker: cannot define more than one checker, got %T but already had %T", helper.ProcessThrottle, eventChecker)
 		}
 		eventChecker = helper.ProcessThrottle
 	}
+	if helper.ProcessUpdate != nil {
+		if eventChecker != nil {
+			return fmt.Errorf("EventChecker: cannot define more than one checker, got %T but already had %T", helper.ProcessUpdate, eventChecker)
+		}
+		eventChecker = helper.ProcessUpdate
+	}
 	checker.EventChecker = eventChecker
 	return nil
 }
@@ -250,8 +264,12 @@ func (checker EventChecker) MarshalJSON() ([]byte, error) {
 		helper.ProcessLoader = c
 	case *eventchecker.RateLimitInfoChecker:
 		helper.RateLimitInfo = c
+	case *eventchecker.LostEventsChecker:
+		helper.LostEvents = c
 	case *eventchecker.ProcessThrottleChecker:
 		helper.ProcessThrottle = c
+	case *eventchecker.ProcessUpdateChecker:
+		helper.ProcessUpdate = c
 	default:
 		return nil, fmt.Errorf("EventChecker: unknown checker type %T", c)
 	}
@@ -39,10 +39,14 @@ func ResponseTypeString(response *tetragon.GetEventsResponse) (string, error) {
 		return tetragon.EventType_PROCESS_THROTTLE.String(), nil
 	case *tetragon.GetEventsResponse_ProcessLsm:
 		return tetragon.EventType_PROCESS_LSM.String(), nil
+	case *tetragon.GetEventsResponse_ProcessUpdate:
+		return tetragon.EventType_PROCESS_UPDATE.String(), nil
 	case *tetragon.GetEventsResponse_Test:
 		return tetragon.EventType_TEST.String(), nil
 	case *tetragon.GetEventsResponse_RateLimitInfo:
 		return tetragon.EventType_RATE_LIMIT_INFO.String(), nil
+	case *tetragon.GetEventsResponse_LostEvents:
+		return tetragon.EventType_LOST_EVENTS.String(), nil
 
 	}
 	return "", fmt.Errorf("Unhandled response type %T", event)
@@ -79,6 +83,8 @@ func ResponseInnerGetProcess(event tetragon.IsGetEventsResponse_Event) *tetragon
 		return ev.ProcessLsm.Process
 	case *tetragon.GetEventsResponse_ProcessLoader:
 		return ev.ProcessLoader.Process
+	case *tetragon.GetEventsResponse_ProcessUpdate:
+		return ev.ProcessUpdate.Process
 
 	}
 	return nil
@@ -122,6 +128,8 @@ func ResponseInnerGetParent(event tetragon.IsGetEventsResponse_Event) *tetragon.
 		return ev.ProcessUprobe.Parent
 	case *tetragon.GetEventsResponse_ProcessLsm:
 		return ev.ProcessLsm.Parent
+	case *tetragon.GetEventsResponse_ProcessUpdate:
+		return ev.ProcessUpdate.Parent
 
 	}
 	return nil
@@ -173,8 +181,10 @@ func ResponseTypeMap() map[string]proto.Message {
 		"process_uprobe":     &tetragon.ProcessUprobe{},
 		"process_throttle":   &tetragon.ProcessThrottle{},
 		"process_lsm":        &tetragon.ProcessLsm{},
+		"process_update":     &tetragon.ProcessUpdate{},
 		"test":               &tetragon.Test{},
 		"rate_limit_info":    &tetragon.RateLimitInfo{},
+		"lost_events":        &tetragon.LostEvents{},
 	}
 }
 
@@ -198,10 +208,14 @@ func ProcessEventMapTuple(response *tetragon.GetEventsResponse) (string, any, an
 		return "process_throttle", response.GetProcessThrottle(), (*tetragon.ProcessThrottle)(nil)
 	case *tetragon.GetEventsResponse_ProcessLsm:
 		return "process_lsm", response.GetProcessLsm(), (*tetragon.ProcessLsm)(nil)
+	case *tetragon.GetEventsResponse_ProcessUpdate:
+		return "process_update", response.GetProcessUpdate(), (*tetragon.ProcessUpdate)(nil)
 	case *tetragon.GetEventsResponse_Test:
 		return "test", response.GetTest(), (*tetragon.Test)(nil)
 	case *tetragon.GetEventsResponse_RateLimitInfo:
 		return "rate_limit_info", response.GetRateLimitInfo(), (*tetragon.RateLimitInfo)(nil)
+	case *tetragon.GetEventsResponse_LostEvents:
+		return "lost_events", response.GetLostEvents(), (*tetragon.LostEvents)(nil)
 
 	}
 	return "", nil, nil
@@ -218,7 +232,9 @@ func ProcessEventMapEmpty() map[string]any {
 		"process_uprobe":     (*tetragon.ProcessUprobe)(nil),
 		"process_throttle":   (*tetragon.ProcessThrottle)(nil),
 		"process_lsm":        (*tetragon.ProcessLsm)(nil),
+		"process_update":     (*tetragon.ProcessUpdate)(nil),
 		"test":               (*tetragon.Test)(nil),
 		"rate_limit_info":    (*tetragon.RateLimitInfo)(nil),
+		"lost_events":        (*tetragon.LostEvents)(nil),
 	}
 }
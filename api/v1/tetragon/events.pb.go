@@ -14,6 +14,7 @@ import (
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
 	reflect "reflect"
@@ -43,8 +44,10 @@ const (
 	EventType_PROCESS_UPROBE     EventType = 12
 	EventType_PROCESS_THROTTLE   EventType = 27
 	EventType_PROCESS_LSM        EventType = 28
+	EventType_PROCESS_UPDATE     EventType = 29
 	EventType_TEST               EventType = 40000
 	EventType_RATE_LIMIT_INFO    EventType = 40001
+	EventType_LOST_EVENTS        EventType = 40002
 )
 
 // Enum value maps for EventType.
@@ -59,8 +62,10 @@ var (
 		12:    "PROCESS_UPROBE",
 		27:    "PROCESS_THROTTLE",
 		28:    "PROCESS_LSM",
+		29:    "PROCESS_UPDATE",
 		40000: "TEST",
 		40001: "RATE_LIMIT_INFO",
+		40002: "LOST_EVENTS",
 	}
 	EventType_value = map[string]int32{
 		"UNDEF":              0,
@@ -72,8 +77,10 @@ var (
 		"PROCESS_UPROBE":     12,
 		"PROCESS_THROTTLE":   27,
 		"PROCESS_LSM":        28,
+		"PROCESS_UPDATE":     29,
 		"TEST":               40000,
 		"RATE_LIMIT_INFO":    40001,
+		"LOST_EVENTS":        40002,
 	}
 )
 
@@ -151,6 +158,59 @@ func (FieldFilterAction) EnumDescriptor() ([]byte, []int) {
 	return file_tetragon_events_proto_rawDescGZIP(), []int{1}
 }
 
+// SamplingKey selects the field on which the sampling decision is based.
+type SamplingKey int32
+
+const (
+	// Events of the same process (process.exec_id) are sampled together.
+	SamplingKey_SAMPLING_KEY_EXEC_ID SamplingKey = 0
+	// Events of the same pod (namespace and name) are sampled together.
+	SamplingKey_SAMPLING_KEY_POD SamplingKey = 1
+	// Events of the same binary (process.binary) are sampled together.
+	SamplingKey_SAMPLING_KEY_BINARY SamplingKey = 2
+)
+
+// Enum value maps for SamplingKey.
+var (
+	SamplingKey_name = map[int32]string{
+		0: "SAMPLING_KEY_EXEC_ID",
+		1: "SAMPLING_KEY_POD",
+		2: "SAMPLING_KEY_BINARY",
+	}
+	SamplingKey_value = map[string]int32{
+		"SAMPLING_KEY_EXEC_ID": 0,
+		"SAMPLING_KEY_POD":     1,
+		"SAMPLING_KEY_BINARY":  2,
+	}
+)
+
+func (x SamplingKey) Enum() *SamplingKey {
+	p := new(SamplingKey)
+	*p = x
+	return p
+}
+
+func (x SamplingKey) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SamplingKey) Descriptor() protoreflect.EnumDescriptor {
+	return file_tetragon_events_proto_enumTypes[2].Descriptor()
+}
+
+func (SamplingKey) Type() protoreflect.EnumType {
+	return &file_tetragon_events_proto_enumTypes[2]
+}
+
+func (x SamplingKey) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SamplingKey.Descriptor instead.
+func (SamplingKey) EnumDescriptor() ([]byte, []int) {
+	return file_tetragon_events_proto_rawDescGZIP(), []int{2}
+}
+
 type ThrottleType int32
 
 const (
@@ -184,11 +244,11 @@ func (x ThrottleType) String() string {
 }
 
 func (ThrottleType) Descriptor() protoreflect.EnumDescriptor {
-	return file_tetragon_events_proto_enumTypes[2].Descriptor()
+	return file_tetragon_events_proto_enumTypes[3].Descriptor()
 }
 
 func (ThrottleType) Type() protoreflect.EnumType {
-	return &file_tetragon_events_proto_enumTypes[2]
+	return &file_tetragon_events_proto_enumTypes[3]
 }
 
 func (x ThrottleType) Number() protoreflect.EnumNumber {
@@ -197,7 +257,7 @@ func (x ThrottleType) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ThrottleType.Descriptor instead.
 func (ThrottleType) EnumDescriptor() ([]byte, []int) {
-	return file_tetragon_events_proto_rawDescGZIP(), []int{2}
+	return file_tetragon_events_proto_rawDescGZIP(), []int{3}
 }
 
 type Filter struct {
@@ -690,15 +750,29 @@ type GetEventsRequest struct {
 	DenyList []*Filter `protobuf:"bytes,2,rep,name=deny_list,json=denyList,proto3" json:"deny_list,omitempty"`
 	// aggregation_options configures aggregation options for this request.
 	// If this field is not set, responses will not be aggregated.
-	// Note that currently only process_accept and process_connect events are
-	// aggregated. Other events remain unaggregated.
+	// Note that currently only process_kprobe, process_tracepoint, process_uprobe
+	// and process_lsm events are aggregated. Other events remain unaggregated.
 	AggregationOptions *AggregationOptions `protobuf:"bytes,3,opt,name=aggregation_options,json=aggregationOptions,proto3" json:"aggregation_options,omitempty"`
 	// Fields to include or exclude for events in the GetEventsResponse. Omitting this
 	// field implies that all fields will be included. Exclusion always takes precedence
 	// over inclusion in the case of conflicts.
-	FieldFilters  []*FieldFilter `protobuf:"bytes,4,rep,name=field_filters,json=fieldFilters,proto3" json:"field_filters,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	FieldFilters []*FieldFilter `protobuf:"bytes,4,rep,name=field_filters,json=fieldFilters,proto3" json:"field_filters,omitempty"`
+	// If set, events are read from the event spool, starting with the first
+	// event after the given cursor, instead of being streamed live. Once all
+	// spooled events are sent, new events are sent as they are spooled. A value
+	// of 0 starts with the oldest spooled event. This requires the event spool
+	// to be enabled on the agent.
+	SinceCursor *wrapperspb.UInt64Value `protobuf:"bytes,5,opt,name=since_cursor,json=sinceCursor,proto3" json:"since_cursor,omitempty"`
+	// sampling_options configures deterministic sampling of the events that
+	// match allow_list and deny_list. If this field is not set, events are not
+	// sampled.
+	SamplingOptions *SamplingOptions `protobuf:"bytes,6,opt,name=sampling_options,json=samplingOptions,proto3" json:"sampling_options,omitempty"`
+	// computed_fields are CEL expressions evaluated on the events that match
+	// allow_list and deny_list. Their results are set in the computed_fields of
+	// the responses.
+	ComputedFields []*ComputedField `protobuf:"bytes,7,rep,name=computed_fields,json=computedFields,proto3" json:"computed_fields,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *GetEventsRequest) Reset() {
@@ -759,6 +833,86 @@ func (x *GetEventsRequest) GetFieldFilters() []*FieldFilter {
 	return nil
 }
 
+func (x *GetEventsRequest) GetSinceCursor() *wrapperspb.UInt64Value {
+	if x != nil {
+		return x.SinceCursor
+	}
+	return nil
+}
+
+func (x *GetEventsRequest) GetSamplingOptions() *SamplingOptions {
+	if x != nil {
+		return x.SamplingOptions
+	}
+	return nil
+}
+
+func (x *GetEventsRequest) GetComputedFields() []*ComputedField {
+	if x != nil {
+		return x.ComputedFields
+	}
+	return nil
+}
+
+// ComputedField is a named CEL expression evaluated on events, for example
+// `process_exec.process.binary.endsWith("sh")` named is_shell. Expressions
+// use the same environment as Filter.cel_expression, and must evaluate to a
+// bool, an integer, a double or a string.
+type ComputedField struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the field in GetEventsResponse.computed_fields.
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// CEL expression. If it refers to events of specific types, it is only
+	// evaluated on events of these types.
+	Expression    string `protobuf:"bytes,2,opt,name=expression,proto3" json:"expression,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ComputedField) Reset() {
+	*x = ComputedField{}
+	mi := &file_tetragon_events_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ComputedField) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComputedField) ProtoMessage() {}
+
+func (x *ComputedField) ProtoReflect() protoreflect.Message {
+	mi := &file_tetragon_events_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComputedField.ProtoReflect.Descriptor instead.
+func (*ComputedField) Descriptor() ([]byte, []int) {
+	return file_tetragon_events_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ComputedField) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ComputedField) GetExpression() string {
+	if x != nil {
+		return x.Expression
+	}
+	return ""
+}
+
 // AggregationOptions defines configuration options for aggregating events.
 type AggregationOptions struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -768,13 +922,21 @@ type AggregationOptions struct {
 	// buffer becomes full, the aggregator will log a warning and start dropping
 	// incoming events.
 	ChannelBufferSize uint64 `protobuf:"varint,2,opt,name=channel_buffer_size,json=channelBufferSize,proto3" json:"channel_buffer_size,omitempty"`
-	unknownFields     protoimpl.UnknownFields
-	sizeCache         protoimpl.SizeCache
+	// Event types to aggregate. Supported types are PROCESS_KPROBE,
+	// PROCESS_TRACEPOINT, PROCESS_UPROBE and PROCESS_LSM. If empty, all
+	// supported types are aggregated. Other events remain unaggregated.
+	EventSet []EventType `protobuf:"varint,3,rep,packed,name=event_set,json=eventSet,proto3,enum=tetragon.EventType" json:"event_set,omitempty"`
+	// Fields identifying the events that are aggregated together. If this field
+	// is not set, events are aggregated by policy name, function name, binary
+	// and pod.
+	Key           *AggregationKey `protobuf:"bytes,4,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *AggregationOptions) Reset() {
 	*x = AggregationOptions{}
-	mi := &file_tetragon_events_proto_msgTypes[6]
+	mi := &file_tetragon_events_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -786,7 +948,7 @@ func (x *AggregationOptions) String() string {
 func (*AggregationOptions) ProtoMessage() {}
 
 func (x *AggregationOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_tetragon_events_proto_msgTypes[6]
+	mi := &file_tetragon_events_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -799,7 +961,7 @@ func (x *AggregationOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AggregationOptions.ProtoReflect.Descriptor instead.
 func (*AggregationOptions) Descriptor() ([]byte, []int) {
-	return file_tetragon_events_proto_rawDescGZIP(), []int{6}
+	return file_tetragon_events_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *AggregationOptions) GetWindowSize() *durationpb.Duration {
@@ -816,18 +978,123 @@ func (x *AggregationOptions) GetChannelBufferSize() uint64 {
 	return 0
 }
 
+func (x *AggregationOptions) GetEventSet() []EventType {
+	if x != nil {
+		return x.EventSet
+	}
+	return nil
+}
+
+func (x *AggregationOptions) GetKey() *AggregationKey {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+// AggregationKey selects the fields identifying the events that are aggregated
+// together. Events of different types, or with different actions, are never
+// aggregated together. The aggregated response is the first event observed
+// in the aggregation window.
+type AggregationKey struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the policy that generated the event.
+	PolicyName bool `protobuf:"varint,1,opt,name=policy_name,json=policyName,proto3" json:"policy_name,omitempty"`
+	// Hook that generated the event: the function name for kprobes and LSM
+	// hooks, the subsystem and event for tracepoints, and the path and symbol
+	// for uprobes.
+	FunctionName bool `protobuf:"varint,2,opt,name=function_name,json=functionName,proto3" json:"function_name,omitempty"`
+	// Binary of the process.
+	Binary bool `protobuf:"varint,3,opt,name=binary,proto3" json:"binary,omitempty"`
+	// Namespace and name of the pod.
+	Pod bool `protobuf:"varint,4,opt,name=pod,proto3" json:"pod,omitempty"`
+	// Indices of the event arguments that are part of the key.
+	ArgIndices    []uint32 `protobuf:"varint,5,rep,packed,name=arg_indices,json=argIndices,proto3" json:"arg_indices,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AggregationKey) Reset() {
+	*x = AggregationKey{}
+	mi := &file_tetragon_events_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AggregationKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AggregationKey) ProtoMessage() {}
+
+func (x *AggregationKey) ProtoReflect() protoreflect.Message {
+	mi := &file_tetragon_events_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AggregationKey.ProtoReflect.Descriptor instead.
+func (*AggregationKey) Descriptor() ([]byte, []int) {
+	return file_tetragon_events_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *AggregationKey) GetPolicyName() bool {
+	if x != nil {
+		return x.PolicyName
+	}
+	return false
+}
+
+func (x *AggregationKey) GetFunctionName() bool {
+	if x != nil {
+		return x.FunctionName
+	}
+	return false
+}
+
+func (x *AggregationKey) GetBinary() bool {
+	if x != nil {
+		return x.Binary
+	}
+	return false
+}
+
+func (x *AggregationKey) GetPod() bool {
+	if x != nil {
+		return x.Pod
+	}
+	return false
+}
+
+func (x *AggregationKey) GetArgIndices() []uint32 {
+	if x != nil {
+		return x.ArgIndices
+	}
+	return nil
+}
+
 // AggregationInfo contains information about aggregation results.
 type AggregationInfo struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Total count of events in this aggregation time window.
-	Count         uint64 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	Count uint64 `protobuf:"varint,1,opt,name=count,proto3" json:"count,omitempty"`
+	// Time of the first event in this aggregation time window.
+	FirstSeen *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=first_seen,json=firstSeen,proto3" json:"first_seen,omitempty"`
+	// Time of the last event in this aggregation time window.
+	LastSeen      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *AggregationInfo) Reset() {
 	*x = AggregationInfo{}
-	mi := &file_tetragon_events_proto_msgTypes[7]
+	mi := &file_tetragon_events_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -839,7 +1106,7 @@ func (x *AggregationInfo) String() string {
 func (*AggregationInfo) ProtoMessage() {}
 
 func (x *AggregationInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_tetragon_events_proto_msgTypes[7]
+	mi := &file_tetragon_events_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -852,7 +1119,7 @@ func (x *AggregationInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AggregationInfo.ProtoReflect.Descriptor instead.
 func (*AggregationInfo) Descriptor() ([]byte, []int) {
-	return file_tetragon_events_proto_rawDescGZIP(), []int{7}
+	return file_tetragon_events_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *AggregationInfo) GetCount() uint64 {
@@ -862,6 +1129,148 @@ func (x *AggregationInfo) GetCount() uint64 {
 	return 0
 }
 
+func (x *AggregationInfo) GetFirstSeen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FirstSeen
+	}
+	return nil
+}
+
+func (x *AggregationInfo) GetLastSeen() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastSeen
+	}
+	return nil
+}
+
+// SamplingOptions defines configuration options for sampling events. The
+// sampling decision is a deterministic function of the value of the key, so
+// that all the events with the same value are either kept or dropped, on all
+// nodes. Events without a value for the key (e.g. events of processes that do
+// not run in a pod when sampling by pod) are always kept.
+type SamplingOptions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Keep the events of 1 in every one_in_n values of the key. Exactly one of
+	// one_in_n and percentage must be set.
+	OneInN uint32 `protobuf:"varint,1,opt,name=one_in_n,json=oneInN,proto3" json:"one_in_n,omitempty"`
+	// Percentage of the values of the key whose events are kept, greater than 0
+	// and at most 100.
+	Percentage float64 `protobuf:"fixed64,2,opt,name=percentage,proto3" json:"percentage,omitempty"`
+	// Field on which the sampling decision is based. Defaults to exec_id.
+	Key           SamplingKey `protobuf:"varint,3,opt,name=key,proto3,enum=tetragon.SamplingKey" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SamplingOptions) Reset() {
+	*x = SamplingOptions{}
+	mi := &file_tetragon_events_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SamplingOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SamplingOptions) ProtoMessage() {}
+
+func (x *SamplingOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_tetragon_events_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SamplingOptions.ProtoReflect.Descriptor instead.
+func (*SamplingOptions) Descriptor() ([]byte, []int) {
+	return file_tetragon_events_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SamplingOptions) GetOneInN() uint32 {
+	if x != nil {
+		return x.OneInN
+	}
+	return 0
+}
+
+func (x *SamplingOptions) GetPercentage() float64 {
+	if x != nil {
+		return x.Percentage
+	}
+	return 0
+}
+
+func (x *SamplingOptions) GetKey() SamplingKey {
+	if x != nil {
+		return x.Key
+	}
+	return SamplingKey_SAMPLING_KEY_EXEC_ID
+}
+
+// SamplingInfo records the sampling decision for an event that was kept by
+// sampling.
+type SamplingInfo struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Fraction of the values of the key whose events are kept, e.g. 0.01 when
+	// sampling 1 in 100. When extrapolating counts, each event stands for
+	// 1/rate events.
+	Rate float64 `protobuf:"fixed64,1,opt,name=rate,proto3" json:"rate,omitempty"`
+	// Field on which the sampling decision was based.
+	Key           SamplingKey `protobuf:"varint,2,opt,name=key,proto3,enum=tetragon.SamplingKey" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SamplingInfo) Reset() {
+	*x = SamplingInfo{}
+	mi := &file_tetragon_events_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SamplingInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SamplingInfo) ProtoMessage() {}
+
+func (x *SamplingInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_tetragon_events_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SamplingInfo.ProtoReflect.Descriptor instead.
+func (*SamplingInfo) Descriptor() ([]byte, []int) {
+	return file_tetragon_events_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SamplingInfo) GetRate() float64 {
+	if x != nil {
+		return x.Rate
+	}
+	return 0
+}
+
+func (x *SamplingInfo) GetKey() SamplingKey {
+	if x != nil {
+		return x.Key
+	}
+	return SamplingKey_SAMPLING_KEY_EXEC_ID
+}
+
 type RateLimitInfo struct {
 	state                        protoimpl.MessageState `protogen:"open.v1"`
 	NumberOfDroppedProcessEvents uint64                 `protobuf:"varint,1,opt,name=number_of_dropped_process_events,json=numberOfDroppedProcessEvents,proto3" json:"number_of_dropped_process_events,omitempty"`
@@ -871,7 +1280,7 @@ type RateLimitInfo struct {
 
 func (x *RateLimitInfo) Reset() {
 	*x = RateLimitInfo{}
-	mi := &file_tetragon_events_proto_msgTypes[8]
+	mi := &file_tetragon_events_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -883,7 +1292,7 @@ func (x *RateLimitInfo) String() string {
 func (*RateLimitInfo) ProtoMessage() {}
 
 func (x *RateLimitInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_tetragon_events_proto_msgTypes[8]
+	mi := &file_tetragon_events_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -896,7 +1305,7 @@ func (x *RateLimitInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RateLimitInfo.ProtoReflect.Descriptor instead.
 func (*RateLimitInfo) Descriptor() ([]byte, []int) {
-	return file_tetragon_events_proto_rawDescGZIP(), []int{8}
+	return file_tetragon_events_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *RateLimitInfo) GetNumberOfDroppedProcessEvents() uint64 {
@@ -906,6 +1315,85 @@ func (x *RateLimitInfo) GetNumberOfDroppedProcessEvents() uint64 {
 	return 0
 }
 
+// LostEvents reports the number of events that were lost, by cause, since the
+// previous LostEvents message of the GetEvents stream (or since the start of
+// the stream).
+type LostEvents struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Number of events lost by the perf ring buffer, or dropped because the
+	// ring buffer events queue was full.
+	RingBuffer uint64 `protobuf:"varint,1,opt,name=ring_buffer,json=ringBuffer,proto3" json:"ring_buffer,omitempty"`
+	// Number of events dropped by the event cache after exhausting their
+	// retries, because they cannot be delivered without complete process
+	// information.
+	EventCache uint64 `protobuf:"varint,2,opt,name=event_cache,json=eventCache,proto3" json:"event_cache,omitempty"`
+	// Number of events dropped by the export rate limit.
+	RateLimit uint64 `protobuf:"varint,3,opt,name=rate_limit,json=rateLimit,proto3" json:"rate_limit,omitempty"`
+	// Number of events dropped because the GetEvents client did not read events
+	// fast enough.
+	SlowClient    uint64 `protobuf:"varint,4,opt,name=slow_client,json=slowClient,proto3" json:"slow_client,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LostEvents) Reset() {
+	*x = LostEvents{}
+	mi := &file_tetragon_events_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LostEvents) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LostEvents) ProtoMessage() {}
+
+func (x *LostEvents) ProtoReflect() protoreflect.Message {
+	mi := &file_tetragon_events_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LostEvents.ProtoReflect.Descriptor instead.
+func (*LostEvents) Descriptor() ([]byte, []int) {
+	return file_tetragon_events_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *LostEvents) GetRingBuffer() uint64 {
+	if x != nil {
+		return x.RingBuffer
+	}
+	return 0
+}
+
+func (x *LostEvents) GetEventCache() uint64 {
+	if x != nil {
+		return x.EventCache
+	}
+	return 0
+}
+
+func (x *LostEvents) GetRateLimit() uint64 {
+	if x != nil {
+		return x.RateLimit
+	}
+	return 0
+}
+
+func (x *LostEvents) GetSlowClient() uint64 {
+	if x != nil {
+		return x.SlowClient
+	}
+	return 0
+}
+
 type ProcessThrottle struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Throttle type
@@ -918,7 +1406,7 @@ type ProcessThrottle struct {
 
 func (x *ProcessThrottle) Reset() {
 	*x = ProcessThrottle{}
-	mi := &file_tetragon_events_proto_msgTypes[9]
+	mi := &file_tetragon_events_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -930,7 +1418,7 @@ func (x *ProcessThrottle) String() string {
 func (*ProcessThrottle) ProtoMessage() {}
 
 func (x *ProcessThrottle) ProtoReflect() protoreflect.Message {
-	mi := &file_tetragon_events_proto_msgTypes[9]
+	mi := &file_tetragon_events_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -943,7 +1431,7 @@ func (x *ProcessThrottle) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProcessThrottle.ProtoReflect.Descriptor instead.
 func (*ProcessThrottle) Descriptor() ([]byte, []int) {
-	return file_tetragon_events_proto_rawDescGZIP(), []int{9}
+	return file_tetragon_events_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *ProcessThrottle) GetType() ThrottleType {
@@ -960,6 +1448,64 @@ func (x *ProcessThrottle) GetCgroup() string {
 	return ""
 }
 
+// ProcessUpdate is emitted when the pod information of a process becomes
+// available after events of the process were emitted without it, because the
+// event cache stopped waiting for it. It is only emitted if enabled with the
+// --event-cache-enrichment-updates option.
+type ProcessUpdate struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Process with its pod information.
+	Process *Process `protobuf:"bytes,1,opt,name=process,proto3" json:"process,omitempty"`
+	// Parent of the process, if it is known.
+	Parent        *Process `protobuf:"bytes,2,opt,name=parent,proto3" json:"parent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProcessUpdate) Reset() {
+	*x = ProcessUpdate{}
+	mi := &file_tetragon_events_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProcessUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProcessUpdate) ProtoMessage() {}
+
+func (x *ProcessUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_tetragon_events_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProcessUpdate.ProtoReflect.Descriptor instead.
+func (*ProcessUpdate) Descriptor() ([]byte, []int) {
+	return file_tetragon_events_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ProcessUpdate) GetProcess() *Process {
+	if x != nil {
+		return x.Process
+	}
+	return nil
+}
+
+func (x *ProcessUpdate) GetParent() *Process {
+	if x != nil {
+		return x.Parent
+	}
+	return nil
+}
+
 type GetEventsResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The type-specific fields of an event.
@@ -976,8 +1522,10 @@ type GetEventsResponse struct {
 	//	*GetEventsResponse_ProcessUprobe
 	//	*GetEventsResponse_ProcessThrottle
 	//	*GetEventsResponse_ProcessLsm
+	//	*GetEventsResponse_ProcessUpdate
 	//	*GetEventsResponse_Test
 	//	*GetEventsResponse_RateLimitInfo
+	//	*GetEventsResponse_LostEvents
 	Event isGetEventsResponse_Event `protobuf_oneof:"event"`
 	// Name of the node where this event was observed.
 	NodeName string `protobuf:"bytes,1000,opt,name=node_name,json=nodeName,proto3" json:"node_name,omitempty"`
@@ -991,14 +1539,31 @@ type GetEventsResponse struct {
 	// Name of the cluster where this event was observed.
 	ClusterName string `protobuf:"bytes,1003,opt,name=cluster_name,json=clusterName,proto3" json:"cluster_name,omitempty"`
 	// Labels associated with the node where this event was observed.
-	NodeLabels    map[string]string `protobuf:"bytes,1004,rep,name=node_labels,json=nodeLabels,proto3" json:"node_labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	NodeLabels map[string]string `protobuf:"bytes,1004,rep,name=node_labels,json=nodeLabels,proto3" json:"node_labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Position of this event in the event spool. This field is set only for
+	// events read from the spool (see GetEventsRequest.since_cursor), and can
+	// be used to resume reading after this event.
+	Cursor uint64 `protobuf:"varint,1005,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	// Per-node sequence number of this event. Sequence numbers increase
+	// monotonically in the order events are observed, starting at 1 when the
+	// agent starts. Gaps are caused by events filtered out by the request, or by
+	// lost events, which are reported in lost_events messages.
+	Sequence uint64 `protobuf:"varint,1006,opt,name=sequence,proto3" json:"sequence,omitempty"`
+	// sampling_info contains information about the sampling decision. This
+	// field is set only for events kept by sampling (see
+	// GetEventsRequest.sampling_options).
+	SamplingInfo *SamplingInfo `protobuf:"bytes,1007,opt,name=sampling_info,json=samplingInfo,proto3" json:"sampling_info,omitempty"`
+	// Results of the computed fields of the GetEventsRequest, by name. Fields
+	// whose expression does not apply to the event or fails to evaluate are not
+	// set.
+	ComputedFields map[string]*structpb.Value `protobuf:"bytes,1008,rep,name=computed_fields,json=computedFields,proto3" json:"computed_fields,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *GetEventsResponse) Reset() {
 	*x = GetEventsResponse{}
-	mi := &file_tetragon_events_proto_msgTypes[10]
+	mi := &file_tetragon_events_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1010,7 +1575,7 @@ func (x *GetEventsResponse) String() string {
 func (*GetEventsResponse) ProtoMessage() {}
 
 func (x *GetEventsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_tetragon_events_proto_msgTypes[10]
+	mi := &file_tetragon_events_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1023,7 +1588,7 @@ func (x *GetEventsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetEventsResponse.ProtoReflect.Descriptor instead.
 func (*GetEventsResponse) Descriptor() ([]byte, []int) {
-	return file_tetragon_events_proto_rawDescGZIP(), []int{10}
+	return file_tetragon_events_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *GetEventsResponse) GetEvent() isGetEventsResponse_Event {
@@ -1105,6 +1670,15 @@ func (x *GetEventsResponse) GetProcessLsm() *ProcessLsm {
 	return nil
 }
 
+func (x *GetEventsResponse) GetProcessUpdate() *ProcessUpdate {
+	if x != nil {
+		if x, ok := x.Event.(*GetEventsResponse_ProcessUpdate); ok {
+			return x.ProcessUpdate
+		}
+	}
+	return nil
+}
+
 func (x *GetEventsResponse) GetTest() *Test {
 	if x != nil {
 		if x, ok := x.Event.(*GetEventsResponse_Test); ok {
@@ -1123,6 +1697,15 @@ func (x *GetEventsResponse) GetRateLimitInfo() *RateLimitInfo {
 	return nil
 }
 
+func (x *GetEventsResponse) GetLostEvents() *LostEvents {
+	if x != nil {
+		if x, ok := x.Event.(*GetEventsResponse_LostEvents); ok {
+			return x.LostEvents
+		}
+	}
+	return nil
+}
+
 func (x *GetEventsResponse) GetNodeName() string {
 	if x != nil {
 		return x.NodeName
@@ -1158,6 +1741,34 @@ func (x *GetEventsResponse) GetNodeLabels() map[string]string {
 	return nil
 }
 
+func (x *GetEventsResponse) GetCursor() uint64 {
+	if x != nil {
+		return x.Cursor
+	}
+	return 0
+}
+
+func (x *GetEventsResponse) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *GetEventsResponse) GetSamplingInfo() *SamplingInfo {
+	if x != nil {
+		return x.SamplingInfo
+	}
+	return nil
+}
+
+func (x *GetEventsResponse) GetComputedFields() map[string]*structpb.Value {
+	if x != nil {
+		return x.ComputedFields
+	}
+	return nil
+}
+
 type isGetEventsResponse_Event interface {
 	isGetEventsResponse_Event()
 }
@@ -1201,6 +1812,10 @@ type GetEventsResponse_ProcessLsm struct {
 	ProcessLsm *ProcessLsm `protobuf:"bytes,28,opt,name=process_lsm,json=processLsm,proto3,oneof"`
 }
 
+type GetEventsResponse_ProcessUpdate struct {
+	ProcessUpdate *ProcessUpdate `protobuf:"bytes,29,opt,name=process_update,json=processUpdate,proto3,oneof"`
+}
+
 type GetEventsResponse_Test struct {
 	Test *Test `protobuf:"bytes,40000,opt,name=test,proto3,oneof"`
 }
@@ -1209,6 +1824,10 @@ type GetEventsResponse_RateLimitInfo struct {
 	RateLimitInfo *RateLimitInfo `protobuf:"bytes,40001,opt,name=rate_limit_info,json=rateLimitInfo,proto3,oneof"`
 }
 
+type GetEventsResponse_LostEvents struct {
+	LostEvents *LostEvents `protobuf:"bytes,40002,opt,name=lost_events,json=lostEvents,proto3,oneof"`
+}
+
 func (*GetEventsResponse_ProcessExec) isGetEventsResponse_Event() {}
 
 func (*GetEventsResponse_ProcessExit) isGetEventsResponse_Event() {}
@@ -1225,10 +1844,14 @@ func (*GetEventsResponse_ProcessThrottle) isGetEventsResponse_Event() {}
 
 func (*GetEventsResponse_ProcessLsm) isGetEventsResponse_Event() {}
 
+func (*GetEventsResponse_ProcessUpdate) isGetEventsResponse_Event() {}
+
 func (*GetEventsResponse_Test) isGetEventsResponse_Event() {}
 
 func (*GetEventsResponse_RateLimitInfo) isGetEventsResponse_Event() {}
 
+func (*GetEventsResponse_LostEvents) isGetEventsResponse_Event() {}
+
 var File_tetragon_events_proto protoreflect.FileDescriptor
 
 var file_tetragon_events_proto_rawDesc = []byte{
@@ -1238,143 +1861,213 @@ var file_tetragon_events_proto_rawDesc = []byte{
 	0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x1a, 0x20, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
 	0x75, 0x66, 0x2f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x6d, 0x61, 0x73, 0x6b, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
-	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x77, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x73, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1b, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2f, 0x63,
-	0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x1a, 0x17, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2f, 0x74, 0x65, 0x74, 0x72,
-	0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xc1, 0x05, 0x0a, 0x06, 0x46,
-	0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x5f,
-	0x72, 0x65, 0x67, 0x65, 0x78, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x62, 0x69, 0x6e,
-	0x61, 0x72, 0x79, 0x52, 0x65, 0x67, 0x65, 0x78, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65,
-	0x73, 0x70, 0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d,
-	0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x12, 0x3d, 0x0a, 0x0c, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68,
-	0x5f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x42,
-	0x6f, 0x6f, 0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x0b, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68,
-	0x43, 0x68, 0x65, 0x63, 0x6b, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x69, 0x64, 0x18, 0x04, 0x20, 0x03,
-	0x28, 0x0d, 0x52, 0x03, 0x70, 0x69, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x69, 0x64, 0x5f, 0x73,
-	0x65, 0x74, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x06, 0x70, 0x69, 0x64, 0x53, 0x65, 0x74,
-	0x12, 0x30, 0x0a, 0x09, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x65, 0x74, 0x18, 0x06, 0x20,
-	0x03, 0x28, 0x0e, 0x32, 0x13, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x45,
-	0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x53,
-	0x65, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x70, 0x6f, 0x64, 0x5f, 0x72, 0x65, 0x67, 0x65, 0x78, 0x18,
-	0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6f, 0x64, 0x52, 0x65, 0x67, 0x65, 0x78, 0x12,
-	0x27, 0x0a, 0x0f, 0x61, 0x72, 0x67, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x5f, 0x72, 0x65, 0x67,
-	0x65, 0x78, 0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x61, 0x72, 0x67, 0x75, 0x6d, 0x65,
-	0x6e, 0x74, 0x73, 0x52, 0x65, 0x67, 0x65, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65,
-	0x6c, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73,
-	0x12, 0x21, 0x0a, 0x0c, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73,
-	0x18, 0x0a, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x4e, 0x61,
-	0x6d, 0x65, 0x73, 0x12, 0x37, 0x0a, 0x0c, 0x63, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74,
-	0x69, 0x65, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x74, 0x65, 0x74, 0x72,
-	0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x43, 0x61, 0x70, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x0c,
-	0x63, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x2e, 0x0a, 0x13,
-	0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x5f, 0x72, 0x65,
-	0x67, 0x65, 0x78, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x09, 0x52, 0x11, 0x70, 0x61, 0x72, 0x65, 0x6e,
-	0x74, 0x42, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x52, 0x65, 0x67, 0x65, 0x78, 0x12, 0x25, 0x0a, 0x0e,
-	0x63, 0x65, 0x6c, 0x5f, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0d,
-	0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x63, 0x65, 0x6c, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73,
-	0x69, 0x6f, 0x6e, 0x12, 0x34, 0x0a, 0x16, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x72,
-	0x67, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x5f, 0x72, 0x65, 0x67, 0x65, 0x78, 0x18, 0x0e, 0x20,
-	0x03, 0x28, 0x09, 0x52, 0x14, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x41, 0x72, 0x67, 0x75, 0x6d,
-	0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x67, 0x65, 0x78, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e,
-	0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x09, 0x52,
-	0x0b, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x12, 0x3c, 0x0a, 0x0c,
-	0x69, 0x6e, 0x5f, 0x69, 0x6e, 0x69, 0x74, 0x5f, 0x74, 0x72, 0x65, 0x65, 0x18, 0x10, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x0a,
-	0x69, 0x6e, 0x49, 0x6e, 0x69, 0x74, 0x54, 0x72, 0x65, 0x65, 0x12, 0x32, 0x0a, 0x15, 0x61, 0x6e,
-	0x63, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x5f, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x5f, 0x72, 0x65,
-	0x67, 0x65, 0x78, 0x18, 0x11, 0x20, 0x03, 0x28, 0x09, 0x52, 0x13, 0x61, 0x6e, 0x63, 0x65, 0x73,
-	0x74, 0x6f, 0x72, 0x42, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x52, 0x65, 0x67, 0x65, 0x78, 0x22, 0xb1,
-	0x01, 0x0a, 0x09, 0x43, 0x61, 0x70, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x34, 0x0a, 0x09,
-	0x70, 0x65, 0x72, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x16, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x43, 0x61, 0x70, 0x46, 0x69,
-	0x6c, 0x74, 0x65, 0x72, 0x53, 0x65, 0x74, 0x52, 0x09, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x74, 0x74,
-	0x65, 0x64, 0x12, 0x34, 0x0a, 0x09, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x18,
-	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e,
-	0x2e, 0x43, 0x61, 0x70, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x53, 0x65, 0x74, 0x52, 0x09, 0x65,
-	0x66, 0x66, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x12, 0x38, 0x0a, 0x0b, 0x69, 0x6e, 0x68, 0x65,
-	0x72, 0x69, 0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e,
+	0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2f, 0x77, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x73, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x1a, 0x1b, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2f, 0x63, 0x61, 0x70,
+	0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x17, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2f, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67,
+	0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xc1, 0x05, 0x0a, 0x06, 0x46, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x5f, 0x72, 0x65,
+	0x67, 0x65, 0x78, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x62, 0x69, 0x6e, 0x61, 0x72,
+	0x79, 0x52, 0x65, 0x67, 0x65, 0x78, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70,
+	0x61, 0x63, 0x65, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x09, 0x6e, 0x61, 0x6d, 0x65, 0x73,
+	0x70, 0x61, 0x63, 0x65, 0x12, 0x3d, 0x0a, 0x0c, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x5f, 0x63,
+	0x68, 0x65, 0x63, 0x6b, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f,
+	0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x42, 0x6f, 0x6f,
+	0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x0b, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x43, 0x68,
+	0x65, 0x63, 0x6b, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x69, 0x64, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0d,
+	0x52, 0x03, 0x70, 0x69, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x70, 0x69, 0x64, 0x5f, 0x73, 0x65, 0x74,
+	0x18, 0x05, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x06, 0x70, 0x69, 0x64, 0x53, 0x65, 0x74, 0x12, 0x30,
+	0x0a, 0x09, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x65, 0x74, 0x18, 0x06, 0x20, 0x03, 0x28,
+	0x0e, 0x32, 0x13, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x53, 0x65, 0x74,
+	0x12, 0x1b, 0x0a, 0x09, 0x70, 0x6f, 0x64, 0x5f, 0x72, 0x65, 0x67, 0x65, 0x78, 0x18, 0x07, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x08, 0x70, 0x6f, 0x64, 0x52, 0x65, 0x67, 0x65, 0x78, 0x12, 0x27, 0x0a,
+	0x0f, 0x61, 0x72, 0x67, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x5f, 0x72, 0x65, 0x67, 0x65, 0x78,
+	0x18, 0x08, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0e, 0x61, 0x72, 0x67, 0x75, 0x6d, 0x65, 0x6e, 0x74,
+	0x73, 0x52, 0x65, 0x67, 0x65, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73,
+	0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x12, 0x21,
+	0x0a, 0x0c, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x73, 0x18, 0x0a,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x4e, 0x61, 0x6d, 0x65,
+	0x73, 0x12, 0x37, 0x0a, 0x0c, 0x63, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65,
+	0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67,
+	0x6f, 0x6e, 0x2e, 0x43, 0x61, 0x70, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x0c, 0x63, 0x61,
+	0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x70, 0x61,
+	0x72, 0x65, 0x6e, 0x74, 0x5f, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x5f, 0x72, 0x65, 0x67, 0x65,
+	0x78, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x09, 0x52, 0x11, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x42,
+	0x69, 0x6e, 0x61, 0x72, 0x79, 0x52, 0x65, 0x67, 0x65, 0x78, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x65,
+	0x6c, 0x5f, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x0d, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0d, 0x63, 0x65, 0x6c, 0x45, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x34, 0x0a, 0x16, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x5f, 0x61, 0x72, 0x67, 0x75,
+	0x6d, 0x65, 0x6e, 0x74, 0x73, 0x5f, 0x72, 0x65, 0x67, 0x65, 0x78, 0x18, 0x0e, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x14, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x41, 0x72, 0x67, 0x75, 0x6d, 0x65, 0x6e,
+	0x74, 0x73, 0x52, 0x65, 0x67, 0x65, 0x78, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x6f, 0x6e, 0x74, 0x61,
+	0x69, 0x6e, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x0f, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x63,
+	0x6f, 0x6e, 0x74, 0x61, 0x69, 0x6e, 0x65, 0x72, 0x49, 0x64, 0x12, 0x3c, 0x0a, 0x0c, 0x69, 0x6e,
+	0x5f, 0x69, 0x6e, 0x69, 0x74, 0x5f, 0x74, 0x72, 0x65, 0x65, 0x18, 0x10, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x0a, 0x69, 0x6e,
+	0x49, 0x6e, 0x69, 0x74, 0x54, 0x72, 0x65, 0x65, 0x12, 0x32, 0x0a, 0x15, 0x61, 0x6e, 0x63, 0x65,
+	0x73, 0x74, 0x6f, 0x72, 0x5f, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x5f, 0x72, 0x65, 0x67, 0x65,
+	0x78, 0x18, 0x11, 0x20, 0x03, 0x28, 0x09, 0x52, 0x13, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x74, 0x6f,
+	0x72, 0x42, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x52, 0x65, 0x67, 0x65, 0x78, 0x22, 0xb1, 0x01, 0x0a,
+	0x09, 0x43, 0x61, 0x70, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x34, 0x0a, 0x09, 0x70, 0x65,
+	0x72, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e,
 	0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x43, 0x61, 0x70, 0x46, 0x69, 0x6c, 0x74,
-	0x65, 0x72, 0x53, 0x65, 0x74, 0x52, 0x0b, 0x69, 0x6e, 0x68, 0x65, 0x72, 0x69, 0x74, 0x61, 0x62,
-	0x6c, 0x65, 0x22, 0xd0, 0x01, 0x0a, 0x0c, 0x43, 0x61, 0x70, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
-	0x53, 0x65, 0x74, 0x12, 0x2c, 0x0a, 0x03, 0x61, 0x6e, 0x79, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0e,
-	0x32, 0x1a, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x43, 0x61, 0x70, 0x61,
-	0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x54, 0x79, 0x70, 0x65, 0x52, 0x03, 0x61, 0x6e,
-	0x79, 0x12, 0x2c, 0x0a, 0x03, 0x61, 0x6c, 0x6c, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x1a,
+	0x65, 0x72, 0x53, 0x65, 0x74, 0x52, 0x09, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x64,
+	0x12, 0x34, 0x0a, 0x09, 0x65, 0x66, 0x66, 0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x43,
+	0x61, 0x70, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x53, 0x65, 0x74, 0x52, 0x09, 0x65, 0x66, 0x66,
+	0x65, 0x63, 0x74, 0x69, 0x76, 0x65, 0x12, 0x38, 0x0a, 0x0b, 0x69, 0x6e, 0x68, 0x65, 0x72, 0x69,
+	0x74, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x74, 0x65,
+	0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x43, 0x61, 0x70, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x53, 0x65, 0x74, 0x52, 0x0b, 0x69, 0x6e, 0x68, 0x65, 0x72, 0x69, 0x74, 0x61, 0x62, 0x6c, 0x65,
+	0x22, 0xd0, 0x01, 0x0a, 0x0c, 0x43, 0x61, 0x70, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x53, 0x65,
+	0x74, 0x12, 0x2c, 0x0a, 0x03, 0x61, 0x6e, 0x79, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x1a,
+	0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69,
+	0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x54, 0x79, 0x70, 0x65, 0x52, 0x03, 0x61, 0x6e, 0x79, 0x12,
+	0x2c, 0x0a, 0x03, 0x61, 0x6c, 0x6c, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x74,
+	0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69,
+	0x74, 0x69, 0x65, 0x73, 0x54, 0x79, 0x70, 0x65, 0x52, 0x03, 0x61, 0x6c, 0x6c, 0x12, 0x34, 0x0a,
+	0x07, 0x65, 0x78, 0x61, 0x63, 0x74, 0x6c, 0x79, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x1a,
 	0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x43, 0x61, 0x70, 0x61, 0x62, 0x69,
-	0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x54, 0x79, 0x70, 0x65, 0x52, 0x03, 0x61, 0x6c, 0x6c, 0x12,
-	0x34, 0x0a, 0x07, 0x65, 0x78, 0x61, 0x63, 0x74, 0x6c, 0x79, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0e,
-	0x32, 0x1a, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x43, 0x61, 0x70, 0x61,
-	0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x54, 0x79, 0x70, 0x65, 0x52, 0x07, 0x65, 0x78,
-	0x61, 0x63, 0x74, 0x6c, 0x79, 0x12, 0x2e, 0x0a, 0x04, 0x6e, 0x6f, 0x6e, 0x65, 0x18, 0x04, 0x20,
-	0x03, 0x28, 0x0e, 0x32, 0x1a, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x43,
-	0x61, 0x70, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x54, 0x79, 0x70, 0x65, 0x52,
-	0x04, 0x6e, 0x6f, 0x6e, 0x65, 0x22, 0x78, 0x0a, 0x0f, 0x52, 0x65, 0x64, 0x61, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x2a, 0x0a, 0x05, 0x6d, 0x61, 0x74, 0x63,
-	0x68, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67,
-	0x6f, 0x6e, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x42, 0x02, 0x18, 0x01, 0x52, 0x05, 0x6d,
-	0x61, 0x74, 0x63, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x64, 0x61, 0x63, 0x74, 0x18, 0x02,
-	0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x64, 0x61, 0x63, 0x74, 0x12, 0x21, 0x0a, 0x0c,
-	0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x5f, 0x72, 0x65, 0x67, 0x65, 0x78, 0x18, 0x03, 0x20, 0x03,
-	0x28, 0x09, 0x52, 0x0b, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x52, 0x65, 0x67, 0x65, 0x78, 0x22,
-	0xee, 0x01, 0x0a, 0x0b, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12,
-	0x30, 0x0a, 0x09, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x03,
+	0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x54, 0x79, 0x70, 0x65, 0x52, 0x07, 0x65, 0x78, 0x61, 0x63,
+	0x74, 0x6c, 0x79, 0x12, 0x2e, 0x0a, 0x04, 0x6e, 0x6f, 0x6e, 0x65, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x0e, 0x32, 0x1a, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x43, 0x61, 0x70,
+	0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x69, 0x65, 0x73, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x6e,
+	0x6f, 0x6e, 0x65, 0x22, 0x78, 0x0a, 0x0f, 0x52, 0x65, 0x64, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x2a, 0x0a, 0x05, 0x6d, 0x61, 0x74, 0x63, 0x68, 0x18,
+	0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e,
+	0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x42, 0x02, 0x18, 0x01, 0x52, 0x05, 0x6d, 0x61, 0x74,
+	0x63, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x64, 0x61, 0x63, 0x74, 0x18, 0x02, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x64, 0x61, 0x63, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x69,
+	0x6e, 0x61, 0x72, 0x79, 0x5f, 0x72, 0x65, 0x67, 0x65, 0x78, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x0b, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x52, 0x65, 0x67, 0x65, 0x78, 0x22, 0xee, 0x01,
+	0x0a, 0x0b, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x30, 0x0a,
+	0x09, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x65, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0e,
+	0x32, 0x13, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x53, 0x65, 0x74, 0x12,
+	0x32, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x52, 0x06, 0x66, 0x69, 0x65,
+	0x6c, 0x64, 0x73, 0x12, 0x33, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x46,
+	0x69, 0x65, 0x6c, 0x64, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x44, 0x0a, 0x10, 0x69, 0x6e, 0x76, 0x65,
+	0x72, 0x74, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x65, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x0e,
+	0x69, 0x6e, 0x76, 0x65, 0x72, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x53, 0x65, 0x74, 0x22, 0xc6,
+	0x03, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x2f, 0x0a, 0x0a, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x6c, 0x69, 0x73,
+	0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67,
+	0x6f, 0x6e, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x09, 0x61, 0x6c, 0x6c, 0x6f, 0x77,
+	0x4c, 0x69, 0x73, 0x74, 0x12, 0x2d, 0x0a, 0x09, 0x64, 0x65, 0x6e, 0x79, 0x5f, 0x6c, 0x69, 0x73,
+	0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67,
+	0x6f, 0x6e, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x08, 0x64, 0x65, 0x6e, 0x79, 0x4c,
+	0x69, 0x73, 0x74, 0x12, 0x4d, 0x0a, 0x13, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1c, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x41, 0x67, 0x67, 0x72,
+	0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x12,
+	0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x3a, 0x0a, 0x0d, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x66, 0x69, 0x6c, 0x74,
+	0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x74, 0x65, 0x74, 0x72,
+	0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72,
+	0x52, 0x0c, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x3f,
+	0x0a, 0x0c, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x5f, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x55, 0x49, 0x6e, 0x74, 0x36, 0x34, 0x56, 0x61, 0x6c,
+	0x75, 0x65, 0x52, 0x0b, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x43, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12,
+	0x44, 0x0a, 0x10, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x5f, 0x6f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x74, 0x65, 0x74, 0x72,
+	0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x52, 0x0f, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x40, 0x0a, 0x0f, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65,
+	0x64, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17,
+	0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74,
+	0x65, 0x64, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x52, 0x0e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65,
+	0x64, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x22, 0x43, 0x0a, 0x0d, 0x43, 0x6f, 0x6d, 0x70, 0x75,
+	0x74, 0x65, 0x64, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1e, 0x0a, 0x0a,
+	0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x65, 0x78, 0x70, 0x72, 0x65, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0xde, 0x01, 0x0a,
+	0x12, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x73, 0x12, 0x3a, 0x0a, 0x0b, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x5f, 0x73, 0x69,
+	0x7a, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x53, 0x69, 0x7a, 0x65, 0x12,
+	0x2e, 0x0a, 0x13, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x62, 0x75, 0x66, 0x66, 0x65,
+	0x72, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x11, 0x63, 0x68,
+	0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x53, 0x69, 0x7a, 0x65, 0x12,
+	0x30, 0x0a, 0x09, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x65, 0x74, 0x18, 0x03, 0x20, 0x03,
 	0x28, 0x0e, 0x32, 0x13, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x45, 0x76,
 	0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x52, 0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x53, 0x65,
-	0x74, 0x12, 0x32, 0x0a, 0x06, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
-	0x62, 0x75, 0x66, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x4d, 0x61, 0x73, 0x6b, 0x52, 0x06, 0x66,
-	0x69, 0x65, 0x6c, 0x64, 0x73, 0x12, 0x33, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e,
-	0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x41, 0x63, 0x74, 0x69,
-	0x6f, 0x6e, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x44, 0x0a, 0x10, 0x69, 0x6e,
-	0x76, 0x65, 0x72, 0x74, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x65, 0x74, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x42, 0x6f, 0x6f, 0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65,
-	0x52, 0x0e, 0x69, 0x6e, 0x76, 0x65, 0x72, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x53, 0x65, 0x74,
-	0x22, 0xfd, 0x01, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2f, 0x0a, 0x0a, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x6c,
-	0x69, 0x73, 0x74, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x74, 0x65, 0x74, 0x72,
-	0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x09, 0x61, 0x6c, 0x6c,
-	0x6f, 0x77, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x2d, 0x0a, 0x09, 0x64, 0x65, 0x6e, 0x79, 0x5f, 0x6c,
-	0x69, 0x73, 0x74, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x74, 0x65, 0x74, 0x72,
-	0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x08, 0x64, 0x65, 0x6e,
-	0x79, 0x4c, 0x69, 0x73, 0x74, 0x12, 0x4d, 0x0a, 0x13, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x03, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x41, 0x67,
-	0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
-	0x52, 0x12, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x70, 0x74,
-	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x3a, 0x0a, 0x0d, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x5f, 0x66, 0x69,
-	0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x74, 0x65,
-	0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x46, 0x69, 0x6c, 0x74,
-	0x65, 0x72, 0x52, 0x0c, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73,
-	0x22, 0x80, 0x01, 0x0a, 0x12, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x3a, 0x0a, 0x0b, 0x77, 0x69, 0x6e, 0x64, 0x6f,
-	0x77, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44,
-	0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x53,
-	0x69, 0x7a, 0x65, 0x12, 0x2e, 0x0a, 0x13, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x62,
-	0x75, 0x66, 0x66, 0x65, 0x72, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
-	0x52, 0x11, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x53,
-	0x69, 0x7a, 0x65, 0x22, 0x27, 0x0a, 0x0f, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x57, 0x0a, 0x0d,
-	0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x46, 0x0a,
-	0x20, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x5f, 0x6f, 0x66, 0x5f, 0x64, 0x72, 0x6f, 0x70, 0x70,
-	0x65, 0x64, 0x5f, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74,
-	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x1c, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x4f,
-	0x66, 0x44, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x45,
-	0x76, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x55, 0x0a, 0x0f, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73,
-	0x54, 0x68, 0x72, 0x6f, 0x74, 0x74, 0x6c, 0x65, 0x12, 0x2a, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f,
-	0x6e, 0x2e, 0x54, 0x68, 0x72, 0x6f, 0x74, 0x74, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04,
-	0x74, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x63, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x02,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x22, 0xf0, 0x07, 0x0a,
+	0x74, 0x12, 0x2a, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18,
+	0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x65, 0x79, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0xa1, 0x01,
+	0x0a, 0x0e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4b, 0x65, 0x79,
+	0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x70, 0x6f, 0x6c, 0x69, 0x63, 0x79, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x23, 0x0a, 0x0d, 0x66, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x61,
+	0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x66, 0x75, 0x6e, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x70, 0x6f, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x03, 0x70, 0x6f, 0x64,
+	0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x72, 0x67, 0x5f, 0x69, 0x6e, 0x64, 0x69, 0x63, 0x65, 0x73, 0x18,
+	0x05, 0x20, 0x03, 0x28, 0x0d, 0x52, 0x0a, 0x61, 0x72, 0x67, 0x49, 0x6e, 0x64, 0x69, 0x63, 0x65,
+	0x73, 0x22, 0x9b, 0x01, 0x0a, 0x0f, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x66,
+	0x69, 0x72, 0x73, 0x74, 0x5f, 0x73, 0x65, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x66, 0x69, 0x72,
+	0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x12, 0x37, 0x0a, 0x09, 0x6c, 0x61, 0x73, 0x74, 0x5f, 0x73,
+	0x65, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x53, 0x65, 0x65, 0x6e, 0x22,
+	0x74, 0x0a, 0x0f, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x4f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x18, 0x0a, 0x08, 0x6f, 0x6e, 0x65, 0x5f, 0x69, 0x6e, 0x5f, 0x6e, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0d, 0x52, 0x06, 0x6f, 0x6e, 0x65, 0x49, 0x6e, 0x4e, 0x12, 0x1e, 0x0a, 0x0a,
+	0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x0a, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x61, 0x67, 0x65, 0x12, 0x27, 0x0a, 0x03,
+	0x6b, 0x65, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x74, 0x65, 0x74, 0x72,
+	0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x4b, 0x65, 0x79,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x22, 0x4b, 0x0a, 0x0c, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e,
+	0x67, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x04, 0x72, 0x61, 0x74, 0x65, 0x12, 0x27, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f,
+	0x6e, 0x2e, 0x53, 0x61, 0x6d, 0x70, 0x6c, 0x69, 0x6e, 0x67, 0x4b, 0x65, 0x79, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x22, 0x57, 0x0a, 0x0d, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x49,
+	0x6e, 0x66, 0x6f, 0x12, 0x46, 0x0a, 0x20, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x5f, 0x6f, 0x66,
+	0x5f, 0x64, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x5f, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73,
+	0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x1c, 0x6e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x4f, 0x66, 0x44, 0x72, 0x6f, 0x70, 0x70, 0x65, 0x64, 0x50, 0x72,
+	0x6f, 0x63, 0x65, 0x73, 0x73, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x8e, 0x01, 0x0a, 0x0a,
+	0x4c, 0x6f, 0x73, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x69,
+	0x6e, 0x67, 0x5f, 0x62, 0x75, 0x66, 0x66, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0a, 0x72, 0x69, 0x6e, 0x67, 0x42, 0x75, 0x66, 0x66, 0x65, 0x72, 0x12, 0x1f, 0x0a, 0x0b, 0x65,
+	0x76, 0x65, 0x6e, 0x74, 0x5f, 0x63, 0x61, 0x63, 0x68, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0a, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x43, 0x61, 0x63, 0x68, 0x65, 0x12, 0x1d, 0x0a, 0x0a,
+	0x72, 0x61, 0x74, 0x65, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x09, 0x72, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x73,
+	0x6c, 0x6f, 0x77, 0x5f, 0x63, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0a, 0x73, 0x6c, 0x6f, 0x77, 0x43, 0x6c, 0x69, 0x65, 0x6e, 0x74, 0x22, 0x55, 0x0a, 0x0f,
+	0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x54, 0x68, 0x72, 0x6f, 0x74, 0x74, 0x6c, 0x65, 0x12,
+	0x2a, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x16, 0x2e,
+	0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x54, 0x68, 0x72, 0x6f, 0x74, 0x74, 0x6c,
+	0x65, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x63,
+	0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x63, 0x67, 0x72,
+	0x6f, 0x75, 0x70, 0x22, 0x67, 0x0a, 0x0d, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x55, 0x70,
+	0x64, 0x61, 0x74, 0x65, 0x12, 0x2b, 0x0a, 0x07, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x11, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e,
+	0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x07, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73,
+	0x73, 0x12, 0x29, 0x0a, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x11, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x50, 0x72, 0x6f,
+	0x63, 0x65, 0x73, 0x73, 0x52, 0x06, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x22, 0x97, 0x0b, 0x0a,
 	0x11, 0x47, 0x65, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
 	0x73, 0x65, 0x12, 0x3a, 0x0a, 0x0c, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x65, 0x78,
 	0x65, 0x63, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61,
@@ -1409,61 +2102,96 @@ var file_tetragon_events_proto_rawDesc = []byte{
 	0x73, 0x5f, 0x6c, 0x73, 0x6d, 0x18, 0x1c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x74, 0x65,
 	0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x4c, 0x73,
 	0x6d, 0x48, 0x00, 0x52, 0x0a, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x4c, 0x73, 0x6d, 0x12,
-	0x26, 0x0a, 0x04, 0x74, 0x65, 0x73, 0x74, 0x18, 0xc0, 0xb8, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x0e, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x54, 0x65, 0x73, 0x74, 0x48,
-	0x00, 0x52, 0x04, 0x74, 0x65, 0x73, 0x74, 0x12, 0x43, 0x0a, 0x0f, 0x72, 0x61, 0x74, 0x65, 0x5f,
-	0x6c, 0x69, 0x6d, 0x69, 0x74, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0xc1, 0xb8, 0x02, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x17, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x52, 0x61,
-	0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x48, 0x00, 0x52, 0x0d, 0x72,
-	0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1c, 0x0a, 0x09,
-	0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0xe8, 0x07, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x08, 0x6e, 0x6f, 0x64, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2f, 0x0a, 0x04, 0x74, 0x69,
-	0x6d, 0x65, 0x18, 0xe9, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
-	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65,
-	0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x45, 0x0a, 0x10, 0x61,
-	0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18,
-	0xea, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f,
-	0x6e, 0x2e, 0x41, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66,
-	0x6f, 0x52, 0x0f, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e,
-	0x66, 0x6f, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x6e, 0x61,
-	0x6d, 0x65, 0x18, 0xeb, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6c, 0x75, 0x73, 0x74,
-	0x65, 0x72, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x4d, 0x0a, 0x0b, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x6c,
-	0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0xec, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x74,
-	0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x4c, 0x61,
-	0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x6e, 0x6f, 0x64, 0x65, 0x4c,
-	0x61, 0x62, 0x65, 0x6c, 0x73, 0x1a, 0x3d, 0x0a, 0x0f, 0x4e, 0x6f, 0x64, 0x65, 0x4c, 0x61, 0x62,
-	0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61,
-	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x3a, 0x02, 0x38, 0x01, 0x42, 0x07, 0x0a, 0x05, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x4a, 0x04, 0x08,
-	0x02, 0x10, 0x05, 0x4a, 0x04, 0x08, 0x06, 0x10, 0x09, 0x4a, 0x04, 0x08, 0x0d, 0x10, 0x1b, 0x2a,
-	0xea, 0x01, 0x0a, 0x09, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x09, 0x0a,
-	0x05, 0x55, 0x4e, 0x44, 0x45, 0x46, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x50, 0x52, 0x4f, 0x43,
-	0x45, 0x53, 0x53, 0x5f, 0x45, 0x58, 0x45, 0x43, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x50, 0x52,
-	0x4f, 0x43, 0x45, 0x53, 0x53, 0x5f, 0x45, 0x58, 0x49, 0x54, 0x10, 0x05, 0x12, 0x12, 0x0a, 0x0e,
-	0x50, 0x52, 0x4f, 0x43, 0x45, 0x53, 0x53, 0x5f, 0x4b, 0x50, 0x52, 0x4f, 0x42, 0x45, 0x10, 0x09,
-	0x12, 0x16, 0x0a, 0x12, 0x50, 0x52, 0x4f, 0x43, 0x45, 0x53, 0x53, 0x5f, 0x54, 0x52, 0x41, 0x43,
-	0x45, 0x50, 0x4f, 0x49, 0x4e, 0x54, 0x10, 0x0a, 0x12, 0x12, 0x0a, 0x0e, 0x50, 0x52, 0x4f, 0x43,
-	0x45, 0x53, 0x53, 0x5f, 0x4c, 0x4f, 0x41, 0x44, 0x45, 0x52, 0x10, 0x0b, 0x12, 0x12, 0x0a, 0x0e,
-	0x50, 0x52, 0x4f, 0x43, 0x45, 0x53, 0x53, 0x5f, 0x55, 0x50, 0x52, 0x4f, 0x42, 0x45, 0x10, 0x0c,
-	0x12, 0x14, 0x0a, 0x10, 0x50, 0x52, 0x4f, 0x43, 0x45, 0x53, 0x53, 0x5f, 0x54, 0x48, 0x52, 0x4f,
-	0x54, 0x54, 0x4c, 0x45, 0x10, 0x1b, 0x12, 0x0f, 0x0a, 0x0b, 0x50, 0x52, 0x4f, 0x43, 0x45, 0x53,
-	0x53, 0x5f, 0x4c, 0x53, 0x4d, 0x10, 0x1c, 0x12, 0x0a, 0x0a, 0x04, 0x54, 0x45, 0x53, 0x54, 0x10,
-	0xc0, 0xb8, 0x02, 0x12, 0x15, 0x0a, 0x0f, 0x52, 0x41, 0x54, 0x45, 0x5f, 0x4c, 0x49, 0x4d, 0x49,
-	0x54, 0x5f, 0x49, 0x4e, 0x46, 0x4f, 0x10, 0xc1, 0xb8, 0x02, 0x22, 0x04, 0x08, 0x02, 0x10, 0x04,
-	0x22, 0x04, 0x08, 0x06, 0x10, 0x08, 0x22, 0x04, 0x08, 0x0d, 0x10, 0x1a, 0x2a, 0x2d, 0x0a, 0x11,
-	0x46, 0x69, 0x65, 0x6c, 0x64, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x41, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x12, 0x0b, 0x0a, 0x07, 0x49, 0x4e, 0x43, 0x4c, 0x55, 0x44, 0x45, 0x10, 0x00, 0x12, 0x0b,
-	0x0a, 0x07, 0x45, 0x58, 0x43, 0x4c, 0x55, 0x44, 0x45, 0x10, 0x01, 0x2a, 0x4b, 0x0a, 0x0c, 0x54,
-	0x68, 0x72, 0x6f, 0x74, 0x74, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x10, 0x54,
-	0x48, 0x52, 0x4f, 0x54, 0x54, 0x4c, 0x45, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10,
-	0x00, 0x12, 0x12, 0x0a, 0x0e, 0x54, 0x48, 0x52, 0x4f, 0x54, 0x54, 0x4c, 0x45, 0x5f, 0x53, 0x54,
-	0x41, 0x52, 0x54, 0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d, 0x54, 0x48, 0x52, 0x4f, 0x54, 0x54, 0x4c,
-	0x45, 0x5f, 0x53, 0x54, 0x4f, 0x50, 0x10, 0x02, 0x42, 0x2c, 0x5a, 0x2a, 0x67, 0x69, 0x74, 0x68,
-	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x69, 0x6c, 0x69, 0x75, 0x6d, 0x2f, 0x74, 0x65,
-	0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2f, 0x61, 0x70, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x74, 0x65,
-	0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x40, 0x0a, 0x0e, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x5f, 0x75, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x18, 0x1d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67,
+	0x6f, 0x6e, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74, 0x65,
+	0x48, 0x00, 0x52, 0x0d, 0x70, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x12, 0x26, 0x0a, 0x04, 0x74, 0x65, 0x73, 0x74, 0x18, 0xc0, 0xb8, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x0e, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x54, 0x65, 0x73,
+	0x74, 0x48, 0x00, 0x52, 0x04, 0x74, 0x65, 0x73, 0x74, 0x12, 0x43, 0x0a, 0x0f, 0x72, 0x61, 0x74,
+	0x65, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0xc1, 0xb8, 0x02,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e,
+	0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x48, 0x00, 0x52,
+	0x0d, 0x72, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x39,
+	0x0a, 0x0b, 0x6c, 0x6f, 0x73, 0x74, 0x5f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0xc2, 0xb8,
+	0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e,
+	0x2e, 0x4c, 0x6f, 0x73, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x48, 0x00, 0x52, 0x0a, 0x6c,
+	0x6f, 0x73, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x6e, 0x6f, 0x64,
+	0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0xe8, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6e,
+	0x6f, 0x64, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x2f, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18,
+	0xe9, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61,
+	0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x45, 0x0a, 0x10, 0x61, 0x67, 0x67, 0x72,
+	0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0xea, 0x07, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x41,
+	0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0f,
+	0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x66, 0x6f, 0x12,
+	0x22, 0x0a, 0x0c, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18,
+	0xeb, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x63, 0x6c, 0x75, 0x73, 0x74, 0x65, 0x72, 0x4e,
+	0x61, 0x6d, 0x65, 0x12, 0x4d, 0x0a, 0x0b, 0x6e, 0x6f, 0x64, 0x65, 0x5f, 0x6c, 0x61, 0x62, 0x65,
+	0x6c, 0x73, 0x18, 0xec, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2b, 0x2e, 0x74, 0x65, 0x74, 0x72,
+	0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x4e, 0x6f, 0x64, 0x65, 0x4c, 0x61, 0x62, 0x65, 0x6c,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x6e, 0x6f, 0x64, 0x65, 0x4c, 0x61, 0x62, 0x65,
+	0x6c, 0x73, 0x12, 0x17, 0x0a, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x18, 0xed, 0x07, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x06, 0x63, 0x75, 0x72, 0x73, 0x6f, 0x72, 0x12, 0x1b, 0x0a, 0x08, 0x73,
+	0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x18, 0xee, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08,
+	0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x3c, 0x0a, 0x0d, 0x73, 0x61, 0x6d, 0x70,
+	0x6c, 0x69, 0x6e, 0x67, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0xef, 0x07, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x16, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x53, 0x61, 0x6d, 0x70,
+	0x6c, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x0c, 0x73, 0x61, 0x6d, 0x70, 0x6c, 0x69,
+	0x6e, 0x67, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x59, 0x0a, 0x0f, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74,
+	0x65, 0x64, 0x5f, 0x66, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x18, 0xf0, 0x07, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x2f, 0x2e, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2e, 0x47, 0x65, 0x74, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x43, 0x6f,
+	0x6d, 0x70, 0x75, 0x74, 0x65, 0x64, 0x46, 0x69, 0x65, 0x6c, 0x64, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x52, 0x0e, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x64, 0x46, 0x69, 0x65, 0x6c, 0x64,
+	0x73, 0x1a, 0x3d, 0x0a, 0x0f, 0x4e, 0x6f, 0x64, 0x65, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x1a, 0x59, 0x0a, 0x13, 0x43, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x65, 0x64, 0x46, 0x69, 0x65, 0x6c,
+	0x64, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2c, 0x0a, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42, 0x07, 0x0a, 0x05, 0x65,
+	0x76, 0x65, 0x6e, 0x74, 0x4a, 0x04, 0x08, 0x02, 0x10, 0x05, 0x4a, 0x04, 0x08, 0x06, 0x10, 0x09,
+	0x4a, 0x04, 0x08, 0x0d, 0x10, 0x1b, 0x2a, 0x91, 0x02, 0x0a, 0x09, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x54, 0x79, 0x70, 0x65, 0x12, 0x09, 0x0a, 0x05, 0x55, 0x4e, 0x44, 0x45, 0x46, 0x10, 0x00, 0x12,
+	0x10, 0x0a, 0x0c, 0x50, 0x52, 0x4f, 0x43, 0x45, 0x53, 0x53, 0x5f, 0x45, 0x58, 0x45, 0x43, 0x10,
+	0x01, 0x12, 0x10, 0x0a, 0x0c, 0x50, 0x52, 0x4f, 0x43, 0x45, 0x53, 0x53, 0x5f, 0x45, 0x58, 0x49,
+	0x54, 0x10, 0x05, 0x12, 0x12, 0x0a, 0x0e, 0x50, 0x52, 0x4f, 0x43, 0x45, 0x53, 0x53, 0x5f, 0x4b,
+	0x50, 0x52, 0x4f, 0x42, 0x45, 0x10, 0x09, 0x12, 0x16, 0x0a, 0x12, 0x50, 0x52, 0x4f, 0x43, 0x45,
+	0x53, 0x53, 0x5f, 0x54, 0x52, 0x41, 0x43, 0x45, 0x50, 0x4f, 0x49, 0x4e, 0x54, 0x10, 0x0a, 0x12,
+	0x12, 0x0a, 0x0e, 0x50, 0x52, 0x4f, 0x43, 0x45, 0x53, 0x53, 0x5f, 0x4c, 0x4f, 0x41, 0x44, 0x45,
+	0x52, 0x10, 0x0b, 0x12, 0x12, 0x0a, 0x0e, 0x50, 0x52, 0x4f, 0x43, 0x45, 0x53, 0x53, 0x5f, 0x55,
+	0x50, 0x52, 0x4f, 0x42, 0x45, 0x10, 0x0c, 0x12, 0x14, 0x0a, 0x10, 0x50, 0x52, 0x4f, 0x43, 0x45,
+	0x53, 0x53, 0x5f, 0x54, 0x48, 0x52, 0x4f, 0x54, 0x54, 0x4c, 0x45, 0x10, 0x1b, 0x12, 0x0f, 0x0a,
+	0x0b, 0x50, 0x52, 0x4f, 0x43, 0x45, 0x53, 0x53, 0x5f, 0x4c, 0x53, 0x4d, 0x10, 0x1c, 0x12, 0x12,
+	0x0a, 0x0e, 0x50, 0x52, 0x4f, 0x43, 0x45, 0x53, 0x53, 0x5f, 0x55, 0x50, 0x44, 0x41, 0x54, 0x45,
+	0x10, 0x1d, 0x12, 0x0a, 0x0a, 0x04, 0x54, 0x45, 0x53, 0x54, 0x10, 0xc0, 0xb8, 0x02, 0x12, 0x15,
+	0x0a, 0x0f, 0x52, 0x41, 0x54, 0x45, 0x5f, 0x4c, 0x49, 0x4d, 0x49, 0x54, 0x5f, 0x49, 0x4e, 0x46,
+	0x4f, 0x10, 0xc1, 0xb8, 0x02, 0x12, 0x11, 0x0a, 0x0b, 0x4c, 0x4f, 0x53, 0x54, 0x5f, 0x45, 0x56,
+	0x45, 0x4e, 0x54, 0x53, 0x10, 0xc2, 0xb8, 0x02, 0x22, 0x04, 0x08, 0x02, 0x10, 0x04, 0x22, 0x04,
+	0x08, 0x06, 0x10, 0x08, 0x22, 0x04, 0x08, 0x0d, 0x10, 0x1a, 0x2a, 0x2d, 0x0a, 0x11, 0x46, 0x69,
+	0x65, 0x6c, 0x64, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12,
+	0x0b, 0x0a, 0x07, 0x49, 0x4e, 0x43, 0x4c, 0x55, 0x44, 0x45, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07,
+	0x45, 0x58, 0x43, 0x4c, 0x55, 0x44, 0x45, 0x10, 0x01, 0x2a, 0x56, 0x0a, 0x0b, 0x53, 0x61, 0x6d,
+	0x70, 0x6c, 0x69, 0x6e, 0x67, 0x4b, 0x65, 0x79, 0x12, 0x18, 0x0a, 0x14, 0x53, 0x41, 0x4d, 0x50,
+	0x4c, 0x49, 0x4e, 0x47, 0x5f, 0x4b, 0x45, 0x59, 0x5f, 0x45, 0x58, 0x45, 0x43, 0x5f, 0x49, 0x44,
+	0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x53, 0x41, 0x4d, 0x50, 0x4c, 0x49, 0x4e, 0x47, 0x5f, 0x4b,
+	0x45, 0x59, 0x5f, 0x50, 0x4f, 0x44, 0x10, 0x01, 0x12, 0x17, 0x0a, 0x13, 0x53, 0x41, 0x4d, 0x50,
+	0x4c, 0x49, 0x4e, 0x47, 0x5f, 0x4b, 0x45, 0x59, 0x5f, 0x42, 0x49, 0x4e, 0x41, 0x52, 0x59, 0x10,
+	0x02, 0x2a, 0x4b, 0x0a, 0x0c, 0x54, 0x68, 0x72, 0x6f, 0x74, 0x74, 0x6c, 0x65, 0x54, 0x79, 0x70,
+	0x65, 0x12, 0x14, 0x0a, 0x10, 0x54, 0x48, 0x52, 0x4f, 0x54, 0x54, 0x4c, 0x45, 0x5f, 0x55, 0x4e,
+	0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x12, 0x0a, 0x0e, 0x54, 0x48, 0x52, 0x4f, 0x54,
+	0x54, 0x4c, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x52, 0x54, 0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d, 0x54,
+	0x48, 0x52, 0x4f, 0x54, 0x54, 0x4c, 0x45, 0x5f, 0x53, 0x54, 0x4f, 0x50, 0x10, 0x02, 0x42, 0x2c,
+	0x5a, 0x2a, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x63, 0x69, 0x6c,
+	0x69, 0x75, 0x6d, 0x2f, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x2f, 0x61, 0x70, 0x69,
+	0x2f, 0x76, 0x31, 0x2f, 0x74, 0x65, 0x74, 0x72, 0x61, 0x67, 0x6f, 0x6e, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -1478,79 +2206,106 @@ func file_tetragon_events_proto_rawDescGZIP() []byte {
 	return file_tetragon_events_proto_rawDescData
 }
 
-var file_tetragon_events_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_tetragon_events_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
+var file_tetragon_events_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_tetragon_events_proto_msgTypes = make([]protoimpl.MessageInfo, 19)
 var file_tetragon_events_proto_goTypes = []any{
-	(EventType)(0),                // 0: tetragon.EventType
-	(FieldFilterAction)(0),        // 1: tetragon.FieldFilterAction
-	(ThrottleType)(0),             // 2: tetragon.ThrottleType
-	(*Filter)(nil),                // 3: tetragon.Filter
-	(*CapFilter)(nil),             // 4: tetragon.CapFilter
-	(*CapFilterSet)(nil),          // 5: tetragon.CapFilterSet
-	(*RedactionFilter)(nil),       // 6: tetragon.RedactionFilter
-	(*FieldFilter)(nil),           // 7: tetragon.FieldFilter
-	(*GetEventsRequest)(nil),      // 8: tetragon.GetEventsRequest
-	(*AggregationOptions)(nil),    // 9: tetragon.AggregationOptions
-	(*AggregationInfo)(nil),       // 10: tetragon.AggregationInfo
-	(*RateLimitInfo)(nil),         // 11: tetragon.RateLimitInfo
-	(*ProcessThrottle)(nil),       // 12: tetragon.ProcessThrottle
-	(*GetEventsResponse)(nil),     // 13: tetragon.GetEventsResponse
-	nil,                           // 14: tetragon.GetEventsResponse.NodeLabelsEntry
-	(*wrapperspb.BoolValue)(nil),  // 15: google.protobuf.BoolValue
-	(CapabilitiesType)(0),         // 16: tetragon.CapabilitiesType
-	(*fieldmaskpb.FieldMask)(nil), // 17: google.protobuf.FieldMask
-	(*durationpb.Duration)(nil),   // 18: google.protobuf.Duration
-	(*ProcessExec)(nil),           // 19: tetragon.ProcessExec
-	(*ProcessExit)(nil),           // 20: tetragon.ProcessExit
-	(*ProcessKprobe)(nil),         // 21: tetragon.ProcessKprobe
-	(*ProcessTracepoint)(nil),     // 22: tetragon.ProcessTracepoint
-	(*ProcessLoader)(nil),         // 23: tetragon.ProcessLoader
-	(*ProcessUprobe)(nil),         // 24: tetragon.ProcessUprobe
-	(*ProcessLsm)(nil),            // 25: tetragon.ProcessLsm
-	(*Test)(nil),                  // 26: tetragon.Test
-	(*timestamppb.Timestamp)(nil), // 27: google.protobuf.Timestamp
+	(EventType)(0),                 // 0: tetragon.EventType
+	(FieldFilterAction)(0),         // 1: tetragon.FieldFilterAction
+	(SamplingKey)(0),               // 2: tetragon.SamplingKey
+	(ThrottleType)(0),              // 3: tetragon.ThrottleType
+	(*Filter)(nil),                 // 4: tetragon.Filter
+	(*CapFilter)(nil),              // 5: tetragon.CapFilter
+	(*CapFilterSet)(nil),           // 6: tetragon.CapFilterSet
+	(*RedactionFilter)(nil),        // 7: tetragon.RedactionFilter
+	(*FieldFilter)(nil),            // 8: tetragon.FieldFilter
+	(*GetEventsRequest)(nil),       // 9: tetragon.GetEventsRequest
+	(*ComputedField)(nil),          // 10: tetragon.ComputedField
+	(*AggregationOptions)(nil),     // 11: tetragon.AggregationOptions
+	(*AggregationKey)(nil),         // 12: tetragon.AggregationKey
+	(*AggregationInfo)(nil),        // 13: tetragon.AggregationInfo
+	(*SamplingOptions)(nil),        // 14: tetragon.SamplingOptions
+	(*SamplingInfo)(nil),           // 15: tetragon.SamplingInfo
+	(*RateLimitInfo)(nil),          // 16: tetragon.RateLimitInfo
+	(*LostEvents)(nil),             // 17: tetragon.LostEvents
+	(*ProcessThrottle)(nil),        // 18: tetragon.ProcessThrottle
+	(*ProcessUpdate)(nil),          // 19: tetragon.ProcessUpdate
+	(*GetEventsResponse)(nil),      // 20: tetragon.GetEventsResponse
+	nil,                            // 21: tetragon.GetEventsResponse.NodeLabelsEntry
+	nil,                            // 22: tetragon.GetEventsResponse.ComputedFieldsEntry
+	(*wrapperspb.BoolValue)(nil),   // 23: google.protobuf.BoolValue
+	(CapabilitiesType)(0),          // 24: tetragon.CapabilitiesType
+	(*fieldmaskpb.FieldMask)(nil),  // 25: google.protobuf.FieldMask
+	(*wrapperspb.UInt64Value)(nil), // 26: google.protobuf.UInt64Value
+	(*durationpb.Duration)(nil),    // 27: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil),  // 28: google.protobuf.Timestamp
+	(*Process)(nil),                // 29: tetragon.Process
+	(*ProcessExec)(nil),            // 30: tetragon.ProcessExec
+	(*ProcessExit)(nil),            // 31: tetragon.ProcessExit
+	(*ProcessKprobe)(nil),          // 32: tetragon.ProcessKprobe
+	(*ProcessTracepoint)(nil),      // 33: tetragon.ProcessTracepoint
+	(*ProcessLoader)(nil),          // 34: tetragon.ProcessLoader
+	(*ProcessUprobe)(nil),          // 35: tetragon.ProcessUprobe
+	(*ProcessLsm)(nil),             // 36: tetragon.ProcessLsm
+	(*Test)(nil),                   // 37: tetragon.Test
+	(*structpb.Value)(nil),         // 38: google.protobuf.Value
 }
 var file_tetragon_events_proto_depIdxs = []int32{
-	15, // 0: tetragon.Filter.health_check:type_name -> google.protobuf.BoolValue
+	23, // 0: tetragon.Filter.health_check:type_name -> google.protobuf.BoolValue
 	0,  // 1: tetragon.Filter.event_set:type_name -> tetragon.EventType
-	4,  // 2: tetragon.Filter.capabilities:type_name -> tetragon.CapFilter
-	15, // 3: tetragon.Filter.in_init_tree:type_name -> google.protobuf.BoolValue
-	5,  // 4: tetragon.CapFilter.permitted:type_name -> tetragon.CapFilterSet
-	5,  // 5: tetragon.CapFilter.effective:type_name -> tetragon.CapFilterSet
-	5,  // 6: tetragon.CapFilter.inheritable:type_name -> tetragon.CapFilterSet
-	16, // 7: tetragon.CapFilterSet.any:type_name -> tetragon.CapabilitiesType
-	16, // 8: tetragon.CapFilterSet.all:type_name -> tetragon.CapabilitiesType
-	16, // 9: tetragon.CapFilterSet.exactly:type_name -> tetragon.CapabilitiesType
-	16, // 10: tetragon.CapFilterSet.none:type_name -> tetragon.CapabilitiesType
-	3,  // 11: tetragon.RedactionFilter.match:type_name -> tetragon.Filter
+	5,  // 2: tetragon.Filter.capabilities:type_name -> tetragon.CapFilter
+	23, // 3: tetragon.Filter.in_init_tree:type_name -> google.protobuf.BoolValue
+	6,  // 4: tetragon.CapFilter.permitted:type_name -> tetragon.CapFilterSet
+	6,  // 5: tetragon.CapFilter.effective:type_name -> tetragon.CapFilterSet
+	6,  // 6: tetragon.CapFilter.inheritable:type_name -> tetragon.CapFilterSet
+	24, // 7: tetragon.CapFilterSet.any:type_name -> tetragon.CapabilitiesType
+	24, // 8: tetragon.CapFilterSet.all:type_name -> tetragon.CapabilitiesType
+	24, // 9: tetragon.CapFilterSet.exactly:type_name -> tetragon.CapabilitiesType
+	24, // 10: tetragon.CapFilterSet.none:type_name -> tetragon.CapabilitiesType
+	4,  // 11: tetragon.RedactionFilter.match:type_name -> tetragon.Filter
 	0,  // 12: tetragon.FieldFilter.event_set:type_name -> tetragon.EventType
-	17, // 13: tetragon.FieldFilter.fields:type_name -> google.protobuf.FieldMask
+	25, // 13: tetragon.FieldFilter.fields:type_name -> google.protobuf.FieldMask
 	1,  // 14: tetragon.FieldFilter.action:type_name -> tetragon.FieldFilterAction
-	15, // 15: tetragon.FieldFilter.invert_event_set:type_name -> google.protobuf.BoolValue
-	3,  // 16: tetragon.GetEventsRequest.allow_list:type_name -> tetragon.Filter
-	3,  // 17: tetragon.GetEventsRequest.deny_list:type_name -> tetragon.Filter
-	9,  // 18: tetragon.GetEventsRequest.aggregation_options:type_name -> tetragon.AggregationOptions
-	7,  // 19: tetragon.GetEventsRequest.field_filters:type_name -> tetragon.FieldFilter
-	18, // 20: tetragon.AggregationOptions.window_size:type_name -> google.protobuf.Duration
-	2,  // 21: tetragon.ProcessThrottle.type:type_name -> tetragon.ThrottleType
-	19, // 22: tetragon.GetEventsResponse.process_exec:type_name -> tetragon.ProcessExec
-	20, // 23: tetragon.GetEventsResponse.process_exit:type_name -> tetragon.ProcessExit
-	21, // 24: tetragon.GetEventsResponse.process_kprobe:type_name -> tetragon.ProcessKprobe
-	22, // 25: tetragon.GetEventsResponse.process_tracepoint:type_name -> tetragon.ProcessTracepoint
-	23, // 26: tetragon.GetEventsResponse.process_loader:type_name -> tetragon.ProcessLoader
-	24, // 27: tetragon.GetEventsResponse.process_uprobe:type_name -> tetragon.ProcessUprobe
-	12, // 28: tetragon.GetEventsResponse.process_throttle:type_name -> tetragon.ProcessThrottle
-	25, // 29: tetragon.GetEventsResponse.process_lsm:type_name -> tetragon.ProcessLsm
-	26, // 30: tetragon.GetEventsResponse.test:type_name -> tetragon.Test
-	11, // 31: tetragon.GetEventsResponse.rate_limit_info:type_name -> tetragon.RateLimitInfo
-	27, // 32: tetragon.GetEventsResponse.time:type_name -> google.protobuf.Timestamp
-	10, // 33: tetragon.GetEventsResponse.aggregation_info:type_name -> tetragon.AggregationInfo
-	14, // 34: tetragon.GetEventsResponse.node_labels:type_name -> tetragon.GetEventsResponse.NodeLabelsEntry
-	35, // [35:35] is the sub-list for method output_type
-	35, // [35:35] is the sub-list for method input_type
-	35, // [35:35] is the sub-list for extension type_name
-	35, // [35:35] is the sub-list for extension extendee
-	0,  // [0:35] is the sub-list for field type_name
+	23, // 15: tetragon.FieldFilter.invert_event_set:type_name -> google.protobuf.BoolValue
+	4,  // 16: tetragon.GetEventsRequest.allow_list:type_name -> tetragon.Filter
+	4,  // 17: tetragon.GetEventsRequest.deny_list:type_name -> tetragon.Filter
+	11, // 18: tetragon.GetEventsRequest.aggregation_options:type_name -> tetragon.AggregationOptions
+	8,  // 19: tetragon.GetEventsRequest.field_filters:type_name -> tetragon.FieldFilter
+	26, // 20: tetragon.GetEventsRequest.since_cursor:type_name -> google.protobuf.UInt64Value
+	14, // 21: tetragon.GetEventsRequest.sampling_options:type_name -> tetragon.SamplingOptions
+	10, // 22: tetragon.GetEventsRequest.computed_fields:type_name -> tetragon.ComputedField
+	27, // 23: tetragon.AggregationOptions.window_size:type_name -> google.protobuf.Duration
+	0,  // 24: tetragon.AggregationOptions.event_set:type_name -> tetragon.EventType
+	12, // 25: tetragon.AggregationOptions.key:type_name -> tetragon.AggregationKey
+	28, // 26: tetragon.AggregationInfo.first_seen:type_name -> google.protobuf.Timestamp
+	28, // 27: tetragon.AggregationInfo.last_seen:type_name -> google.protobuf.Timestamp
+	2,  // 28: tetragon.SamplingOptions.key:type_name -> tetragon.SamplingKey
+	2,  // 29: tetragon.SamplingInfo.key:type_name -> tetragon.SamplingKey
+	3,  // 30: tetragon.ProcessThrottle.type:type_name -> tetragon.ThrottleType
+	29, // 31: tetragon.ProcessUpdate.process:type_name -> tetragon.Process
+	29, // 32: tetragon.ProcessUpdate.parent:type_name -> tetragon.Process
+	30, // 33: tetragon.GetEventsResponse.process_exec:type_name -> tetragon.ProcessExec
+	31, // 34: tetragon.GetEventsResponse.process_exit:type_name -> tetragon.ProcessExit
+	32, // 35: tetragon.GetEventsResponse.process_kprobe:type_name -> tetragon.ProcessKprobe
+	33, // 36: tetragon.GetEventsResponse.process_tracepoint:type_name -> tetragon.ProcessTracepoint
+	34, // 37: tetragon.GetEventsResponse.process_loader:type_name -> tetragon.ProcessLoader
+	35, // 38: tetragon.GetEventsResponse.process_uprobe:type_name -> tetragon.ProcessUprobe
+	18, // 39: tetragon.GetEventsResponse.process_throttle:type_name -> tetragon.ProcessThrottle
+	36, // 40: tetragon.GetEventsResponse.process_lsm:type_name -> tetragon.ProcessLsm
+	19, // 41: tetragon.GetEventsResponse.process_update:type_name -> tetragon.ProcessUpdate
+	37, // 42: tetragon.GetEventsResponse.test:type_name -> tetragon.Test
+	16, // 43: tetragon.GetEventsResponse.rate_limit_info:type_name -> tetragon.RateLimitInfo
+	17, // 44: tetragon.GetEventsResponse.lost_events:type_name -> tetragon.LostEvents
+	28, // 45: tetragon.GetEventsResponse.time:type_name -> google.protobuf.Timestamp
+	13, // 46: tetragon.GetEventsResponse.aggregation_info:type_name -> tetragon.AggregationInfo
+	21, // 47: tetragon.GetEventsResponse.node_labels:type_name -> tetragon.GetEventsResponse.NodeLabelsEntry
+	15, // 48: tetragon.GetEventsResponse.sampling_info:type_name -> tetragon.SamplingInfo
+	22, // 49: tetragon.GetEventsResponse.computed_fields:type_name -> tetragon.GetEventsResponse.ComputedFieldsEntry
+	38, // 50: tetragon.GetEventsResponse.ComputedFieldsEntry.value:type_name -> google.protobuf.Value
+	51, // [51:51] is the sub-list for method output_type
+	51, // [51:51] is the sub-list for method input_type
+	51, // [51:51] is the sub-list for extension type_name
+	51, // [51:51] is the sub-list for extension extendee
+	0,  // [0:51] is the sub-list for field type_name
 }
 
 func init() { file_tetragon_events_proto_init() }
@@ -1560,7 +2315,7 @@ func file_tetragon_events_proto_init() {
 	}
 	file_tetragon_capabilities_proto_init()
 	file_tetragon_tetragon_proto_init()
-	file_tetragon_events_proto_msgTypes[10].OneofWrappers = []any{
+	file_tetragon_events_proto_msgTypes[16].OneofWrappers = []any{
 		(*GetEventsResponse_ProcessExec)(nil),
 		(*GetEventsResponse_ProcessExit)(nil),
 		(*GetEventsResponse_ProcessKprobe)(nil),
@@ -1569,16 +2324,18 @@ func file_tetragon_events_proto_init() {
 		(*GetEventsResponse_ProcessUprobe)(nil),
 		(*GetEventsResponse_ProcessThrottle)(nil),
 		(*GetEventsResponse_ProcessLsm)(nil),
+		(*GetEventsResponse_ProcessUpdate)(nil),
 		(*GetEventsResponse_Test)(nil),
 		(*GetEventsResponse_RateLimitInfo)(nil),
+		(*GetEventsResponse_LostEvents)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_tetragon_events_proto_rawDesc,
-			NumEnums:      3,
-			NumMessages:   12,
+			NumEnums:      4,
+			NumMessages:   19,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
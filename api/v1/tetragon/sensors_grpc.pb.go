@@ -39,6 +39,8 @@ const (
 	FineGuidanceSensors_RuntimeHook_FullMethodName            = "/tetragon.FineGuidanceSensors/RuntimeHook"
 	FineGuidanceSensors_GetDebug_FullMethodName               = "/tetragon.FineGuidanceSensors/GetDebug"
 	FineGuidanceSensors_SetDebug_FullMethodName               = "/tetragon.FineGuidanceSensors/SetDebug"
+	FineGuidanceSensors_GetProcessTree_FullMethodName         = "/tetragon.FineGuidanceSensors/GetProcessTree"
+	FineGuidanceSensors_GetProcess_FullMethodName             = "/tetragon.FineGuidanceSensors/GetProcess"
 )
 
 // FineGuidanceSensorsClient is the client API for FineGuidanceSensors service.
@@ -75,6 +77,10 @@ type FineGuidanceSensorsClient interface {
 	RuntimeHook(ctx context.Context, in *RuntimeHookRequest, opts ...grpc.CallOption) (*RuntimeHookResponse, error)
 	GetDebug(ctx context.Context, in *GetDebugRequest, opts ...grpc.CallOption) (*GetDebugResponse, error)
 	SetDebug(ctx context.Context, in *SetDebugRequest, opts ...grpc.CallOption) (*SetDebugResponse, error)
+	// GetProcessTree returns the processes of the process cache.
+	GetProcessTree(ctx context.Context, in *GetProcessTreeRequest, opts ...grpc.CallOption) (*GetProcessTreeResponse, error)
+	// GetProcess returns a process of the process cache by exec ID.
+	GetProcess(ctx context.Context, in *GetProcessRequest, opts ...grpc.CallOption) (*GetProcessResponse, error)
 }
 
 type fineGuidanceSensorsClient struct {
@@ -270,6 +276,26 @@ func (c *fineGuidanceSensorsClient) SetDebug(ctx context.Context, in *SetDebugRe
 	return out, nil
 }
 
+func (c *fineGuidanceSensorsClient) GetProcessTree(ctx context.Context, in *GetProcessTreeRequest, opts ...grpc.CallOption) (*GetProcessTreeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetProcessTreeResponse)
+	err := c.cc.Invoke(ctx, FineGuidanceSensors_GetProcessTree_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fineGuidanceSensorsClient) GetProcess(ctx context.Context, in *GetProcessRequest, opts ...grpc.CallOption) (*GetProcessResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetProcessResponse)
+	err := c.cc.Invoke(ctx, FineGuidanceSensors_GetProcess_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // FineGuidanceSensorsServer is the server API for FineGuidanceSensors service.
 // All implementations must embed UnimplementedFineGuidanceSensorsServer
 // for forward compatibility.
@@ -304,6 +330,10 @@ type FineGuidanceSensorsServer interface {
 	RuntimeHook(context.Context, *RuntimeHookRequest) (*RuntimeHookResponse, error)
 	GetDebug(context.Context, *GetDebugRequest) (*GetDebugResponse, error)
 	SetDebug(context.Context, *SetDebugRequest) (*SetDebugResponse, error)
+	// GetProcessTree returns the processes of the process cache.
+	GetProcessTree(context.Context, *GetProcessTreeRequest) (*GetProcessTreeResponse, error)
+	// GetProcess returns a process of the process cache by exec ID.
+	GetProcess(context.Context, *GetProcessRequest) (*GetProcessResponse, error)
 	mustEmbedUnimplementedFineGuidanceSensorsServer()
 }
 
@@ -365,6 +395,12 @@ func (UnimplementedFineGuidanceSensorsServer) GetDebug(context.Context, *GetDebu
 func (UnimplementedFineGuidanceSensorsServer) SetDebug(context.Context, *SetDebugRequest) (*SetDebugResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SetDebug not implemented")
 }
+func (UnimplementedFineGuidanceSensorsServer) GetProcessTree(context.Context, *GetProcessTreeRequest) (*GetProcessTreeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProcessTree not implemented")
+}
+func (UnimplementedFineGuidanceSensorsServer) GetProcess(context.Context, *GetProcessRequest) (*GetProcessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProcess not implemented")
+}
 func (UnimplementedFineGuidanceSensorsServer) mustEmbedUnimplementedFineGuidanceSensorsServer() {}
 func (UnimplementedFineGuidanceSensorsServer) testEmbeddedByValue()                             {}
 
@@ -685,6 +721,42 @@ func _FineGuidanceSensors_SetDebug_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _FineGuidanceSensors_GetProcessTree_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProcessTreeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FineGuidanceSensorsServer).GetProcessTree(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FineGuidanceSensors_GetProcessTree_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FineGuidanceSensorsServer).GetProcessTree(ctx, req.(*GetProcessTreeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FineGuidanceSensors_GetProcess_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetProcessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FineGuidanceSensorsServer).GetProcess(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: FineGuidanceSensors_GetProcess_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FineGuidanceSensorsServer).GetProcess(ctx, req.(*GetProcessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // FineGuidanceSensors_ServiceDesc is the grpc.ServiceDesc for FineGuidanceSensors service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -756,6 +828,14 @@ var FineGuidanceSensors_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SetDebug",
 			Handler:    _FineGuidanceSensors_SetDebug_Handler,
 		},
+		{
+			MethodName: "GetProcessTree",
+			Handler:    _FineGuidanceSensors_GetProcessTree_Handler,
+		},
+		{
+			MethodName: "GetProcess",
+			Handler:    _FineGuidanceSensors_GetProcess_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -221,6 +221,14 @@ func (event *RateLimitInfo) Encapsulate() IsGetEventsResponse_Event {
 	}
 }
 
+// Encapsulate implements the Event interface.
+// Returns the event wrapped by its GetEventsResponse_* type.
+func (event *LostEvents) Encapsulate() IsGetEventsResponse_Event {
+	return &GetEventsResponse_LostEvents{
+		LostEvents: event,
+	}
+}
+
 // Encapsulate implements the Event interface.
 // Returns the event wrapped by its GetEventsResponse_* type.
 func (event *ProcessThrottle) Encapsulate() IsGetEventsResponse_Event {
@@ -229,6 +237,26 @@ func (event *ProcessThrottle) Encapsulate() IsGetEventsResponse_Event {
 	}
 }
 
+// Encapsulate implements the Event interface.
+// Returns the event wrapped by its GetEventsResponse_* type.
+func (event *ProcessUpdate) Encapsulate() IsGetEventsResponse_Event {
+	return &GetEventsResponse_ProcessUpdate{
+		ProcessUpdate: event,
+	}
+}
+
+// SetProcess implements the ProcessEvent interface.
+// Sets the Process field of an event.
+func (event *ProcessUpdate) SetProcess(p *Process) {
+	event.Process = p
+}
+
+// SetParent implements the ParentEvent interface.
+// Sets the Parent field of an event.
+func (event *ProcessUpdate) SetParent(p *Process) {
+	event.Parent = p
+}
+
 // UnwrapGetEventsResponse gets the inner event type from a GetEventsResponse
 func UnwrapGetEventsResponse(response *GetEventsResponse) interface{} {
 	event := response.GetEvent()
@@ -254,8 +282,12 @@ func UnwrapGetEventsResponse(response *GetEventsResponse) interface{} {
 		return ev.ProcessLoader
 	case *GetEventsResponse_RateLimitInfo:
 		return ev.RateLimitInfo
+	case *GetEventsResponse_LostEvents:
+		return ev.LostEvents
 	case *GetEventsResponse_ProcessThrottle:
 		return ev.ProcessThrottle
+	case *GetEventsResponse_ProcessUpdate:
+		return ev.ProcessUpdate
 	}
 	return nil
 }
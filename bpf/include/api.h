@@ -248,6 +248,8 @@ static int BPF_FUNC(override_return, void *regs, uint64_t rc);
 static long BPF_FUNC(get_stackid, void *ctx, void *map, uint64_t flags);
 static long BPF_FUNC(loop, __u32 nr_loops, void *callback_fn, void *callback_ctx, __u64 flags);
 static __u64 BPF_FUNC(get_attach_cookie, void *ctx);
+static long BPF_FUNC(get_func_arg, void *ctx, __u32 n, __u64 *value);
+static long BPF_FUNC(get_func_ret, void *ctx, __u64 *value);
 
 /* Perf and Rignbuffer */
 static int BPF_FUNC(perf_event_output, void *ctx, void *map, uint64_t flags, void *data, uint64_t size);
@@ -12,6 +12,10 @@ enum {
 
 struct policy_conf {
 	__u8 mode;
+	/* mode of the pods selected by the pod_mode_filter_id policy filter */
+	__u8 pod_mode;
+	/* policy filter id of the pods whose mode is pod_mode, 0 if none */
+	__u32 pod_mode_filter_id;
 } __attribute__((packed));
 
 struct {
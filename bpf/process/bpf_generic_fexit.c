@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: (GPL-2.0-only OR BSD-2-Clause)
+/* Copyright Authors of Cilium */
+
+#include "vmlinux.h"
+#include "api.h"
+
+#define GENERIC_FENTRY
+#define GENERIC_FEXIT
+
+#include "compiler.h"
+#include "bpf_event.h"
+#include "bpf_task.h"
+#include "retprobe_map.h"
+#include "types/operations.h"
+#include "types/basic.h"
+#include "policy_filter.h"
+
+char _license[] __attribute__((section("license"), used)) = "Dual BSD/GPL";
+
+int generic_fexit_setup_event(void *ctx);
+int generic_fexit_process_event(void *ctx);
+int generic_fexit_process_filter(void *ctx);
+int generic_fexit_filter_arg(void *ctx);
+int generic_fexit_actions(void *ctx);
+int generic_fexit_output(void *ctx);
+int generic_fexit_path(void *ctx);
+
+struct {
+	__uint(type, BPF_MAP_TYPE_PROG_ARRAY);
+	__uint(max_entries, 13);
+	__type(key, __u32);
+	__array(values, int(void *));
+} fexit_calls SEC(".maps") = {
+	.values = {
+		[TAIL_CALL_SETUP] = (void *)&generic_fexit_setup_event,
+		[TAIL_CALL_PROCESS] = (void *)&generic_fexit_process_event,
+		[TAIL_CALL_FILTER] = (void *)&generic_fexit_process_filter,
+		[TAIL_CALL_ARGS] = (void *)&generic_fexit_filter_arg,
+		[TAIL_CALL_ACTIONS] = (void *)&generic_fexit_actions,
+		[TAIL_CALL_SEND] = (void *)&generic_fexit_output,
+#ifndef __V61_BPF_PROG
+		[TAIL_CALL_PATH] = (void *)&generic_fexit_path,
+#endif
+	},
+};
+
+#include "generic_maps.h"
+#include "generic_calls.h"
+
+/* Generic fexit programs are the generic fentry programs (see
+ * bpf_generic_fentry.c) run at the function return: the trampoline context
+ * still holds the arguments, so a single event reports both the arguments
+ * and the return value, without the retprobe map used by kretprobes.
+ */
+__attribute__((section("fexit/generic_fexit"), used)) int
+generic_fexit_event(void *ctx)
+{
+	return generic_start_process_filter(ctx, (struct bpf_map_def *)&fexit_calls);
+}
+
+__attribute__((section("fexit"), used)) int
+generic_fexit_setup_event(void *ctx)
+{
+	return generic_process_event_and_setup(ctx, (struct bpf_map_def *)&fexit_calls);
+}
+
+__attribute__((section("fexit"), used)) int
+generic_fexit_process_event(void *ctx)
+{
+	return generic_process_event(ctx, (struct bpf_map_def *)&fexit_calls);
+}
+
+__attribute__((section("fexit"), used)) int
+generic_fexit_process_filter(void *ctx)
+{
+	int ret;
+
+	ret = generic_process_filter();
+	if (ret == PFILTER_CONTINUE)
+		tail_call(ctx, &fexit_calls, TAIL_CALL_FILTER);
+	else if (ret == PFILTER_ACCEPT)
+		tail_call(ctx, &fexit_calls, TAIL_CALL_SETUP);
+	return PFILTER_REJECT;
+}
+
+__attribute__((section("fexit"), used)) int
+generic_fexit_filter_arg(void *ctx)
+{
+	return generic_filter_arg(ctx, (struct bpf_map_def *)&fexit_calls, true);
+}
+
+__attribute__((section("fexit"), used)) int
+generic_fexit_actions(void *ctx)
+{
+	generic_actions(ctx, (struct bpf_map_def *)&fexit_calls);
+	return 0;
+}
+
+__attribute__((section("fexit"), used)) int
+generic_fexit_output(void *ctx)
+{
+	return generic_output(ctx, MSG_OP_GENERIC_KPROBE);
+}
+
+#ifndef __V61_BPF_PROG
+__attribute__((section("fexit"), used)) int
+generic_fexit_path(void *ctx)
+{
+	return generic_path(ctx, (struct bpf_map_def *)&fexit_calls);
+}
+#endif
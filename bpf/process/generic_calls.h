@@ -12,6 +12,7 @@
 #include "policy_conf.h"
 #include "generic_path.h"
 #include "bpf_ktime.h"
+#include "selector_stats.h"
 
 #define MAX_TOTAL 9000
 
@@ -607,6 +608,49 @@ generic_process_event_and_setup(struct pt_regs *ctx, struct bpf_map_def *tailcal
 	generic_process_init(e, MSG_OP_GENERIC_LSM, config);
 #endif
 
+#ifdef GENERIC_FENTRY
+	/* The trampoline context only holds the arguments of the function,
+	 * so use the helper that checks the index against their number.
+	 */
+	if (config->syscall) {
+		struct pt_regs *_ctx = 0;
+
+		get_func_arg(ctx, 0, (__u64 *)&_ctx);
+		if (!_ctx)
+			return 0;
+		e->a0 = PT_REGS_PARM1_CORE_SYSCALL(_ctx);
+		e->a1 = PT_REGS_PARM2_CORE_SYSCALL(_ctx);
+		e->a2 = PT_REGS_PARM3_CORE_SYSCALL(_ctx);
+		e->a3 = PT_REGS_PARM4_CORE_SYSCALL(_ctx);
+		e->a4 = PT_REGS_PARM5_CORE_SYSCALL(_ctx);
+	} else {
+		e->a0 = 0;
+		e->a1 = 0;
+		e->a2 = 0;
+		e->a3 = 0;
+		e->a4 = 0;
+		get_func_arg(ctx, 0, (__u64 *)&e->a0);
+		get_func_arg(ctx, 1, (__u64 *)&e->a1);
+		get_func_arg(ctx, 2, (__u64 *)&e->a2);
+		get_func_arg(ctx, 3, (__u64 *)&e->a3);
+		get_func_arg(ctx, 4, (__u64 *)&e->a4);
+	}
+	generic_process_init(e, MSG_OP_GENERIC_KPROBE, config);
+
+#ifdef GENERIC_FEXIT
+	/* The return value goes first, so the user space knows where it
+	 * is without parsing the arguments.
+	 */
+	ty = config->argreturn;
+	if (ty > 0) {
+		__u64 ret = 0;
+
+		get_func_ret(ctx, &ret);
+		e->common.size = read_arg(ctx, e, 0, ty, 0, ret, 0);
+	}
+#endif
+#endif
+
 #ifdef GENERIC_UPROBE
 	/* no arguments for uprobes for now */
 	e->a0 = PT_REGS_PARM1_CORE(ctx);
@@ -671,6 +715,7 @@ do_action(void *ctx, __u32 i, struct selector_action *actions, bool *post, bool
 	int newfdi, oldfdi;
 	int socki;
 	int argi __maybe_unused;
+	__u32 state __maybe_unused;
 	int err = 0;
 	int zero = 0;
 
@@ -686,8 +731,10 @@ do_action(void *ctx, __u32 i, struct selector_action *actions, bool *post, bool
 		__u64 ratelimit_interval __maybe_unused = actions->act[++i];
 		__u64 ratelimit_scope __maybe_unused = actions->act[++i];
 #ifdef __LARGE_BPF_PROG
-		if (rate_limit(ratelimit_interval, ratelimit_scope, e))
+		if (rate_limit(ratelimit_interval, ratelimit_scope, e)) {
 			*post = false;
+			selector_stats_inc(e, SELECTOR_STATS_RATELIMITED);
+		}
 #endif /* __LARGE_BPF_PROG */
 		__u32 kernel_stack_trace = actions->act[++i];
 
@@ -732,13 +779,18 @@ do_action(void *ctx, __u32 i, struct selector_action *actions, bool *post, bool
 	case ACTION_SIGNAL:
 		signal = actions->act[++i];
 	case ACTION_SIGKILL:
-		if (enforce_mode)
+		if (enforce_mode) {
 			do_action_signal(signal);
+			if (signal == FGS_SIGKILL)
+				selector_stats_inc(e, SELECTOR_STATS_SIGKILL);
+		}
 		break;
 	case ACTION_OVERRIDE:
 		error = actions->act[++i];
-		if (enforce_mode)
+		if (enforce_mode) {
 			do_override_action(error);
+			selector_stats_inc(e, SELECTOR_STATS_OVERRIDE);
+		}
 		break;
 	case ACTION_GETURL:
 	case ACTION_DNSLOOKUP:
@@ -759,6 +811,13 @@ do_action(void *ctx, __u32 i, struct selector_action *actions, bool *post, bool
 		break;
 	case ACTION_CLEANUP_ENFORCER_NOTIFICATION:
 		do_enforcer_cleanup();
+		break;
+	case ACTION_SET_STATE:
+		state = actions->act[++i];
+#ifdef __LARGE_BPF_PROG
+		set_process_state(&e->current, state);
+#endif
+		break;
 	default:
 		break;
 	}
@@ -790,8 +849,15 @@ do_actions(void *ctx, struct selector_action *actions)
 	 * actions
 	 */
 	pcnf = map_lookup_elem(&policy_conf, &zero);
-	if (pcnf && pcnf->mode == POLICY_MODE_MONITOR)
-		enforce_mode = false;
+	if (pcnf) {
+		__u8 mode = pcnf->mode;
+
+		/* pods selected by the pod mode filter override the policy mode */
+		if (pcnf->pod_mode_filter_id && policy_filter_check(pcnf->pod_mode_filter_id))
+			mode = pcnf->pod_mode;
+		if (mode == POLICY_MODE_MONITOR)
+			enforce_mode = false;
+	}
 
 #ifndef __LARGE_BPF_PROG
 #pragma unroll
@@ -839,8 +905,10 @@ generic_actions(void *ctx, struct bpf_map_def *calls)
 	actions = (struct selector_action *)&f[actoff];
 
 	postit = do_actions(ctx, actions);
-	if (postit)
+	if (postit) {
+		selector_stats_inc(e, SELECTOR_STATS_POST);
 		tail_call(ctx, calls, TAIL_CALL_SEND);
+	}
 	return postit;
 }
 
@@ -1153,6 +1221,7 @@ FUNC_INLINE long generic_filter_arg(void *ctx, struct bpf_map_def *tailcalls,
 	// If pass >1 then we need to consult the selector actions
 	// otherwise pass==1 indicates using default action.
 	if (pass > 1) {
+		selector_stats_inc(e, SELECTOR_STATS_MATCH);
 		e->pass = pass;
 		tail_call(ctx, tailcalls, TAIL_CALL_ACTIONS);
 	}
@@ -1,6 +1,8 @@
 #ifndef __PFILTER_H__
 #define __PFILTER_H__
 
+#include "process_state.h"
+
 /**
  * Process filters (see generic_process_filter)
  */
@@ -429,6 +431,11 @@ selector_process_filter(__u32 *f, __u32 index, struct execve_map_value *enter,
 	if (!match_binaries(index, enter))
 		return 0;
 
+#ifdef __LARGE_BPF_PROG
+	if (!match_states(index, enter))
+		return 0;
+#endif
+
 	/* Find selector offset byte index */
 	index *= 4;
 	index += 4;
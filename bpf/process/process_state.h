@@ -0,0 +1,111 @@
+// SPDX-License-Identifier: (GPL-2.0-only OR BSD-2-Clause)
+/* Copyright Authors of Tetragon */
+
+#ifndef __PROCESS_STATE_H__
+#define __PROCESS_STATE_H__
+
+#include "bpf_ktime.h"
+
+/* Process states are set by the SetState action and checked by the matchState
+ * selectors of the hooks of the same policy, which share process_state_map.
+ */
+
+/* If you update the value of PROCESS_STATE_MAX_VALUES below you should
+ * also update MaxMatchStateValues in pkg/selectors/state.go
+ */
+#define PROCESS_STATE_MAX_VALUES 4
+
+struct process_state_key {
+	__u32 pid; /* thread group id */
+	__u32 state; /* state id, see StateID() in pkg/selectors/state.go */
+};
+
+struct process_state_value {
+	__u64 exec_ktime; /* ktime of the process exec, to detect pid reuse */
+	__u64 ktime; /* time the state was set */
+};
+
+struct {
+	__uint(type, BPF_MAP_TYPE_LRU_HASH);
+	__uint(max_entries, 1); // Agent is resizing this if the policy sets states
+	__type(key, struct process_state_key);
+	__type(value, struct process_state_value);
+} process_state_map SEC(".maps");
+
+struct match_state_sel_opts {
+	__u32 op; /* op_filter_in or op_filter_notin, 0 if there is no matchState */
+	__u32 len; /* number of states */
+	__u64 within; /* in ns, 0 if states do not expire */
+	__u32 states[PROCESS_STATE_MAX_VALUES];
+};
+
+// This map is used by the matchState selectors to retrieve their options
+struct {
+	__uint(type, BPF_MAP_TYPE_ARRAY);
+	__uint(max_entries, MAX_SELECTORS);
+	__type(key, __u32); /* selector id */
+	__type(value, struct match_state_sel_opts);
+} tg_ms_sel_opts SEC(".maps");
+
+#ifdef __LARGE_BPF_PROG
+FUNC_INLINE void
+set_process_state(struct msg_execve_key *current, __u32 state)
+{
+	struct process_state_key key = {
+		.pid = current->pid,
+		.state = state,
+	};
+	struct process_state_value value = {
+		.exec_ktime = current->ktime,
+		.ktime = tg_get_ktime(),
+	};
+
+	map_update_elem(&process_state_map, &key, &value, BPF_ANY);
+}
+
+FUNC_INLINE bool
+process_state_is_set(struct execve_map_value *enter, __u32 state, __u64 within, __u64 now)
+{
+	struct process_state_key key = {
+		.pid = enter->key.pid,
+		.state = state,
+	};
+	struct process_state_value *value;
+
+	value = map_lookup_elem(&process_state_map, &key);
+	if (!value || value->exec_ktime != enter->key.ktime)
+		return false;
+	return !within || now - value->ktime <= within;
+}
+
+/* match_states: returns whether the process matches the matchState filter of
+ * the selector, if any.
+ */
+FUNC_INLINE int
+match_states(__u32 selidx, struct execve_map_value *enter)
+{
+	struct match_state_sel_opts *opts;
+	bool found = false;
+	__u64 now;
+	int i;
+
+	opts = map_lookup_elem(&tg_ms_sel_opts, &selidx);
+	if (!opts || opts->op == op_filter_none)
+		return 1;
+
+	now = tg_get_ktime();
+#pragma unroll
+	for (i = 0; i < PROCESS_STATE_MAX_VALUES; i++) {
+		if (i >= opts->len)
+			break;
+		if (process_state_is_set(enter, opts->states[i], opts->within, now)) {
+			found = true;
+			break;
+		}
+	}
+
+	return opts->op == op_filter_notin ? !found : found;
+}
+#endif /* __LARGE_BPF_PROG */
+
+#endif /* __PROCESS_STATE_H__ */
@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: (GPL-2.0-only OR BSD-2-Clause)
+/* Copyright Authors of Tetragon */
+
+#ifndef __SELECTOR_STATS_H__
+#define __SELECTOR_STATS_H__
+
+#include "bpf_ktime.h"
+
+/* Per-selector counters, see selectorStatsValue in pkg/sensors/tracing/selectorstats.go.
+ * Return kprobes do not count, so that a hook is only accounted once.
+ */
+struct selector_stats {
+	__u64 match; /* arguments filters of the selector matched */
+	__u64 post; /* event was posted to user space */
+	__u64 override; /* override action was applied */
+	__u64 sigkill; /* process was killed */
+	__u64 ratelimited; /* event was dropped by the post action rate limit */
+	__u64 last_match; /* ktime of the last match */
+};
+
+enum {
+	SELECTOR_STATS_MATCH,
+	SELECTOR_STATS_POST,
+	SELECTOR_STATS_OVERRIDE,
+	SELECTOR_STATS_SIGKILL,
+	SELECTOR_STATS_RATELIMITED,
+};
+
+/* Entries are indexed by hook index (e->idx) * MAX_SELECTORS + selector index.
+ * Agent is resizing this for programs with multiple hooks.
+ */
+struct {
+	__uint(type, BPF_MAP_TYPE_PERCPU_ARRAY);
+	__uint(max_entries, MAX_SELECTORS);
+	__type(key, __u32);
+	__type(value, struct selector_stats);
+} selector_stats SEC(".maps");
+
+#ifdef GENERIC_KRETPROBE
+#define selector_stats_inc(e, counter)
+#else
+FUNC_INLINE void
+selector_stats_inc(struct msg_generic_kprobe *e, int counter)
+{
+	struct selector_stats *stats;
+	__u32 key;
+
+	key = e->idx * MAX_SELECTORS + (e->tailcall_index_selector & MAX_SELECTORS_MASK);
+	stats = map_lookup_elem(&selector_stats, &key);
+	if (!stats)
+		return;
+
+	switch (counter) {
+	case SELECTOR_STATS_MATCH:
+		stats->match++;
+		stats->last_match = tg_get_ktime();
+		break;
+	case SELECTOR_STATS_POST:
+		stats->post++;
+		break;
+	case SELECTOR_STATS_OVERRIDE:
+		stats->override++;
+		break;
+	case SELECTOR_STATS_SIGKILL:
+		stats->sigkill++;
+		break;
+	case SELECTOR_STATS_RATELIMITED:
+		stats->ratelimited++;
+		break;
+	}
+}
+#endif
+
+#endif /* __SELECTOR_STATS_H__ */
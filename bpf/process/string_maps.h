@@ -276,4 +276,33 @@ struct {
 	__type(value, struct string_postfix_lpm_trie);
 } string_postfix_maps_heap SEC(".maps");
 
+/* Glob and regex values are compiled into a DFA. Each inner map holds its
+ * transitions as (state << 8 | character) -> next state, starting from state
+ * 0. Accepting states have a transition on the 0 character to
+ * STRING_DFA_ACCEPT_STATE.
+ */
+#define STRING_DFA_MAX_LENGTH	256
+#define STRING_DFA_MAX_MASK	(STRING_DFA_MAX_LENGTH - 1)
+#define STRING_DFA_ACCEPT_STATE 0xffff
+
+struct {
+	__uint(type, BPF_MAP_TYPE_ARRAY_OF_MAPS);
+	__uint(max_entries, STRING_MAPS_OUTER_MAX_ENTRIES);
+	__type(key, __u32);
+	__array(
+		values, struct {
+			__uint(type, BPF_MAP_TYPE_HASH);
+			__uint(max_entries, 1);
+			__type(key, __u32);
+			__type(value, __u16);
+		});
+} string_dfa_maps SEC(".maps");
+
+struct {
+	__uint(type, BPF_MAP_TYPE_PERCPU_ARRAY);
+	__uint(max_entries, 1);
+	__type(key, __u32);
+	__type(value, __u8[STRING_DFA_MAX_LENGTH]);
+} string_dfa_maps_heap SEC(".maps");
+
 #endif // STRING_MAPS_H__
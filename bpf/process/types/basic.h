@@ -121,6 +121,7 @@ enum {
 	ACTION_UNTRACKSOCK = 11,
 	ACTION_NOTIFY_ENFORCER = 12,
 	ACTION_CLEANUP_ENFORCER_NOTIFICATION = 13,
+	ACTION_SET_STATE = 14,
 };
 
 enum {
@@ -857,9 +858,65 @@ filter_char_buf_postfix(struct selector_arg_filter *filter, char *arg_str, uint
 	return !!pass;
 }
 
+#ifdef __LARGE_BPF_PROG
+/* string_dfa_match: runs the DFA compiled from Glob or Regex values over the
+ * string. Strings of STRING_DFA_MAX_LENGTH characters or more do not match.
+ */
+FUNC_LOCAL long
+string_dfa_match(void *dfa, char *str, uint len)
+{
+	__u32 state = 0, key;
+	__u16 *next;
+	int zero = 0;
+	__u8 *buf;
+	uint i;
+
+	if (len >= STRING_DFA_MAX_LENGTH)
+		return 0;
+
+	buf = map_lookup_elem(&string_dfa_maps_heap, &zero);
+	if (!buf)
+		return 0;
+
+	asm volatile("%[len] &= %[mask] ;\n"
+		     : [len] "+r"(len)
+		     : [mask] "i"(STRING_DFA_MAX_MASK));
+	if (probe_read(buf, len & STRING_DFA_MAX_MASK, str) < 0)
+		return 0;
+
+	for (i = 0; i < STRING_DFA_MAX_LENGTH - 1; i++) {
+		if (i >= len)
+			break;
+		key = state << 8 | buf[i & STRING_DFA_MAX_MASK];
+		next = map_lookup_elem(dfa, &key);
+		if (!next)
+			return 0;
+		state = *next;
+	}
+
+	key = state << 8;
+	next = map_lookup_elem(dfa, &key);
+	return next && *next == STRING_DFA_ACCEPT_STATE;
+}
+
+FUNC_LOCAL long
+filter_char_buf_dfa(struct selector_arg_filter *filter, char *arg_str, uint arg_len)
+{
+	__u32 map_idx = *(__u32 *)&filter->value;
+	void *dfa;
+
+	dfa = map_lookup_elem(&string_dfa_maps, &map_idx);
+	if (!dfa)
+		return 0;
+
+	return string_dfa_match(dfa, arg_str, arg_len);
+}
+#endif /* __LARGE_BPF_PROG */
+
 FUNC_INLINE bool is_not_operator(__u32 op)
 {
-	return (op == op_filter_neq || op == op_filter_str_notprefix || op == op_filter_str_notpostfix || op == op_filter_notin);
+	return (op == op_filter_neq || op == op_filter_str_notprefix || op == op_filter_str_notpostfix || op == op_filter_notin ||
+		op == op_filter_str_notglob || op == op_filter_str_notregex);
 }
 
 FUNC_LOCAL long
@@ -883,6 +940,14 @@ filter_char_buf(struct selector_arg_filter *filter, char *args, int value_off)
 	case op_filter_str_notpostfix:
 		match = filter_char_buf_postfix(filter, arg_str, len);
 		break;
+#ifdef __LARGE_BPF_PROG
+	case op_filter_str_glob:
+	case op_filter_str_notglob:
+	case op_filter_str_regex:
+	case op_filter_str_notregex:
+		match = filter_char_buf_dfa(filter, arg_str, len);
+		break;
+#endif /* __LARGE_BPF_PROG */
 	}
 
 	return is_not_operator(filter->op) ? !match : match;
@@ -922,6 +987,14 @@ filter_file_buf(struct selector_arg_filter *filter, struct string_buf *args)
 	case op_filter_str_notpostfix:
 		match = filter_char_buf_postfix(filter, args->buf, args->len);
 		break;
+#ifdef __LARGE_BPF_PROG
+	case op_filter_str_glob:
+	case op_filter_str_notglob:
+	case op_filter_str_regex:
+	case op_filter_str_notregex:
+		match = filter_char_buf_dfa(filter, args->buf, args->len);
+		break;
+#endif /* __LARGE_BPF_PROG */
 	}
 
 	return is_not_operator(filter->op) ? !match : match;
@@ -1210,7 +1283,7 @@ filter_64ty_selector_val(struct selector_arg_filter *filter, char *args)
 				if (*(s64 *)args > (s64)w)
 					return 1;
 			} else {
-				if (*(u64 *)args < w)
+				if (*(u64 *)args > w)
 					return 1;
 			}
 			break;
@@ -1551,6 +1624,15 @@ FUNC_INLINE int match_binaries(__u32 selidx, struct execve_map_value *current)
 					return 0;
 			found_key = map_lookup_elem(path_map, postfix_key);
 			break;
+		case op_filter_str_glob:
+		case op_filter_str_notglob:
+		case op_filter_str_regex:
+		case op_filter_str_notregex:
+			path_map = map_lookup_elem(&string_dfa_maps, &selector_options->map_id);
+			if (!path_map)
+				return 0;
+			match = string_dfa_match(path_map, current->bin.path, current->bin.path_length);
+			return is_not_operator(selector_options->op) ? !match : match;
 #endif /* __LARGE_BPF_PROG */
 		default:
 			// should not happen
@@ -2020,7 +2102,7 @@ struct {
 	__uint(value_size, sizeof(__u64) * PERF_MAX_STACK_DEPTH);
 } stack_trace_map SEC(".maps");
 
-#if defined GENERIC_TRACEPOINT || defined GENERIC_KPROBE
+#if defined GENERIC_TRACEPOINT || defined GENERIC_KPROBE || defined GENERIC_FENTRY
 FUNC_INLINE void do_action_notify_enforcer(struct msg_generic_kprobe *e,
 					   int error, int signal, int info_arg_id)
 {
@@ -43,6 +43,11 @@ enum {
 	op_filter_state = 29,
 	// capability ops
 	op_capabilities_gained = 30,
+	// more string ops
+	op_filter_str_glob = 31,
+	op_filter_str_notglob = 32,
+	op_filter_str_regex = 33,
+	op_filter_str_notregex = 34,
 };
 
 #endif // __OPERATIONS_H__
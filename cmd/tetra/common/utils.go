@@ -8,6 +8,7 @@ import (
 	"io"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/cilium/tetragon/api/v1/tetragon"
 )
@@ -83,3 +84,26 @@ func PrintTracingPolicies(output io.Writer, policies []*tetragon.TracingPolicySt
 	}
 	w.Flush()
 }
+
+func PrintTracingPolicySelectorStats(output io.Writer, stats []*tetragon.TracingPolicySelectorStats) {
+	w := tabwriter.NewWriter(output, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "HOOK\tSELECTOR\tMATCH\tPOST\tOVERRIDE\tSIGKILL\tRATELIMITED\tLASTMATCH")
+
+	for _, s := range stats {
+		lastMatch := "never"
+		if s.LastMatchTime != nil {
+			lastMatch = s.LastMatchTime.AsTime().Local().Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%d\t%d\t%s\n",
+			s.Hook,
+			s.Selector,
+			s.MatchCount,
+			s.PostCount,
+			s.OverrideCount,
+			s.SigkillCount,
+			s.RatelimitedCount,
+			lastMatch,
+		)
+	}
+	w.Flush()
+}
@@ -5,7 +5,10 @@ package common
 
 import (
 	"strconv"
+	"strings"
 	"testing"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
 )
 
 func TestHumanizeByteCount(t *testing.T) {
@@ -32,3 +35,18 @@ func TestHumanizeByteCount(t *testing.T) {
 		})
 	}
 }
+
+func TestPrintTracingPolicySelectorStats(t *testing.T) {
+	var out strings.Builder
+	PrintTracingPolicySelectorStats(&out, []*tetragon.TracingPolicySelectorStats{
+		{Hook: "fd_install", Selector: 0, MatchCount: 4, PostCount: 3, RatelimitedCount: 1},
+		{Hook: "fd_install", Selector: 1},
+	})
+	want := `HOOK         SELECTOR   MATCH   POST   OVERRIDE   SIGKILL   RATELIMITED   LASTMATCH
+fd_install   0          4       3      0          0         1             never
+fd_install   1          0       0      0          0         0             never
+`
+	if got := out.String(); got != want {
+		t.Errorf("PrintTracingPolicySelectorStats() = %q, want %q", got, want)
+	}
+}
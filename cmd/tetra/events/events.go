@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/cmd/tetra/common"
+	"github.com/cilium/tetragon/cmd/tetra/getevents"
+	"github.com/cilium/tetragon/pkg/encoder"
+	"github.com/cilium/tetragon/pkg/filters"
+	"github.com/spf13/cobra"
+)
+
+func New() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "events",
+		Short: "Process exported events offline",
+	}
+	cmd.AddCommand(newQueryCmd())
+	return cmd
+}
+
+type queryOpts struct {
+	Request     string
+	InputFormat string
+	Output      string
+	Color       string
+	Timestamps  bool
+}
+
+func newQueryCmd() *cobra.Command {
+	var opts queryOpts
+	cmd := &cobra.Command{
+		Use:   "query [FILE]...",
+		Short: "Apply a GetEvents request to exported events",
+		Long: `This command reads events from export files, or from stdin if no file is
+given, and applies a GetEvents request to them: allow and deny lists
+(including CEL expressions), sampling, field filters and aggregation, the
+same way the agent does. The request uses the same YAML representation as
+export pipelines. Files are read in the given order as a single stream, so
+rotated files should be listed from the oldest to the newest. Compressed
+files (gzip or zstd) are detected. Aggregation windows are based on the time
+of the events. Examples:
+
+  # Apply a request to an export file and its rotated backups
+  tetra events query --request request.yaml \
+    tetragon-2024-01-01T00-00-00.000.log.gz tetragon.log
+
+  # request.yaml
+  allow_list:
+  - event_set: [PROCESS_KPROBE]
+    cel_expression: ["process_kprobe.policy_name == 'file-monitoring'"]
+  deny_list:
+  - namespace: [kube-system]
+  field_filters:
+  - fields: process.pod
+    action: EXCLUDE
+  aggregation_options:
+    window_size: 60s`,
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			if opts.Output != "json" && opts.Output != "compact" {
+				return fmt.Errorf("invalid value for %q flag: %s", common.KeyOutput, opts.Output)
+			}
+			if opts.InputFormat != encoder.FormatJSON && opts.InputFormat != encoder.FormatProtobuf {
+				return fmt.Errorf("invalid value for %q flag: %s. Supported are json and protobuf", "input-format", opts.InputFormat)
+			}
+			return nil
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			request := &tetragon.GetEventsRequest{}
+			if opts.Request != "" {
+				data, err := os.ReadFile(opts.Request)
+				if err != nil {
+					return err
+				}
+				// pidSet filters are fine offline, since the whole
+				// input is processed in order
+				request, err = filters.ParseGetEventsRequest(data, true)
+				if err != nil {
+					return fmt.Errorf("failed to parse request %s: %w", opts.Request, err)
+				}
+			}
+
+			readers := []io.Reader{os.Stdin}
+			if len(args) > 0 {
+				var closeFiles func()
+				var err error
+				readers, closeFiles, err = getevents.OpenFiles(args)
+				if err != nil {
+					return err
+				}
+				defer closeFiles()
+			}
+
+			eventEncoder := getevents.GetEncoder(os.Stdout, encoder.ColorMode(opts.Color), opts.Timestamps, opts.Output == "compact", "", true, true)
+			return query(context.Background(), request, readers, opts.InputFormat, eventEncoder)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVarP(&opts.Request, "request", "r", "", "File with the GetEvents request to apply, in YAML. All events are printed if not set")
+	flags.StringVar(&opts.InputFormat, "input-format", encoder.FormatJSON, "Format of the events. json or protobuf")
+	flags.StringVarP(&opts.Output, common.KeyOutput, "o", "json", "Output format. json or compact")
+	flags.StringVar(&opts.Color, "color", "auto", "Colorize compact output. auto, always, or never")
+	flags.BoolVar(&opts.Timestamps, "timestamps", false, "Include timestamps in compact output")
+	return cmd
+}
+
+// query applies request to the events of readers, and encodes the resulting
+// events.
+func query(ctx context.Context, request *tetragon.GetEventsRequest, readers []io.Reader, format string, enc encoder.EventEncoder) error {
+	client, err := getevents.NewIOReaderClient(readers, format, common.Debug)
+	if err != nil {
+		return err
+	}
+	stream, err := client.GetEvents(ctx, request)
+	if err != nil {
+		return err
+	}
+	defer stream.CloseSend()
+	for {
+		res, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read events: %w", err)
+		}
+		if err := enc.Encode(res); err != nil {
+			return fmt.Errorf("failed to encode event: %w", err)
+		}
+	}
+}
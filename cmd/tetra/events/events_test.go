@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package events
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/encoder"
+	"github.com/cilium/tetragon/pkg/filters"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type eventCollector struct {
+	events []*tetragon.GetEventsResponse
+}
+
+func (c *eventCollector) Encode(v interface{}) error {
+	c.events = append(c.events, v.(*tetragon.GetEventsResponse))
+	return nil
+}
+
+func kprobeEvent(ts int64, namespace, binary string) *tetragon.GetEventsResponse {
+	return &tetragon.GetEventsResponse{
+		Event: &tetragon.GetEventsResponse_ProcessKprobe{ProcessKprobe: &tetragon.ProcessKprobe{
+			Process: &tetragon.Process{
+				Binary: binary,
+				Pod:    &tetragon.Pod{Namespace: namespace, Name: "pod"},
+			},
+			FunctionName: "security_file_permission",
+			PolicyName:   "file-monitoring",
+		}},
+		Time: timestamppb.New(time.Unix(ts, 0)),
+	}
+}
+
+// exportFile returns the JSON lines export of events, gzip compressed if
+// compress is set.
+func exportFile(t *testing.T, compress bool, events ...*tetragon.GetEventsResponse) io.Reader {
+	var buf bytes.Buffer
+	var w io.Writer = &buf
+	var gw *gzip.Writer
+	if compress {
+		gw = gzip.NewWriter(&buf)
+		w = gw
+	}
+	enc := encoder.NewProtojsonEncoder(w)
+	for _, ev := range events {
+		require.NoError(t, enc.Encode(ev))
+	}
+	if gw != nil {
+		require.NoError(t, gw.Close())
+	}
+	return &buf
+}
+
+func TestQuery(t *testing.T) {
+	rotated := exportFile(t, true,
+		kprobeEvent(1, "default", "/usr/bin/cat"),
+		kprobeEvent(2, "kube-system", "/usr/bin/cat"),
+		&tetragon.GetEventsResponse{
+			Event: &tetragon.GetEventsResponse_ProcessExec{ProcessExec: &tetragon.ProcessExec{
+				Process: &tetragon.Process{Binary: "/usr/bin/cat"},
+			}},
+			Time: timestamppb.New(time.Unix(3, 0)),
+		},
+	)
+	current := exportFile(t, false,
+		kprobeEvent(4, "default", "/usr/bin/cat"),
+		kprobeEvent(5, "default", "/usr/bin/less"),
+		kprobeEvent(100, "default", "/usr/bin/cat"),
+	)
+
+	request, err := filters.ParseGetEventsRequest([]byte(`
+allow_list:
+- event_set: [PROCESS_KPROBE]
+  cel_expression: ["process_kprobe.policy_name == 'file-monitoring'"]
+deny_list:
+- namespace: [kube-system]
+field_filters:
+- fields: process.pod
+  action: EXCLUDE
+aggregation_options:
+  window_size: 60s
+  key:
+    binary: true
+`), true)
+	require.NoError(t, err)
+
+	var out eventCollector
+	require.NoError(t, query(context.Background(), request, []io.Reader{rotated, current}, encoder.FormatJSON, &out))
+
+	type result struct {
+		binary string
+		count  uint64
+		first  int64
+	}
+	var got []result
+	for _, ev := range out.events {
+		kprobe := ev.GetProcessKprobe()
+		require.NotNil(t, kprobe)
+		assert.Nil(t, kprobe.Process.Pod)
+		got = append(got, result{kprobe.Process.Binary, ev.AggregationInfo.Count, ev.AggregationInfo.FirstSeen.Seconds})
+	}
+	// the first window is flushed in an unspecified order
+	require.Len(t, got, 3)
+	assert.ElementsMatch(t, []result{{"/usr/bin/cat", 2, 1}, {"/usr/bin/less", 1, 5}}, got[:2])
+	assert.Equal(t, result{"/usr/bin/cat", 1, 100}, got[2])
+}
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -19,6 +20,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 type Opts struct {
@@ -42,10 +44,21 @@ type Opts struct {
 	CelExpression []string
 	Reconnect     bool
 	ReconnectWait time.Duration
+	SinceCursor   string
+	SampleOneInN  uint32
+	SamplePercent float64
+	SampleKey     string
+	Input         []string
+	InputFormat   string
 }
 
 var Options Opts
 
+// sinceCursor is the cursor after which events are read from the spool. It is
+// updated as events are received, so that reconnecting resumes after the
+// last received event.
+var sinceCursor *wrapperspb.UInt64Value
+
 // GetEncoder returns an encoder for an event stream based on configuration options.
 var GetEncoder = func(w io.Writer, colorMode encoder.ColorMode, timestamps bool, compact bool, tty string, stackTraces bool, imaHash bool) encoder.EventEncoder {
 	if tty != "" {
@@ -123,12 +136,30 @@ func getRequest(includeFields, excludeFields []string, filter *tetragon.Filter)
 	}
 }
 
+const samplingKeyPrefix = "SAMPLING_KEY_"
+
+// getSamplingOptions returns the sampling options of the request, or nil if
+// events are not sampled.
+func getSamplingOptions() *tetragon.SamplingOptions {
+	if Options.SampleOneInN == 0 && Options.SamplePercent == 0 {
+		return nil
+	}
+	return &tetragon.SamplingOptions{
+		OneInN:     Options.SampleOneInN,
+		Percentage: Options.SamplePercent,
+		Key:        tetragon.SamplingKey(tetragon.SamplingKey_value[samplingKeyPrefix+strings.ToUpper(Options.SampleKey)]),
+	}
+}
+
 func getEvents(ctx context.Context, client tetragon.FineGuidanceSensorsClient) error {
 	request := getRequest(Options.IncludeFields, Options.ExcludeFields, GetFilter())
+	request.SinceCursor = sinceCursor
+	request.SamplingOptions = getSamplingOptions()
 	stream, err := client.GetEvents(ctx, request)
 	if err != nil {
 		return fmt.Errorf("failed to call GetEvents: %w", err)
 	}
+	defer stream.CloseSend()
 	eventEncoder := GetEncoder(os.Stdout, encoder.ColorMode(Options.Color), Options.Timestamps, Options.Output == "compact", Options.TTYEncode, Options.StackTraces, Options.ImaHash)
 	for {
 		res, err := stream.Recv()
@@ -141,7 +172,46 @@ func getEvents(ctx context.Context, client tetragon.FineGuidanceSensorsClient) e
 		if err = eventEncoder.Encode(res); err != nil {
 			return fmt.Errorf("failed to encode event %#v: %w", res, err)
 		}
+		if sinceCursor != nil && res.Cursor != 0 {
+			sinceCursor = wrapperspb.UInt64(res.Cursor)
+		}
+	}
+}
+
+// getEventsFromFiles prints the events of export files.
+func getEventsFromFiles(names []string) error {
+	readers, closeFiles, err := OpenFiles(names)
+	if err != nil {
+		return err
+	}
+	defer closeFiles()
+	client, err := NewIOReaderClient(readers, Options.InputFormat, common.Debug)
+	if err != nil {
+		return err
+	}
+	return getEvents(context.Background(), client)
+}
+
+// OpenFiles opens files for NewIOReaderClient, and returns a function to
+// close them.
+func OpenFiles(names []string) ([]io.Reader, func(), error) {
+	var files []*os.File
+	closeFiles := func() {
+		for _, f := range files {
+			f.Close()
+		}
+	}
+	readers := make([]io.Reader, 0, len(names))
+	for _, name := range names {
+		f, err := os.Open(name)
+		if err != nil {
+			closeFiles()
+			return nil, nil, err
+		}
+		files = append(files, f)
+		readers = append(readers, f)
 	}
+	return readers, closeFiles, nil
 }
 
 func New() *cobra.Command {
@@ -161,7 +231,18 @@ redirection of events to the stdin. Examples:
   tetra getevents -F parent
 
   # Include only process and parent.pod fields
-  tetra getevents -f process,parent.pod`,
+  tetra getevents -f process,parent.pod
+
+  # Read spooled events after cursor 1234, and resume after the last
+  # received event when reconnecting
+  tetra getevents --since-cursor 1234 --reconnect
+
+  # Print the events of 1 in 10 binaries
+  tetra getevents --sample-one-in-n 10 --sample-key binary
+
+  # Replay a rotated binary protobuf export file and the current one
+  tetra getevents -o compact --input-format protobuf \
+    --input tetragon-2024-01-01T00-00-00.000.bin,tetragon.bin`,
 		PreRunE: func(_ *cobra.Command, _ []string) error {
 			if Options.Output != "json" && Options.Output != "compact" {
 				return fmt.Errorf("invalid value for %q flag: %s", common.KeyOutput, Options.Output)
@@ -186,13 +267,38 @@ redirection of events to the stdin. Examples:
 			Options.Pods = append(Options.Pod, Options.Pods...)
 			Options.Processes = append(Options.Process, Options.Processes...)
 
+			if _, found := tetragon.SamplingKey_value[samplingKeyPrefix+strings.ToUpper(Options.SampleKey)]; !found {
+				return fmt.Errorf("invalid value for %q flag: %s. Supported are exec_id, pod and binary", "sample-key", Options.SampleKey)
+			}
+
+			if Options.InputFormat != encoder.FormatJSON && Options.InputFormat != encoder.FormatProtobuf {
+				return fmt.Errorf("invalid value for %q flag: %s. Supported are json and protobuf", "input-format", Options.InputFormat)
+			}
+
+			if Options.SinceCursor != "" {
+				cursor, err := strconv.ParseUint(Options.SinceCursor, 10, 64)
+				if err != nil {
+					return fmt.Errorf("invalid value for %q flag: %s", "since-cursor", Options.SinceCursor)
+				}
+				sinceCursor = wrapperspb.UInt64(cursor)
+			}
+
 			return nil
 		},
 		RunE: func(_ *cobra.Command, _ []string) error {
+			if len(Options.Input) > 0 {
+				// read events from files, in order
+				return getEventsFromFiles(Options.Input)
+			}
+
 			fi, _ := os.Stdin.Stat()
 			if fi.Mode()&os.ModeNamedPipe != 0 {
 				// read events from stdin
-				return getEvents(context.Background(), newIOReaderClient(os.Stdin, common.Debug))
+				client, err := newIOReaderClient(os.Stdin, Options.InputFormat, common.Debug)
+				if err != nil {
+					return err
+				}
+				return getEvents(context.Background(), client)
 			}
 
 			reconnect := Options.Reconnect
@@ -251,5 +357,11 @@ redirection of events to the stdin. Examples:
 	flags.StringSliceVar(&Options.CelExpression, "cel-expression", nil, "Get events satisfying the CEL expression")
 	flags.BoolVar(&Options.Reconnect, "reconnect", false, "Keep trying to connect even if an error occurred")
 	flags.DurationVar(&Options.ReconnectWait, "reconnect-wait", 2*time.Second, "wait time before attempting to reconnect")
+	flags.StringVar(&Options.SinceCursor, "since-cursor", "", "Read events from the agent's event spool, starting after the given cursor (0 for the oldest spooled event)")
+	flags.Uint32Var(&Options.SampleOneInN, "sample-one-in-n", 0, "Sample the events of 1 in every N values of the sample key")
+	flags.Float64Var(&Options.SamplePercent, "sample-percentage", 0, "Sample the events of the given percentage of the values of the sample key")
+	flags.StringVar(&Options.SampleKey, "sample-key", "exec_id", "Field on which sampling is based. exec_id, pod, or binary")
+	flags.StringSliceVar(&Options.Input, "input", nil, "Read events from export files instead of the server, in the given order")
+	flags.StringVar(&Options.InputFormat, "input-format", encoder.FormatJSON, "Format of the events read from files or stdin. json or protobuf (zstd compression is detected)")
 	return &cmd
 }
@@ -5,35 +5,176 @@ package getevents
 
 import (
 	"bufio"
+	"bytes"
+	"compress/gzip"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 
 	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/aggregator"
+	"github.com/cilium/tetragon/pkg/encoder"
 	"github.com/cilium/tetragon/pkg/event"
 	"github.com/cilium/tetragon/pkg/fieldfilters"
 	"github.com/cilium/tetragon/pkg/filters"
+	"github.com/klauspost/compress/zstd"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protodelim"
 	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 )
 
 // ioReaderClient implements tetragon.FineGuidanceSensors_GetEventsClient.
 // ioReaderObserver implements tetragon.FineGuidanceSensorsClient interface. It reads Tetragon events
 type ioReaderClient struct {
-	scanner      *bufio.Scanner
+	// read returns the next event of the stream, or io.EOF at its end
+	read         func() (*tetragon.GetEventsResponse, error)
 	allowlist    filters.FilterFuncs
+	denylist     filters.FilterFuncs
 	fieldFilters []*fieldfilters.FieldFilter
-	unmarshaller protojson.UnmarshalOptions
-	debug        bool
+	sampler      *filters.Sampler
+	computed     *filters.ComputedFields
+	ctx          context.Context
+	aggregator   *aggregator.Aggregator
+	// pending are the events sent by the aggregator and not received yet
+	pending pendingEvents
+	eof     bool
+	debug   bool
+	// closers release the decompressors of the input
+	closers []io.Closer
 	grpc.ClientStream
 }
 
-func newIOReaderClient(reader io.Reader, debug bool) *ioReaderClient {
-	return &ioReaderClient{
-		scanner:      bufio.NewScanner(reader),
-		unmarshaller: protojson.UnmarshalOptions{DiscardUnknown: true},
-		debug:        debug,
+// pendingEvents queues the events sent by the aggregator.
+type pendingEvents struct {
+	tetragon.FineGuidanceSensors_GetEventsServer
+	events []*tetragon.GetEventsResponse
+}
+
+func (p *pendingEvents) Send(event *tetragon.GetEventsResponse) error {
+	p.events = append(p.events, event)
+	return nil
+}
+
+func (p *pendingEvents) pop() (*tetragon.GetEventsResponse, bool) {
+	if len(p.events) == 0 {
+		return nil, false
+	}
+	ev := p.events[0]
+	p.events = p.events[1:]
+	return ev, true
+}
+
+var (
+	// zstdMagic is the magic number at the start of every zstd frame.
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+	// gzipMagic is the magic number at the start of gzip files, such as
+	// rotated export files compressed with --export-file-compress.
+	gzipMagic = []byte{0x1f, 0x8b}
+)
+
+// decompress returns a reader of the decompressed content of reader if it is
+// zstd or gzip compressed, and of reader itself otherwise. Closing the returned
+// reader releases the decompressor, but does not close reader.
+func decompress(reader io.Reader) (io.ReadCloser, error) {
+	br := bufio.NewReader(reader)
+	magic, _ := br.Peek(len(zstdMagic))
+	switch {
+	case bytes.HasPrefix(magic, zstdMagic):
+		// the input is a single stream, so don't start concurrent decoders
+		zr, err := zstd.NewReader(br, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create zstd reader: %w", err)
+		}
+		return zr.IOReadCloser(), nil
+	case bytes.HasPrefix(magic, gzipMagic):
+		gr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create gzip reader: %w", err)
+		}
+		return gr, nil
+	}
+	return io.NopCloser(br), nil
+}
+
+// newIOReaderClient returns a client that reads events in the given format,
+// encoder.FormatJSON (JSON lines) or encoder.FormatProtobuf (length-delimited
+// binary protobuf). zstd and gzip compressed input is detected and
+// decompressed.
+func newIOReaderClient(reader io.Reader, format string, debug bool) (*ioReaderClient, error) {
+	return newMultiIOReaderClient([]io.Reader{reader}, format, debug)
+}
+
+// NewIOReaderClient returns a client that reads events from readers, in
+// order, as a single stream, e.g. from an export file and its rotated
+// backups. See newIOReaderClient for the supported formats.
+func NewIOReaderClient(readers []io.Reader, format string, debug bool) (tetragon.FineGuidanceSensorsClient, error) {
+	return newMultiIOReaderClient(readers, format, debug)
+}
+
+func newMultiIOReaderClient(readers []io.Reader, format string, debug bool) (*ioReaderClient, error) {
+	i := &ioReaderClient{debug: debug}
+	decompressed := make([]io.Reader, 0, len(readers))
+	for _, r := range readers {
+		d, err := decompress(r)
+		if err != nil {
+			i.close()
+			return nil, err
+		}
+		decompressed = append(decompressed, d)
+		i.closers = append(i.closers, d)
+	}
+	br := bufio.NewReader(io.MultiReader(decompressed...))
+
+	switch format {
+	case encoder.FormatJSON:
+		i.read = i.jsonReader(br)
+	case encoder.FormatProtobuf:
+		i.read = protobufReader(br)
+	default:
+		i.close()
+		return nil, fmt.Errorf("unknown input format %q, valid values are %q and %q", format, encoder.FormatJSON, encoder.FormatProtobuf)
+	}
+	return i, nil
+}
+
+func (i *ioReaderClient) jsonReader(reader io.Reader) func() (*tetragon.GetEventsResponse, error) {
+	scanner := bufio.NewScanner(reader)
+	unmarshaller := protojson.UnmarshalOptions{DiscardUnknown: true}
+	return func() (*tetragon.GetEventsResponse, error) {
+		for scanner.Scan() {
+			res := &tetragon.GetEventsResponse{}
+			line := scanner.Bytes()
+			err := unmarshaller.Unmarshal(line, res)
+			if err != nil && i.debug {
+				fmt.Fprintf(os.Stderr, "DEBUG: failed unmarshal: %s: %s\n", line, err)
+				continue
+			}
+			return res, nil
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, io.EOF
+	}
+}
+
+func protobufReader(reader *bufio.Reader) func() (*tetragon.GetEventsResponse, error) {
+	unmarshaller := protodelim.UnmarshalOptions{
+		UnmarshalOptions: proto.UnmarshalOptions{DiscardUnknown: true},
+		MaxSize:          -1,
+	}
+	return func() (*tetragon.GetEventsResponse, error) {
+		res := &tetragon.GetEventsResponse{}
+		if err := unmarshaller.UnmarshalFrom(reader, res); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, io.EOF
+			}
+			return nil, fmt.Errorf("failed to read event: %w", err)
+		}
+		return res, nil
 	}
 }
 
@@ -42,12 +183,33 @@ func (i *ioReaderClient) GetEvents(ctx context.Context, in *tetragon.GetEventsRe
 	if err != nil {
 		return nil, err
 	}
+	denylist, err := filters.BuildFilterList(ctx, in.DenyList, filters.Filters)
+	if err != nil {
+		return nil, err
+	}
 	ffs, err := fieldfilters.FieldFiltersFromGetEventsRequest(in)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create field filters: %w", err)
 	}
+	sampler, err := filters.NewSampler(in.SamplingOptions)
+	if err != nil {
+		return nil, err
+	}
+	computed, err := filters.NewComputedFields(in.ComputedFields)
+	if err != nil {
+		return nil, err
+	}
+	aggregator, err := aggregator.NewAggregator(&i.pending, in.AggregationOptions)
+	if err != nil {
+		return nil, err
+	}
 	i.allowlist = allowlist
+	i.denylist = denylist
 	i.fieldFilters = ffs
+	i.sampler = sampler
+	i.computed = computed
+	i.ctx = ctx
+	i.aggregator = aggregator
 	if i.debug {
 		fmt.Fprintf(os.Stderr, "DEBUG: GetEvents request: %+v\n", in)
 	}
@@ -102,30 +264,72 @@ func (i *ioReaderClient) GetVersion(_ context.Context, _ *tetragon.GetVersionReq
 	panic("stub")
 }
 
+// Recv returns the next event. The decompressors of the input are released
+// once it returns an error, including io.EOF at the end of the input.
 func (i *ioReaderClient) Recv() (*tetragon.GetEventsResponse, error) {
-	for i.scanner.Scan() {
-		res := &tetragon.GetEventsResponse{}
-		line := i.scanner.Bytes()
-		err := i.unmarshaller.Unmarshal(line, res)
-		if err != nil && i.debug {
-			fmt.Fprintf(os.Stderr, "DEBUG: failed unmarshal: %s: %s\n", line, err)
+	res, err := i.recv()
+	if err != nil {
+		i.close()
+	}
+	return res, err
+}
+
+// CloseSend releases the decompressors of the input, for callers that stop
+// receiving events before the end of the input.
+func (i *ioReaderClient) CloseSend() error {
+	i.close()
+	return nil
+}
+
+func (i *ioReaderClient) close() {
+	for _, c := range i.closers {
+		c.Close()
+	}
+	i.closers = nil
+}
+
+func (i *ioReaderClient) recv() (*tetragon.GetEventsResponse, error) {
+	for {
+		if res, ok := i.pending.pop(); ok {
+			return res, nil
+		}
+		if i.eof {
+			return nil, io.EOF
+		}
+		res, err := i.read()
+		if errors.Is(err, io.EOF) && i.aggregator != nil {
+			// send the events of the last aggregation window
+			i.eof = true
+			i.aggregator.Flush()
 			continue
 		}
-		if !filters.Apply(i.allowlist, nil, &event.Event{Event: res}) {
+		if err != nil {
+			return nil, err
+		}
+		if !filters.Apply(i.allowlist, i.denylist, &event.Event{Event: res}) {
 			continue
 		}
+		if i.sampler != nil {
+			var keep bool
+			if res, keep = i.sampler.Sample(res); !keep {
+				continue
+			}
+		}
+		if i.computed != nil {
+			res = i.computed.Apply(i.ctx, res)
+		}
 		for _, filter := range i.fieldFilters {
 			res, err = filter.Filter(res)
 			if err != nil {
 				return nil, err
 			}
 		}
+		if i.aggregator != nil {
+			i.aggregator.Replay(res)
+			continue
+		}
 		return res, nil
 	}
-	if err := i.scanner.Err(); err != nil {
-		return nil, err
-	}
-	return nil, io.EOF
 }
 
 func (i *ioReaderClient) RuntimeHook(_ context.Context, _ *tetragon.RuntimeHookRequest, _ ...grpc.CallOption) (*tetragon.RuntimeHookResponse, error) {
@@ -140,6 +344,14 @@ func (i *ioReaderClient) SetDebug(_ context.Context, _ *tetragon.SetDebugRequest
 	panic("stub")
 }
 
+func (i *ioReaderClient) GetProcessTree(_ context.Context, _ *tetragon.GetProcessTreeRequest, _ ...grpc.CallOption) (*tetragon.GetProcessTreeResponse, error) {
+	panic("stub")
+}
+
+func (i *ioReaderClient) GetProcess(_ context.Context, _ *tetragon.GetProcessRequest, _ ...grpc.CallOption) (*tetragon.GetProcessResponse, error) {
+	panic("stub")
+}
+
 func (i *ioReaderClient) ConfigureTracingPolicy(_ context.Context, _ *tetragon.ConfigureTracingPolicyRequest, _ ...grpc.CallOption) (*tetragon.ConfigureTracingPolicyResponse, error) {
 	panic("stub")
 }
@@ -4,20 +4,28 @@
 package getevents
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"io"
 	"os"
 	"testing"
 
 	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/encoder"
 	"github.com/cilium/tetragon/pkg/testutils"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 )
 
 func Test_ioReaderClient_GetEvents(t *testing.T) {
 	events, err := os.Open(testutils.RepoRootPath("testdata/events.json"))
 	require.NoError(t, err)
-	client := newIOReaderClient(events, false)
+	client, err := newIOReaderClient(events, encoder.FormatJSON, false)
+	require.NoError(t, err)
 	getEventsClient, err := client.GetEvents(context.Background(), &tetragon.GetEventsRequest{})
 	require.NoError(t, err)
 	for range 3 {
@@ -27,3 +35,55 @@ func Test_ioReaderClient_GetEvents(t *testing.T) {
 	_, err = getEventsClient.Recv()
 	require.ErrorIs(t, err, io.EOF)
 }
+
+func Test_ioReaderClient_Protobuf(t *testing.T) {
+	// convert the JSON test events to length-delimited protobuf
+	f, err := os.Open(testutils.RepoRootPath("testdata/events.json"))
+	require.NoError(t, err)
+	defer f.Close()
+	var events []*tetragon.GetEventsResponse
+	var buf bytes.Buffer
+	enc := encoder.NewProtobufEncoder(&buf)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		ev := &tetragon.GetEventsResponse{}
+		require.NoError(t, protojson.Unmarshal(scanner.Bytes(), ev))
+		require.NoError(t, enc.Encode(ev))
+		events = append(events, ev)
+	}
+	require.NoError(t, scanner.Err())
+
+	zenc, err := zstd.NewWriter(nil)
+	require.NoError(t, err)
+	compressed := zenc.EncodeAll(buf.Bytes(), nil)
+
+	for name, data := range map[string][]byte{"plain": buf.Bytes(), "zstd": compressed} {
+		t.Run(name, func(t *testing.T) {
+			client, err := newIOReaderClient(bytes.NewReader(data), encoder.FormatProtobuf, false)
+			require.NoError(t, err)
+			getEventsClient, err := client.GetEvents(context.Background(), &tetragon.GetEventsRequest{})
+			require.NoError(t, err)
+			for _, want := range events {
+				got, err := getEventsClient.Recv()
+				require.NoError(t, err)
+				assert.True(t, proto.Equal(want, got))
+			}
+			_, err = getEventsClient.Recv()
+			require.ErrorIs(t, err, io.EOF)
+			// the decompressors are released at the end of the input
+			assert.Empty(t, client.closers)
+		})
+	}
+
+	t.Run("close", func(t *testing.T) {
+		client, err := newIOReaderClient(bytes.NewReader(compressed), encoder.FormatProtobuf, false)
+		require.NoError(t, err)
+		getEventsClient, err := client.GetEvents(context.Background(), &tetragon.GetEventsRequest{})
+		require.NoError(t, err)
+		_, err = getEventsClient.Recv()
+		require.NoError(t, err)
+		require.Len(t, client.closers, 1)
+		require.NoError(t, getEventsClient.CloseSend())
+		assert.Empty(t, client.closers)
+	})
+}
@@ -0,0 +1,285 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package ps
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/cmd/tetra/common"
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type opts struct {
+	output     string
+	namespaces []string
+	pods       []string
+	processes  []string
+	host       bool
+	inInitTree bool
+	all        bool
+}
+
+func (o *opts) addFlags(cmd *cobra.Command) {
+	flags := cmd.Flags()
+	flags.StringVarP(&o.output, common.KeyOutput, "o", "text", "Output format. text or json")
+	flags.StringSliceVarP(&o.namespaces, common.KeyNamespace, "n", nil, "Get processes by Kubernetes namespace")
+	flags.StringSliceVar(&o.pods, "pod", nil, "Get processes by pod name regex")
+	flags.StringSliceVar(&o.processes, "process", nil, "Get processes by binary regex")
+	flags.BoolVar(&o.host, "host", false, "Get host processes")
+	flags.BoolVar(&o.inInitTree, "in-init-tree", false, "Get processes that are in the init tree of their container")
+	flags.BoolVarP(&o.all, "all", "a", false, "Include processes that exited and are pending removal")
+}
+
+func (o *opts) validate() error {
+	if o.output != "json" && o.output != "text" {
+		return fmt.Errorf("invalid value for %q flag: %s", common.KeyOutput, o.output)
+	}
+	return nil
+}
+
+// request returns the GetProcessTree request for the options. Like for
+// getevents, the filters are only set if they are not empty.
+func (o *opts) request(cmd *cobra.Command) *tetragon.GetProcessTreeRequest {
+	req := &tetragon.GetProcessTreeRequest{IncludeExited: o.all}
+	namespaces := o.namespaces
+	if o.host {
+		// Host processes can be matched by an empty namespace string.
+		namespaces = append(namespaces, "")
+	}
+	filter := &tetragon.Filter{}
+	if len(namespaces) > 0 {
+		filter.Namespace = namespaces
+	}
+	if len(o.pods) > 0 {
+		filter.PodRegex = o.pods
+	}
+	if len(o.processes) > 0 {
+		filter.BinaryRegex = o.processes
+	}
+	if cmd.Flags().Changed("in-init-tree") {
+		filter.InInitTree = wrapperspb.Bool(o.inInitTree)
+	}
+	if filter.Namespace != nil || filter.PodRegex != nil || filter.BinaryRegex != nil || filter.InInitTree != nil {
+		req.AllowList = []*tetragon.Filter{filter}
+	}
+	return req
+}
+
+func getProcessTree(cmd *cobra.Command, o *opts) (*tetragon.GetProcessTreeResponse, error) {
+	c, err := common.NewClientWithDefaultContextAndAddress()
+	if err != nil {
+		return nil, fmt.Errorf("failed create gRPC client: %w", err)
+	}
+	defer c.Close()
+
+	res, err := c.Client.GetProcessTree(c.Ctx, o.request(cmd))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get process tree: %w", err)
+	}
+	return res, nil
+}
+
+func New() *cobra.Command {
+	var o opts
+	cmd := &cobra.Command{
+		Use:   "ps [exec_id]",
+		Short: "List the processes of the process cache",
+		Long: `List the processes known by the agent, with their ancestors, or a single
+process by exec ID. Use the JSON output format for full output, including pods,
+credentials, namespaces and reference counts. Examples:
+
+  # List the processes of the pods of a namespace
+  tetra ps --namespace default
+
+  # Show a process
+  tetra ps -o json OjE2NzM2NzAxMjkwMTM6MTIzNA==`,
+		Args: cobra.MaximumNArgs(1),
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			return o.validate()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 1 {
+				return getProcess(cmd, o.output, args[0])
+			}
+
+			res, err := getProcessTree(cmd, &o)
+			if err != nil {
+				return err
+			}
+			switch o.output {
+			case "json":
+				b, err := res.MarshalJSON()
+				if err != nil {
+					return fmt.Errorf("failed to generate json: %w", err)
+				}
+				cmd.Println(string(b))
+			case "text":
+				printProcesses(cmd.OutOrStdout(), res.Processes)
+			}
+			return nil
+		},
+	}
+	o.addFlags(cmd)
+	return cmd
+}
+
+func getProcess(cmd *cobra.Command, output, execID string) error {
+	c, err := common.NewClientWithDefaultContextAndAddress()
+	if err != nil {
+		return fmt.Errorf("failed create gRPC client: %w", err)
+	}
+	defer c.Close()
+
+	res, err := c.Client.GetProcess(c.Ctx, &tetragon.GetProcessRequest{ExecId: execID})
+	if err != nil {
+		return fmt.Errorf("failed to get process: %w", err)
+	}
+	switch output {
+	case "json":
+		b, err := res.Process.MarshalJSON()
+		if err != nil {
+			return fmt.Errorf("failed to generate json: %w", err)
+		}
+		cmd.Println(string(b))
+	case "text":
+		printProcesses(cmd.OutOrStdout(), []*tetragon.ProcessInternal{res.Process})
+	}
+	return nil
+}
+
+func NewTree() *cobra.Command {
+	var o opts
+	cmd := &cobra.Command{
+		Use:   "pstree",
+		Short: "Print the tree of the processes of the process cache",
+		Long: `Print the tree of the processes known by the agent. With filters, only the
+matching processes and their ancestors are printed. Examples:
+
+  # Print what is running in a pod
+  tetra pstree --pod nginx`,
+		Args: cobra.NoArgs,
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			return o.validate()
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			res, err := getProcessTree(cmd, &o)
+			if err != nil {
+				return err
+			}
+			switch o.output {
+			case "json":
+				b, err := res.MarshalJSON()
+				if err != nil {
+					return fmt.Errorf("failed to generate json: %w", err)
+				}
+				cmd.Println(string(b))
+			case "text":
+				printTree(cmd.OutOrStdout(), res.Processes)
+			}
+			return nil
+		},
+	}
+	o.addFlags(cmd)
+	return cmd
+}
+
+func podName(p *tetragon.Process) string {
+	if p.Pod == nil {
+		return ""
+	}
+	return p.Pod.Namespace + "/" + p.Pod.Name
+}
+
+// exited returns true if the process exited, and is only kept in the cache
+// for its children or until it is removed.
+func exited(pi *tetragon.ProcessInternal) bool {
+	return pi.RefcntOps["process++"] <= pi.RefcntOps["process--"]
+}
+
+func printProcesses(output io.Writer, procs []*tetragon.ProcessInternal) {
+	pids := make(map[string]uint32, len(procs))
+	for _, pi := range procs {
+		pids[pi.Process.ExecId] = pi.Process.Pid.GetValue()
+	}
+
+	// tabwriter config imitates kubectl default output, i.e. 3 spaces padding
+	w := tabwriter.NewWriter(output, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(w, "PID\tPPID\tUID\tREFCNT\tPOD\tBINARY\tARGUMENTS\tEXEC_ID")
+	for _, pi := range procs {
+		p := pi.Process
+		ppid := "-"
+		if pid, ok := pids[p.ParentExecId]; ok {
+			ppid = fmt.Sprint(pid)
+		}
+		pod := podName(p)
+		if pod == "" {
+			pod = "-"
+		}
+		args := p.Arguments
+		if args == "" {
+			args = "-"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%d\t%d\t%s\t%s\t%s\t%s\n",
+			p.Pid.GetValue(),
+			ppid,
+			p.Uid.GetValue(),
+			pi.Refcnt.GetValue(),
+			pod,
+			p.Binary,
+			args,
+			p.ExecId,
+		)
+	}
+	w.Flush()
+}
+
+func printTree(output io.Writer, procs []*tetragon.ProcessInternal) {
+	known := make(map[string]bool, len(procs))
+	for _, pi := range procs {
+		known[pi.Process.ExecId] = true
+	}
+	// processes are ordered by start time, and so are their children
+	children := make(map[string][]*tetragon.ProcessInternal)
+	var roots []*tetragon.ProcessInternal
+	for _, pi := range procs {
+		p := pi.Process
+		if known[p.ParentExecId] && p.ParentExecId != p.ExecId {
+			children[p.ParentExecId] = append(children[p.ParentExecId], pi)
+		} else {
+			roots = append(roots, pi)
+		}
+	}
+
+	var printNode func(pi *tetragon.ProcessInternal, prefix, childPrefix string)
+	printNode = func(pi *tetragon.ProcessInternal, prefix, childPrefix string) {
+		p := pi.Process
+		line := fmt.Sprintf("%s%d %s", prefix, p.Pid.GetValue(), p.Binary)
+		if p.Arguments != "" {
+			line += " " + p.Arguments
+		}
+		if pod := podName(p); pod != "" {
+			line += " [" + pod + "]"
+		}
+		if exited(pi) {
+			line += " (exited)"
+		}
+		fmt.Fprintln(output, line)
+
+		kids := children[p.ExecId]
+		for i, c := range kids {
+			if i == len(kids)-1 {
+				printNode(c, childPrefix+"└─ ", childPrefix+"   ")
+			} else {
+				printNode(c, childPrefix+"├─ ", childPrefix+"│  ")
+			}
+		}
+	}
+	for _, pi := range roots {
+		printNode(pi, "", "")
+	}
+}
@@ -0,0 +1,51 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package ps
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func process(pid uint32, execID, parentExecID, binary string, ops map[string]int32) *tetragon.ProcessInternal {
+	return &tetragon.ProcessInternal{
+		Process: &tetragon.Process{
+			ExecId:       execID,
+			Pid:          wrapperspb.UInt32(pid),
+			ParentExecId: parentExecID,
+			Binary:       binary,
+		},
+		RefcntOps: ops,
+	}
+}
+
+func TestPrintTree(t *testing.T) {
+	running := map[string]int32{"process++": 1}
+	sleep := process(101, "sleep", "bash", "/usr/bin/sleep", running)
+	sleep.Process.Arguments = "infinity"
+	sleep.Process.Pod = &tetragon.Pod{Namespace: "default", Name: "nginx"}
+	procs := []*tetragon.ProcessInternal{
+		process(1, "init", "", "/sbin/init", running),
+		process(100, "bash", "init", "/bin/bash", map[string]int32{"process++": 1, "process--": 1}),
+		sleep,
+		process(102, "cat", "bash", "/usr/bin/cat", running),
+		process(103, "vi", "init", "/usr/bin/vi", running),
+		// the parent is not in the cache anymore
+		process(200, "less", "gone", "/usr/bin/less", running),
+	}
+
+	var b bytes.Buffer
+	printTree(&b, procs)
+	assert.Equal(t, `1 /sbin/init
+├─ 100 /bin/bash (exited)
+│  ├─ 101 /usr/bin/sleep infinity [default/nginx]
+│  └─ 102 /usr/bin/cat
+└─ 103 /usr/bin/vi
+200 /usr/bin/less
+`, b.String())
+}
@@ -0,0 +1,243 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+//go:build !windows
+
+package tracingpolicy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/api/v1/tetragon/codegen/helpers"
+	"github.com/cilium/tetragon/cmd/tetra/common"
+	"github.com/cilium/tetragon/cmd/tetra/getevents"
+	"github.com/cilium/tetragon/pkg/encoder"
+	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
+	"github.com/cilium/tetragon/pkg/policyconf"
+	"github.com/cilium/tetragon/pkg/selectors"
+	"github.com/cilium/tetragon/pkg/tracingpolicy"
+	"github.com/spf13/cobra"
+)
+
+// simulationMode returns the mode to simulate the policy in: mode if set,
+// otherwise the policy-mode option of the policy.
+func simulationMode(spec *v1alpha1.TracingPolicySpec, mode string) (policyconf.Mode, error) {
+	if mode == "" {
+		for _, opt := range spec.Options {
+			if opt.Name == "policy-mode" {
+				mode = opt.Value
+			}
+		}
+	}
+	if mode == "" {
+		return policyconf.EnforceMode, nil
+	}
+	return policyconf.ParseMode(mode)
+}
+
+type simulationSummary struct {
+	events     int
+	hookEvents int
+	posted     int
+	overridden int
+	killed     int
+}
+
+// simulationJSON is the JSON output of a simulated event
+type simulationJSON struct {
+	Hook     string          `json:"hook"`
+	Selector int             `json:"selector"`
+	Post     bool            `json:"post"`
+	Override bool            `json:"override"`
+	Sigkill  bool            `json:"sigkill"`
+	Event    json.RawMessage `json:"event"`
+}
+
+func simulationActions(r *selectors.SimulationResult) string {
+	var actions []string
+	if r.Post {
+		actions = append(actions, "post")
+	}
+	if r.Override {
+		actions = append(actions, "override")
+	}
+	if r.Sigkill {
+		actions = append(actions, "sigkill")
+	}
+	return strings.Join(actions, ",")
+}
+
+func simulationProcess(res *tetragon.GetEventsResponse) string {
+	proc := helpers.ResponseGetProcess(res)
+	if proc == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s (%d)", proc.Binary, proc.Pid.GetValue())
+}
+
+// simulate runs the simulation over the events of readers, and writes the
+// events that would have been posted, overridden or killed to w.
+func simulate(ctx context.Context, sim *selectors.Simulator, readers []io.Reader, format string, w io.Writer, output string) (simulationSummary, error) {
+	var summary simulationSummary
+
+	client, err := getevents.NewIOReaderClient(readers, format, common.Debug)
+	if err != nil {
+		return summary, err
+	}
+	stream, err := client.GetEvents(ctx, &tetragon.GetEventsRequest{})
+	if err != nil {
+		return summary, err
+	}
+	defer stream.CloseSend()
+
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	if output == "text" {
+		fmt.Fprintln(tw, "TIME\tHOOK\tSELECTOR\tACTIONS\tPROCESS")
+	}
+	for {
+		res, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return summary, fmt.Errorf("failed to read events: %w", err)
+		}
+		summary.events++
+
+		results, err := sim.Simulate(res)
+		if err != nil {
+			return summary, fmt.Errorf("failed to simulate event %d: %w", summary.events, err)
+		}
+		if len(results) > 0 {
+			summary.hookEvents++
+		}
+		for i := range results {
+			r := &results[i]
+			if !r.Post && !r.Override && !r.Sigkill {
+				continue
+			}
+			if r.Post {
+				summary.posted++
+			}
+			if r.Override {
+				summary.overridden++
+			}
+			if r.Sigkill {
+				summary.killed++
+			}
+
+			switch output {
+			case "json":
+				ev, err := protojson.Marshal(res)
+				if err != nil {
+					return summary, fmt.Errorf("failed to generate json: %w", err)
+				}
+				b, err := json.Marshal(simulationJSON{
+					Hook:     r.Hook,
+					Selector: r.Selector,
+					Post:     r.Post,
+					Override: r.Override,
+					Sigkill:  r.Sigkill,
+					Event:    ev,
+				})
+				if err != nil {
+					return summary, fmt.Errorf("failed to generate json: %w", err)
+				}
+				fmt.Fprintln(w, string(b))
+			case "text":
+				fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\n",
+					res.GetTime().AsTime().Local().Format(time.RFC3339),
+					r.Hook,
+					r.Selector,
+					simulationActions(r),
+					simulationProcess(res),
+				)
+			}
+		}
+	}
+	tw.Flush()
+	return summary, nil
+}
+
+func tpSimulateCmd() *cobra.Command {
+	var mode, inputFormat, output string
+	ret := &cobra.Command{
+		Use:   "simulate <yaml_file> [EVENTS_FILE]...",
+		Short: "simulate a tracing policy against exported events",
+		Long: `Evaluate the selectors of a tracing policy against exported events, from
+files or from stdin if no file is given, without loading the policy. The
+events that the policy would have posted, overridden or killed are printed.
+
+Events are evaluated by the hooks of the policy they come from, so the events
+should be recorded with a policy hooking the same functions, for example the
+policy without its selectors. The matchArgs, matchBinaries, matchNamespaces
+and matchCapabilities filters are supported. Rate limits are not applied.
+Examples:
+
+  # Simulate an enforcement policy against exported events
+  tetra tracingpolicy simulate enforce.yaml tetragon.log
+
+  # Simulate the policy in monitor mode
+  tetra tracingpolicy simulate --mode monitor enforce.yaml tetragon.log`,
+		Args: cobra.MinimumNArgs(1),
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			if output != "json" && output != "text" {
+				return fmt.Errorf("invalid value for %q flag: %s", common.KeyOutput, output)
+			}
+			if inputFormat != encoder.FormatJSON && inputFormat != encoder.FormatProtobuf {
+				return fmt.Errorf("invalid value for %q flag: %s. Supported are json and protobuf", "input-format", inputFormat)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			tp, err := tracingpolicy.FromFile(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to parse tracing policy %s: %w", args[0], err)
+			}
+			m, err := simulationMode(tp.TpSpec(), mode)
+			if err != nil {
+				return err
+			}
+			sim, err := selectors.NewSimulator(tp.TpSpec(), m)
+			if err != nil {
+				return fmt.Errorf("cannot simulate tracing policy %s: %w", args[0], err)
+			}
+
+			readers := []io.Reader{os.Stdin}
+			if len(args) > 1 {
+				var closeFiles func()
+				readers, closeFiles, err = getevents.OpenFiles(args[1:])
+				if err != nil {
+					return err
+				}
+				defer closeFiles()
+			}
+
+			summary, err := simulate(cmd.Context(), sim, readers, inputFormat, cmd.OutOrStdout(), output)
+			if err != nil {
+				return err
+			}
+			if output == "text" {
+				cmd.Printf("\n%d events read, %d from the policy hooks: %d posted, %d overridden, %d killed\n",
+					summary.events, summary.hookEvents, summary.posted, summary.overridden, summary.killed)
+			}
+			return nil
+		},
+	}
+	flags := ret.Flags()
+	flags.StringVarP(&mode, "mode", "m", "", "Tracing policy mode (enforce|monitor). Defaults to the mode of the policy")
+	flags.StringVar(&inputFormat, "input-format", encoder.FormatJSON, "Format of the events. json or protobuf")
+	flags.StringVarP(&output, common.KeyOutput, "o", "text", "Output format. text or json")
+	return ret
+}
@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+//go:build !windows
+
+package tracingpolicy
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/encoder"
+	"github.com/cilium/tetragon/pkg/policyconf"
+	"github.com/cilium/tetragon/pkg/selectors"
+	"github.com/cilium/tetragon/pkg/tracingpolicy"
+)
+
+const simulatePolicy = `
+apiVersion: cilium.io/v1alpha1
+kind: TracingPolicy
+metadata:
+  name: "file-monitoring"
+spec:
+  options:
+  - name: policy-mode
+    value: monitor
+  kprobes:
+  - call: "security_file_open"
+    syscall: false
+    args:
+    - index: 0
+      type: "file"
+    selectors:
+    - matchArgs:
+      - index: 0
+        operator: "Prefix"
+        values: ["/etc/"]
+      matchActions:
+      - action: Sigkill
+`
+
+func fileOpenEvent(ts int64, binary, path string) *tetragon.GetEventsResponse {
+	return &tetragon.GetEventsResponse{
+		Event: &tetragon.GetEventsResponse_ProcessKprobe{ProcessKprobe: &tetragon.ProcessKprobe{
+			Process:      &tetragon.Process{Binary: binary, Pid: wrapperspb.UInt32(42)},
+			FunctionName: "security_file_open",
+			Args: []*tetragon.KprobeArgument{
+				{Arg: &tetragon.KprobeArgument_FileArg{FileArg: &tetragon.KprobeFile{Path: path}}},
+			},
+		}},
+		Time: timestamppb.New(time.Unix(ts, 0)),
+	}
+}
+
+func exportEvents(t *testing.T, events ...*tetragon.GetEventsResponse) io.Reader {
+	var buf bytes.Buffer
+	enc := encoder.NewProtojsonEncoder(&buf)
+	for _, ev := range events {
+		require.NoError(t, enc.Encode(ev))
+	}
+	return &buf
+}
+
+func TestSimulationMode(t *testing.T) {
+	tp, err := tracingpolicy.FromYAML(simulatePolicy)
+	require.NoError(t, err)
+
+	mode, err := simulationMode(tp.TpSpec(), "")
+	require.NoError(t, err)
+	assert.Equal(t, policyconf.MonitorMode, mode)
+
+	mode, err = simulationMode(tp.TpSpec(), "enforce")
+	require.NoError(t, err)
+	assert.Equal(t, policyconf.EnforceMode, mode)
+
+	_, err = simulationMode(tp.TpSpec(), "audit")
+	require.Error(t, err)
+}
+
+func TestSimulate(t *testing.T) {
+	tp, err := tracingpolicy.FromYAML(simulatePolicy)
+	require.NoError(t, err)
+	sim, err := selectors.NewSimulator(tp.TpSpec(), policyconf.EnforceMode)
+	require.NoError(t, err)
+
+	events := func() []io.Reader {
+		return []io.Reader{exportEvents(t,
+			fileOpenEvent(1, "/usr/bin/cat", "/etc/passwd"),
+			fileOpenEvent(2, "/usr/bin/cat", "/tmp/file"),
+			&tetragon.GetEventsResponse{Event: &tetragon.GetEventsResponse_ProcessExec{ProcessExec: &tetragon.ProcessExec{
+				Process: &tetragon.Process{Binary: "/usr/bin/cat"},
+			}}},
+		)}
+	}
+
+	var out bytes.Buffer
+	summary, err := simulate(context.Background(), sim, events(), encoder.FormatJSON, &out, "text")
+	require.NoError(t, err)
+	assert.Equal(t, simulationSummary{events: 3, hookEvents: 2, posted: 1, killed: 1}, summary)
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Contains(t, lines[1], "security_file_open")
+	assert.Contains(t, lines[1], "post,sigkill")
+	assert.Contains(t, lines[1], "/usr/bin/cat (42)")
+
+	out.Reset()
+	_, err = simulate(context.Background(), sim, events(), encoder.FormatJSON, &out, "json")
+	require.NoError(t, err)
+	var res simulationJSON
+	require.NoError(t, json.Unmarshal(out.Bytes(), &res))
+	assert.Equal(t, "security_file_open", res.Hook)
+	assert.True(t, res.Post)
+	assert.True(t, res.Sigkill)
+	assert.False(t, res.Override)
+	assert.Contains(t, string(res.Event), "/etc/passwd")
+}
@@ -8,6 +8,7 @@ package tracingpolicy
 import (
 	"fmt"
 	"os"
+	"slices"
 
 	"github.com/cilium/tetragon/api/v1/tetragon"
 	"github.com/cilium/tetragon/cmd/tetra/common"
@@ -225,6 +226,63 @@ func tpListCmd() *cobra.Command {
 	return ret
 }
 
+func tpStatsCmd() *cobra.Command {
+	var namespace, output string
+	ret := &cobra.Command{
+		Use:   "stats <name>",
+		Short: "show the selector counters of a tracing policy",
+		Long: `Show, for each hook and selector of a tracing policy, how many times the
+selector matched and how many post, override, sigkill and rate limited
+actions it triggered.`,
+		Args: cobra.ExactArgs(1),
+		PreRunE: func(_ *cobra.Command, _ []string) error {
+			if output != "json" && output != "text" {
+				return fmt.Errorf("invalid value for %q flag: %s", common.KeyOutput, output)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := common.NewClientWithDefaultContextAndAddress()
+			if err != nil {
+				return fmt.Errorf("failed create gRPC client: %w", err)
+			}
+			defer c.Close()
+
+			res, err := c.Client.ListTracingPolicies(c.Ctx, &tetragon.ListTracingPoliciesRequest{})
+			if err != nil || res == nil {
+				return fmt.Errorf("failed to list tracing policies: %w", err)
+			}
+
+			idx := slices.IndexFunc(res.Policies, func(pol *tetragon.TracingPolicyStatus) bool {
+				return pol.Name == args[0] && pol.Namespace == namespace
+			})
+			if idx == -1 {
+				return fmt.Errorf("tracing policy %q not found", args[0])
+			}
+			pol := res.Policies[idx]
+
+			switch output {
+			case "json":
+				for _, s := range pol.SelectorStats {
+					b, err := s.MarshalJSON()
+					if err != nil {
+						return fmt.Errorf("failed to generate json: %w", err)
+					}
+					cmd.Println(string(b))
+				}
+			case "text":
+				common.PrintTracingPolicySelectorStats(cmd.OutOrStdout(), pol.SelectorStats)
+			}
+
+			return nil
+		},
+	}
+	flags := ret.Flags()
+	flags.StringVarP(&namespace, common.KeyNamespace, "n", "", "Namespace of the tracing policy.")
+	flags.StringVarP(&output, common.KeyOutput, "o", "text", "Output format. text or json")
+	return ret
+}
+
 func tpSetModeCmd() *cobra.Command {
 	var namespace string
 	ret := &cobra.Command{
@@ -272,7 +330,9 @@ func New() *cobra.Command {
 		tpEnableCmd(),
 		tpDisableCmd(),
 		tpListCmd(),
+		tpStatsCmd(),
 		tpSetModeCmd(),
+		tpSimulateCmd(),
 		generate.New(),
 	)
 
@@ -5,7 +5,6 @@ package main
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"io/fs"
 	"log/slog"
@@ -23,16 +22,17 @@ import (
 	"time"
 
 	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/aggregator"
 	"github.com/cilium/tetragon/pkg/bpf"
 	"github.com/cilium/tetragon/pkg/logger/logfields"
 
 	"github.com/cilium/tetragon/pkg/bugtool"
 	"github.com/cilium/tetragon/pkg/cgrouprate"
+	"github.com/cilium/tetragon/pkg/cri"
 	"github.com/cilium/tetragon/pkg/defaults"
-	"github.com/cilium/tetragon/pkg/encoder"
 	"github.com/cilium/tetragon/pkg/exporter"
+	"github.com/cilium/tetragon/pkg/exporter/otlp"
 	"github.com/cilium/tetragon/pkg/fieldfilters"
-	"github.com/cilium/tetragon/pkg/fileutils"
 	"github.com/cilium/tetragon/pkg/filters"
 	tetragonGrpc "github.com/cilium/tetragon/pkg/grpc"
 	"github.com/cilium/tetragon/pkg/health"
@@ -51,6 +51,7 @@ import (
 	"github.com/cilium/tetragon/pkg/sensors/exec/procevents"
 	"github.com/cilium/tetragon/pkg/sensors/program"
 	"github.com/cilium/tetragon/pkg/server"
+	"github.com/cilium/tetragon/pkg/spool"
 	"github.com/cilium/tetragon/pkg/tracingpolicy"
 	"github.com/cilium/tetragon/pkg/unixlisten"
 	"github.com/cilium/tetragon/pkg/version"
@@ -60,7 +61,6 @@ import (
 	// Imported to allow sensors to be initialized inside init().
 	_ "github.com/cilium/tetragon/pkg/sensors"
 
-	"github.com/cilium/lumberjack/v2"
 	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
 	gops "github.com/google/gops/agent"
 	"github.com/spf13/cobra"
@@ -189,6 +189,27 @@ func deleteOldBpfDir(path string) {
 	log.Info("Removed bpf instance: " + path)
 }
 
+func saveProcessCache(path string) {
+	if err := process.SaveSnapshot(path); err != nil {
+		log.Warn("Failed to save process cache snapshot", "file", path, logfields.Error, err)
+	}
+}
+
+// saveProcessCachePeriodically saves the process cache to path every
+// interval, until ctx is done.
+func saveProcessCachePeriodically(ctx context.Context, path string, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			saveProcessCache(path)
+		}
+	}
+}
+
 func loadInitialSensor(ctx context.Context) error {
 	mgr := observer.GetSensorManager()
 	initialSensor := base.GetInitialSensor()
@@ -426,6 +447,16 @@ func tetragonExecuteCtx(ctx context.Context, cancel context.CancelFunc, ready fu
 		} else {
 			node.SetNodeLabels(k8sNode.Labels)
 		}
+	} else if option.Config.EnableCRIPodMetadata {
+		log.Info("Disabling Kubernetes API, using the CRI for pod metadata")
+		criClient, err := cri.GetClient(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to connect to the CRI: %w", err)
+		}
+		podAccessor, err = watcher.NewCRIPodAccessor(ctx, criClient, option.Config.KubeletPodsURL)
+		if err != nil {
+			return err
+		}
 	} else {
 		log.Info("Disabling Kubernetes API")
 		podAccessor = watcher.NewFakeK8sWatcher(nil)
@@ -481,6 +512,21 @@ func tetragonExecuteCtx(ctx context.Context, cancel context.CancelFunc, ready fu
 	if err != nil {
 		return err
 	}
+	if option.Config.EventSpoolDir != "" {
+		sp, err := spool.Open(spool.Config{
+			Dir:         option.Config.EventSpoolDir,
+			MaxSize:     int64(option.Config.EventSpoolMaxSizeMB) * 1024 * 1024,
+			SegmentSize: int64(option.Config.EventSpoolSegmentSizeMB) * 1024 * 1024,
+			QueueSize:   option.Config.EventSpoolQueueSize,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to open event spool: %w", err)
+		}
+		sp.Start(ctx, &cleanupWg)
+		pm.AddListener(sp)
+		pm.Server.SetSpool(sp)
+		log.Info("Event spool enabled", "directory", option.Config.EventSpoolDir, "lastCursor", sp.LastCursor())
+	}
 	if err = Serve(ctx, option.Config.ServerAddress, pm.Server); err != nil {
 		return err
 	}
@@ -489,12 +535,23 @@ func tetragonExecuteCtx(ctx context.Context, cancel context.CancelFunc, ready fu
 			return err
 		}
 	}
+	if option.Config.ExportOTLPEndpoint != "" {
+		if err = startOTLPExporter(ctx, pm.Server); err != nil {
+			return err
+		}
+	}
+	if option.Config.ExportPipelines != "" {
+		if err = startExportPipelines(ctx, pm.Server); err != nil {
+			return err
+		}
+	}
 
 	if option.Config.HealthServerAddress != "" {
 		health.StartHealthServer(ctx, option.Config.HealthServerAddress, option.Config.HealthServerInterval)
 	}
 
 	log.Info("Exporter configuration", "enabled", option.Config.ExportFilename != "", "fileName", option.Config.ExportFilename)
+	log.Info("OTLP exporter configuration", "enabled", option.Config.ExportOTLPEndpoint != "", "endpoint", option.Config.ExportOTLPEndpoint)
 	obs.AddListener(pm)
 	saveInitInfo()
 
@@ -512,10 +569,21 @@ func tetragonExecuteCtx(ctx context.Context, cancel context.CancelFunc, ready fu
 
 	obs.LogPinnedBpf(observerDir)
 
-	if err = procevents.GetRunningProcs(); err != nil {
+	// Restore the process cache from the previous instance, if its sensors
+	// were kept running, so that exec IDs do not change.
+	if err = procevents.RestoreRunningProcs(oldBpfDir, option.Config.ProcessCacheSnapshotFile); err != nil {
 		return err
 	}
 
+	// Start saving the process cache once it is restored, not to overwrite
+	// the snapshot of the previous instance.
+	if path := option.Config.ProcessCacheSnapshotFile; path != "" {
+		defer saveProcessCache(path)
+		if interval := option.Config.ProcessCacheSnapshotInterval; interval > 0 {
+			go saveProcessCachePeriodically(ctx, path, interval)
+		}
+	}
+
 	if err := cgrouprate.NewCgroupRate(ctx, pm, &option.Config.CgroupRate); err != nil {
 		return err
 	}
@@ -654,87 +722,112 @@ func getObserverDir() string {
 	return bpf.MapPrefixPath()
 }
 
-func startExporter(ctx context.Context, server *server.Server) error {
+// getExportRequest returns the GetEventsRequest used by the exporters, built
+// from the export filters, field filters and aggregation options.
+func getExportRequest() (*tetragon.GetEventsRequest, error) {
 	allowList, denyList, err := getExportFilters()
 	if err != nil {
-		return err
+		return nil, err
 	}
 	fieldFilters, err := getFieldFilters()
 	if err != nil {
-		return err
+		return nil, err
 	}
-	writer := &lumberjack.Logger{
-		Filename:   option.Config.ExportFilename,
-		MaxSize:    option.Config.ExportFileMaxSizeMB,
-		MaxBackups: option.Config.ExportFileMaxBackups,
-		Compress:   option.Config.ExportFileCompress,
+	var aggregationOptions *tetragon.AggregationOptions
+	if option.Config.EnableExportAggregation {
+		aggregationOptions = &tetragon.AggregationOptions{
+			WindowSize:        durationpb.New(option.Config.ExportAggregationWindowSize),
+			ChannelBufferSize: option.Config.ExportAggregationBufferSize,
+		}
+		if len(option.Config.ExportAggregationKey) > 0 {
+			key, err := aggregator.ParseKey(option.Config.ExportAggregationKey)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %w", option.KeyExportAggregationKey, err)
+			}
+			aggregationOptions.Key = key
+		}
 	}
-
-	perms, err := fileutils.RegularFilePerms(option.Config.ExportFilePerm)
+	computedFields, err := filters.ParseComputedFields(viper.GetString(option.KeyExportComputedFields))
 	if err != nil {
-		log.Warn(fmt.Sprintf("Failed to parse export file permission '%s', failing back to %v",
-			option.KeyExportFilePerm, perms), logfields.Error, err)
+		return nil, fmt.Errorf("failed to parse %s: %w", option.KeyExportComputedFields, err)
 	}
-	writer.FileMode = perms
+	return &tetragon.GetEventsRequest{AllowList: allowList, DenyList: denyList, AggregationOptions: aggregationOptions, FieldFilters: fieldFilters, ComputedFields: computedFields}, nil
+}
 
-	finfo, err := os.Stat(filepath.Clean(option.Config.ExportFilename))
-	if err == nil && finfo.IsDir() {
-		// Error if exportFilename points to a directory
-		return errors.New("passed export JSON logs file point to a directory")
+func startExporter(ctx context.Context, server *server.Server) error {
+	req, err := getExportRequest()
+	if err != nil {
+		return err
 	}
-	logFile := filepath.Base(option.Config.ExportFilename)
-	logsDir, err := filepath.Abs(filepath.Dir(filepath.Clean(option.Config.ExportFilename)))
+	conf := &exporter.FileConfig{
+		Filename:         option.Config.ExportFilename,
+		MaxSizeMB:        option.Config.ExportFileMaxSizeMB,
+		MaxBackups:       option.Config.ExportFileMaxBackups,
+		Compress:         option.Config.ExportFileCompress,
+		RotationInterval: option.Config.ExportFileRotationInterval,
+		Perm:             option.Config.ExportFilePerm,
+		Format:           option.Config.ExportFileFormat,
+		Compression:      option.Config.ExportFileCompression,
+	}
+	encoder, writer, err := exporter.NewFileEncoder(ctx, conf)
 	if err != nil {
-		log.Warn(fmt.Sprintf("Failed to get absolute path of exported JSON logs '%s'", option.Config.ExportFilename), logfields.Error, err)
-		// Do not fail; we let lumberjack handle this. We want to
-		// log the rotate logs operation.
-		logsDir = filepath.Dir(option.Config.ExportFilename)
-	}
-
-	if option.Config.ExportFileRotationInterval < 0 {
-		// Passed an invalid interval let's error out
-		return fmt.Errorf("frequency '%s' at which to rotate JSON export files is negative", option.Config.ExportFileRotationInterval.String())
-	} else if option.Config.ExportFileRotationInterval > 0 {
-		log.Info("Periodically rotating JSON export files",
-			"directory", logsDir,
-			"frequency", option.Config.ExportFileRotationInterval.String())
-		go func() {
-			ticker := time.NewTicker(option.Config.ExportFileRotationInterval)
-			for {
-				select {
-				case <-ctx.Done():
-					return
-				case <-ticker.C:
-					log.Info("Rotating JSON logs export", "file", logFile, "directory", logsDir)
-					if rotationErr := writer.Rotate(); rotationErr != nil {
-						log.Warn("Failed to rotate JSON export file", "file", option.Config.ExportFilename, logfields.Error, rotationErr)
-					}
-				}
-			}
-		}()
+		return err
+	}
+	var rateLimiter *ratelimit.RateLimiter
+	if option.Config.ExportRateLimit >= 0 {
+		rateLimiter = ratelimit.NewRateLimiter(ctx, 1*time.Minute, option.Config.ExportRateLimit, encoder)
+	}
+	log.Info("Configured field filters", "fieldFilters", req.FieldFilters)
+	log.Info("Starting file exporter", "file", conf.Filename, "format", conf.Format, "compression", conf.Compression, "request", req)
+	exporter := exporter.NewExporter(ctx, req, server, encoder, writer, rateLimiter)
+	if sp := server.Spool(); sp != nil {
+		exporter.WithSpool(sp, "export")
 	}
+	return exporter.Start()
+}
 
-	// Track how many bytes are written to the event export location
-	encoderWriter := exporter.NewExportedBytesTotalWriter(writer)
-	encoder := encoder.NewProtojsonEncoder(encoderWriter)
+func startOTLPExporter(ctx context.Context, server *server.Server) error {
+	req, err := getExportRequest()
+	if err != nil {
+		return err
+	}
+	conf := otlp.DefaultConfig()
+	conf.Endpoint = option.Config.ExportOTLPEndpoint
+	conf.Protocol = option.Config.ExportOTLPProtocol
+	conf.Insecure = option.Config.ExportOTLPInsecure
+	conf.Headers = option.Config.ExportOTLPHeaders
+	conf.BatchSize = option.Config.ExportOTLPBatchSize
+	conf.FlushInterval = option.Config.ExportOTLPFlushInterval
+	conf.QueueSize = option.Config.ExportOTLPQueueSize
+	encoder, err := otlp.NewEncoder(conf)
+	if err != nil {
+		return fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
 	var rateLimiter *ratelimit.RateLimiter
 	if option.Config.ExportRateLimit >= 0 {
 		rateLimiter = ratelimit.NewRateLimiter(ctx, 1*time.Minute, option.Config.ExportRateLimit, encoder)
 	}
-	var aggregationOptions *tetragon.AggregationOptions
-	if option.Config.EnableExportAggregation {
-		aggregationOptions = &tetragon.AggregationOptions{
-			WindowSize:        durationpb.New(option.Config.ExportAggregationWindowSize),
-			ChannelBufferSize: option.Config.ExportAggregationBufferSize,
-		}
+	log.Info("Starting OTLP exporter", "endpoint", conf.Endpoint, "protocol", conf.Protocol, "request", req)
+	exporter := exporter.NewExporter(ctx, req, server, encoder, encoder, rateLimiter)
+	if sp := server.Spool(); sp != nil {
+		exporter.WithSpool(sp, "otlp")
 	}
-	req := tetragon.GetEventsRequest{AllowList: allowList, DenyList: denyList, AggregationOptions: aggregationOptions, FieldFilters: fieldFilters}
-	log.Info("Configured field filters", "fieldFilters", fieldFilters)
-	log.Info("Starting JSON exporter", "logger", writer, "request", &req)
-	exporter := exporter.NewExporter(ctx, &req, server, encoder, writer, rateLimiter)
 	return exporter.Start()
 }
 
+func startExportPipelines(ctx context.Context, server *server.Server) error {
+	pipelines, err := exporter.ParsePipelines([]byte(option.Config.ExportPipelines), option.Config.EnablePidSetFilter)
+	if err != nil {
+		return err
+	}
+	for _, p := range pipelines {
+		if err := exporter.StartPipeline(ctx, server, p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func Serve(ctx context.Context, listenAddr string, srv *server.Server) error {
 	// we use an empty listen address to effectively disable the gRPC server
 	if len(listenAddr) == 0 {
@@ -22,6 +22,7 @@ func AddCommonFlags(cmd *cobra.Command) {
 	flags.String(operatorOption.ConfigDir, "", "Directory in which tetragon-operator-config configmap is mounted")
 	flags.Bool(operatorOption.SkipPodInfoCRD, false, "When true, PodInfo Custom Resource Definition (CRD) will not be created")
 	flags.Bool(operatorOption.SkipTracingPolicyCRD, false, "When true, TracingPolicy and TracingPolicyNamespaced Custom Resource Definition (CRD) will not be created")
+	flags.Bool(operatorOption.SkipTracingPolicyTemplateCRD, false, "When true, TracingPolicyTemplate and TracingPolicyTemplateBinding Custom Resource Definition (CRD) will not be created")
 	flags.Bool(operatorOption.ForceUpdateCRDs, false, "When true, operator will ignore current CRD version and forcefully update it")
 }
 
@@ -11,6 +11,7 @@ import (
 	"github.com/cilium/tetragon/operator/cmd/common"
 	operatorOption "github.com/cilium/tetragon/operator/option"
 	"github.com/cilium/tetragon/operator/podinfo"
+	"github.com/cilium/tetragon/operator/policytemplate"
 	ciliumiov1alpha1 "github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
 	"github.com/cilium/tetragon/pkg/logger"
 	"github.com/cilium/tetragon/pkg/logger/logfields"
@@ -85,6 +86,19 @@ func New() *cobra.Command {
 				}
 			}
 
+			if !operatorOption.Config.SkipTracingPolicyTemplateCRD {
+				if err = (&policytemplate.BindingReconciler{
+					Client: mgr.GetClient(),
+				}).SetupWithManager(mgr); err != nil {
+					return fmt.Errorf("unable to create controller: %w %s %s", err, "controller", "tracingpolicytemplatebinding")
+				}
+				if err = (&policytemplate.TemplateReconciler{
+					Client: mgr.GetClient(),
+				}).SetupWithManager(mgr); err != nil {
+					return fmt.Errorf("unable to create controller: %w %s %s", err, "controller", "tracingpolicytemplate")
+				}
+			}
+
 			if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 				return fmt.Errorf("unable to set up health check %w", err)
 			}
@@ -70,6 +70,10 @@ func RegisterCRDs() {
 			continue
 		case option.Config.SkipTracingPolicyCRD && crd.CRDName == client.TracingPolicyNamespacedCRD.CRDName:
 			continue
+		case option.Config.SkipTracingPolicyTemplateCRD && crd.CRDName == client.TracingPolicyTemplateCRD.CRDName:
+			continue
+		case option.Config.SkipTracingPolicyTemplateCRD && crd.CRDName == client.TracingPolicyTemplateBindingCRD.CRDName:
+			continue
 		}
 		crds = append(crds, crd)
 	}
@@ -33,6 +33,10 @@ const (
 	// disabled
 	SkipTracingPolicyCRD = "skip-tracing-policy-crd"
 
+	// SkipTracingPolicyTemplateCRD specifies whether the tracing policy template
+	// CustomResourceDefinitions will be disabled
+	SkipTracingPolicyTemplateCRD = "skip-tracing-policy-template-crd"
+
 	// ForceUpdateCRDs specifies whether operator should ignore current CRD version
 	// and forcefully update it.
 	ForceUpdateCRDs = "force-update-crds"
@@ -78,6 +82,12 @@ type OperatorConfig struct {
 	// TracingPolicyNamespaced CustomResourceDefinition only.
 	SkipTracingPolicyCRD bool
 
+	// SkipTracingPolicyTemplateCRD disables creation of the
+	// TracingPolicyTemplate and TracingPolicyTemplateBinding
+	// CustomResourceDefinition, and the controller that instantiates
+	// templates.
+	SkipTracingPolicyTemplateCRD bool
+
 	// ForceUpdateCRDs forces the CRD to be updated even if it's version
 	// is lower than the one in the cluster.
 	ForceUpdateCRDs bool
@@ -114,6 +124,7 @@ func ConfigPopulate() {
 	Config.ConfigDir = viper.GetString(ConfigDir)
 	Config.SkipPodInfoCRD = viper.GetBool(SkipPodInfoCRD)
 	Config.SkipTracingPolicyCRD = viper.GetBool(SkipTracingPolicyCRD)
+	Config.SkipTracingPolicyTemplateCRD = viper.GetBool(SkipTracingPolicyTemplateCRD)
 	Config.ForceUpdateCRDs = viper.GetBool(ForceUpdateCRDs)
 	Config.MetricsAddr = viper.GetString(MetricsAddr)
 	Config.ProbeAddr = viper.GetString(ProbeAddr)
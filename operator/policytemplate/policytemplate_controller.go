@@ -0,0 +1,297 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package policytemplate
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	ciliumiov1alpha1 "github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
+	"github.com/cilium/tetragon/pkg/policytemplate"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// TemplateLabel is set on the instantiated policies to the name of
+	// their template.
+	TemplateLabel = "cilium.io/tracing-policy-template"
+	// SpecHashAnnotation is set on the instantiated policies to the hash of
+	// their instantiated specification, to detect changes.
+	SpecHashAnnotation = "cilium.io/tracing-policy-template-hash"
+)
+
+// statusError is an error reported in the status of a binding, which is not
+// retried until the binding or its template change.
+type statusError struct {
+	err error
+}
+
+func (e *statusError) Error() string {
+	return e.err.Error()
+}
+
+func (e *statusError) Unwrap() error {
+	return e.err
+}
+
+func newStatusError(format string, a ...any) error {
+	return &statusError{err: fmt.Errorf(format, a...)}
+}
+
+// BindingReconciler reconciles a TracingPolicyTemplateBinding object
+type BindingReconciler struct {
+	client.Client
+}
+
+//+kubebuilder:rbac:groups=cilium.io,resources=tracingpolicytemplates,verbs=get;list;watch
+//+kubebuilder:rbac:groups=cilium.io,resources=tracingpolicytemplatebindings,verbs=get;list;watch
+//+kubebuilder:rbac:groups=cilium.io,resources=tracingpolicytemplatebindings/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=cilium.io,resources=tracingpoliciesnamespaced,verbs=get;list;watch;create;update;patch;delete
+
+// Reconcile gets notified about a binding and reconciles the
+// TracingPolicyNamespaced it instantiates.
+func (r *BindingReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	l := log.FromContext(ctx)
+
+	binding := &ciliumiov1alpha1.TracingPolicyTemplateBinding{}
+	if err := r.Get(ctx, req.NamespacedName, binding); err != nil {
+		// The binding is deleted. Its policy is garbage collected through
+		// its owner reference.
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if binding.GetDeletionTimestamp() != nil {
+		return ctrl.Result{}, nil
+	}
+
+	policy, err := r.reconcilePolicy(ctx, binding)
+	status := ciliumiov1alpha1.TracingPolicyTemplateBindingStatus{
+		ObservedGeneration: binding.Generation,
+		Policy:             policy,
+	}
+	var serr *statusError
+	if errors.As(err, &serr) {
+		l.Info("unable to instantiate template", "binding", req.NamespacedName, "error", err)
+		status.Error = err.Error()
+		err = nil
+	}
+	if err != nil {
+		// Error talking to the API server. Try again later.
+		return ctrl.Result{}, err
+	}
+
+	if !reflect.DeepEqual(binding.Status, status) {
+		binding.Status = status
+		return ctrl.Result{}, r.Status().Update(ctx, binding)
+	}
+	return ctrl.Result{}, nil
+}
+
+// reconcilePolicy creates, updates or deletes the policy instantiated by
+// binding. It returns the name of the instantiated policy, or an empty string
+// if there is none.
+func (r *BindingReconciler) reconcilePolicy(ctx context.Context, binding *ciliumiov1alpha1.TracingPolicyTemplateBinding) (string, error) {
+	existing := &ciliumiov1alpha1.TracingPolicyNamespaced{}
+	err := r.Get(ctx, client.ObjectKeyFromObject(binding), existing)
+	switch {
+	case apierrors.IsNotFound(err):
+		existing = nil
+	case err != nil:
+		return "", err
+	case !metav1.IsControlledBy(existing, binding):
+		return "", newStatusError("TracingPolicyNamespaced %q already exists and is not managed by the binding", binding.Name)
+	}
+	current := ""
+	if existing != nil {
+		current = existing.Name
+	}
+
+	tmpl := &ciliumiov1alpha1.TracingPolicyTemplate{}
+	if err := r.Get(ctx, client.ObjectKey{Name: binding.Spec.TemplateRef}, tmpl); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return current, err
+		}
+		// The template is deleted, and so are the policies it instantiated.
+		if existing != nil {
+			if err := r.Delete(ctx, existing); client.IgnoreNotFound(err) != nil {
+				return current, err
+			}
+		}
+		return "", newStatusError("TracingPolicyTemplate %q not found", binding.Spec.TemplateRef)
+	}
+
+	// On errors, the policy previously instantiated is kept, so that a
+	// mistake in a template or a binding does not remove enforcement.
+	if err := policytemplate.Validate(&tmpl.Spec); err != nil {
+		return current, newStatusError("invalid TracingPolicyTemplate %q: %w", tmpl.Name, err)
+	}
+	spec, err := policytemplate.Instantiate(&tmpl.Spec, binding.Spec.Parameters)
+	if err != nil {
+		return current, newStatusError("%w", err)
+	}
+	if binding.Spec.PodSelector != nil {
+		spec.PodSelector = binding.Spec.PodSelector
+	}
+
+	policy, err := generatePolicy(binding, tmpl, spec)
+	if err != nil {
+		return current, err
+	}
+	switch {
+	case existing == nil:
+		err = r.Create(ctx, policy)
+	case existing.Annotations[SpecHashAnnotation] != policy.Annotations[SpecHashAnnotation] ||
+		existing.Labels[TemplateLabel] != policy.Labels[TemplateLabel]:
+		policy.ResourceVersion = existing.ResourceVersion
+		err = r.Update(ctx, policy)
+	}
+	if apierrors.IsInvalid(err) {
+		return current, newStatusError("TracingPolicyNamespaced rejected: %w", err)
+	}
+	if err != nil {
+		return current, err
+	}
+	return policy.Name, nil
+}
+
+// generatePolicy creates the policy instantiated by binding
+func generatePolicy(
+	binding *ciliumiov1alpha1.TracingPolicyTemplateBinding,
+	tmpl *ciliumiov1alpha1.TracingPolicyTemplate,
+	spec *ciliumiov1alpha1.TracingPolicySpec,
+) (*ciliumiov1alpha1.TracingPolicyNamespaced, error) {
+	b, err := json.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(b)
+	controller := true
+	blockOwnerDeletion := true
+	return &ciliumiov1alpha1.TracingPolicyNamespaced{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        binding.Name,
+			Namespace:   binding.Namespace,
+			Labels:      map[string]string{TemplateLabel: tmpl.Name},
+			Annotations: map[string]string{SpecHashAnnotation: hex.EncodeToString(hash[:])},
+			// setting up owner reference to the binding will ensure that the policy is deleted when the binding is deleted.
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					APIVersion:         ciliumiov1alpha1.SchemeGroupVersion.String(),
+					Kind:               ciliumiov1alpha1.TPTemplateBindingKindDefinition,
+					Name:               binding.Name,
+					UID:                binding.UID,
+					Controller:         &controller,
+					BlockOwnerDeletion: &blockOwnerDeletion,
+				},
+			},
+		},
+		Spec: *spec,
+	}, nil
+}
+
+// templateBindings returns the requests for the bindings of a template.
+func (r *BindingReconciler) templateBindings(ctx context.Context, obj client.Object) []reconcile.Request {
+	bindings := &ciliumiov1alpha1.TracingPolicyTemplateBindingList{}
+	if err := r.List(ctx, bindings); err != nil {
+		log.FromContext(ctx).Error(err, "unable to list TracingPolicyTemplateBindings")
+		return nil
+	}
+	var ret []reconcile.Request
+	for i := range bindings.Items {
+		b := &bindings.Items[i]
+		if b.Spec.TemplateRef == obj.GetName() {
+			ret = append(ret, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(b)})
+		}
+	}
+	return ret
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *BindingReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&ciliumiov1alpha1.TracingPolicyTemplateBinding{}).
+		Owns(&ciliumiov1alpha1.TracingPolicyNamespaced{}).
+		Watches(&ciliumiov1alpha1.TracingPolicyTemplate{}, handler.EnqueueRequestsFromMapFunc(r.templateBindings)).
+		Complete(r)
+}
+
+// TemplateReconciler reconciles the status of a TracingPolicyTemplate object
+type TemplateReconciler struct {
+	client.Client
+}
+
+//+kubebuilder:rbac:groups=cilium.io,resources=tracingpolicytemplates/status,verbs=get;update;patch
+
+// Reconcile gets notified about a template or its bindings and reconciles
+// the status of the template.
+func (r *TemplateReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	tmpl := &ciliumiov1alpha1.TracingPolicyTemplate{}
+	if err := r.Get(ctx, req.NamespacedName, tmpl); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	status := ciliumiov1alpha1.TracingPolicyTemplateStatus{
+		ObservedGeneration: tmpl.Generation,
+	}
+	if err := policytemplate.Validate(&tmpl.Spec); err != nil {
+		status.Error = err.Error()
+	}
+
+	bindings := &ciliumiov1alpha1.TracingPolicyTemplateBindingList{}
+	if err := r.List(ctx, bindings); err != nil {
+		return ctrl.Result{}, err
+	}
+	for i := range bindings.Items {
+		b := &bindings.Items[i]
+		if b.Spec.TemplateRef != tmpl.Name || b.Status.Policy == "" {
+			continue
+		}
+		status.Policies = append(status.Policies, ciliumiov1alpha1.TemplatePolicyReference{
+			Namespace: b.Namespace,
+			Name:      b.Status.Policy,
+		})
+	}
+	slices.SortFunc(status.Policies, func(a, b ciliumiov1alpha1.TemplatePolicyReference) int {
+		if c := strings.Compare(a.Namespace, b.Namespace); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	if !reflect.DeepEqual(tmpl.Status, status) {
+		tmpl.Status = status
+		return ctrl.Result{}, r.Status().Update(ctx, tmpl)
+	}
+	return ctrl.Result{}, nil
+}
+
+// bindingTemplate returns the request for the template of a binding.
+func bindingTemplate(_ context.Context, obj client.Object) []reconcile.Request {
+	b, ok := obj.(*ciliumiov1alpha1.TracingPolicyTemplateBinding)
+	if !ok {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: b.Spec.TemplateRef}}}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *TemplateReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&ciliumiov1alpha1.TracingPolicyTemplate{}).
+		Watches(&ciliumiov1alpha1.TracingPolicyTemplateBinding{}, handler.EnqueueRequestsFromMapFunc(bindingTemplate)).
+		Complete(r)
+}
@@ -0,0 +1,144 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package policytemplate
+
+import (
+	"context"
+	"testing"
+
+	ciliumv1alpha1 "github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func getClientBuilder() *fake.ClientBuilder {
+	scheme := runtime.NewScheme()
+	utilruntime.Must(ciliumv1alpha1.AddToScheme(scheme))
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&ciliumv1alpha1.TracingPolicyTemplate{}, &ciliumv1alpha1.TracingPolicyTemplateBinding{})
+}
+
+func testTemplate() *ciliumv1alpha1.TracingPolicyTemplate {
+	return &ciliumv1alpha1.TracingPolicyTemplate{
+		ObjectMeta: metav1.ObjectMeta{Name: "block-binaries"},
+		Spec: ciliumv1alpha1.TracingPolicyTemplateSpec{
+			Parameters: []ciliumv1alpha1.TemplateParameter{
+				{Name: "binaries", Type: "stringList"},
+			},
+			Policy: ciliumv1alpha1.TracingPolicySpec{
+				KProbes: []ciliumv1alpha1.KProbeSpec{{
+					Call: "security_bprm_check",
+					Selectors: []ciliumv1alpha1.KProbeSelector{{
+						MatchBinaries: []ciliumv1alpha1.BinarySelector{{
+							Operator: "In",
+							Values:   []string{"${binaries}"},
+						}},
+					}},
+				}},
+			},
+		},
+	}
+}
+
+func testBinding(namespace string, binaries ...string) *ciliumv1alpha1.TracingPolicyTemplateBinding {
+	return &ciliumv1alpha1.TracingPolicyTemplateBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "block", Namespace: namespace, UID: "binding-uid"},
+		Spec: ciliumv1alpha1.TracingPolicyTemplateBindingSpec{
+			TemplateRef: "block-binaries",
+			Parameters: []ciliumv1alpha1.TemplateParameterValue{
+				{Name: "binaries", TemplateValue: ciliumv1alpha1.TemplateValue{Values: binaries}},
+			},
+		},
+	}
+}
+
+func reconcileBinding(t *testing.T, c client.Client, b *ciliumv1alpha1.TracingPolicyTemplateBinding) *ciliumv1alpha1.TracingPolicyTemplateBinding {
+	key := client.ObjectKeyFromObject(b)
+	_, err := (&BindingReconciler{c}).Reconcile(context.Background(), ctrl.Request{NamespacedName: key})
+	require.NoError(t, err)
+	ret := &ciliumv1alpha1.TracingPolicyTemplateBinding{}
+	require.NoError(t, c.Get(context.Background(), key, ret))
+	return ret
+}
+
+func TestReconcileBinding(t *testing.T) {
+	ctx := context.Background()
+	tmpl := testTemplate()
+	binding := testBinding("default", "/usr/bin/nc")
+	c := getClientBuilder().WithObjects(tmpl, binding).Build()
+
+	binding = reconcileBinding(t, c, binding)
+	assert.Equal(t, "block", binding.Status.Policy)
+	assert.Empty(t, binding.Status.Error)
+
+	policy := &ciliumv1alpha1.TracingPolicyNamespaced{}
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Namespace: "default", Name: "block"}, policy))
+	assert.Equal(t, []string{"/usr/bin/nc"}, policy.Spec.KProbes[0].Selectors[0].MatchBinaries[0].Values)
+	assert.Equal(t, "block-binaries", policy.Labels[TemplateLabel])
+	assert.True(t, metav1.IsControlledBy(policy, binding))
+
+	// the policy follows the binding
+	binding.Spec.Parameters[0].Values = []string{"/usr/bin/nc", "/usr/bin/socat"}
+	require.NoError(t, c.Update(ctx, binding))
+	binding = reconcileBinding(t, c, binding)
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Namespace: "default", Name: "block"}, policy))
+	assert.Equal(t, []string{"/usr/bin/nc", "/usr/bin/socat"}, policy.Spec.KProbes[0].Selectors[0].MatchBinaries[0].Values)
+
+	// an invalid binding keeps the previous policy
+	binding.Spec.Parameters[0].Values = nil
+	require.NoError(t, c.Update(ctx, binding))
+	binding = reconcileBinding(t, c, binding)
+	assert.Equal(t, "block", binding.Status.Policy)
+	assert.Contains(t, binding.Status.Error, `parameter "binaries" is not set`)
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Namespace: "default", Name: "block"}, policy))
+	assert.Len(t, policy.Spec.KProbes[0].Selectors[0].MatchBinaries[0].Values, 2)
+
+	// deleting the template deletes its policies
+	require.NoError(t, c.Delete(ctx, tmpl))
+	binding = reconcileBinding(t, c, binding)
+	assert.Empty(t, binding.Status.Policy)
+	assert.Contains(t, binding.Status.Error, `TracingPolicyTemplate "block-binaries" not found`)
+	err := c.Get(ctx, types.NamespacedName{Namespace: "default", Name: "block"}, policy)
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestReconcileBindingConflict(t *testing.T) {
+	existing := &ciliumv1alpha1.TracingPolicyNamespaced{
+		ObjectMeta: metav1.ObjectMeta{Name: "block", Namespace: "default"},
+	}
+	c := getClientBuilder().WithObjects(testTemplate(), testBinding("default", "/usr/bin/nc"), existing).Build()
+
+	binding := reconcileBinding(t, c, testBinding("default"))
+	assert.Empty(t, binding.Status.Policy)
+	assert.Contains(t, binding.Status.Error, "is not managed by the binding")
+}
+
+func TestReconcileTemplate(t *testing.T) {
+	ctx := context.Background()
+	tmpl := testTemplate()
+	// a reference to an undeclared parameter
+	tmpl.Spec.Policy.KProbes[0].Message = "${message}"
+	b1 := testBinding("ns1", "/usr/bin/nc")
+	b1.Status.Policy = "block"
+	b2 := testBinding("ns2", "/usr/bin/nc")
+	other := testBinding("ns3", "/usr/bin/nc")
+	other.Spec.TemplateRef = "other"
+	other.Status.Policy = "block"
+	c := getClientBuilder().WithObjects(tmpl, b1, b2, other).Build()
+
+	_, err := (&TemplateReconciler{c}).Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: tmpl.Name}})
+	require.NoError(t, err)
+	require.NoError(t, c.Get(ctx, types.NamespacedName{Name: tmpl.Name}, tmpl))
+	assert.Equal(t, []ciliumv1alpha1.TemplatePolicyReference{{Namespace: "ns1", Name: "block"}}, tmpl.Status.Policies)
+	assert.Contains(t, tmpl.Status.Error, `parameter "message" is not declared by the template`)
+}
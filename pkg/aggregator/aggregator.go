@@ -4,20 +4,46 @@
 package aggregator
 
 import (
+	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/cilium/tetragon/api/v1/tetragon"
 	"github.com/cilium/tetragon/pkg/logger"
 	"github.com/cilium/tetragon/pkg/logger/logfields"
+	"google.golang.org/protobuf/proto"
 )
 
+// aggregatedTypes are the event types that can be aggregated.
+var aggregatedTypes = []tetragon.EventType{
+	tetragon.EventType_PROCESS_KPROBE,
+	tetragon.EventType_PROCESS_TRACEPOINT,
+	tetragon.EventType_PROCESS_UPROBE,
+	tetragon.EventType_PROCESS_LSM,
+}
+
+// DefaultKey is the aggregation key used if AggregationOptions.key is not set.
+func DefaultKey() *tetragon.AggregationKey {
+	return &tetragon.AggregationKey{
+		PolicyName:   true,
+		FunctionName: true,
+		Binary:       true,
+		Pod:          true,
+	}
+}
+
 type Aggregator struct {
 	server tetragon.FineGuidanceSensors_GetEventsServer
 	window time.Duration
 	events chan *tetragon.GetEventsResponse
 	cache  map[string]*tetragon.GetEventsResponse
+	types  map[tetragon.EventType]struct{}
+	key    *tetragon.AggregationKey
+	// windowStart is the time of the first event of the current window when
+	// replaying events.
+	windowStart time.Time
 }
 
 func NewAggregator(
@@ -31,14 +57,41 @@ func NewAggregator(
 	if options.WindowSize != nil {
 		window = options.WindowSize.AsDuration()
 	}
+	types := make(map[tetragon.EventType]struct{})
+	for _, t := range options.EventSet {
+		if !isAggregatedType(t) {
+			return nil, fmt.Errorf("aggregation of %s events is not supported", t)
+		}
+		types[t] = struct{}{}
+	}
+	if len(types) == 0 {
+		for _, t := range aggregatedTypes {
+			types[t] = struct{}{}
+		}
+	}
+	key := options.Key
+	if key == nil {
+		key = DefaultKey()
+	}
 	return &Aggregator{
-		server,
-		window,
-		make(chan *tetragon.GetEventsResponse, options.ChannelBufferSize),
-		make(map[string]*tetragon.GetEventsResponse),
+		server: server,
+		window: window,
+		events: make(chan *tetragon.GetEventsResponse, options.ChannelBufferSize),
+		cache:  make(map[string]*tetragon.GetEventsResponse),
+		types:  types,
+		key:    key,
 	}, nil
 }
 
+func isAggregatedType(t tetragon.EventType) bool {
+	for _, at := range aggregatedTypes {
+		if t == at {
+			return true
+		}
+	}
+	return false
+}
+
 func (a *Aggregator) Start() {
 	// nolint Since Aggregator.Start is an endless function,
 	// this qualifies as an acceptable use of time.Tick
@@ -53,8 +106,47 @@ func (a *Aggregator) Start() {
 	}
 }
 
+// Replay aggregates an event read offline, e.g. from an export file. Unlike
+// Start, windows are based on the time of the events rather than on the wall
+// clock: the aggregated events are sent when an event is at least one window
+// after the first event of the current window. Call Flush at the end of the
+// input to send the events of the last window.
+func (a *Aggregator) Replay(event *tetragon.GetEventsResponse) {
+	if t := event.GetTime(); t != nil {
+		now := t.AsTime()
+		if a.windowStart.IsZero() {
+			a.windowStart = now
+		} else if now.Sub(a.windowStart) >= a.window {
+			a.flush()
+			a.windowStart = now
+		}
+	}
+	a.handleEvent(event)
+}
+
+// Flush sends the aggregated events of the current window.
+func (a *Aggregator) Flush() {
+	a.flush()
+	a.windowStart = time.Time{}
+}
+
+// flush sends the cached events ordered by the time of their first event, and
+// then by key, so that the output does not depend on the map iteration order.
 func (a *Aggregator) flush() {
-	for _, event := range a.cache {
+	keys := make([]string, 0, len(a.cache))
+	for key := range a.cache {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		ti := a.cache[keys[i]].AggregationInfo.FirstSeen.AsTime()
+		tj := a.cache[keys[j]].AggregationInfo.FirstSeen.AsTime()
+		if !ti.Equal(tj) {
+			return ti.Before(tj)
+		}
+		return keys[i] < keys[j]
+	})
+	for _, key := range keys {
+		event := a.cache[key]
 		if err := a.server.Send(event); err != nil {
 			logger.GetLogger().Warn("Failed to send aggregated response", logfields.Error, err)
 		}
@@ -64,12 +156,136 @@ func (a *Aggregator) flush() {
 }
 
 func (a *Aggregator) handleEvent(event *tetragon.GetEventsResponse) {
-	switch event.Event.(type) {
-	default:
+	key, ok := a.aggregationKey(event)
+	if !ok {
 		if err := a.server.Send(event); err != nil {
 			logger.GetLogger().Warn("Failed to send unaggregated response", logfields.Error, err)
 		}
+		return
+	}
+
+	if cached, ok := a.cache[key]; ok {
+		cached.AggregationInfo.Count++
+		if event.Time != nil {
+			cached.AggregationInfo.LastSeen = event.Time
+		}
+		return
+	}
+
+	// The event might be shared with other listeners, so aggregate a copy.
+	event = proto.Clone(event).(*tetragon.GetEventsResponse)
+	event.AggregationInfo = &tetragon.AggregationInfo{
+		Count:     1,
+		FirstSeen: event.Time,
+		LastSeen:  event.Time,
+	}
+	a.cache[key] = event
+}
+
+// aggregationKey returns the key identifying the events aggregated together
+// with event, or false if the event is not aggregated.
+func (a *Aggregator) aggregationKey(event *tetragon.GetEventsResponse) (string, bool) {
+	var (
+		typ     tetragon.EventType
+		process *tetragon.Process
+		policy  string
+		hook    string
+		action  tetragon.KprobeAction
+		args    []*tetragon.KprobeArgument
+	)
+	switch ev := event.Event.(type) {
+	case *tetragon.GetEventsResponse_ProcessKprobe:
+		e := ev.ProcessKprobe
+		typ, process, policy, hook, action, args = tetragon.EventType_PROCESS_KPROBE,
+			e.Process, e.PolicyName, e.FunctionName, e.Action, e.Args
+	case *tetragon.GetEventsResponse_ProcessTracepoint:
+		e := ev.ProcessTracepoint
+		typ, process, policy, hook, action, args = tetragon.EventType_PROCESS_TRACEPOINT,
+			e.Process, e.PolicyName, e.Subsys+"/"+e.Event, e.Action, e.Args
+	case *tetragon.GetEventsResponse_ProcessUprobe:
+		e := ev.ProcessUprobe
+		hook = e.Path + ":" + e.Symbol
+		if e.Symbol == "" {
+			hook = e.Path + ":" + strconv.FormatUint(e.Offset, 10)
+		}
+		typ, process, policy, args = tetragon.EventType_PROCESS_UPROBE,
+			e.Process, e.PolicyName, e.Args
+	case *tetragon.GetEventsResponse_ProcessLsm:
+		e := ev.ProcessLsm
+		typ, process, policy, hook, action, args = tetragon.EventType_PROCESS_LSM,
+			e.Process, e.PolicyName, e.FunctionName, e.Action, e.Args
+	default:
+		return "", false
+	}
+	if _, ok := a.types[typ]; !ok {
+		return "", false
+	}
+
+	// Fields are separated by NUL bytes. Arguments are length-prefixed since
+	// their binary encoding might contain NUL bytes.
+	var b strings.Builder
+	b.WriteString(typ.String())
+	b.WriteByte(0)
+	b.WriteString(action.String())
+	if a.key.PolicyName {
+		b.WriteByte(0)
+		b.WriteString(policy)
+	}
+	if a.key.FunctionName {
+		b.WriteByte(0)
+		b.WriteString(hook)
+	}
+	if a.key.Binary {
+		b.WriteByte(0)
+		b.WriteString(process.GetBinary())
+	}
+	if a.key.Pod {
+		b.WriteByte(0)
+		b.WriteString(process.GetPod().GetNamespace())
+		b.WriteByte(0)
+		b.WriteString(process.GetPod().GetName())
+	}
+	for _, idx := range a.key.ArgIndices {
+		b.WriteByte(0)
+		if int(idx) >= len(args) {
+			continue
+		}
+		data, err := proto.MarshalOptions{Deterministic: true}.Marshal(args[idx])
+		if err != nil {
+			logger.GetLogger().Warn("Failed to marshal aggregation key argument", logfields.Error, err)
+			return "", false
+		}
+		b.WriteString(strconv.Itoa(len(data)))
+		b.WriteByte(':')
+		b.Write(data)
+	}
+	return b.String(), true
+}
+
+// ParseKey parses a list of aggregation key fields. Valid fields are
+// policy_name, function_name, binary, pod and argN, where N is the index of
+// an event argument.
+func ParseKey(fields []string) (*tetragon.AggregationKey, error) {
+	key := &tetragon.AggregationKey{}
+	for _, f := range fields {
+		switch f = strings.TrimSpace(f); f {
+		case "policy_name":
+			key.PolicyName = true
+		case "function_name":
+			key.FunctionName = true
+		case "binary":
+			key.Binary = true
+		case "pod":
+			key.Pod = true
+		default:
+			idx, err := strconv.ParseUint(strings.TrimPrefix(f, "arg"), 10, 32)
+			if !strings.HasPrefix(f, "arg") || err != nil {
+				return nil, fmt.Errorf("invalid aggregation key field %q", f)
+			}
+			key.ArgIndices = append(key.ArgIndices, uint32(idx))
+		}
 	}
+	return key, nil
 }
 
 func getNameOrIp(ip string, names []string) string {
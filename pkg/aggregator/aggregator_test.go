@@ -5,11 +5,175 @@ package aggregator
 
 import (
 	"testing"
+	"time"
 
+	"github.com/cilium/tetragon/api/v1/tetragon"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func Test_getNameOrIp(t *testing.T) {
 	assert.Equal(t, "1.1.1.1", getNameOrIp("1.1.1.1", []string{}))
 	assert.Equal(t, "a.com,b.com,c.com", getNameOrIp("1.1.1.1", []string{"b.com", "c.com", "a.com"}))
 }
+
+type fakeServer struct {
+	tetragon.FineGuidanceSensors_GetEventsServer
+	events []*tetragon.GetEventsResponse
+}
+
+func (s *fakeServer) Send(event *tetragon.GetEventsResponse) error {
+	s.events = append(s.events, event)
+	return nil
+}
+
+func kprobeEvent(ts int64, binary, pod, path string) *tetragon.GetEventsResponse {
+	return &tetragon.GetEventsResponse{
+		Event: &tetragon.GetEventsResponse_ProcessKprobe{ProcessKprobe: &tetragon.ProcessKprobe{
+			Process: &tetragon.Process{
+				Binary: binary,
+				Pod:    &tetragon.Pod{Namespace: "default", Name: pod},
+			},
+			FunctionName: "security_file_permission",
+			PolicyName:   "file-monitoring",
+			Action:       tetragon.KprobeAction_KPROBE_ACTION_POST,
+			Args: []*tetragon.KprobeArgument{
+				{Arg: &tetragon.KprobeArgument_FileArg{FileArg: &tetragon.KprobeFile{Path: path}}},
+				{Arg: &tetragon.KprobeArgument_IntArg{IntArg: int32(ts)}},
+			},
+		}},
+		Time: timestamppb.New(time.Unix(ts, 0)),
+	}
+}
+
+func TestAggregator(t *testing.T) {
+	server := &fakeServer{}
+	a, err := NewAggregator(server, &tetragon.AggregationOptions{})
+	require.NoError(t, err)
+
+	a.handleEvent(kprobeEvent(1, "/usr/bin/cat", "pod-a", "/etc/passwd"))
+	a.handleEvent(kprobeEvent(2, "/usr/bin/cat", "pod-a", "/etc/shadow"))
+	a.handleEvent(kprobeEvent(3, "/usr/bin/cat", "pod-a", "/etc/passwd"))
+	a.handleEvent(kprobeEvent(4, "/usr/bin/cat", "pod-b", "/etc/passwd"))
+	a.handleEvent(&tetragon.GetEventsResponse{Event: &tetragon.GetEventsResponse_ProcessExec{ProcessExec: &tetragon.ProcessExec{}}})
+
+	// unaggregated events are sent right away
+	require.Len(t, server.events, 1)
+	assert.NotNil(t, server.events[0].GetProcessExec())
+
+	a.flush()
+	require.Len(t, server.events, 3)
+	counts := map[string]*tetragon.AggregationInfo{}
+	for _, ev := range server.events[1:] {
+		counts[ev.GetProcessKprobe().Process.Pod.Name] = ev.AggregationInfo
+	}
+	require.Contains(t, counts, "pod-a")
+	assert.Equal(t, uint64(3), counts["pod-a"].Count)
+	assert.Equal(t, int64(1), counts["pod-a"].FirstSeen.Seconds)
+	assert.Equal(t, int64(3), counts["pod-a"].LastSeen.Seconds)
+	require.Contains(t, counts, "pod-b")
+	assert.Equal(t, uint64(1), counts["pod-b"].Count)
+
+	// the cache is cleared after a flush
+	a.flush()
+	assert.Len(t, server.events, 3)
+}
+
+func TestAggregatorKey(t *testing.T) {
+	server := &fakeServer{}
+	a, err := NewAggregator(server, &tetragon.AggregationOptions{
+		Key: &tetragon.AggregationKey{FunctionName: true, ArgIndices: []uint32{0}},
+	})
+	require.NoError(t, err)
+
+	first := kprobeEvent(1, "/usr/bin/cat", "pod-a", "/etc/passwd")
+	a.handleEvent(first)
+	a.handleEvent(kprobeEvent(2, "/usr/bin/less", "pod-b", "/etc/passwd"))
+	a.handleEvent(kprobeEvent(3, "/usr/bin/cat", "pod-a", "/etc/shadow"))
+	a.handleEvent(kprobeEvent(4, "/usr/bin/cat", "pod-a", "/etc/shadow"))
+	a.flush()
+
+	require.Len(t, server.events, 2)
+	counts := map[string]uint64{}
+	for _, ev := range server.events {
+		counts[ev.GetProcessKprobe().Args[0].GetFileArg().Path] = ev.AggregationInfo.Count
+	}
+	assert.Equal(t, map[string]uint64{"/etc/passwd": 2, "/etc/shadow": 2}, counts)
+	// the original event is not modified
+	assert.Nil(t, first.AggregationInfo)
+}
+
+func TestAggregatorReplay(t *testing.T) {
+	server := &fakeServer{}
+	a, err := NewAggregator(server, &tetragon.AggregationOptions{
+		WindowSize: durationpb.New(10 * time.Second),
+	})
+	require.NoError(t, err)
+
+	// windows are based on the event times: [1, 11) and [12, 22)
+	a.Replay(kprobeEvent(1, "/usr/bin/cat", "pod-a", "/etc/passwd"))
+	a.Replay(kprobeEvent(5, "/usr/bin/cat", "pod-a", "/etc/passwd"))
+	a.Replay(kprobeEvent(10, "/usr/bin/cat", "pod-a", "/etc/passwd"))
+	assert.Empty(t, server.events)
+	a.Replay(kprobeEvent(12, "/usr/bin/cat", "pod-a", "/etc/passwd"))
+	require.Len(t, server.events, 1)
+	assert.Equal(t, uint64(3), server.events[0].AggregationInfo.Count)
+	a.Replay(kprobeEvent(21, "/usr/bin/cat", "pod-a", "/etc/passwd"))
+	assert.Len(t, server.events, 1)
+
+	a.Flush()
+	require.Len(t, server.events, 2)
+	assert.Equal(t, uint64(2), server.events[1].AggregationInfo.Count)
+	assert.Equal(t, int64(12), server.events[1].AggregationInfo.FirstSeen.Seconds)
+}
+
+func TestAggregatorFlushOrder(t *testing.T) {
+	server := &fakeServer{}
+	a, err := NewAggregator(server, &tetragon.AggregationOptions{})
+	require.NoError(t, err)
+
+	a.handleEvent(kprobeEvent(3, "/usr/bin/cat", "pod-c", "/etc/passwd"))
+	a.handleEvent(kprobeEvent(1, "/usr/bin/cat", "pod-b", "/etc/passwd"))
+	a.handleEvent(kprobeEvent(2, "/usr/bin/cat", "pod-d", "/etc/passwd"))
+	a.handleEvent(kprobeEvent(1, "/usr/bin/cat", "pod-a", "/etc/passwd"))
+	a.flush()
+
+	pods := []string{}
+	for _, ev := range server.events {
+		pods = append(pods, ev.GetProcessKprobe().Process.Pod.Name)
+	}
+	assert.Equal(t, []string{"pod-a", "pod-b", "pod-d", "pod-c"}, pods)
+}
+
+func TestAggregatorEventSet(t *testing.T) {
+	_, err := NewAggregator(&fakeServer{}, &tetragon.AggregationOptions{
+		EventSet: []tetragon.EventType{tetragon.EventType_PROCESS_EXEC},
+	})
+	require.Error(t, err)
+
+	server := &fakeServer{}
+	a, err := NewAggregator(server, &tetragon.AggregationOptions{
+		EventSet: []tetragon.EventType{tetragon.EventType_PROCESS_LSM},
+	})
+	require.NoError(t, err)
+	a.handleEvent(kprobeEvent(1, "/usr/bin/cat", "pod-a", "/etc/passwd"))
+	a.handleEvent(kprobeEvent(2, "/usr/bin/cat", "pod-a", "/etc/passwd"))
+	assert.Len(t, server.events, 2)
+}
+
+func TestParseKey(t *testing.T) {
+	key, err := ParseKey([]string{"policy_name", "pod", "arg0", "arg2"})
+	require.NoError(t, err)
+	assert.True(t, key.PolicyName)
+	assert.False(t, key.FunctionName)
+	assert.False(t, key.Binary)
+	assert.True(t, key.Pod)
+	assert.Equal(t, []uint32{0, 2}, key.ArgIndices)
+
+	for _, f := range []string{"foo", "arg", "argx", "arg-1"} {
+		_, err = ParseKey([]string{f})
+		require.Error(t, err, f)
+	}
+}
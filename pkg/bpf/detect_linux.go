@@ -45,6 +45,7 @@ var (
 	missedStatsKprobe      Feature
 	missedStatsKprobeMulti Feature
 	batchUpdate            Feature
+	fentry                 Feature
 )
 
 func HasOverrideHelper() bool {
@@ -306,6 +307,52 @@ func HasLSMPrograms() bool {
 	return lsm.detected
 }
 
+// detectFentry checks that fexit programs can be attached and use the
+// bpf_get_func_arg and bpf_get_func_ret helpers, which generic fentry and
+// fexit programs rely on to read arguments and return values.
+func detectFentry() bool {
+	prog, err := ebpf.NewProgram(&ebpf.ProgramSpec{
+		Name: "probe_fexit",
+		Type: ebpf.Tracing,
+		Instructions: asm.Instructions{
+			asm.Mov.Reg(asm.R6, asm.R1),
+			asm.Mov.Reg(asm.R3, asm.RFP),
+			asm.Add.Imm(asm.R3, -8),
+			asm.Mov.Imm(asm.R2, 0),
+			asm.FnGetFuncArg.Call(),
+			asm.Mov.Reg(asm.R1, asm.R6),
+			asm.Mov.Reg(asm.R2, asm.RFP),
+			asm.Add.Imm(asm.R2, -8),
+			asm.FnGetFuncRet.Call(),
+			asm.Mov.Imm(asm.R0, 0),
+			asm.Return(),
+		},
+		AttachType: ebpf.AttachTraceFExit,
+		License:    "MIT",
+		AttachTo:   "security_task_prctl",
+	})
+	if err != nil {
+		return false
+	}
+	defer prog.Close()
+
+	link, err := link.AttachTracing(link.TracingOptions{
+		Program: prog,
+	})
+	if err != nil {
+		return false
+	}
+	link.Close()
+	return true
+}
+
+func HasFentry() bool {
+	fentry.init.Do(func() {
+		fentry.detected = detectFentry()
+	})
+	return fentry.detected
+}
+
 func detectLinkPin() (bool, error) {
 	prog, err := ebpf.NewProgram(&ebpf.ProgramSpec{
 		Name: "probe_bpf_kprobe",
@@ -491,9 +538,9 @@ func LogFeatures() string {
 	// we cache all values so calling again a Has* function will
 	// not load the BTF again
 	defer ebtf.FlushKernelSpec()
-	return fmt.Sprintf("override_return: %t, buildid: %t, kprobe_multi: %t, uprobe_multi %t, fmodret: %t, fmodret_syscall: %t, signal: %t, large: %t, link_pin: %t, lsm: %t, missed_stats_kprobe_multi: %t, missed_stats_kprobe: %t, batch_update: %t",
+	return fmt.Sprintf("override_return: %t, buildid: %t, kprobe_multi: %t, uprobe_multi %t, fmodret: %t, fmodret_syscall: %t, signal: %t, large: %t, link_pin: %t, lsm: %t, missed_stats_kprobe_multi: %t, missed_stats_kprobe: %t, batch_update: %t, fentry: %t",
 		HasOverrideHelper(), HasBuildId(), HasKprobeMulti(), HasUprobeMulti(),
 		HasModifyReturn(), HasModifyReturnSyscall(), HasSignalHelper(), HasProgramLargeSize(),
 		HasLinkPin(), HasLSMPrograms(), HasMissedStatsKprobeMulti(), HasMissedStatsPerfEvent(),
-		HasBatchAPI())
+		HasBatchAPI(), HasFentry())
 }
@@ -16,6 +16,7 @@ import (
 	"github.com/cilium/ebpf/btf"
 	api "github.com/cilium/tetragon/pkg/api/tracingapi"
 	"github.com/cilium/tetragon/pkg/defaults"
+	gt "github.com/cilium/tetragon/pkg/generictypes"
 	"github.com/cilium/tetragon/pkg/kernels"
 	"github.com/cilium/tetragon/pkg/logger"
 	"github.com/cilium/tetragon/pkg/option"
@@ -135,6 +136,113 @@ func findBTFFuncParamFromHookWithSpec(spec *btf.Spec, hook string, argIndex int)
 	return &btfHookProto.Params[argIndex], nil
 }
 
+// FindFuncProto returns the BTF prototype of the kernel function fn.
+func FindFuncProto(fn string) (*btf.FuncProto, error) {
+	spec, err := NewBTF()
+	if err != nil {
+		return nil, err
+	}
+	return findFuncProtoWithSpec(spec, fn)
+}
+
+func findFuncProtoWithSpec(spec *btf.Spec, fn string) (*btf.FuncProto, error) {
+	var btfFn *btf.Func
+
+	if err := spec.TypeByName(fn, &btfFn); err != nil {
+		return nil, fmt.Errorf("failed to find BTF type for function %q: %w", fn, err)
+	}
+	proto, ok := btfFn.Type.(*btf.FuncProto)
+	if !ok {
+		return nil, fmt.Errorf("function %q has no BTF type FuncProto", fn)
+	}
+	return proto, nil
+}
+
+// FindTracepointProto returns the BTF prototype of the tracepoint event, from
+// its btf_trace_<event> type. The first parameter of the prototype is the
+// tracepoint data pointer, the tracepoint arguments follow.
+func FindTracepointProto(event string) (*btf.FuncProto, error) {
+	spec, err := NewBTF()
+	if err != nil {
+		return nil, err
+	}
+	return findTracepointProtoWithSpec(spec, event)
+}
+
+func findTracepointProtoWithSpec(spec *btf.Spec, event string) (*btf.FuncProto, error) {
+	var typedef *btf.Typedef
+
+	name := "btf_trace_" + event
+	if err := spec.TypeByName(name, &typedef); err != nil {
+		return nil, fmt.Errorf("failed to find BTF type for tracepoint %q: %w", event, err)
+	}
+	ptr, ok := typedef.Type.(*btf.Pointer)
+	if !ok {
+		return nil, fmt.Errorf("BTF type %q is not a pointer", name)
+	}
+	proto, ok := ptr.Target.(*btf.FuncProto)
+	if !ok {
+		return nil, fmt.Errorf("BTF type %q is not a FuncProto pointer", name)
+	}
+	return proto, nil
+}
+
+// GenericTypeFromFuncArg returns the generic type used to read a function
+// argument, or return value, of BTF type ty. Pointers to kernel objects that
+// have a generic type (file, dentry, linux_binprm...) map to it, pointers to
+// char map to strings, and other pointers and integers map to the integer
+// type of the same size and signedness.
+func GenericTypeFromFuncArg(ty btf.Type) int {
+	switch t := ResolveNestedTypes(ty).(type) {
+	case *btf.Pointer:
+		switch target := ResolveNestedTypes(t.Target).(type) {
+		case *btf.Struct, *btf.Union:
+			if ret := gt.GenericTypeFromBTF(target); ret != gt.GenericInvalidType {
+				return ret
+			}
+		case *btf.Int:
+			if target.Name == "char" {
+				return gt.GenericStringType
+			}
+		}
+		return gt.GenericU64Type
+	case *btf.Int:
+		if t.Encoding == btf.Bool {
+			return gt.GenericU8Type
+		}
+		return genericIntType(t.Size, t.Encoding == btf.Signed)
+	case *btf.Enum:
+		return genericIntType(t.Size, t.Signed)
+	}
+	return gt.GenericInvalidType
+}
+
+func genericIntType(size uint32, signed bool) int {
+	switch size {
+	case 1:
+		if signed {
+			return gt.GenericS8Type
+		}
+		return gt.GenericU8Type
+	case 2:
+		if signed {
+			return gt.GenericS16Type
+		}
+		return gt.GenericU16Type
+	case 4:
+		if signed {
+			return gt.GenericS32Type
+		}
+		return gt.GenericU32Type
+	case 8:
+		if signed {
+			return gt.GenericS64Type
+		}
+		return gt.GenericU64Type
+	}
+	return gt.GenericInvalidType
+}
+
 func ResolveNestedTypes(ty btf.Type) btf.Type {
 	switch t := ty.(type) {
 	case *btf.Restrict:
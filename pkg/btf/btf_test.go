@@ -20,6 +20,7 @@ import (
 	"github.com/cilium/ebpf/btf"
 	api "github.com/cilium/tetragon/pkg/api/tracingapi"
 	"github.com/cilium/tetragon/pkg/defaults"
+	gt "github.com/cilium/tetragon/pkg/generictypes"
 	"github.com/cilium/tetragon/pkg/kernels"
 	"github.com/cilium/tetragon/pkg/option"
 	"github.com/stretchr/testify/assert"
@@ -581,3 +582,74 @@ func TestResolveBTFPath(t *testing.T) {
 		t.Run(btfFile, testResolveBTFPath(btfFile))
 	}
 }
+
+func TestGenericTypeFromFuncArg(t *testing.T) {
+	char := &btf.Int{Name: "char", Size: 1, Encoding: btf.Signed}
+	u8 := &btf.Int{Name: "unsigned char", Size: 1}
+	long := &btf.Int{Name: "long int", Size: 8, Encoding: btf.Signed}
+	file := &btf.Struct{Name: "file"}
+
+	tests := []struct {
+		ty   btf.Type
+		want int
+	}{
+		{&btf.Int{Name: "int", Size: 4, Encoding: btf.Signed}, gt.GenericS32Type},
+		{&btf.Typedef{Name: "size_t", Type: &btf.Int{Name: "long unsigned int", Size: 8}}, gt.GenericU64Type},
+		{&btf.Typedef{Name: "umode_t", Type: &btf.Int{Name: "short unsigned int", Size: 2}}, gt.GenericU16Type},
+		{&btf.Int{Name: "_Bool", Size: 1, Encoding: btf.Bool}, gt.GenericU8Type},
+		{&btf.Enum{Name: "bpf_cmd", Size: 4}, gt.GenericU32Type},
+		{long, gt.GenericS64Type},
+		{&btf.Pointer{Target: &btf.Const{Type: char}}, gt.GenericStringType},
+		{&btf.Pointer{Target: u8}, gt.GenericU64Type},
+		{&btf.Pointer{Target: file}, gt.GenericFileType},
+		{&btf.Pointer{Target: &btf.Struct{Name: "task_struct"}}, gt.GenericU64Type},
+		{&btf.Pointer{Target: &btf.Void{}}, gt.GenericU64Type},
+		{file, gt.GenericInvalidType},
+		{&btf.Void{}, gt.GenericInvalidType},
+	}
+	for _, test := range tests {
+		assert.Equal(t, test.want, GenericTypeFromFuncArg(test.ty), test.ty)
+	}
+}
+
+func testFindFuncProto(btfFName string) func(t *testing.T) {
+	return func(t *testing.T) {
+		spec, err := btf.LoadSpec(btfFName)
+		if err != nil {
+			t.Skipf("%q not found", btfFName)
+		}
+
+		proto, err := findFuncProtoWithSpec(spec, "do_sys_open")
+		require.NoError(t, err)
+		require.Len(t, proto.Params, 4)
+		assert.Equal(t, gt.GenericS32Type, GenericTypeFromFuncArg(proto.Params[0].Type))
+		assert.Equal(t, gt.GenericStringType, GenericTypeFromFuncArg(proto.Params[1].Type))
+
+		proto, err = findFuncProtoWithSpec(spec, "security_file_open")
+		require.NoError(t, err)
+		assert.Equal(t, gt.GenericFileType, GenericTypeFromFuncArg(proto.Params[0].Type))
+		assert.Equal(t, gt.GenericS32Type, GenericTypeFromFuncArg(proto.Return))
+
+		_, err = findFuncProtoWithSpec(spec, "fake_function")
+		require.ErrorContains(t, err, "failed to find BTF type for function \"fake_function\"")
+
+		// sched_process_exec(struct task_struct *p, pid_t old_pid, struct linux_binprm *bprm)
+		proto, err = findTracepointProtoWithSpec(spec, "sched_process_exec")
+		require.NoError(t, err)
+		require.Len(t, proto.Params, 4)
+		assert.Equal(t, gt.GenericS32Type, GenericTypeFromFuncArg(proto.Params[2].Type))
+		assert.Equal(t, gt.GenericLinuxBinprmType, GenericTypeFromFuncArg(proto.Params[3].Type))
+
+		_, err = findTracepointProtoWithSpec(spec, "fake_event")
+		require.ErrorContains(t, err, "failed to find BTF type for tracepoint \"fake_event\"")
+	}
+}
+
+func TestFindFuncProto(t *testing.T) {
+	btfFiles, err := listBTFFiles()
+	fatalOnError(t, err)
+
+	for _, btfFile := range btfFiles {
+		t.Run(btfFile, testFindFuncProto(btfFile))
+	}
+}
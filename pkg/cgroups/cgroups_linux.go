@@ -269,7 +269,7 @@ func setDeploymentMode(cgroupPath string) error {
 		return nil
 	}
 
-	if option.Config.EnableK8s {
+	if option.PodMetadataEnabled() {
 		deploymentMode = DEPLOY_K8S
 		return nil
 	}
@@ -116,6 +116,15 @@ func GenericTracepointObjs(raw bool) string {
 	return "bpf_generic_tracepoint.o"
 }
 
+func GenericTpBtfObjs() string {
+	if EnableV612Progs() {
+		return "bpf_generic_tp_btf_v612.o"
+	} else if EnableV61Progs() {
+		return "bpf_generic_tp_btf_v61.o"
+	}
+	return "bpf_generic_tp_btf_v511.o"
+}
+
 func GenericLsmObjs() (string, string) {
 	if EnableV612Progs() {
 		return "bpf_generic_lsm_core_v612.o", "bpf_generic_lsm_output_v612.o"
@@ -127,6 +136,15 @@ func GenericLsmObjs() (string, string) {
 	return "bpf_generic_lsm_core.o", "bpf_generic_lsm_output.o"
 }
 
+func GenericFentryObjs() (string, string) {
+	if EnableV612Progs() {
+		return "bpf_generic_fentry_v612.o", "bpf_generic_fexit_v612.o"
+	} else if EnableV61Progs() {
+		return "bpf_generic_fentry_v61.o", "bpf_generic_fexit_v61.o"
+	}
+	return "bpf_generic_fentry_v511.o", "bpf_generic_fexit_v511.o"
+}
+
 func EnableRhel7Progs() bool {
 	kernelVer, _, _ := kernels.GetKernelVersion(option.Config.KernelVersion, option.Config.ProcFS)
 	return (int64(kernelVer) < kernels.KernelStringToNumeric("3.11.0"))
@@ -33,10 +33,18 @@ func GenericTracepointObjs(_ bool) string {
 	return ""
 }
 
+func GenericTpBtfObjs() string {
+	return ""
+}
+
 func GenericLsmObjs() (string, string) {
 	return "", ""
 }
 
+func GenericFentryObjs() (string, string) {
+	return "", ""
+}
+
 func EnableV61Progs() bool {
 	return false
 }
@@ -51,8 +51,9 @@ const (
 	DefaultPidFile = DefaultRunDir + "tetragon.pid"
 
 	// defaults for the event cache
-	DefaultEventCacheNumRetries = 15
-	DefaultEventCacheRetryDelay = 2
+	DefaultEventCacheNumRetries    = 15
+	DefaultEventCacheRetryDelay    = 2
+	DefaultEventCacheMaxRetryDelay = 30
 
 	// defaults for the process cache
 	DefaultProcessCacheGCInterval = 30 * time.Second
@@ -16,6 +16,8 @@ import (
 	"github.com/cilium/tetragon/pkg/logger"
 
 	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
 )
 
 const rfc3339Nano = "2006-01-02T15:04:05.000000000Z07:00"
@@ -31,6 +33,31 @@ type EventEncoder interface {
 	Encode(v interface{}) error
 }
 
+// TransientError is an error of an encoder that might not happen again if the
+// same event is encoded again later, for example a failed write or delivery.
+type TransientError struct {
+	Err error
+}
+
+// NewTransientError marks err as transient.
+func NewTransientError(err error) error {
+	return &TransientError{Err: err}
+}
+
+func (e *TransientError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *TransientError) Unwrap() error {
+	return e.Err
+}
+
+// IsTransient returns true if err is, or wraps, a TransientError.
+func IsTransient(err error) bool {
+	var te *TransientError
+	return errors.As(err, &te)
+}
+
 // ColorMode defines color mode flags for compact output.
 type ColorMode string
 
@@ -164,6 +191,37 @@ func (p *ProtojsonEncoder) Encode(v interface{}) error {
 	return nil
 }
 
+// ProtobufEncoder writes events as length-delimited binary protobuf: each
+// GetEventsResponse is prefixed with its size as a varint, like
+// protodelim.MarshalTo.
+type ProtobufEncoder struct {
+	w io.Writer
+}
+
+func NewProtobufEncoder(w io.Writer) *ProtobufEncoder {
+	return &ProtobufEncoder{
+		w,
+	}
+}
+
+func (p *ProtobufEncoder) Encode(v interface{}) error {
+	event, ok := v.(*tetragon.GetEventsResponse)
+	if !ok {
+		return ErrInvalidEvent
+	}
+	size := proto.Size(event)
+	buf := make([]byte, 0, protowire.SizeVarint(uint64(size))+size)
+	buf = protowire.AppendVarint(buf, uint64(size))
+	buf, err := proto.MarshalOptions{UseCachedSize: true}.MarshalAppend(buf, event)
+	if err != nil {
+		return err
+	}
+	// Write the event in a single call, so that rotating writers never split
+	// it across files.
+	_, err = p.w.Write(buf)
+	return err
+}
+
 const (
 	capsPad = 120
 )
@@ -558,6 +616,20 @@ func (p *CompactEncoder) EventToString(response *tetragon.GetEventsResponse) (st
 		processInfo, caps := p.Colorer.ProcessInfo(response.NodeName, lsm.Process)
 		event := p.Colorer.Blue.Sprintf("🔒 %-7s", "LSM")
 		return CapTrailorPrinter(fmt.Sprintf("%s %s %s", event, processInfo, lsm.FunctionName), caps), nil
+	case *tetragon.GetEventsResponse_ProcessUpdate:
+		update := response.GetProcessUpdate()
+		if update.Process == nil {
+			return "", ErrMissingProcessInfo
+		}
+		event := p.Colorer.Blue.Sprintf("🔄 %-7s", "update")
+		processInfo, caps := p.Colorer.ProcessInfo(response.NodeName, update.Process)
+		args := p.Colorer.Cyan.Sprint(update.Process.Arguments)
+		return CapTrailorPrinter(fmt.Sprintf("%s %s %s", event, processInfo, args), caps), nil
+	case *tetragon.GetEventsResponse_LostEvents:
+		lost := response.GetLostEvents()
+		event := p.Colorer.Red.Sprintf("⚠️ %-7s", "lost")
+		return fmt.Sprintf("%s ring_buffer=%d event_cache=%d rate_limit=%d slow_client=%d", event,
+			lost.RingBuffer, lost.EventCache, lost.RateLimit, lost.SlowClient), nil
 	}
 
 	return "", fmt.Errorf("%w: %s", ErrUnknownEventType, response.EventType())
@@ -11,6 +11,7 @@ import (
 
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/cilium/tetragon/api/v1/tetragon"
@@ -123,6 +124,51 @@ func TestCompactEncoder_ExitEventToString(t *testing.T) {
 	assert.Equal(t, "💥 exit    kube-system/tetragon /usr/bin/curl cilium.io SIGKILL", result)
 }
 
+func TestCompactEncoder_ProcessUpdateToString(t *testing.T) {
+	p := NewCompactEncoder(os.Stdout, Never, false, false, false)
+
+	// should fail without process field
+	_, err := p.EventToString(&tetragon.GetEventsResponse{
+		Event: &tetragon.GetEventsResponse_ProcessUpdate{
+			ProcessUpdate: &tetragon.ProcessUpdate{},
+		},
+	})
+	require.ErrorIs(t, err, ErrMissingProcessInfo)
+
+	result, err := p.EventToString(&tetragon.GetEventsResponse{
+		Event: &tetragon.GetEventsResponse_ProcessUpdate{
+			ProcessUpdate: &tetragon.ProcessUpdate{
+				Process: &tetragon.Process{
+					Binary:    "/usr/bin/curl",
+					Arguments: "cilium.io",
+					Pod: &tetragon.Pod{
+						Namespace: "kube-system",
+						Name:      "tetragon",
+					},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "🔄 update  kube-system/tetragon /usr/bin/curl cilium.io", result)
+}
+
+func TestCompactEncoder_LostEventsToString(t *testing.T) {
+	p := NewCompactEncoder(os.Stdout, Never, false, false, false)
+
+	result, err := p.EventToString(&tetragon.GetEventsResponse{
+		Event: &tetragon.GetEventsResponse_LostEvents{
+			LostEvents: &tetragon.LostEvents{
+				RingBuffer: 1,
+				EventCache: 2,
+				SlowClient: 3,
+			},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "⚠️ lost    ring_buffer=1 event_cache=2 rate_limit=0 slow_client=3", result)
+}
+
 func TestCompactEncoder_KprobeEventToString(t *testing.T) {
 	p := NewCompactEncoder(os.Stdout, Never, false, false, false)
 
@@ -514,6 +560,12 @@ func FuzzProtojsonCompatibility(f *testing.F) {
 		ev := &tetragon.GetEventsResponse{}
 		msg, err := pr.Gen(ev)
 		require.NoError(t, err)
+		// protorand does not set the kind of google.protobuf.Value, which
+		// then can't be encoded
+		computed := msg.(*tetragon.GetEventsResponse).ComputedFields
+		for k := range computed {
+			computed[k] = structpb.NewStringValue(k)
+		}
 
 		var buf1 bytes.Buffer
 		protojsonEncoder := NewProtojsonEncoder(&buf1)
@@ -573,6 +625,12 @@ func FuzzCompactEncoder(f *testing.F) {
 		ev := &tetragon.GetEventsResponse{}
 		msg, err := pr.Gen(ev)
 		require.NoError(t, err)
+		// protorand does not set the kind of google.protobuf.Value, which
+		// then can't be encoded
+		computed := msg.(*tetragon.GetEventsResponse).ComputedFields
+		for k := range computed {
+			computed[k] = structpb.NewStringValue(k)
+		}
 
 		if helpers.ResponseGetProcess(msg.(*tetragon.GetEventsResponse)) == nil {
 			t.Skipf("Empty process")
@@ -584,3 +642,32 @@ func FuzzCompactEncoder(f *testing.F) {
 		require.NoError(t, err)
 	})
 }
+
+func TestSerializer(t *testing.T) {
+	ev := &tetragon.GetEventsResponse{
+		Event: &tetragon.GetEventsResponse_ProcessExec{ProcessExec: &tetragon.ProcessExec{
+			Process: &tetragon.Process{ExecId: "exec-1", Binary: "/bin/sh"},
+		}},
+		NodeName: "node",
+	}
+
+	s, err := NewSerializer(FormatJSON)
+	require.NoError(t, err)
+	data, err := s.Serialize(ev)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"exec_id":"exec-1"`)
+	got := &tetragon.GetEventsResponse{}
+	require.NoError(t, protojson.Unmarshal(data, got))
+	assert.True(t, proto.Equal(ev, got))
+
+	s, err = NewSerializer(FormatProtobuf)
+	require.NoError(t, err)
+	data, err = s.Serialize(ev)
+	require.NoError(t, err)
+	got = &tetragon.GetEventsResponse{}
+	require.NoError(t, proto.Unmarshal(data, got))
+	assert.True(t, proto.Equal(ev, got))
+
+	_, err = NewSerializer("avro")
+	require.Error(t, err)
+}
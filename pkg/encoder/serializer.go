@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package encoder
+
+import (
+	"fmt"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// FormatJSON serializes events as protojson.
+	FormatJSON = "json"
+	// FormatProtobuf serializes events as binary protobuf GetEventsResponse
+	// messages.
+	FormatProtobuf = "protobuf"
+)
+
+// Serializer serializes events into self-contained payloads, for example to
+// publish them as messages.
+type Serializer interface {
+	Serialize(event *tetragon.GetEventsResponse) ([]byte, error)
+}
+
+// NewSerializer returns the serializer for the given format.
+func NewSerializer(format string) (Serializer, error) {
+	switch format {
+	case FormatJSON:
+		return ProtojsonSerializer{}, nil
+	case FormatProtobuf:
+		return ProtobufSerializer{}, nil
+	}
+	return nil, fmt.Errorf("unknown serialization format %q, valid values are %q and %q", format, FormatJSON, FormatProtobuf)
+}
+
+// ProtojsonSerializer serializes events as protojson, using the same
+// snake_case field names as ProtojsonEncoder.
+type ProtojsonSerializer struct{}
+
+// Serialize implements Serializer.Serialize.
+func (ProtojsonSerializer) Serialize(event *tetragon.GetEventsResponse) ([]byte, error) {
+	return protojson.MarshalOptions{UseProtoNames: true}.Marshal(event)
+}
+
+// ProtobufSerializer serializes events as binary protobuf.
+type ProtobufSerializer struct{}
+
+// Serialize implements Serializer.Serialize.
+func (ProtobufSerializer) Serialize(event *tetragon.GetEventsResponse) ([]byte, error) {
+	return proto.Marshal(event)
+}
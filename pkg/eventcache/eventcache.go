@@ -5,15 +5,18 @@ package eventcache
 
 import (
 	"errors"
+	"sync/atomic"
 	"time"
 
 	"github.com/cilium/tetragon/api/v1/tetragon"
 	"github.com/cilium/tetragon/pkg/ktime"
 	"github.com/cilium/tetragon/pkg/logger"
+	"github.com/cilium/tetragon/pkg/lostevents"
 	"github.com/cilium/tetragon/pkg/option"
 	"github.com/cilium/tetragon/pkg/process"
 	"github.com/cilium/tetragon/pkg/reader/notify"
 	"github.com/cilium/tetragon/pkg/server"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 const (
@@ -27,6 +30,10 @@ var (
 	cache *Cache
 )
 
+// enrichmentTimeout is how long the pod information of a process whose events
+// were emitted without it is looked up, to emit a process_update event.
+const enrichmentTimeout = 10 * time.Minute
+
 type CacheObj struct {
 	internal  *process.ProcessInternal
 	event     notify.Event
@@ -34,6 +41,12 @@ type CacheObj struct {
 	startTime uint64
 	color     int
 	msg       notify.Message
+	// time at which the event was added to the cache
+	inserted time.Time
+	// time of the next retry, in adaptive mode
+	nextRetry time.Time
+	// pod information was missing in a retry
+	podMissing bool
 }
 
 type Cache struct {
@@ -42,6 +55,20 @@ type Cache struct {
 	cache    []CacheObj
 	notifier server.Notifier
 	dur      time.Duration
+	// podLag is an estimate of the delay, in nanoseconds, before the pod
+	// information of events is available. In adaptive mode, the delay
+	// between the retries of events waiting for pod information is based
+	// on it.
+	podLag atomic.Int64
+	// enrichments are the deadlines of the processes whose events were
+	// emitted without pod information, by exec ID, to emit a
+	// process_update event when the information is available.
+	enrichments map[string]enrichment
+}
+
+type enrichment struct {
+	internal *process.ProcessInternal
+	deadline time.Time
 }
 
 var (
@@ -119,7 +146,7 @@ func HandleGenericInternal(ev notify.Event, pid uint32, tid *uint32, timestamp u
 // but that event is not fully populated yet.
 func HandleGenericEvent(internal *process.ProcessInternal, ev notify.Event, tid *uint32) error {
 	p := internal.UnsafeGetProcess()
-	if option.Config.EnableK8s && p.Pod == nil {
+	if option.PodMetadataEnabled() && p.Pod == nil {
 		CacheRetries(PodInfo).Inc()
 		return ErrFailedToGetPodInfo
 	}
@@ -137,49 +164,160 @@ func HandleGenericEvent(internal *process.ProcessInternal, ev notify.Event, tid
 	return nil
 }
 
-func (ec *Cache) handleEvents() {
+// observePodLag updates the estimate of the delay before pod information is
+// available with a new sample.
+func (ec *Cache) observePodLag(sample time.Duration) {
+	lag := time.Duration(ec.podLag.Load())
+	// exponentially weighted moving average, as for TCP round-trip times
+	ec.podLag.Store(int64(lag + (sample-lag)/8))
+}
+
+// retryDelay returns the delay before the next retry of an event in adaptive
+// mode. Events waiting for pod information are retried so that their retries
+// last about the observed delay of the pod information.
+func (ec *Cache) retryDelay(err error) time.Duration {
+	if !errors.Is(err, ErrFailedToGetPodInfo) {
+		return ec.dur
+	}
+	delay := time.Duration(ec.podLag.Load()) / time.Duration(max(option.Config.EventCacheNumRetries, 1))
+	return min(max(delay, ec.dur), time.Duration(option.Config.EventCacheMaxRetryDelay)*time.Second)
+}
+
+func (ec *Cache) retry(event *CacheObj) error {
+	var err error
+
+	// If the process wasn't found before the Add(), likely because
+	// the execve event was processed after this event, lets look it up
+	// now because it should be available. Otherwise we have a valid
+	// process and lets copy it across.
+	if event.internal == nil {
+		event.internal, err = event.msg.RetryInternal(event.event, event.startTime)
+	}
+	if err == nil {
+		err = event.msg.Retry(event.internal, event.event)
+	}
+	if errors.Is(err, ErrFailedToGetPodInfo) {
+		event.podMissing = true
+	}
+	return err
+}
+
+// complete emits an event that was completed by its retries, or that exhausted
+// them, in which case err is the error of its last retry.
+func (ec *Cache) complete(event *CacheObj, err error, now time.Time) {
+	outcome := OutcomeComplete
+	if err != nil {
+		eventType := notify.EventType(event.event).String()
+		if errors.Is(err, ErrFailedToGetParentInfo) {
+			failedFetches.WithLabelValues(eventType, ParentInfo.String()).Inc()
+		} else if errors.Is(err, ErrFailedToGetProcessInfo) {
+			failedFetches.WithLabelValues(eventType, ProcessInfo.String()).Inc()
+		} else if errors.Is(err, ErrFailedToGetAncestorsInfo) {
+			failedFetches.WithLabelValues(eventType, AncestorsInfo.String()).Inc()
+		} else if errors.Is(err, ErrFailedToGetPodInfo) {
+			failedFetches.WithLabelValues(eventType, PodInfo.String()).Inc()
+		}
+		outcome = OutcomeIncomplete
+		if errors.Is(err, ErrFailedToGetPodInfo) {
+			outcome = OutcomeWithoutPod
+		}
+	}
+
+	if event.podMissing {
+		switch {
+		case err == nil:
+			ec.observePodLag(now.Sub(event.inserted))
+		case errors.Is(err, ErrFailedToGetPodInfo):
+			// the delay is longer than the wait, so back off
+			ec.observePodLag(2 * now.Sub(event.inserted))
+		}
+	}
+
+	if event.msg.Notify() {
+		processedEvent := &tetragon.GetEventsResponse{
+			Event: event.event.Encapsulate(),
+			Time:  ktime.ToProto(event.timestamp),
+		}
+
+		ec.notifier.NotifyListener(event.msg, processedEvent)
+		if outcome == OutcomeWithoutPod && option.Config.EventCacheEnrichmentUpdates {
+			ec.addEnrichment(event.internal, now)
+		}
+	} else if err != nil {
+		outcome = OutcomeDropped
+		lostevents.EventCache.Add(1)
+	}
+	CacheOutcomes(outcome, notify.EventType(event.event)).Inc()
+}
+
+func (ec *Cache) handleEvents(now time.Time) {
 	tmp := ec.cache[:0]
 	for _, event := range ec.cache {
-		var err error
-
-		// If the process wasn't found before the Add(), likely because
-		// the execve event was processed after this event, lets look it up
-		// now because it should be available. Otherwise we have a valid
-		// process and lets copy it across.
-		if event.internal == nil {
-			event.internal, err = event.msg.RetryInternal(event.event, event.startTime)
-		}
-		if err == nil {
-			err = event.msg.Retry(event.internal, event.event)
+		if now.Before(event.nextRetry) {
+			tmp = append(tmp, event)
+			continue
 		}
+		err := ec.retry(&event)
 		if err != nil {
 			event.color++
 			if event.color < option.Config.EventCacheNumRetries {
+				if option.Config.EventCacheAdaptive {
+					event.nextRetry = now.Add(ec.retryDelay(err))
+				}
 				tmp = append(tmp, event)
 				continue
 			}
-			eventType := notify.EventType(event.event).String()
-			if errors.Is(err, ErrFailedToGetParentInfo) {
-				failedFetches.WithLabelValues(eventType, ParentInfo.String()).Inc()
-			} else if errors.Is(err, ErrFailedToGetProcessInfo) {
-				failedFetches.WithLabelValues(eventType, ProcessInfo.String()).Inc()
-			} else if errors.Is(err, ErrFailedToGetAncestorsInfo) {
-				failedFetches.WithLabelValues(eventType, AncestorsInfo.String()).Inc()
-			} else if errors.Is(err, ErrFailedToGetPodInfo) {
-				failedFetches.WithLabelValues(eventType, PodInfo.String()).Inc()
-			}
 		}
+		ec.complete(&event, err, now)
+	}
+	ec.cache = tmp
+	ec.handleEnrichments(now)
+}
+
+// overflow emits the oldest event of the cache, after a last retry, to make
+// room for a new one.
+func (ec *Cache) overflow(now time.Time) {
+	cacheOverflows.Inc()
+	event := ec.cache[0]
+	ec.cache = ec.cache[1:]
+	ec.complete(&event, ec.retry(&event), now)
+}
 
-		if event.msg.Notify() {
-			processedEvent := &tetragon.GetEventsResponse{
-				Event: event.event.Encapsulate(),
-				Time:  ktime.ToProto(event.timestamp),
+func (ec *Cache) addEnrichment(internal *process.ProcessInternal, now time.Time) {
+	if internal == nil {
+		return
+	}
+	id := internal.UnsafeGetProcess().ExecId
+	if _, ok := ec.enrichments[id]; ok {
+		return
+	}
+	if option.Config.EventCacheSize > 0 && len(ec.enrichments) >= option.Config.EventCacheSize {
+		return
+	}
+	ec.enrichments[id] = enrichment{internal: internal, deadline: now.Add(enrichmentTimeout)}
+}
+
+// handleEnrichments emits a process_update event for the processes whose pod
+// information became available.
+func (ec *Cache) handleEnrichments(now time.Time) {
+	for id, e := range ec.enrichments {
+		if !e.internal.RefreshPodInfo() {
+			if now.After(e.deadline) {
+				delete(ec.enrichments, id)
 			}
+			continue
+		}
+		delete(ec.enrichments, id)
 
-			ec.notifier.NotifyListener(event.msg, processedEvent)
+		update := &tetragon.ProcessUpdate{Process: e.internal.GetProcessCopy()}
+		if parent, err := process.Get(update.Process.ParentExecId); err == nil {
+			update.Parent = parent.GetProcessCopy()
 		}
+		ec.notifier.NotifyListener(nil, &tetragon.GetEventsResponse{
+			Event: update.Encapsulate(),
+			Time:  timestamppb.New(now),
+		})
 	}
-	ec.cache = tmp
 }
 
 func (ec *Cache) loop() {
@@ -193,11 +331,14 @@ func (ec *Cache) loop() {
 			 * pending pod info. If an event hasn't completed its podInfo after two iterations
 			 * send the event anyways.
 			 */
-			ec.handleEvents()
+			ec.handleEvents(time.Now())
 
 		case event := <-ec.objsChan:
 			cacheInserts.Inc()
 			ec.cache = append(ec.cache, event)
+			if option.Config.EventCacheSize > 0 && len(ec.cache) > option.Config.EventCacheSize {
+				ec.overflow(time.Now())
+			}
 
 		case <-ec.done:
 			return
@@ -224,7 +365,7 @@ func (ec *Cache) Needed(proc *tetragon.Process) bool {
 	if proc == nil {
 		return true
 	}
-	if option.Config.EnableK8s {
+	if option.PodMetadataEnabled() {
 		if proc.Docker != "" && proc.Pod == nil {
 			return true
 		}
@@ -252,7 +393,7 @@ func (ec *Cache) Add(internal *process.ProcessInternal,
 	t uint64,
 	s uint64,
 	msg notify.Message) {
-	ec.objsChan <- CacheObj{internal: internal, event: e, timestamp: t, startTime: s, msg: msg}
+	ec.objsChan <- CacheObj{internal: internal, event: e, timestamp: t, startTime: s, msg: msg, inserted: time.Now()}
 }
 
 func NewWithTimer(n server.Notifier, dur time.Duration) *Cache {
@@ -260,14 +401,16 @@ func NewWithTimer(n server.Notifier, dur time.Duration) *Cache {
 		cache.done <- true
 	}
 
-	logger.GetLogger().Info("Creating new EventCache", "retries", option.Config.EventCacheNumRetries, "delay", dur)
+	logger.GetLogger().Info("Creating new EventCache", "retries", option.Config.EventCacheNumRetries, "delay", dur,
+		"adaptive", option.Config.EventCacheAdaptive, "size", option.Config.EventCacheSize)
 
 	cache = &Cache{
-		objsChan: make(chan CacheObj),
-		done:     make(chan bool),
-		cache:    make([]CacheObj, 0),
-		notifier: n,
-		dur:      dur,
+		objsChan:    make(chan CacheObj),
+		done:        make(chan bool),
+		cache:       make([]CacheObj, 0),
+		notifier:    n,
+		dur:         dur,
+		enrichments: make(map[string]enrichment),
 	}
 	go cache.loop()
 	return cache
@@ -0,0 +1,154 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package eventcache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/lostevents"
+	"github.com/cilium/tetragon/pkg/option"
+	"github.com/cilium/tetragon/pkg/process"
+	"github.com/cilium/tetragon/pkg/reader/notify"
+	"github.com/cilium/tetragon/pkg/server"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeMsg is a message whose first retries fail with err. It is only sent to
+// listeners if drop is not set.
+type fakeMsg struct {
+	failures int
+	err      error
+	drop     bool
+}
+
+func (m *fakeMsg) HandleMessage() *tetragon.GetEventsResponse {
+	return nil
+}
+
+func (m *fakeMsg) RetryInternal(notify.Event, uint64) (*process.ProcessInternal, error) {
+	return nil, nil
+}
+
+func (m *fakeMsg) Retry(*process.ProcessInternal, notify.Event) error {
+	if m.failures > 0 {
+		m.failures--
+		return m.err
+	}
+	return nil
+}
+
+func (m *fakeMsg) Notify() bool {
+	return !m.drop
+}
+
+func (m *fakeMsg) Cast(interface{}) notify.Message {
+	return m
+}
+
+type fakeNotifier struct {
+	events []*tetragon.GetEventsResponse
+}
+
+func (n *fakeNotifier) AddListener(server.Listener)    {}
+func (n *fakeNotifier) RemoveListener(server.Listener) {}
+func (n *fakeNotifier) NotifyListener(_ interface{}, processed *tetragon.GetEventsResponse) {
+	n.events = append(n.events, processed)
+}
+
+func newTestCache(t *testing.T, adaptive bool) (*Cache, *fakeNotifier) {
+	oldConfig := option.Config
+	t.Cleanup(func() { option.Config = oldConfig })
+	option.Config.EventCacheNumRetries = 3
+	option.Config.EventCacheMaxRetryDelay = 30
+	option.Config.EventCacheAdaptive = adaptive
+
+	n := &fakeNotifier{}
+	return &Cache{
+		notifier:    n,
+		dur:         time.Second,
+		enrichments: make(map[string]enrichment),
+	}, n
+}
+
+func (ec *Cache) addTest(msg notify.Message, now time.Time) {
+	ec.cache = append(ec.cache, CacheObj{event: &tetragon.ProcessKprobe{}, msg: msg, inserted: now})
+}
+
+func outcomes(outcome CacheOutcome) float64 {
+	return testutil.ToFloat64(CacheOutcomes(outcome, tetragon.EventType_PROCESS_KPROBE))
+}
+
+func TestEventCacheAdaptive(t *testing.T) {
+	ec, n := newTestCache(t, true)
+	complete, withoutPod := outcomes(OutcomeComplete), outcomes(OutcomeWithoutPod)
+	now := time.Now()
+
+	// pod info after the first retry
+	ec.addTest(&fakeMsg{failures: 1, err: ErrFailedToGetPodInfo}, now)
+	// no pod info
+	ec.addTest(&fakeMsg{failures: 10, err: ErrFailedToGetPodInfo}, now)
+	for i := range 3 {
+		ec.handleEvents(now.Add(time.Duration(i) * time.Second))
+	}
+	assert.Equal(t, 0, ec.len())
+	assert.Len(t, n.events, 2)
+	assert.InDelta(t, complete+1, outcomes(OutcomeComplete), 0)
+	assert.InDelta(t, withoutPod+1, outcomes(OutcomeWithoutPod), 0)
+	// samples of 1s, and of twice the 2s wait of the event without pod info
+	assert.Equal(t, 1*time.Second/8+(4*time.Second-time.Second/8)/8, time.Duration(ec.podLag.Load()))
+
+	// retries are spread over the observed delay, within the limits
+	ec.podLag.Store(int64(15 * time.Second))
+	assert.Equal(t, 5*time.Second, ec.retryDelay(ErrFailedToGetPodInfo))
+	assert.Equal(t, time.Second, ec.retryDelay(ErrFailedToGetParentInfo))
+	ec.podLag.Store(int64(time.Second))
+	assert.Equal(t, time.Second, ec.retryDelay(ErrFailedToGetPodInfo))
+	ec.podLag.Store(int64(time.Hour))
+	assert.Equal(t, 30*time.Second, ec.retryDelay(ErrFailedToGetPodInfo))
+
+	// events are only retried after their delay
+	ec.podLag.Store(int64(15 * time.Second))
+	ec.addTest(&fakeMsg{failures: 2, err: ErrFailedToGetPodInfo}, now)
+	ec.handleEvents(now)
+	ec.handleEvents(now.Add(4 * time.Second))
+	require.Equal(t, 1, ec.len())
+	assert.Equal(t, 1, ec.cache[0].color)
+	ec.handleEvents(now.Add(5 * time.Second))
+	ec.handleEvents(now.Add(10 * time.Second))
+	assert.Equal(t, 0, ec.len())
+	assert.InDelta(t, complete+2, outcomes(OutcomeComplete), 0)
+}
+
+func TestEventCacheOverflow(t *testing.T) {
+	ec, n := newTestCache(t, false)
+	incomplete := outcomes(OutcomeIncomplete)
+	now := time.Now()
+
+	ec.addTest(&fakeMsg{failures: 10, err: ErrFailedToGetParentInfo}, now)
+	ec.addTest(&fakeMsg{failures: 10, err: ErrFailedToGetParentInfo}, now)
+	ec.overflow(now)
+	assert.Equal(t, 1, ec.len())
+	assert.Len(t, n.events, 1)
+	assert.InDelta(t, incomplete+1, outcomes(OutcomeIncomplete), 0)
+}
+
+func TestEventCacheLostEvents(t *testing.T) {
+	ec, n := newTestCache(t, false)
+	lost := lostevents.EventCache.Load()
+	now := time.Now()
+
+	// only events that are not sent once their retries are exhausted are lost
+	ec.addTest(&fakeMsg{failures: 10, err: ErrFailedToGetProcessInfo}, now)
+	ec.addTest(&fakeMsg{failures: 10, err: ErrFailedToGetProcessInfo, drop: true}, now)
+	for i := range 4 {
+		ec.handleEvents(now.Add(time.Duration(i) * time.Minute))
+	}
+	assert.Equal(t, 0, ec.len())
+	assert.Len(t, n.events, 1)
+	assert.Equal(t, lost+1, lostevents.EventCache.Load())
+}
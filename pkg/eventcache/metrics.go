@@ -6,6 +6,7 @@ package eventcache
 import (
 	"maps"
 	"slices"
+	"time"
 
 	"github.com/cilium/tetragon/api/v1/tetragon"
 	"github.com/cilium/tetragon/pkg/metrics"
@@ -51,6 +52,30 @@ func (e CacheError) String() string {
 	return cacheErrorLabelValues[e]
 }
 
+type CacheOutcome int
+
+const (
+	// The event was completed by its retries
+	OutcomeComplete CacheOutcome = iota
+	// The event was emitted without pod information
+	OutcomeWithoutPod
+	// The event was emitted without process, parent or ancestors information
+	OutcomeIncomplete
+	// The event was not completed, and is not emitted
+	OutcomeDropped
+)
+
+var cacheOutcomeLabelValues = map[CacheOutcome]string{
+	OutcomeComplete:   "complete",
+	OutcomeWithoutPod: "without_pod",
+	OutcomeIncomplete: "incomplete",
+	OutcomeDropped:    "dropped",
+}
+
+func (o CacheOutcome) String() string {
+	return cacheOutcomeLabelValues[o]
+}
+
 var (
 	entryTypeLabel = metrics.ConstrainedLabel{
 		Name:   "entry_type",
@@ -60,6 +85,10 @@ var (
 		Name:   "error",
 		Values: slices.Collect(maps.Values(cacheErrorLabelValues)),
 	}
+	outcomeLabel = metrics.ConstrainedLabel{
+		Name:   "outcome",
+		Values: slices.Collect(maps.Values(cacheOutcomeLabelValues)),
+	}
 )
 
 var (
@@ -90,17 +119,36 @@ var (
 		"Number of failed fetches from the event cache. These won't be retried as they already exceeded the limit.",
 		nil, []metrics.ConstrainedLabel{metrics.EventTypeLabel, entryTypeLabel}, nil,
 	), nil)
+	cacheOutcomes = metrics.MustNewCounter(metrics.NewOpts(
+		consts.MetricsNamespace, subsystem, "outcomes_total",
+		"Number of events that left the event cache, by outcome.",
+		nil, []metrics.ConstrainedLabel{outcomeLabel, metrics.EventTypeLabel}, nil,
+	), nil)
+	cacheOverflows = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: consts.MetricsNamespace,
+		Subsystem: subsystem,
+		Name:      "overflows_total",
+		Help:      "Number of events emitted before exhausting their retries because the event cache was full.",
+	})
+	cachePodLag = metrics.MustNewCustomGauge(metrics.NewOpts(
+		consts.MetricsNamespace, subsystem, "pod_info_delay_seconds",
+		"The estimated delay before the pod information of events is available, observed by the event cache.",
+		nil, nil, nil,
+	))
 )
 
 func newCacheCollector() prometheus.Collector {
 	return metrics.NewCustomCollector(
-		metrics.CustomMetrics{cacheSize},
+		metrics.CustomMetrics{cacheSize, cachePodLag},
 		func(ch chan<- prometheus.Metric) {
 			size := 0
+			var podLag time.Duration
 			if cache != nil {
 				size = cache.len()
+				podLag = time.Duration(cache.podLag.Load())
 			}
 			ch <- cacheSize.MustMetric(float64(size))
+			ch <- cachePodLag.MustMetric(podLag.Seconds())
 		},
 		nil,
 	)
@@ -113,6 +161,8 @@ func RegisterMetrics(group metrics.Group) {
 		cacheInserts,
 		cacheRetries,
 		failedFetches,
+		cacheOutcomes,
+		cacheOverflows,
 	)
 }
 
@@ -125,3 +175,8 @@ func CacheErrors(er CacheError, eventType tetragon.EventType) prometheus.Counter
 func CacheRetries(entryType CacheEntryType) prometheus.Counter {
 	return cacheRetries.WithLabelValues(entryType.String())
 }
+
+// Get a new handle on an eventCacheOutcomesTotal metric for an outcome
+func CacheOutcomes(outcome CacheOutcome, eventType tetragon.EventType) prometheus.Counter {
+	return cacheOutcomes.WithLabelValues(outcome.String(), eventType.String())
+}
@@ -8,19 +8,42 @@ import (
 	"fmt"
 	"io"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/encoder"
 	"github.com/cilium/tetragon/pkg/logger"
 	"github.com/cilium/tetragon/pkg/logger/logfields"
 	"github.com/cilium/tetragon/pkg/ratelimit"
 	"github.com/cilium/tetragon/pkg/server"
+	"github.com/cilium/tetragon/pkg/spool"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+const (
+	// checkpointInterval is the interval at which the cursor of the last
+	// exported event is saved in the spool checkpoint.
+	checkpointInterval = time.Second
+	// encodeRetryInterval is the interval at which encoding an event is
+	// retried after a transient error, when reading events from the spool.
+	encodeRetryInterval = time.Second
 )
 
 type ExportEncoder interface {
 	Encode(v interface{}) error
 }
 
+// syncDeliveryEncoder is implemented by encoders that deliver events
+// asynchronously, and can wait for the delivery of each event in Encode
+// instead. Exporters that read events from the spool use it, so that the
+// checkpoint only advances past delivered events, and undelivered events are
+// retried.
+type syncDeliveryEncoder interface {
+	SetSyncDelivery()
+}
+
 type Exporter struct {
 	ctx         context.Context
 	request     *tetragon.GetEventsRequest
@@ -28,6 +51,19 @@ type Exporter struct {
 	encoder     ExportEncoder
 	closer      io.Closer
 	rateLimiter *ratelimit.RateLimiter
+	// pipeline is the name of the export pipeline, empty for the default
+	// exporter.
+	pipeline string
+	// rateLimitDropped counts the events dropped by the rate limiter since
+	// the last lost_events message.
+	rateLimitDropped atomic.Uint64
+
+	// spool and checkpoint are set if events are read from the spool
+	spool      *spool.Spool
+	checkpoint string
+	lastCursor atomic.Uint64
+	savedMu    sync.Mutex
+	saved      uint64
 }
 
 func NewExporter(
@@ -38,15 +74,64 @@ func NewExporter(
 	closer io.Closer,
 	rateLimiter *ratelimit.RateLimiter,
 ) *Exporter {
-	return &Exporter{ctx, request, server, encoder, closer, rateLimiter}
+	return NewPipelineExporter(ctx, "", request, server, encoder, closer, rateLimiter)
+}
+
+// NewPipelineExporter creates an exporter for the named export pipeline.
+// Per-pipeline metrics are only reported if name is not empty.
+func NewPipelineExporter(
+	ctx context.Context,
+	name string,
+	request *tetragon.GetEventsRequest,
+	server *server.Server,
+	encoder ExportEncoder,
+	closer io.Closer,
+	rateLimiter *ratelimit.RateLimiter,
+) *Exporter {
+	return &Exporter{
+		ctx:         ctx,
+		request:     request,
+		server:      server,
+		encoder:     encoder,
+		closer:      closer,
+		rateLimiter: rateLimiter,
+		pipeline:    name,
+	}
+}
+
+// WithSpool makes the exporter read events from the event spool, starting
+// after the cursor stored in the named checkpoint. The checkpoint is updated
+// as events are exported, so that the exporter resumes where it left off
+// after a restart. Events that fail to be encoded with a transient error (see
+// encoder.TransientError) are retried instead of being dropped.
+func (e *Exporter) WithSpool(sp *spool.Spool, checkpoint string) *Exporter {
+	e.spool = sp
+	e.checkpoint = checkpoint
+	if enc, ok := e.encoder.(syncDeliveryEncoder); ok {
+		enc.SetSyncDelivery()
+	}
+	return e
 }
 
 func (e *Exporter) Start() error {
+	closer := e.closer
+	if e.spool != nil {
+		cursor, _, err := e.spool.LoadCheckpoint(e.checkpoint)
+		if err != nil {
+			return fmt.Errorf("failed to load spool checkpoint: %w", err)
+		}
+		e.saved = cursor
+		e.lastCursor.Store(cursor)
+		e.request.SinceCursor = wrapperspb.UInt64(cursor)
+		closer = checkpointCloser{e}
+		go e.saveCheckpoints()
+	}
+
 	var readyWG sync.WaitGroup
 	var exporterStartErr error
 	readyWG.Add(1)
 	go func() {
-		if err := e.server.GetEventsWG(e.request, e, e.closer, &readyWG); err != nil {
+		if err := e.server.GetEventsWG(e.request, e, closer, &readyWG); err != nil {
 			exporterStartErr = fmt.Errorf("error starting JSON exporter: %w", err)
 		}
 	}()
@@ -55,20 +140,113 @@ func (e *Exporter) Start() error {
 }
 
 func (e *Exporter) Send(event *tetragon.GetEventsResponse) error {
-	if e.rateLimiter != nil && !e.rateLimiter.Allow() {
+	// lost_events messages are not rate limited, so that lost events are
+	// always reported.
+	if e.rateLimiter != nil && event.GetLostEvents() == nil && !e.rateLimiter.Allow() {
 		e.rateLimiter.Drop()
+		e.rateLimitDropped.Add(1)
 		rateLimitDropped.Inc()
+		if e.pipeline != "" {
+			pipelineRateLimitDropped.WithLabelValues(e.pipeline).Inc()
+		}
 		return nil
 	}
 
-	if err := e.encoder.Encode(event); err != nil {
-		logger.GetLogger().Warn("Failed to JSON encode", logfields.Error, err)
+	for {
+		err := e.encoder.Encode(event)
+		if err == nil {
+			break
+		}
+		if e.spool == nil || !encoder.IsTransient(err) {
+			logger.GetLogger().Warn("Failed to JSON encode, dropping event", "cursor", event.Cursor, logfields.Error, err)
+			encodeDropped.Inc()
+			if e.pipeline != "" {
+				pipelineEncodeDropped.WithLabelValues(e.pipeline).Inc()
+			}
+			e.advanceCursor(event)
+			return nil
+		}
+		logger.GetLogger().Warn("Failed to JSON encode, retrying", "cursor", event.Cursor, logfields.Error, err)
+		select {
+		case <-time.After(encodeRetryInterval):
+		case <-e.ctx.Done():
+			return e.ctx.Err()
+		}
 	}
+	e.advanceCursor(event)
 	eventsExportedTotal.Inc()
+	if e.pipeline != "" {
+		pipelineEventsExportedTotal.WithLabelValues(e.pipeline).Inc()
+	}
 	eventsExportTimestamp.Set(float64(event.GetTime().GetSeconds()))
 	return nil
 }
 
+// advanceCursor moves the cursor to be saved in the spool checkpoint past
+// the event, once it is exported or dropped.
+func (e *Exporter) advanceCursor(event *tetragon.GetEventsResponse) {
+	if e.spool != nil && event.Cursor != 0 {
+		e.lastCursor.Store(event.Cursor)
+	}
+}
+
+// RateLimitDropped implements server.RateLimitedStream.
+func (e *Exporter) RateLimitDropped() uint64 {
+	return e.rateLimitDropped.Swap(0)
+}
+
+// transientWriter marks the write errors of an export writer as transient, so
+// that events that fail to be written, for example because the disk is full,
+// are retried when they are read from the spool.
+type transientWriter struct {
+	io.Writer
+}
+
+func (w transientWriter) Write(p []byte) (int, error) {
+	n, err := w.Writer.Write(p)
+	if err != nil {
+		err = encoder.NewTransientError(err)
+	}
+	return n, err
+}
+
+func (e *Exporter) saveCheckpoints() {
+	ticker := time.NewTicker(checkpointInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			e.saveCheckpoint()
+		case <-e.ctx.Done():
+			return
+		}
+	}
+}
+
+func (e *Exporter) saveCheckpoint() {
+	e.savedMu.Lock()
+	defer e.savedMu.Unlock()
+	cursor := e.lastCursor.Load()
+	if cursor == e.saved {
+		return
+	}
+	if err := e.spool.SaveCheckpoint(e.checkpoint, cursor); err != nil {
+		logger.GetLogger().Warn("Failed to save spool checkpoint", "checkpoint", e.checkpoint, logfields.Error, err)
+		return
+	}
+	e.saved = cursor
+}
+
+// checkpointCloser saves the checkpoint of the exporter before closing it.
+type checkpointCloser struct {
+	e *Exporter
+}
+
+func (c checkpointCloser) Close() error {
+	c.e.saveCheckpoint()
+	return c.e.closer.Close()
+}
+
 func (e *Exporter) SetHeader(metadata.MD) error {
 	return nil
 }
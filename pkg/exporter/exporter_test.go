@@ -6,6 +6,7 @@ package exporter
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"strings"
@@ -19,8 +20,11 @@ import (
 	"github.com/cilium/tetragon/pkg/reader/node"
 	"github.com/cilium/tetragon/pkg/rthooks"
 	"github.com/cilium/tetragon/pkg/server"
+	"github.com/cilium/tetragon/pkg/spool"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
 )
 
 type arrayWriter struct {
@@ -112,6 +116,132 @@ func TestExporter_Send(t *testing.T) {
 	<-eventNotifier.removed
 }
 
+func checkSpooledEvents(t *testing.T, eventsJSON []string, want map[uint64]string) {
+	t.Helper()
+	got := map[uint64]string{}
+	for _, data := range eventsJSON {
+		var ev tetragon.GetEventsResponse
+		require.NoError(t, protojson.Unmarshal([]byte(data), &ev))
+		got[ev.Cursor] = ev.GetProcessExec().GetProcess().GetBinary()
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestExporter_Spool(t *testing.T) {
+	sp, err := spool.Open(spool.Config{Dir: t.TempDir(), MaxSize: 1 << 20, QueueSize: 100})
+	require.NoError(t, err)
+	var spoolWG sync.WaitGroup
+	spoolCtx, spoolCancel := context.WithCancel(context.Background())
+	defer func() {
+		spoolCancel()
+		spoolWG.Wait()
+	}()
+	sp.Start(spoolCtx, &spoolWG)
+
+	eventNotifier := newFakeNotifier()
+	eventNotifier.AddListener(sp)
+	notify := func(binary string) {
+		eventNotifier.NotifyListener(nil, &tetragon.GetEventsResponse{
+			Event: &tetragon.GetEventsResponse_ProcessExec{
+				ProcessExec: &tetragon.ProcessExec{Process: &tetragon.Process{Binary: binary}},
+			}})
+	}
+
+	// export the first two events
+	var wg sync.WaitGroup
+	ctx, cancel := context.WithCancel(context.Background())
+	grpcServer := server.NewServer(ctx, &wg, eventNotifier, &server.FakeObserver{}, rthooks.DummyHookRunner{})
+	grpcServer.SetSpool(sp)
+	results := newArrayWriter(2)
+	exporter := NewExporter(ctx, &tetragon.GetEventsRequest{}, grpcServer, encoder.NewProtojsonEncoder(results), results, nil).
+		WithSpool(sp, "test")
+	require.NoError(t, exporter.Start())
+	notify("a")
+	notify("b")
+	<-results.done
+	cancel()
+	wg.Wait()
+	checkSpooledEvents(t, results.items, map[uint64]string{1: "a", 2: "b"})
+	cursor, ok, err := sp.LoadCheckpoint("test")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(2), cursor)
+
+	// events spooled while the exporter is down are exported after restarting it
+	notify("c")
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	grpcServer = server.NewServer(ctx, &wg, eventNotifier, &server.FakeObserver{}, rthooks.DummyHookRunner{})
+	grpcServer.SetSpool(sp)
+	results = newArrayWriter(2)
+	exporter = NewExporter(ctx, &tetragon.GetEventsRequest{}, grpcServer, encoder.NewProtojsonEncoder(results), results, nil).
+		WithSpool(sp, "test")
+	require.NoError(t, exporter.Start())
+	notify("d")
+	<-results.done
+	checkSpooledEvents(t, results.items, map[uint64]string{3: "c", 4: "d"})
+}
+
+// failingEncoder fails to encode events with the errors set for their binary,
+// in order, before encoding them with enc.
+type failingEncoder struct {
+	enc    ExportEncoder
+	errors map[string][]error
+}
+
+func (f *failingEncoder) Encode(v interface{}) error {
+	binary := v.(*tetragon.GetEventsResponse).GetProcessExec().GetProcess().GetBinary()
+	if errs := f.errors[binary]; len(errs) > 0 {
+		f.errors[binary] = errs[1:]
+		return errs[0]
+	}
+	return f.enc.Encode(v)
+}
+
+func TestExporter_SpoolEncodeErrors(t *testing.T) {
+	sp, err := spool.Open(spool.Config{Dir: t.TempDir(), MaxSize: 1 << 20, QueueSize: 100})
+	require.NoError(t, err)
+	var spoolWG sync.WaitGroup
+	spoolCtx, spoolCancel := context.WithCancel(context.Background())
+	defer func() {
+		spoolCancel()
+		spoolWG.Wait()
+	}()
+	sp.Start(spoolCtx, &spoolWG)
+
+	eventNotifier := newFakeNotifier()
+	eventNotifier.AddListener(sp)
+	var wg sync.WaitGroup
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	grpcServer := server.NewServer(ctx, &wg, eventNotifier, &server.FakeObserver{}, rthooks.DummyHookRunner{})
+	grpcServer.SetSpool(sp)
+	results := newArrayWriter(2)
+	// transient errors are retried, other errors drop the event
+	enc := &failingEncoder{
+		enc: encoder.NewProtojsonEncoder(results),
+		errors: map[string][]error{
+			"a": {encoder.NewTransientError(errors.New("unavailable"))},
+			"b": {errors.New("invalid")},
+		},
+	}
+	exporter := NewPipelineExporter(ctx, "test", &tetragon.GetEventsRequest{}, grpcServer, enc, results, nil).
+		WithSpool(sp, "test")
+	require.NoError(t, exporter.Start())
+	for _, binary := range []string{"a", "b", "c"} {
+		eventNotifier.NotifyListener(nil, &tetragon.GetEventsResponse{
+			Event: &tetragon.GetEventsResponse_ProcessExec{
+				ProcessExec: &tetragon.ProcessExec{Process: &tetragon.Process{Binary: binary}},
+			}})
+	}
+	<-results.done
+	checkSpooledEvents(t, results.items, map[uint64]string{1: "a", 3: "c"})
+	require.Eventually(t, func() bool {
+		return exporter.lastCursor.Load() == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.InDelta(t, 1, testutil.ToFloat64(pipelineEncodeDropped.WithLabelValues("test")), 0)
+}
+
 type jsonEvent struct {
 	Event         json.RawMessage `json:"process_exec"`
 	RateLimitInfo json.RawMessage `json:"rate_limit_info"`
@@ -222,3 +352,29 @@ func Test_rateLimitExport(t *testing.T) {
 		})
 	}
 }
+
+func TestExporter_RateLimitDropped(t *testing.T) {
+	ctx := t.Context()
+	results := newArrayWriter(10)
+	enc := encoder.NewProtojsonEncoder(results)
+	exporter := NewExporter(ctx, &tetragon.GetEventsRequest{}, nil, enc, results,
+		ratelimit.NewRateLimiter(ctx, time.Minute, 0, enc))
+
+	for i := range 3 {
+		require.NoError(t, exporter.Send(&tetragon.GetEventsResponse{
+			Event: &tetragon.GetEventsResponse_ProcessExec{
+				ProcessExec: &tetragon.ProcessExec{Process: &tetragon.Process{Binary: fmt.Sprintf("a%d", i)}},
+			}}))
+	}
+	assert.Empty(t, results.items)
+
+	// lost_events messages are not rate limited
+	dropped := exporter.RateLimitDropped()
+	assert.Equal(t, uint64(3), dropped)
+	require.NoError(t, exporter.Send(&tetragon.GetEventsResponse{
+		Event: &tetragon.GetEventsResponse_LostEvents{
+			LostEvents: &tetragon.LostEvents{RateLimit: dropped},
+		}}))
+	assert.Len(t, results.items, 1)
+	assert.Zero(t, exporter.RateLimitDropped())
+}
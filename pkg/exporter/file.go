@@ -0,0 +1,153 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package exporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cilium/lumberjack/v2"
+	"github.com/cilium/tetragon/pkg/encoder"
+	"github.com/cilium/tetragon/pkg/fileutils"
+	"github.com/cilium/tetragon/pkg/logger"
+	"github.com/cilium/tetragon/pkg/logger/logfields"
+)
+
+// FileConfig configures a rotating export file.
+type FileConfig struct {
+	Filename         string
+	MaxSizeMB        int
+	MaxBackups       int
+	Compress         bool
+	RotationInterval time.Duration
+	Perm             string
+	// Format is the format of the events, encoder.FormatJSON (JSON lines,
+	// the default) or encoder.FormatProtobuf (length-delimited binary
+	// protobuf).
+	Format string
+	// Compression is the compression of the files, CompressionNone (the
+	// default) or CompressionZstd. Zstd is not compatible with Compress,
+	// which compresses rotated files with gzip.
+	Compression string
+}
+
+const (
+	CompressionNone = "none"
+	CompressionZstd = "zstd"
+)
+
+// validate checks the format and compression of the file.
+func (conf *FileConfig) validate() error {
+	switch conf.Format {
+	case "", encoder.FormatJSON, encoder.FormatProtobuf:
+	default:
+		return fmt.Errorf("unknown export file format %q, valid values are %q and %q", conf.Format, encoder.FormatJSON, encoder.FormatProtobuf)
+	}
+	switch conf.Compression {
+	case "", CompressionNone:
+	case CompressionZstd:
+		if conf.Compress {
+			return errors.New("zstd compression of export files cannot be combined with gzip compression of rotated files")
+		}
+	default:
+		return fmt.Errorf("unknown export file compression %q, valid values are %q and %q", conf.Compression, CompressionNone, CompressionZstd)
+	}
+	return nil
+}
+
+// NewFileEncoder returns an encoder that writes events to the export file
+// described by conf, in the configured format and compression, and the closer
+// of the file.
+func NewFileEncoder(ctx context.Context, conf *FileConfig) (ExportEncoder, io.Closer, error) {
+	if err := conf.validate(); err != nil {
+		return nil, nil, err
+	}
+	writer, err := NewFileWriter(ctx, conf)
+	if err != nil {
+		return nil, nil, err
+	}
+	// Track how many bytes are written to the event export location
+	var w io.Writer = NewExportedBytesTotalWriter(writer)
+	var closer io.Closer = writer
+	if conf.Compression == CompressionZstd {
+		zw, err := newZstdBlockWriter(ctx, struct {
+			io.Writer
+			io.Closer
+		}{w, writer})
+		if err != nil {
+			writer.Close()
+			return nil, nil, err
+		}
+		w, closer = zw, zw
+	}
+	if conf.Format == encoder.FormatProtobuf {
+		return encoder.NewProtobufEncoder(w), closer, nil
+	}
+	return encoder.NewProtojsonEncoder(w), closer, nil
+}
+
+// NewFileWriter returns a writer for the export file described by conf. If
+// conf.RotationInterval is set, the file is also rotated periodically until
+// ctx is done.
+func NewFileWriter(ctx context.Context, conf *FileConfig) (*lumberjack.Logger, error) {
+	log := logger.GetLogger()
+	writer := &lumberjack.Logger{
+		Filename:   conf.Filename,
+		MaxSize:    conf.MaxSizeMB,
+		MaxBackups: conf.MaxBackups,
+		Compress:   conf.Compress,
+	}
+
+	perms, err := fileutils.RegularFilePerms(conf.Perm)
+	if err != nil {
+		log.Warn(fmt.Sprintf("Failed to parse export file permission '%s', failing back to %v",
+			conf.Perm, perms), logfields.Error, err)
+	}
+	writer.FileMode = perms
+
+	finfo, err := os.Stat(filepath.Clean(conf.Filename))
+	if err == nil && finfo.IsDir() {
+		// Error if exportFilename points to a directory
+		return nil, errors.New("passed export JSON logs file point to a directory")
+	}
+	logFile := filepath.Base(conf.Filename)
+	logsDir, err := filepath.Abs(filepath.Dir(filepath.Clean(conf.Filename)))
+	if err != nil {
+		log.Warn(fmt.Sprintf("Failed to get absolute path of exported JSON logs '%s'", conf.Filename), logfields.Error, err)
+		// Do not fail; we let lumberjack handle this. We want to
+		// log the rotate logs operation.
+		logsDir = filepath.Dir(conf.Filename)
+	}
+
+	if conf.RotationInterval < 0 {
+		// Passed an invalid interval let's error out
+		return nil, fmt.Errorf("frequency '%s' at which to rotate JSON export files is negative", conf.RotationInterval.String())
+	} else if conf.RotationInterval > 0 {
+		log.Info("Periodically rotating JSON export files",
+			"directory", logsDir,
+			"frequency", conf.RotationInterval.String())
+		go func() {
+			ticker := time.NewTicker(conf.RotationInterval)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					log.Info("Rotating JSON logs export", "file", logFile, "directory", logsDir)
+					if rotationErr := writer.Rotate(); rotationErr != nil {
+						log.Warn("Failed to rotate JSON export file", "file", conf.Filename, logfields.Error, rotationErr)
+					}
+				}
+			}
+		}()
+	}
+
+	return writer, nil
+}
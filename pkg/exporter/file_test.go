@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package exporter
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/encoder"
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/proto"
+)
+
+// readProtobufFile reads the events of a zstd compressed, length-delimited
+// protobuf export file.
+func readProtobufFile(t *testing.T, name string) []*tetragon.GetEventsResponse {
+	f, err := os.Open(name)
+	require.NoError(t, err)
+	defer f.Close()
+	zr, err := zstd.NewReader(f)
+	require.NoError(t, err)
+	defer zr.Close()
+	r := bufio.NewReader(zr)
+	var events []*tetragon.GetEventsResponse
+	for {
+		ev := &tetragon.GetEventsResponse{}
+		err := protodelim.UnmarshalFrom(r, ev)
+		if errors.Is(err, io.EOF) {
+			return events
+		}
+		require.NoError(t, err)
+		events = append(events, ev)
+	}
+}
+
+func fileTestEvent(execID string) *tetragon.GetEventsResponse {
+	return &tetragon.GetEventsResponse{
+		Event: &tetragon.GetEventsResponse_ProcessExec{ProcessExec: &tetragon.ProcessExec{
+			Process: &tetragon.Process{ExecId: execID, Binary: "/bin/sh"},
+		}},
+	}
+}
+
+func TestFileEncoderProtobufZstd(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "events.bin")
+	enc, closer, err := NewFileEncoder(context.Background(), &FileConfig{
+		Filename:    filename,
+		MaxSizeMB:   10,
+		MaxBackups:  5,
+		Format:      encoder.FormatProtobuf,
+		Compression: CompressionZstd,
+	})
+	require.NoError(t, err)
+	events := []*tetragon.GetEventsResponse{fileTestEvent("exec-1"), fileTestEvent("exec-2")}
+	for _, ev := range events {
+		require.NoError(t, enc.Encode(ev))
+	}
+	require.NoError(t, closer.Close())
+
+	got := readProtobufFile(t, filename)
+	require.Len(t, got, len(events))
+	for i := range events {
+		assert.True(t, proto.Equal(events[i], got[i]))
+	}
+}
+
+func TestZstdBlockWriterRotation(t *testing.T) {
+	dir := t.TempDir()
+	writer, err := NewFileWriter(context.Background(), &FileConfig{
+		Filename:   filepath.Join(dir, "events.bin"),
+		MaxSizeMB:  10,
+		MaxBackups: 5,
+	})
+	require.NoError(t, err)
+	zw, err := newZstdBlockWriter(context.Background(), writer)
+	require.NoError(t, err)
+	enc := encoder.NewProtobufEncoder(zw)
+
+	require.NoError(t, enc.Encode(fileTestEvent("exec-1")))
+	zw.mu.Lock()
+	require.NoError(t, zw.flush())
+	zw.mu.Unlock()
+	require.NoError(t, writer.Rotate())
+	require.NoError(t, enc.Encode(fileTestEvent("exec-2")))
+	require.NoError(t, zw.Close())
+
+	// each file is a valid zstd stream on its own
+	files, err := filepath.Glob(filepath.Join(dir, "events*.bin"))
+	require.NoError(t, err)
+	require.Len(t, files, 2)
+	var execIDs []string
+	for _, name := range files {
+		for _, ev := range readProtobufFile(t, name) {
+			execIDs = append(execIDs, ev.GetProcessExec().GetProcess().GetExecId())
+		}
+	}
+	assert.ElementsMatch(t, []string{"exec-1", "exec-2"}, execIDs)
+}
+
+func TestFileConfigValidate(t *testing.T) {
+	for _, conf := range []FileConfig{
+		{},
+		{Format: encoder.FormatJSON, Compression: CompressionNone},
+		{Format: encoder.FormatProtobuf, Compression: CompressionZstd},
+	} {
+		require.NoError(t, conf.validate(), conf)
+	}
+	for _, conf := range []FileConfig{
+		{Format: "avro"},
+		{Compression: "gzip"},
+		{Compression: CompressionZstd, Compress: true},
+	} {
+		require.Error(t, conf.validate(), conf)
+	}
+}
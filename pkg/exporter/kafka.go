@@ -0,0 +1,155 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package exporter
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/cilium/tetragon/pkg/encoder"
+	"github.com/cilium/tetragon/pkg/logger"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+// KafkaConfig configures a Kafka export sink.
+type KafkaConfig struct {
+	// Brokers are the seed brokers (host:port) of the Kafka cluster.
+	Brokers []string
+	// Topic is the topic events are published to. It can contain the
+	// {event_type}, {policy_name} and {namespace} fields, which are replaced
+	// by the values of the event.
+	Topic string
+	// Format is the serialization format of the events, encoder.FormatJSON
+	// or encoder.FormatProtobuf.
+	Format string
+	// Timeout is the maximum time to deliver an event, after which it is
+	// dropped.
+	Timeout time.Duration
+	// AutoCreateTopics allows the brokers to create topics that do not exist,
+	// if they are configured to do so.
+	AutoCreateTopics bool
+}
+
+const defaultKafkaTimeout = 30 * time.Second
+
+// DefaultKafkaConfig returns the default Kafka sink configuration.
+func DefaultKafkaConfig() KafkaConfig {
+	return KafkaConfig{
+		Topic:   "tetragon",
+		Format:  encoder.FormatJSON,
+		Timeout: defaultKafkaTimeout,
+	}
+}
+
+// NewKafkaEncoder creates an encoder that publishes events to Kafka. Events
+// are delivered asynchronously: the delivery results are reported in metrics.
+// When the exporter reads events from the spool, Encode waits for the
+// delivery of each event instead, and returns its error.
+func NewKafkaEncoder(conf *KafkaConfig) (*MessageBusEncoder, error) {
+	return newMessageBusEncoder(busKafka, conf.Topic, conf.Format, func() (publisher, error) {
+		return newKafkaPublisher(conf)
+	})
+}
+
+type kafkaPublisher struct {
+	client  *kgo.Client
+	timeout time.Duration
+}
+
+func newKafkaPublisher(conf *KafkaConfig) (*kafkaPublisher, error) {
+	if len(conf.Brokers) == 0 {
+		return nil, errors.New("no Kafka brokers configured")
+	}
+	timeout := conf.Timeout
+	if timeout <= 0 {
+		timeout = defaultKafkaTimeout
+	}
+	opts := []kgo.Opt{
+		kgo.SeedBrokers(conf.Brokers...),
+		kgo.ClientID("tetragon"),
+		kgo.RecordDeliveryTimeout(timeout),
+		kgo.WithLogger(kafkaLogger{}),
+	}
+	if conf.AutoCreateTopics {
+		opts = append(opts, kgo.AllowAutoTopicCreation())
+	}
+	client, err := kgo.NewClient(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &kafkaPublisher{
+		client:  client,
+		timeout: timeout,
+	}, nil
+}
+
+func (p *kafkaPublisher) publish(topic string, key []byte, payload []byte, done func(error)) error {
+	rec := &kgo.Record{
+		Topic: topic,
+		Key:   key,
+		Value: payload,
+	}
+	// Produce blocks if too many records are buffered, until buffered
+	// records are delivered or time out.
+	p.client.Produce(context.Background(), rec, func(_ *kgo.Record, err error) {
+		done(err)
+	})
+	return nil
+}
+
+func (p *kafkaPublisher) publishSync(topic string, key []byte, payload []byte) error {
+	rec := &kgo.Record{
+		Topic: topic,
+		Key:   key,
+		Value: payload,
+	}
+	// the record fails after the delivery timeout
+	err := p.client.ProduceSync(context.Background(), rec).FirstErr()
+	if err != nil && kafkaRetriable(err) {
+		return encoder.NewTransientError(err)
+	}
+	return err
+}
+
+// kafkaRetriable returns true if producing a record again can succeed after it
+// failed with err. Kafka errors are retriable unless they are not retriable
+// for Kafka, such as too large records and authorization failures, or the
+// topic does not exist, which the client already retried. Other errors, such
+// as delivery timeouts, are retriable.
+func kafkaRetriable(err error) bool {
+	if errors.Is(err, kerr.UnknownTopicOrPartition) {
+		return false
+	}
+	var kafkaErr *kerr.Error
+	if errors.As(err, &kafkaErr) {
+		return kafkaErr.Retriable
+	}
+	return true
+}
+
+func (p *kafkaPublisher) close() error {
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+	err := p.client.Flush(ctx)
+	p.client.Close()
+	return err
+}
+
+// kafkaLogger logs the warnings and errors of the Kafka client.
+type kafkaLogger struct{}
+
+func (kafkaLogger) Level() kgo.LogLevel {
+	return kgo.LogLevelWarn
+}
+
+func (kafkaLogger) Log(level kgo.LogLevel, msg string, keyvals ...any) {
+	lvl := slog.LevelWarn
+	if level == kgo.LogLevelError {
+		lvl = slog.LevelError
+	}
+	logger.GetLogger().Log(context.Background(), lvl, "Kafka export: "+msg, keyvals...)
+}
@@ -36,6 +36,49 @@ var (
 		Help:        "Number of events dropped on export due to rate limiting",
 		ConstLabels: nil,
 	})
+
+	encodeDropped = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: consts.MetricsNamespace,
+		Name:      "export_encode_events_dropped_total",
+		Help:      "Number of events dropped on export because they failed to be encoded or delivered",
+	})
+
+	pipelineLabel = metrics.UnconstrainedLabel{Name: "pipeline", ExampleValue: "audit"}
+
+	pipelineEventsExportedTotal = metrics.MustNewCounter(metrics.NewOpts(
+		consts.MetricsNamespace, "", "export_pipeline_events_exported_total",
+		"Total number of events exported by each export pipeline",
+		nil, nil, []metrics.UnconstrainedLabel{pipelineLabel},
+	), nil)
+
+	pipelineRateLimitDropped = metrics.MustNewCounter(metrics.NewOpts(
+		consts.MetricsNamespace, "", "export_pipeline_ratelimit_events_dropped_total",
+		"Number of events dropped by each export pipeline due to rate limiting",
+		nil, nil, []metrics.UnconstrainedLabel{pipelineLabel},
+	), nil)
+
+	pipelineEncodeDropped = metrics.MustNewCounter(metrics.NewOpts(
+		consts.MetricsNamespace, "", "export_pipeline_encode_events_dropped_total",
+		"Number of events dropped by each export pipeline because they failed to be encoded or delivered",
+		nil, nil, []metrics.UnconstrainedLabel{pipelineLabel},
+	), nil)
+
+	busLabel = metrics.ConstrainedLabel{
+		Name:   "bus",
+		Values: []string{busKafka, busNATS},
+	}
+
+	messageBusDelivered = metrics.MustNewCounter(metrics.NewOpts(
+		consts.MetricsNamespace, "", "export_message_bus_messages_delivered_total",
+		"Number of events delivered to message buses",
+		nil, []metrics.ConstrainedLabel{busLabel}, nil,
+	), nil)
+
+	messageBusFailed = metrics.MustNewCounter(metrics.NewOpts(
+		consts.MetricsNamespace, "", "export_message_bus_messages_failed_total",
+		"Number of events that failed to be delivered to message buses",
+		nil, []metrics.ConstrainedLabel{busLabel}, nil,
+	), nil)
 )
 
 func RegisterMetrics(group metrics.Group) {
@@ -44,6 +87,12 @@ func RegisterMetrics(group metrics.Group) {
 		eventsExportedBytesTotal,
 		eventsExportTimestamp,
 		rateLimitDropped,
+		encodeDropped,
+		pipelineEventsExportedTotal,
+		pipelineRateLimitDropped,
+		pipelineEncodeDropped,
+		messageBusDelivered,
+		messageBusFailed,
 	)
 }
 
@@ -62,6 +111,8 @@ func (w byteCounterWriter) Write(p []byte) (int, error) {
 	return n, err
 }
 
+// NewExportedBytesTotalWriter returns a writer that counts the bytes written
+// to w, and marks the write errors of w as transient.
 func NewExportedBytesTotalWriter(w io.Writer) io.Writer {
-	return newExportedBytesCounterWriter(w, eventsExportedBytesTotal)
+	return newExportedBytesCounterWriter(transientWriter{w}, eventsExportedBytesTotal)
 }
@@ -0,0 +1,216 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package exporter
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/api/v1/tetragon/codegen/helpers"
+	"github.com/cilium/tetragon/pkg/encoder"
+	"github.com/cilium/tetragon/pkg/event"
+	"github.com/cilium/tetragon/pkg/filters"
+	"github.com/cilium/tetragon/pkg/logger"
+	"github.com/cilium/tetragon/pkg/logger/logfields"
+)
+
+const (
+	busKafka = "kafka"
+	busNATS  = "nats"
+
+	// topicNone is the value of topic template fields the event has no value
+	// for, e.g. the policy name of a process_exec event.
+	topicNone = "none"
+)
+
+// topicFields are the fields that can be used in topic templates.
+var topicFields = map[string]func(*tetragon.GetEventsResponse) string{
+	"event_type": func(ev *tetragon.GetEventsResponse) string {
+		typ, _ := helpers.ResponseTypeString(ev)
+		return strings.ToLower(typ)
+	},
+	"policy_name": func(ev *tetragon.GetEventsResponse) string {
+		return filters.GetPolicyName(&event.Event{Event: ev})
+	},
+	"namespace": func(ev *tetragon.GetEventsResponse) string {
+		return helpers.ResponseGetProcess(ev).GetPod().GetNamespace()
+	},
+}
+
+// topicTemplate is the template of a Kafka topic or NATS subject, where
+// "{field}" is replaced by the value of field for each event.
+type topicTemplate struct {
+	// literals and fields alternate, starting and ending with a literal
+	literals []string
+	fields   []func(*tetragon.GetEventsResponse) string
+}
+
+func parseTopicTemplate(s string) (*topicTemplate, error) {
+	if s == "" {
+		return nil, errors.New("topic is empty")
+	}
+	t := &topicTemplate{}
+	for {
+		start := strings.IndexByte(s, '{')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(s[start:], '}')
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated field in topic %q", s)
+		}
+		name := s[start+1 : start+end]
+		field, ok := topicFields[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown topic field %q, valid fields are event_type, policy_name and namespace", name)
+		}
+		t.literals = append(t.literals, s[:start])
+		t.fields = append(t.fields, field)
+		s = s[start+end+1:]
+	}
+	t.literals = append(t.literals, s)
+	return t, nil
+}
+
+// topic returns the topic of the event. Field values are sanitized, so that
+// they are valid in both Kafka topics and NATS subject tokens.
+func (t *topicTemplate) topic(ev *tetragon.GetEventsResponse) string {
+	var b strings.Builder
+	for i, field := range t.fields {
+		b.WriteString(t.literals[i])
+		value := field(ev)
+		if value == "" {
+			value = topicNone
+		}
+		for _, c := range value {
+			switch {
+			case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '_', c == '-':
+				b.WriteRune(c)
+			default:
+				b.WriteByte('_')
+			}
+		}
+	}
+	b.WriteString(t.literals[len(t.literals)-1])
+	return b.String()
+}
+
+// validateMessageBus checks the topic template and the serialization format of
+// a message bus sink without connecting to it.
+func validateMessageBus(topic string, format string) error {
+	if _, err := parseTopicTemplate(topic); err != nil {
+		return err
+	}
+	_, err := encoder.NewSerializer(format)
+	return err
+}
+
+// publisher publishes messages to a message bus.
+type publisher interface {
+	// publish publishes the payload to the topic, and calls done with the
+	// result of the delivery, possibly asynchronously. If the message cannot
+	// be published at all, publish returns an error and done is not called.
+	publish(topic string, key []byte, payload []byte, done func(error)) error
+	// publishSync publishes the payload to the topic, and returns once it
+	// is delivered, or with the error that prevented its delivery. Errors
+	// after which publishing the payload again can succeed are returned as
+	// encoder.TransientError.
+	publishSync(topic string, key []byte, payload []byte) error
+	close() error
+}
+
+// MessageBusEncoder is an ExportEncoder that publishes events to a message
+// bus, to a topic chosen per event.
+type MessageBusEncoder struct {
+	bus        string
+	topic      *topicTemplate
+	serializer encoder.Serializer
+	publisher  publisher
+	// sync is set if Encode waits for the delivery of each event
+	sync bool
+}
+
+func newMessageBusEncoder(bus string, topic string, format string, newPublisher func() (publisher, error)) (*MessageBusEncoder, error) {
+	tmpl, err := parseTopicTemplate(topic)
+	if err != nil {
+		return nil, err
+	}
+	serializer, err := encoder.NewSerializer(format)
+	if err != nil {
+		return nil, err
+	}
+	pub, err := newPublisher()
+	if err != nil {
+		return nil, err
+	}
+	return &MessageBusEncoder{
+		bus:        bus,
+		topic:      tmpl,
+		serializer: serializer,
+		publisher:  pub,
+	}, nil
+}
+
+// Encode implements ExportEncoder.Encode.
+func (e *MessageBusEncoder) Encode(v interface{}) error {
+	ev, ok := v.(*tetragon.GetEventsResponse)
+	if !ok {
+		return encoder.ErrInvalidEvent
+	}
+	payload, err := e.serializer.Serialize(ev)
+	if err != nil {
+		return err
+	}
+	// Events of the same process share the same key, so that they are
+	// delivered in order.
+	var key []byte
+	if execID := helpers.ResponseGetProcess(ev).GetExecId(); execID != "" {
+		key = []byte(execID)
+	}
+	size := len(payload)
+	if e.sync {
+		// transient errors are returned for the exporter to retry the
+		// event, others drop it
+		err = e.publisher.publishSync(e.topic.topic(ev), key, payload)
+		if encoder.IsTransient(err) {
+			return err
+		}
+		reportDelivery(e.bus, size, err)
+		if err != nil {
+			logger.GetLogger().Warn("Failed to deliver event to message bus, dropping it",
+				"bus", e.bus, "cursor", ev.Cursor, logfields.Error, err)
+		}
+		return nil
+	}
+	err = e.publisher.publish(e.topic.topic(ev), key, payload, func(err error) {
+		reportDelivery(e.bus, size, err)
+	})
+	if err != nil {
+		reportDelivery(e.bus, size, err)
+	}
+	return err
+}
+
+// SetSyncDelivery implements syncDeliveryEncoder.
+func (e *MessageBusEncoder) SetSyncDelivery() {
+	e.sync = true
+}
+
+// Close flushes the pending messages and closes the connection to the message
+// bus.
+func (e *MessageBusEncoder) Close() error {
+	return e.publisher.close()
+}
+
+// reportDelivery updates the delivery metrics of a message.
+func reportDelivery(bus string, size int, err error) {
+	if err != nil {
+		messageBusFailed.WithLabelValues(bus).Inc()
+		return
+	}
+	messageBusDelivered.WithLabelValues(bus).Inc()
+	eventsExportedBytesTotal.Add(float64(size))
+}
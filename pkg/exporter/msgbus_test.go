@@ -0,0 +1,331 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package exporter
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/encoder"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kfake"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+func msgbusTestEvents() []*tetragon.GetEventsResponse {
+	return []*tetragon.GetEventsResponse{
+		{
+			Event: &tetragon.GetEventsResponse_ProcessExec{ProcessExec: &tetragon.ProcessExec{
+				Process: &tetragon.Process{
+					ExecId: "exec-1",
+					Pod:    &tetragon.Pod{Namespace: "kube-system"},
+				},
+			}},
+		},
+		{
+			Event: &tetragon.GetEventsResponse_ProcessKprobe{ProcessKprobe: &tetragon.ProcessKprobe{
+				Process:    &tetragon.Process{ExecId: "exec-1"},
+				PolicyName: "file.monitoring",
+			}},
+		},
+	}
+}
+
+func TestTopicTemplate(t *testing.T) {
+	events := msgbusTestEvents()
+	tests := []struct {
+		template string
+		topics   []string
+	}{
+		{"tetragon", []string{"tetragon", "tetragon"}},
+		{"tetragon-{event_type}", []string{"tetragon-process_exec", "tetragon-process_kprobe"}},
+		{"{namespace}.{policy_name}", []string{"kube-system.none", "none.file_monitoring"}},
+	}
+	for _, tc := range tests {
+		tmpl, err := parseTopicTemplate(tc.template)
+		require.NoError(t, err, tc.template)
+		for i, ev := range events {
+			assert.Equal(t, tc.topics[i], tmpl.topic(ev), tc.template)
+		}
+	}
+
+	for _, invalid := range []string{"", "tetragon-{pod}", "tetragon-{event_type"} {
+		_, err := parseTopicTemplate(invalid)
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestKafkaEncoder(t *testing.T) {
+	cluster, err := kfake.NewCluster(kfake.NumBrokers(1), kfake.SeedTopics(1, "tetragon-process_exec", "tetragon-process_kprobe"))
+	require.NoError(t, err)
+	defer cluster.Close()
+
+	enc, err := NewKafkaEncoder(&KafkaConfig{
+		Brokers: cluster.ListenAddrs(),
+		Topic:   "tetragon-{event_type}",
+		Format:  encoder.FormatProtobuf,
+		Timeout: 10 * time.Second,
+	})
+	require.NoError(t, err)
+	events := msgbusTestEvents()
+	for _, ev := range events {
+		require.NoError(t, enc.Encode(ev))
+	}
+	require.NoError(t, enc.Close())
+
+	consumer, err := kgo.NewClient(
+		kgo.SeedBrokers(cluster.ListenAddrs()...),
+		kgo.ConsumeTopics("tetragon-process_exec", "tetragon-process_kprobe"),
+	)
+	require.NoError(t, err)
+	defer consumer.Close()
+
+	got := map[string]*tetragon.GetEventsResponse{}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	for len(got) < len(events) {
+		fetches := consumer.PollFetches(ctx)
+		require.NoError(t, ctx.Err())
+		fetches.EachRecord(func(r *kgo.Record) {
+			assert.Equal(t, "exec-1", string(r.Key))
+			ev := &tetragon.GetEventsResponse{}
+			assert.NoError(t, proto.Unmarshal(r.Value, ev))
+			got[r.Topic] = ev
+		})
+	}
+	assert.True(t, proto.Equal(events[0], got["tetragon-process_exec"]))
+	assert.True(t, proto.Equal(events[1], got["tetragon-process_kprobe"]))
+}
+
+func TestKafkaEncoderSync(t *testing.T) {
+	cluster, err := kfake.NewCluster(kfake.NumBrokers(1), kfake.SeedTopics(1, "tetragon-process_exec"))
+	require.NoError(t, err)
+	defer cluster.Close()
+
+	enc, err := NewKafkaEncoder(&KafkaConfig{
+		Brokers: cluster.ListenAddrs(),
+		Topic:   "tetragon-{event_type}",
+		Format:  encoder.FormatJSON,
+		Timeout: 2 * time.Second,
+	})
+	require.NoError(t, err)
+	defer enc.Close()
+	enc.SetSyncDelivery()
+
+	events := msgbusTestEvents()
+	require.NoError(t, enc.Encode(events[0]))
+	// the topic of the process_kprobe event does not exist, so it is dropped
+	failed := testutil.ToFloat64(messageBusFailed.WithLabelValues(busKafka))
+	require.NoError(t, enc.Encode(events[1]))
+	assert.InDelta(t, failed+1, testutil.ToFloat64(messageBusFailed.WithLabelValues(busKafka)), 0)
+}
+
+func TestKafkaRetriable(t *testing.T) {
+	assert.True(t, kafkaRetriable(kgo.ErrRecordTimeout))
+	assert.True(t, kafkaRetriable(kerr.NotLeaderForPartition))
+	assert.True(t, kafkaRetriable(fmt.Errorf("produce: %w", kerr.RequestTimedOut)))
+	assert.False(t, kafkaRetriable(kerr.UnknownTopicOrPartition))
+	assert.False(t, kafkaRetriable(kerr.MessageTooLarge))
+	assert.False(t, kafkaRetriable(kerr.TopicAuthorizationFailed))
+}
+
+// fakeNATSMaxPayload is the maximum payload announced by fakeNATSServer.
+const fakeNATSMaxPayload = 4096
+
+// fakeNATSServer is a minimal NATS server that records the published messages.
+type fakeNATSServer struct {
+	listener net.Listener
+	msgs     chan natsMsg
+	// pause is set to stop answering pings
+	pause atomic.Bool
+}
+
+type natsMsg struct {
+	subject string
+	payload []byte
+}
+
+func newFakeNATSServer(t *testing.T) *fakeNATSServer {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	s := &fakeNATSServer{listener: l, msgs: make(chan natsMsg, 16)}
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go s.serve(conn)
+		}
+	}()
+	t.Cleanup(func() { l.Close() })
+	return s
+}
+
+func (s *fakeNATSServer) serve(conn net.Conn) {
+	defer conn.Close()
+	_, err := io.WriteString(conn, `INFO {"server_id":"fake","version":"2.10.0","proto":1,"max_payload":`+strconv.Itoa(fakeNATSMaxPayload)+`}`+"\r\n")
+	if err != nil {
+		return
+	}
+	r := bufio.NewReader(conn)
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		switch strings.ToUpper(fields[0]) {
+		case "PING":
+			if s.pause.Load() {
+				continue
+			}
+			if _, err := io.WriteString(conn, "PONG\r\n"); err != nil {
+				return
+			}
+		case "PUB":
+			// PUB <subject> [reply-to] <size>
+			size, err := strconv.Atoi(fields[len(fields)-1])
+			if err != nil {
+				return
+			}
+			payload := make([]byte, size+2)
+			if _, err := io.ReadFull(r, payload); err != nil {
+				return
+			}
+			s.msgs <- natsMsg{subject: fields[1], payload: payload[:size]}
+		}
+	}
+}
+
+func TestNATSEncoder(t *testing.T) {
+	server := newFakeNATSServer(t)
+	enc, err := NewNATSEncoder(&NATSConfig{
+		Servers: []string{"nats://" + server.listener.Addr().String()},
+		Subject: "tetragon.{event_type}",
+		Format:  encoder.FormatJSON,
+		Timeout: 5 * time.Second,
+	})
+	require.NoError(t, err)
+	events := msgbusTestEvents()
+	for _, ev := range events {
+		require.NoError(t, enc.Encode(ev))
+	}
+	require.NoError(t, enc.Close())
+
+	for i, subject := range []string{"tetragon.process_exec", "tetragon.process_kprobe"} {
+		select {
+		case msg := <-server.msgs:
+			assert.Equal(t, subject, msg.subject)
+			ev := &tetragon.GetEventsResponse{}
+			require.NoError(t, protojson.Unmarshal(msg.payload, ev))
+			assert.True(t, proto.Equal(events[i], ev))
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for message %d", i)
+		}
+	}
+}
+
+func TestNATSEncoderSync(t *testing.T) {
+	server := newFakeNATSServer(t)
+	enc, err := NewNATSEncoder(&NATSConfig{
+		Servers: []string{"nats://" + server.listener.Addr().String()},
+		Subject: "tetragon.{event_type}",
+		Format:  encoder.FormatJSON,
+		Timeout: time.Second,
+	})
+	require.NoError(t, err)
+	defer enc.Close()
+	enc.SetSyncDelivery()
+
+	events := msgbusTestEvents()
+	require.NoError(t, enc.Encode(events[0]))
+	msg := <-server.msgs
+	assert.Equal(t, "tetragon.process_exec", msg.subject)
+
+	// too large messages are dropped
+	failed := testutil.ToFloat64(messageBusFailed.WithLabelValues(busNATS))
+	large := &tetragon.GetEventsResponse{
+		Event: &tetragon.GetEventsResponse_ProcessExec{ProcessExec: &tetragon.ProcessExec{
+			Process: &tetragon.Process{Binary: strings.Repeat("a", fakeNATSMaxPayload)},
+		}},
+	}
+	require.NoError(t, enc.Encode(large))
+	assert.InDelta(t, failed+1, testutil.ToFloat64(messageBusFailed.WithLabelValues(busNATS)), 0)
+
+	// the server stops answering pings
+	server.pause.Store(true)
+	err = enc.Encode(events[1])
+	require.Error(t, err)
+	assert.True(t, encoder.IsTransient(err))
+}
+
+func TestParsePipelinesMessageBus(t *testing.T) {
+	pipelines, err := ParsePipelines([]byte(`
+- name: kafka
+  kafka:
+    brokers: [kafka-0:9092, kafka-1:9092]
+    topic: tetragon-{event_type}
+    format: protobuf
+    autoCreateTopics: true
+- name: nats
+  nats:
+    servers: [nats://nats:4222]
+    timeout: 1s
+`), false)
+	require.NoError(t, err)
+	require.Len(t, pipelines, 2)
+
+	assert.Equal(t, "kafka", pipelines[0].sinkName())
+	assert.Equal(t, &KafkaConfig{
+		Brokers:          []string{"kafka-0:9092", "kafka-1:9092"},
+		Topic:            "tetragon-{event_type}",
+		Format:           encoder.FormatProtobuf,
+		Timeout:          defaultKafkaTimeout,
+		AutoCreateTopics: true,
+	}, pipelines[0].Kafka)
+
+	assert.Equal(t, "nats", pipelines[1].sinkName())
+	assert.Equal(t, &NATSConfig{
+		Servers: []string{"nats://nats:4222"},
+		Subject: "tetragon",
+		Format:  encoder.FormatJSON,
+		Timeout: time.Second,
+	}, pipelines[1].NATS)
+
+	for _, invalid := range []string{
+		`[{name: a, kafka: {}}]`,
+		`[{name: a, kafka: {brokers: [k:9092], format: avro}}]`,
+		`[{name: a, nats: {servers: [nats://n:4222], subject: "{pod}"}}]`,
+		`[{name: a, nats: {servers: [nats://n:4222]}, kafka: {brokers: [k:9092]}}]`,
+	} {
+		_, err := ParsePipelines([]byte(invalid), false)
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestMessageBusEncoderSpool(t *testing.T) {
+	enc := &MessageBusEncoder{}
+	NewExporter(context.Background(), nil, nil, enc, nil, nil)
+	assert.False(t, enc.sync)
+	NewExporter(context.Background(), nil, nil, enc, nil, nil).WithSpool(nil, "test")
+	assert.True(t, enc.sync)
+}
@@ -0,0 +1,130 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package exporter
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/cilium/tetragon/pkg/encoder"
+	"github.com/cilium/tetragon/pkg/logger"
+	"github.com/cilium/tetragon/pkg/logger/logfields"
+	"github.com/nats-io/nats.go"
+)
+
+// NATSConfig configures a NATS export sink.
+type NATSConfig struct {
+	// Servers are the URLs of the NATS servers (e.g. nats://nats:4222).
+	Servers []string
+	// Subject is the subject events are published to. It can contain the
+	// {event_type}, {policy_name} and {namespace} fields, which are replaced
+	// by the values of the event.
+	Subject string
+	// Format is the serialization format of the events, encoder.FormatJSON
+	// or encoder.FormatProtobuf.
+	Format string
+	// Timeout is the timeout to connect to the servers and to flush pending
+	// messages on close.
+	Timeout time.Duration
+}
+
+const defaultNATSTimeout = 5 * time.Second
+
+// DefaultNATSConfig returns the default NATS sink configuration.
+func DefaultNATSConfig() NATSConfig {
+	return NATSConfig{
+		Subject: "tetragon",
+		Format:  encoder.FormatJSON,
+		Timeout: defaultNATSTimeout,
+	}
+}
+
+// NewNATSEncoder creates an encoder that publishes events to NATS. Core NATS
+// delivery is at most once: events published while disconnected are buffered
+// by the client and are lost if the buffer fills up before it reconnects. When
+// the exporter reads events from the spool, Encode waits for the server to
+// receive each event instead, and returns an error if it does not.
+func NewNATSEncoder(conf *NATSConfig) (*MessageBusEncoder, error) {
+	return newMessageBusEncoder(busNATS, conf.Subject, conf.Format, func() (publisher, error) {
+		return newNATSPublisher(conf)
+	})
+}
+
+type natsPublisher struct {
+	conn    *nats.Conn
+	timeout time.Duration
+}
+
+func newNATSPublisher(conf *NATSConfig) (*natsPublisher, error) {
+	if len(conf.Servers) == 0 {
+		return nil, errors.New("no NATS servers configured")
+	}
+	timeout := conf.Timeout
+	if timeout <= 0 {
+		timeout = defaultNATSTimeout
+	}
+	conn, err := nats.Connect(strings.Join(conf.Servers, ","),
+		nats.Name("tetragon"),
+		nats.Timeout(timeout),
+		nats.MaxReconnects(-1),
+		nats.DisconnectErrHandler(func(_ *nats.Conn, err error) {
+			if err != nil {
+				logger.GetLogger().Warn("NATS export: disconnected", logfields.Error, err)
+			}
+		}),
+		nats.ReconnectHandler(func(c *nats.Conn) {
+			logger.GetLogger().Info("NATS export: reconnected", "server", c.ConnectedUrlRedacted())
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &natsPublisher{
+		conn:    conn,
+		timeout: timeout,
+	}, nil
+}
+
+func (p *natsPublisher) publish(subject string, _ []byte, payload []byte, done func(error)) error {
+	if err := p.conn.Publish(subject, payload); err != nil {
+		return err
+	}
+	done(nil)
+	return nil
+}
+
+// publishSync publishes the payload and flushes the connection, so that it
+// returns once the server received the message. A message published while the
+// connection is down is buffered by the client, and can be delivered after the
+// flush failed, so it can be delivered twice when it is retried.
+func (p *natsPublisher) publishSync(subject string, _ []byte, payload []byte) error {
+	if err := p.conn.Publish(subject, payload); err != nil {
+		return natsDeliveryError(err)
+	}
+	if err := p.conn.FlushTimeout(p.timeout); err != nil {
+		return natsDeliveryError(err)
+	}
+	return nil
+}
+
+// natsDeliveryError marks err as transient, unless the message can never be
+// published because it is too large, its subject is invalid, or publishing is
+// not allowed.
+func natsDeliveryError(err error) error {
+	switch {
+	case errors.Is(err, nats.ErrMaxPayload),
+		errors.Is(err, nats.ErrBadSubject),
+		errors.Is(err, nats.ErrPermissionViolation),
+		errors.Is(err, nats.ErrAuthorization):
+		return err
+	}
+	return encoder.NewTransientError(err)
+}
+
+func (p *natsPublisher) close() error {
+	err := p.conn.FlushTimeout(p.timeout)
+	p.conn.Close()
+	return err
+}
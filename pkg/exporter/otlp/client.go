@@ -0,0 +1,174 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package otlp
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+const httpLogsPath = "/v1/logs"
+
+// client sends a batch of log records to a collector.
+type client interface {
+	// export returns a permanentError if retrying the same request is
+	// pointless.
+	export(ctx context.Context, req *collogspb.ExportLogsServiceRequest) error
+	close() error
+}
+
+// permanentError wraps errors that should not be retried.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+func isPermanent(err error) bool {
+	var perr *permanentError
+	return errors.As(err, &perr)
+}
+
+func newClient(conf *Config) (client, error) {
+	switch conf.Protocol {
+	case ProtocolGRPC:
+		return newGRPCClient(conf)
+	case ProtocolHTTP:
+		return newHTTPClient(conf)
+	default:
+		return nil, fmt.Errorf("unknown OTLP protocol %q, valid values are %q and %q", conf.Protocol, ProtocolGRPC, ProtocolHTTP)
+	}
+}
+
+type grpcClient struct {
+	conn    *grpc.ClientConn
+	client  collogspb.LogsServiceClient
+	headers metadata.MD
+}
+
+func newGRPCClient(conf *Config) (*grpcClient, error) {
+	creds := insecure.NewCredentials()
+	if !conf.Insecure {
+		creds = credentials.NewTLS(&tls.Config{MinVersion: tls.VersionTLS12})
+	}
+	conn, err := grpc.NewClient(conf.Endpoint, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP gRPC client for %q: %w", conf.Endpoint, err)
+	}
+	return &grpcClient{
+		conn:    conn,
+		client:  collogspb.NewLogsServiceClient(conn),
+		headers: metadata.New(conf.Headers),
+	}, nil
+}
+
+// retryableCodes are the gRPC codes for which the OTLP specification allows
+// retrying.
+var retryableCodes = map[codes.Code]struct{}{
+	codes.Canceled:          {},
+	codes.DeadlineExceeded:  {},
+	codes.Aborted:           {},
+	codes.OutOfRange:        {},
+	codes.Unavailable:       {},
+	codes.DataLoss:          {},
+	codes.ResourceExhausted: {},
+}
+
+func (c *grpcClient) export(ctx context.Context, req *collogspb.ExportLogsServiceRequest) error {
+	if len(c.headers) > 0 {
+		ctx = metadata.NewOutgoingContext(ctx, c.headers)
+	}
+	_, err := c.client.Export(ctx, req)
+	if err == nil {
+		return nil
+	}
+	if _, ok := retryableCodes[status.Code(err)]; ok {
+		return err
+	}
+	return &permanentError{err: err}
+}
+
+func (c *grpcClient) close() error {
+	return c.conn.Close()
+}
+
+type httpClient struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+}
+
+func newHTTPClient(conf *Config) (*httpClient, error) {
+	url := conf.Endpoint
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		scheme := "https://"
+		if conf.Insecure {
+			scheme = "http://"
+		}
+		url = scheme + url
+	}
+	if !strings.HasSuffix(url, httpLogsPath) {
+		url = strings.TrimSuffix(url, "/") + httpLogsPath
+	}
+	return &httpClient{
+		url:     url,
+		headers: conf.Headers,
+		client:  &http.Client{},
+	}, nil
+}
+
+func (c *httpClient) export(ctx context.Context, req *collogspb.ExportLogsServiceRequest) error {
+	body, err := proto.Marshal(req)
+	if err != nil {
+		return &permanentError{err: err}
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return &permanentError{err: err}
+	}
+	httpReq.Header.Set("Content-Type", "application/x-protobuf")
+	for k, v := range c.headers {
+		httpReq.Header.Set(k, v)
+	}
+	resp, err := c.client.Do(httpReq)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// drain the body so that the connection can be reused
+	io.Copy(io.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode == http.StatusTooManyRequests,
+		resp.StatusCode == http.StatusBadGateway,
+		resp.StatusCode == http.StatusServiceUnavailable,
+		resp.StatusCode == http.StatusGatewayTimeout:
+		return fmt.Errorf("OTLP collector returned %s", resp.Status)
+	default:
+		return &permanentError{err: fmt.Errorf("OTLP collector returned %s", resp.Status)}
+	}
+}
+
+func (c *httpClient) close() error {
+	c.client.CloseIdleConnections()
+	return nil
+}
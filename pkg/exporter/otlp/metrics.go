@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package otlp
+
+import (
+	"github.com/cilium/tetragon/pkg/metrics"
+	"github.com/cilium/tetragon/pkg/metrics/consts"
+)
+
+const (
+	dropReasonQueueFull = "queue_full"
+	dropReasonRejected  = "rejected"
+	dropReasonShutdown  = "shutdown"
+	dropReasonEncode    = "encode_error"
+)
+
+var (
+	dropReasonLabel = metrics.ConstrainedLabel{
+		Name:   "reason",
+		Values: []string{dropReasonEncode, dropReasonQueueFull, dropReasonRejected, dropReasonShutdown},
+	}
+
+	recordsExported = metrics.MustNewCounter(metrics.NewOpts(
+		consts.MetricsNamespace, "", "export_otlp_log_records_total",
+		"Number of log records successfully exported to the OTLP collector",
+		nil, nil, nil,
+	), nil)
+
+	recordsDropped = metrics.MustNewCounter(metrics.NewOpts(
+		consts.MetricsNamespace, "", "export_otlp_log_records_dropped_total",
+		"Number of log records dropped by the OTLP exporter",
+		nil, []metrics.ConstrainedLabel{dropReasonLabel}, nil,
+	), nil)
+
+	requestsFailed = metrics.MustNewCounter(metrics.NewOpts(
+		consts.MetricsNamespace, "", "export_otlp_requests_failed_total",
+		"Number of failed export requests to the OTLP collector, including retried ones",
+		nil, nil, nil,
+	), nil)
+)
+
+func RegisterMetrics(group metrics.Group) {
+	group.MustRegister(
+		recordsExported,
+		recordsDropped,
+		requestsFailed,
+	)
+}
@@ -0,0 +1,312 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+// Package otlp implements an exporter encoder that sends events as
+// OpenTelemetry log records to an OTLP collector, either over gRPC or over
+// HTTP (protobuf payloads).
+//
+// The encoder is meant to be used with exporter.NewExporter, so that the
+// export filters, field filters, aggregation and rate limiting configured
+// for the exporter apply to it exactly as they do to the JSON file exporter.
+package otlp
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/encoder"
+	"github.com/cilium/tetragon/pkg/logger"
+	"github.com/cilium/tetragon/pkg/logger/logfields"
+	"github.com/cilium/tetragon/pkg/reader/node"
+	"github.com/cilium/tetragon/pkg/version"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+)
+
+const (
+	ProtocolGRPC = "grpc"
+	ProtocolHTTP = "http"
+
+	scopeName = "github.com/cilium/tetragon"
+)
+
+// Config is the configuration of the OTLP logs encoder.
+type Config struct {
+	// Endpoint of the collector. For gRPC this is a host:port target. For
+	// HTTP it can be either a host:port or a URL; the /v1/logs path is
+	// appended if missing.
+	Endpoint string
+	// Protocol is either ProtocolGRPC or ProtocolHTTP.
+	Protocol string
+	// Insecure disables TLS.
+	Insecure bool
+	// Headers are sent with every export request (e.g., for authentication).
+	Headers map[string]string
+
+	// BatchSize is the maximum number of log records per export request.
+	BatchSize int
+	// FlushInterval is the maximum time a record waits before being sent.
+	FlushInterval time.Duration
+	// QueueSize is the number of records buffered while a batch is being
+	// sent or retried. Records are dropped when the queue is full.
+	QueueSize int
+	// ExportTimeout is the timeout of a single export request.
+	ExportTimeout time.Duration
+	// RetryInitialInterval and RetryMaxInterval configure the exponential
+	// backoff used when the collector is unavailable.
+	RetryInitialInterval time.Duration
+	RetryMaxInterval     time.Duration
+}
+
+// DefaultConfig returns a configuration with default values for everything
+// but the endpoint.
+func DefaultConfig() Config {
+	return Config{
+		Protocol:             ProtocolGRPC,
+		BatchSize:            512,
+		FlushInterval:        time.Second,
+		QueueSize:            10000,
+		ExportTimeout:        10 * time.Second,
+		RetryInitialInterval: time.Second,
+		RetryMaxInterval:     30 * time.Second,
+	}
+}
+
+func (c *Config) validate() error {
+	if c.Endpoint == "" {
+		return errors.New("OTLP endpoint is empty")
+	}
+	if c.BatchSize <= 0 {
+		return errors.New("OTLP batch size must be positive")
+	}
+	if c.QueueSize < 0 {
+		return errors.New("OTLP queue size must not be negative")
+	}
+	if c.FlushInterval <= 0 || c.ExportTimeout <= 0 {
+		return errors.New("OTLP flush interval and export timeout must be positive")
+	}
+	if c.RetryInitialInterval <= 0 || c.RetryMaxInterval < c.RetryInitialInterval {
+		return errors.New("invalid OTLP retry intervals")
+	}
+	return nil
+}
+
+// Encoder implements encoder.EventEncoder and io.Closer. Encode converts an
+// event to a log record and queues it. A background goroutine batches queued
+// records and sends them to the collector, retrying with exponential backoff
+// while the collector is unavailable. With SetSyncDelivery, Encode sends the
+// record itself instead.
+type Encoder struct {
+	conf     Config
+	client   client
+	resource *resourcepb.Resource
+	scope    *commonpb.InstrumentationScope
+
+	// sync is set if Encode waits for the export of each record
+	sync bool
+
+	records   chan *logspb.LogRecord
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+var _ encoder.EventEncoder = (*Encoder)(nil)
+
+// NewEncoder creates a new OTLP logs encoder and starts its sender goroutine.
+func NewEncoder(conf Config) (*Encoder, error) {
+	if err := conf.validate(); err != nil {
+		return nil, err
+	}
+	c, err := newClient(&conf)
+	if err != nil {
+		return nil, err
+	}
+	return newEncoder(conf, c), nil
+}
+
+func newEncoder(conf Config, c client) *Encoder {
+	e := &Encoder{
+		conf:   conf,
+		client: c,
+		resource: &resourcepb.Resource{
+			Attributes: []*commonpb.KeyValue{
+				{Key: "service.name", Value: stringValue("tetragon")},
+				{Key: "service.version", Value: stringValue(version.Version)},
+				{Key: "host.name", Value: stringValue(node.GetNodeNameForExport())},
+			},
+		},
+		scope: &commonpb.InstrumentationScope{
+			Name:    scopeName,
+			Version: version.Version,
+		},
+		records: make(chan *logspb.LogRecord, conf.QueueSize),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	go e.run()
+	return e
+}
+
+// Encode implements encoder.EventEncoder.
+func (e *Encoder) Encode(v interface{}) error {
+	event, ok := v.(*tetragon.GetEventsResponse)
+	if !ok {
+		return encoder.ErrInvalidEvent
+	}
+	rec, err := NewLogRecord(event, uint64(time.Now().UnixNano()))
+	if err != nil {
+		recordsDropped.WithLabelValues(dropReasonEncode).Inc()
+		return err
+	}
+	if e.sync {
+		return e.exportSync(rec)
+	}
+
+	select {
+	case <-e.stop:
+		recordsDropped.WithLabelValues(dropReasonShutdown).Inc()
+	case e.records <- rec:
+	default:
+		recordsDropped.WithLabelValues(dropReasonQueueFull).Inc()
+	}
+	return nil
+}
+
+// SetSyncDelivery makes Encode send each record in its own export request and
+// wait for the collector to accept it, instead of queueing it. Transient
+// export errors are returned as encoder.TransientError, so that the exporter
+// retries the event. It is used when events are read from the event spool, so
+// that the spool checkpoint only advances past exported events.
+func (e *Encoder) SetSyncDelivery() {
+	e.sync = true
+}
+
+// exportSync sends a single record to the collector.
+func (e *Encoder) exportSync(rec *logspb.LogRecord) error {
+	ctx, cancel := context.WithTimeout(context.Background(), e.conf.ExportTimeout)
+	defer cancel()
+	err := e.client.export(ctx, e.newRequest([]*logspb.LogRecord{rec}))
+	if err == nil {
+		recordsExported.WithLabelValues().Inc()
+		return nil
+	}
+	requestsFailed.WithLabelValues().Inc()
+	if isPermanent(err) {
+		logger.GetLogger().Warn("OTLP collector rejected log record, dropping it", logfields.Error, err)
+		recordsDropped.WithLabelValues(dropReasonRejected).Inc()
+		return nil
+	}
+	return encoder.NewTransientError(err)
+}
+
+// Close flushes the queued records (with a single attempt, bounded by the
+// export timeout) and closes the connection to the collector.
+func (e *Encoder) Close() error {
+	e.closeOnce.Do(func() {
+		close(e.stop)
+		<-e.done
+	})
+	return e.client.close()
+}
+
+func (e *Encoder) run() {
+	defer close(e.done)
+
+	batch := make([]*logspb.LogRecord, 0, e.conf.BatchSize)
+	ticker := time.NewTicker(e.conf.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case rec := <-e.records:
+			batch = append(batch, rec)
+			if len(batch) >= e.conf.BatchSize {
+				e.send(batch, true)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			if len(batch) > 0 {
+				e.send(batch, true)
+				batch = batch[:0]
+			}
+		case <-e.stop:
+			e.drain(batch)
+			return
+		}
+	}
+}
+
+// drain sends everything that is still queued, without retrying.
+func (e *Encoder) drain(batch []*logspb.LogRecord) {
+	for {
+		select {
+		case rec := <-e.records:
+			batch = append(batch, rec)
+			if len(batch) >= e.conf.BatchSize {
+				e.send(batch, false)
+				batch = batch[:0]
+			}
+		default:
+			if len(batch) > 0 {
+				e.send(batch, false)
+			}
+			return
+		}
+	}
+}
+
+func (e *Encoder) newRequest(batch []*logspb.LogRecord) *collogspb.ExportLogsServiceRequest {
+	return &collogspb.ExportLogsServiceRequest{
+		ResourceLogs: []*logspb.ResourceLogs{{
+			Resource: e.resource,
+			ScopeLogs: []*logspb.ScopeLogs{{
+				Scope:      e.scope,
+				LogRecords: batch,
+			}},
+		}},
+	}
+}
+
+// send exports a batch. If retry is true, transient errors are retried until
+// the export succeeds or the encoder is closed.
+func (e *Encoder) send(batch []*logspb.LogRecord, retry bool) {
+	req := e.newRequest(batch)
+	backoff := e.conf.RetryInitialInterval
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), e.conf.ExportTimeout)
+		err := e.client.export(ctx, req)
+		cancel()
+		if err == nil {
+			recordsExported.WithLabelValues().Add(float64(len(batch)))
+			return
+		}
+		requestsFailed.WithLabelValues().Inc()
+
+		if isPermanent(err) {
+			logger.GetLogger().Warn("OTLP collector rejected log records, dropping them",
+				"records", len(batch), logfields.Error, err)
+			recordsDropped.WithLabelValues(dropReasonRejected).Add(float64(len(batch)))
+			return
+		}
+		if !retry {
+			recordsDropped.WithLabelValues(dropReasonShutdown).Add(float64(len(batch)))
+			return
+		}
+
+		logger.GetLogger().Info("Failed to export log records to OTLP collector, retrying",
+			"records", len(batch), "backoff", backoff, logfields.Error, err)
+		select {
+		case <-time.After(backoff):
+		case <-e.stop:
+			// one last attempt, without retries, before we give up
+			retry = false
+		}
+		backoff = min(2*backoff, e.conf.RetryMaxInterval)
+	}
+}
@@ -0,0 +1,268 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package otlp
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/encoder"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	collogspb "go.opentelemetry.io/proto/otlp/collector/logs/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func kprobeEvent(binary string) *tetragon.GetEventsResponse {
+	return &tetragon.GetEventsResponse{
+		Event: &tetragon.GetEventsResponse_ProcessKprobe{
+			ProcessKprobe: &tetragon.ProcessKprobe{
+				Process: &tetragon.Process{
+					ExecId: "exec-id",
+					Pid:    wrapperspb.UInt32(42),
+					Binary: binary,
+					Pod: &tetragon.Pod{
+						Namespace: "default",
+						Name:      "pod",
+						PodLabels: map[string]string{"app": "test"},
+						Container: &tetragon.Container{Id: "cid", Name: "c", Image: &tetragon.Image{Name: "img"}},
+					},
+				},
+				Parent:       &tetragon.Process{Pid: wrapperspb.UInt32(1)},
+				FunctionName: "security_file_open",
+				PolicyName:   "file-monitoring",
+				Action:       tetragon.KprobeAction_KPROBE_ACTION_POST,
+				Args: []*tetragon.KprobeArgument{
+					{Arg: &tetragon.KprobeArgument_FileArg{FileArg: &tetragon.KprobeFile{Path: "/etc/passwd"}}},
+				},
+			},
+		},
+		NodeName: "node",
+		Time:     timestamppb.New(time.Unix(1, 2)),
+	}
+}
+
+func stringAttrs(rec *logspb.LogRecord) map[string]string {
+	ret := map[string]string{}
+	for _, kv := range rec.Attributes {
+		if v, ok := kv.Value.Value.(*commonpb.AnyValue_StringValue); ok {
+			ret[kv.Key] = v.StringValue
+		}
+	}
+	return ret
+}
+
+func TestNewLogRecord(t *testing.T) {
+	rec, err := NewLogRecord(kprobeEvent("/bin/cat"), 10)
+	require.NoError(t, err)
+
+	assert.Equal(t, uint64(1_000_000_002), rec.TimeUnixNano)
+	assert.Equal(t, uint64(10), rec.ObservedTimeUnixNano)
+	assert.Equal(t, "tetragon.process_kprobe", rec.EventName)
+	assert.Contains(t, rec.Body.GetStringValue(), `"policy_name":"file-monitoring"`)
+
+	ints := map[string]int64{}
+	var args []string
+	for _, kv := range rec.Attributes {
+		switch v := kv.Value.Value.(type) {
+		case *commonpb.AnyValue_ArrayValue:
+			for _, arg := range v.ArrayValue.Values {
+				args = append(args, arg.GetStringValue())
+			}
+		case *commonpb.AnyValue_IntValue:
+			ints[kv.Key] = v.IntValue
+		}
+	}
+	assert.Equal(t, map[string]string{
+		AttrEventType:           "process_kprobe",
+		AttrNodeName:            "node",
+		AttrPolicyName:          "file-monitoring",
+		AttrFunctionName:        "security_file_open",
+		AttrAction:              "KPROBE_ACTION_POST",
+		AttrExecID:              "exec-id",
+		AttrProcessExecutable:   "/bin/cat",
+		AttrK8sNamespace:        "default",
+		AttrK8sPod:              "pod",
+		AttrK8sPodLabel + "app": "test",
+		AttrK8sContainer:        "c",
+		AttrContainerID:         "cid",
+		AttrContainerImg:        "img",
+	}, stringAttrs(rec))
+	assert.Equal(t, map[string]int64{AttrProcessPID: 42, AttrProcessParentPID: 1}, ints)
+	assert.Equal(t, []string{`{"file_arg":{"path":"/etc/passwd"}}`}, args)
+}
+
+func testConfig(endpoint, protocol string) Config {
+	conf := DefaultConfig()
+	conf.Endpoint = endpoint
+	conf.Protocol = protocol
+	conf.Insecure = true
+	conf.BatchSize = 2
+	conf.FlushInterval = 10 * time.Millisecond
+	conf.RetryInitialInterval = 10 * time.Millisecond
+	conf.RetryMaxInterval = 20 * time.Millisecond
+	return conf
+}
+
+// fakeCollector is a stand-in for an OTLP collector. It fails the first
+// failures requests with the given code.
+type fakeCollector struct {
+	collogspb.UnimplementedLogsServiceServer
+	mu       sync.Mutex
+	failures int
+	code     codes.Code
+	binaries []string
+}
+
+func (c *fakeCollector) record(req *collogspb.ExportLogsServiceRequest) {
+	for _, rl := range req.ResourceLogs {
+		for _, sl := range rl.ScopeLogs {
+			for _, rec := range sl.LogRecords {
+				c.binaries = append(c.binaries, stringAttrs(rec)[AttrProcessExecutable])
+			}
+		}
+	}
+}
+
+func (c *fakeCollector) Export(_ context.Context, req *collogspb.ExportLogsServiceRequest) (*collogspb.ExportLogsServiceResponse, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.failures > 0 {
+		c.failures--
+		return nil, status.Error(c.code, "fake failure")
+	}
+	c.record(req)
+	return &collogspb.ExportLogsServiceResponse{}, nil
+}
+
+func (c *fakeCollector) received() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.binaries...)
+}
+
+func startGRPCCollector(t *testing.T, c *fakeCollector) string {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	srv := grpc.NewServer()
+	collogspb.RegisterLogsServiceServer(srv, c)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+	return lis.Addr().String()
+}
+
+func TestEncoderGRPC(t *testing.T) {
+	collector := &fakeCollector{failures: 2, code: codes.Unavailable}
+	addr := startGRPCCollector(t, collector)
+
+	enc, err := NewEncoder(testConfig(addr, ProtocolGRPC))
+	require.NoError(t, err)
+	for _, b := range []string{"a", "b", "c"} {
+		require.NoError(t, enc.Encode(kprobeEvent(b)))
+	}
+	require.Eventually(t, func() bool {
+		return len(collector.received()) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, enc.Close())
+	assert.Equal(t, []string{"a", "b", "c"}, collector.received())
+}
+
+func TestEncoderGRPCPermanentError(t *testing.T) {
+	collector := &fakeCollector{failures: 1, code: codes.InvalidArgument}
+	addr := startGRPCCollector(t, collector)
+
+	conf := testConfig(addr, ProtocolGRPC)
+	// only flush on full batches and on close
+	conf.FlushInterval = time.Hour
+	enc, err := NewEncoder(conf)
+	require.NoError(t, err)
+	// the first batch is rejected and must not be retried
+	require.NoError(t, enc.Encode(kprobeEvent("a")))
+	require.NoError(t, enc.Encode(kprobeEvent("b")))
+	require.NoError(t, enc.Encode(kprobeEvent("c")))
+	require.NoError(t, enc.Close())
+	assert.Equal(t, []string{"c"}, collector.received())
+}
+
+func TestEncoderSync(t *testing.T) {
+	collector := &fakeCollector{failures: 1, code: codes.Unavailable}
+	addr := startGRPCCollector(t, collector)
+
+	enc, err := NewEncoder(testConfig(addr, ProtocolGRPC))
+	require.NoError(t, err)
+	enc.SetSyncDelivery()
+	// transient errors are returned for the exporter to retry the event
+	err = enc.Encode(kprobeEvent("a"))
+	require.Error(t, err)
+	assert.True(t, encoder.IsTransient(err))
+	require.NoError(t, enc.Encode(kprobeEvent("a")))
+	assert.Equal(t, []string{"a"}, collector.received())
+
+	// rejected records are dropped
+	collector.mu.Lock()
+	collector.failures, collector.code = 1, codes.InvalidArgument
+	collector.mu.Unlock()
+	require.NoError(t, enc.Encode(kprobeEvent("b")))
+	require.NoError(t, enc.Encode(kprobeEvent("c")))
+	require.NoError(t, enc.Close())
+	assert.Equal(t, []string{"a", "c"}, collector.received())
+}
+
+func TestEncoderHTTP(t *testing.T) {
+	collector := &fakeCollector{failures: 1}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, httpLogsPath, r.URL.Path)
+		assert.Equal(t, "application/x-protobuf", r.Header.Get("Content-Type"))
+		assert.Equal(t, "secret", r.Header.Get("Authorization"))
+		collector.mu.Lock()
+		defer collector.mu.Unlock()
+		if collector.failures > 0 {
+			collector.failures--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		req := &collogspb.ExportLogsServiceRequest{}
+		require.NoError(t, proto.Unmarshal(body, req))
+		collector.record(req)
+	}))
+	defer srv.Close()
+
+	conf := testConfig(srv.URL, ProtocolHTTP)
+	conf.Headers = map[string]string{"Authorization": "secret"}
+	enc, err := NewEncoder(conf)
+	require.NoError(t, err)
+	for _, b := range []string{"a", "b", "c"} {
+		require.NoError(t, enc.Encode(kprobeEvent(b)))
+	}
+	require.Eventually(t, func() bool {
+		return len(collector.received()) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, enc.Close())
+	assert.Equal(t, []string{"a", "b", "c"}, collector.received())
+}
+
+func TestEncoderInvalidConfig(t *testing.T) {
+	_, err := NewEncoder(DefaultConfig())
+	require.Error(t, err)
+
+	conf := testConfig("localhost:4317", "thrift")
+	_, err = NewEncoder(conf)
+	require.Error(t, err)
+}
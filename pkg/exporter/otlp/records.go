@@ -0,0 +1,233 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package otlp
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/api/v1/tetragon/codegen/helpers"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	logspb "go.opentelemetry.io/proto/otlp/logs/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Attribute keys used for log records. Where an OpenTelemetry semantic
+// convention exists, we use it. Tetragon specific fields are namespaced under
+// "tetragon.".
+const (
+	AttrEventType    = "tetragon.event_type"
+	AttrNodeName     = "tetragon.node_name"
+	AttrClusterName  = "tetragon.cluster_name"
+	AttrPolicyName   = "tetragon.policy_name"
+	AttrFunctionName = "tetragon.function_name"
+	AttrAction       = "tetragon.action"
+	AttrArgs         = "tetragon.args"
+	AttrReturn       = "tetragon.return"
+	AttrTags         = "tetragon.tags"
+	AttrExecID       = "tetragon.process.exec_id"
+	AttrParentExecID = "tetragon.process.parent_exec_id"
+	AttrInInitTree   = "tetragon.process.in_init_tree"
+
+	AttrProcessPID        = "process.pid"
+	AttrProcessExecutable = "process.executable.path"
+	AttrProcessArgs       = "process.command_args"
+	AttrProcessCwd        = "process.working_directory"
+	AttrProcessUID        = "process.user.id"
+	AttrProcessParentPID  = "process.parent_pid"
+
+	AttrK8sNamespace  = "k8s.namespace.name"
+	AttrK8sPod        = "k8s.pod.name"
+	AttrK8sContainer  = "k8s.container.name"
+	AttrK8sWorkload   = "tetragon.k8s.workload.name"
+	AttrK8sWorkloadKd = "tetragon.k8s.workload.kind"
+	AttrK8sPodLabel   = "k8s.pod.label."
+	AttrContainerID   = "container.id"
+	AttrContainerImg  = "container.image.name"
+)
+
+var bodyMarshaler = protojson.MarshalOptions{UseProtoNames: true}
+
+// policyEvent is implemented by all events generated from tracing policies.
+type policyEvent interface {
+	GetPolicyName() string
+	GetArgs() []*tetragon.KprobeArgument
+	GetTags() []string
+}
+
+// innerEvent returns the message that is set in the event oneof of the given
+// response, or nil if none is set.
+func innerEvent(ev *tetragon.GetEventsResponse) proto.Message {
+	m := ev.ProtoReflect()
+	od := m.Descriptor().Oneofs().ByName("event")
+	if od == nil {
+		return nil
+	}
+	fd := m.WhichOneof(od)
+	if fd == nil || fd.Kind() != protoreflect.MessageKind {
+		return nil
+	}
+	return m.Get(fd).Message().Interface()
+}
+
+// NewLogRecord converts a GetEventsResponse into an OpenTelemetry log record.
+// The body of the record is the protojson encoding of the event, i.e., the
+// same document the JSON exporter writes, while the most commonly queried
+// fields are additionally mapped onto record attributes.
+func NewLogRecord(ev *tetragon.GetEventsResponse, observedUnixNano uint64) (*logspb.LogRecord, error) {
+	body, err := bodyMarshaler.Marshal(ev)
+	if err != nil {
+		return nil, err
+	}
+
+	rec := &logspb.LogRecord{
+		ObservedTimeUnixNano: observedUnixNano,
+		SeverityNumber:       logspb.SeverityNumber_SEVERITY_NUMBER_INFO,
+		SeverityText:         "INFO",
+		Body:                 stringValue(string(body)),
+	}
+	if t := ev.GetTime(); t != nil {
+		rec.TimeUnixNano = uint64(t.AsTime().UnixNano())
+	}
+
+	attrs := attributes{}
+	if typ, err := helpers.ResponseTypeString(ev); err == nil {
+		// use the same name as the JSON export, e.g. process_kprobe
+		typ = strings.ToLower(typ)
+		attrs.str(AttrEventType, typ)
+		rec.EventName = "tetragon." + typ
+	}
+	attrs.str(AttrNodeName, ev.GetNodeName())
+	attrs.str(AttrClusterName, ev.GetClusterName())
+
+	process := helpers.ResponseGetProcess(ev)
+	attrs.process(process)
+	if parent := helpers.ResponseGetParent(ev); parent.GetPid() != nil {
+		attrs.int(AttrProcessParentPID, int64(parent.GetPid().GetValue()))
+	}
+
+	if inner := innerEvent(ev); inner != nil {
+		if pe, ok := inner.(policyEvent); ok {
+			attrs.str(AttrPolicyName, pe.GetPolicyName())
+			attrs.args(AttrArgs, pe.GetArgs())
+			attrs.strs(AttrTags, pe.GetTags())
+		}
+		if fn, ok := inner.(interface{ GetFunctionName() string }); ok {
+			attrs.str(AttrFunctionName, fn.GetFunctionName())
+		}
+		if act, ok := inner.(interface{ GetAction() tetragon.KprobeAction }); ok {
+			if act.GetAction() != tetragon.KprobeAction_KPROBE_ACTION_UNKNOWN {
+				attrs.str(AttrAction, act.GetAction().String())
+			}
+		}
+		if ret, ok := inner.(interface {
+			GetReturn() *tetragon.KprobeArgument
+		}); ok && ret.GetReturn() != nil {
+			attrs.args(AttrReturn, []*tetragon.KprobeArgument{ret.GetReturn()})
+		}
+	}
+
+	rec.Attributes = attrs
+	return rec, nil
+}
+
+type attributes []*commonpb.KeyValue
+
+func stringValue(s string) *commonpb.AnyValue {
+	return &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: s}}
+}
+
+func (a *attributes) str(key, val string) {
+	if val == "" {
+		return
+	}
+	*a = append(*a, &commonpb.KeyValue{Key: key, Value: stringValue(val)})
+}
+
+func (a *attributes) int(key string, val int64) {
+	*a = append(*a, &commonpb.KeyValue{
+		Key:   key,
+		Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: val}},
+	})
+}
+
+func (a *attributes) bool(key string, val bool) {
+	*a = append(*a, &commonpb.KeyValue{
+		Key:   key,
+		Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_BoolValue{BoolValue: val}},
+	})
+}
+
+func (a *attributes) strs(key string, vals []string) {
+	if len(vals) == 0 {
+		return
+	}
+	values := make([]*commonpb.AnyValue, 0, len(vals))
+	for _, v := range vals {
+		values = append(values, stringValue(v))
+	}
+	*a = append(*a, &commonpb.KeyValue{
+		Key:   key,
+		Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_ArrayValue{ArrayValue: &commonpb.ArrayValue{Values: values}}},
+	})
+}
+
+// args maps kprobe arguments to an array attribute. Each argument is encoded
+// as its protojson representation so that it keeps its type information.
+func (a *attributes) args(key string, args []*tetragon.KprobeArgument) {
+	if len(args) == 0 {
+		return
+	}
+	vals := make([]string, 0, len(args))
+	for _, arg := range args {
+		b, err := bodyMarshaler.Marshal(arg)
+		if err != nil {
+			continue
+		}
+		vals = append(vals, string(b))
+	}
+	a.strs(key, vals)
+}
+
+func (a *attributes) process(p *tetragon.Process) {
+	if p == nil {
+		return
+	}
+	a.str(AttrExecID, p.GetExecId())
+	a.str(AttrParentExecID, p.GetParentExecId())
+	if p.GetPid() != nil {
+		a.int(AttrProcessPID, int64(p.GetPid().GetValue()))
+	}
+	if p.GetUid() != nil {
+		a.int(AttrProcessUID, int64(p.GetUid().GetValue()))
+	}
+	a.str(AttrProcessExecutable, p.GetBinary())
+	a.str(AttrProcessArgs, p.GetArguments())
+	a.str(AttrProcessCwd, p.GetCwd())
+	if p.GetInInitTree() != nil {
+		a.bool(AttrInInitTree, p.GetInInitTree().GetValue())
+	}
+
+	pod := p.GetPod()
+	if pod == nil {
+		return
+	}
+	a.str(AttrK8sNamespace, pod.GetNamespace())
+	a.str(AttrK8sPod, pod.GetName())
+	a.str(AttrK8sWorkload, pod.GetWorkload())
+	a.str(AttrK8sWorkloadKd, pod.GetWorkloadKind())
+	labels := pod.GetPodLabels()
+	for _, k := range slices.Sorted(maps.Keys(labels)) {
+		a.str(AttrK8sPodLabel+k, labels[k])
+	}
+	if c := pod.GetContainer(); c != nil {
+		a.str(AttrK8sContainer, c.GetName())
+		a.str(AttrContainerID, c.GetId())
+		a.str(AttrContainerImg, c.GetImage().GetName())
+	}
+}
@@ -0,0 +1,360 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package exporter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"time"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/defaults"
+	"github.com/cilium/tetragon/pkg/encoder"
+	"github.com/cilium/tetragon/pkg/exporter/otlp"
+	"github.com/cilium/tetragon/pkg/filters"
+	"github.com/cilium/tetragon/pkg/logger"
+	"github.com/cilium/tetragon/pkg/ratelimit"
+	"github.com/cilium/tetragon/pkg/server"
+	"sigs.k8s.io/yaml"
+)
+
+// Pipeline is a named export pipeline: a GetEventsRequest (filters, field
+// filters and aggregation options), an optional rate limit, and a sink.
+// Exactly one of File, UnixSocket, OTLP, Kafka and NATS is set.
+type Pipeline struct {
+	Name    string
+	Request *tetragon.GetEventsRequest
+	// RateLimit is the maximum number of events exported per minute. A
+	// negative value disables rate limiting.
+	RateLimit int
+
+	File       *FileConfig
+	UnixSocket *UnixSocketConfig
+	OTLP       *otlp.Config
+	Kafka      *KafkaConfig
+	NATS       *NATSConfig
+}
+
+// duration is a time.Duration that is configured as a string (e.g. "1h").
+type duration time.Duration
+
+func (d *duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("invalid duration %s: %w", string(b), err)
+	}
+	v, err := time.ParseDuration(s)
+	if err != nil {
+		return err
+	}
+	*d = duration(v)
+	return nil
+}
+
+type pipelineSpec struct {
+	Name      string          `json:"name"`
+	RateLimit *int            `json:"rateLimit"`
+	Request   json.RawMessage `json:"request"`
+
+	File       *fileSpec       `json:"file"`
+	UnixSocket *unixSocketSpec `json:"unixSocket"`
+	OTLP       *otlpSpec       `json:"otlp"`
+	Kafka      *kafkaSpec      `json:"kafka"`
+	NATS       *natsSpec       `json:"nats"`
+}
+
+type fileSpec struct {
+	Filename         string   `json:"filename"`
+	MaxSizeMB        *int     `json:"maxSizeMB"`
+	MaxBackups       *int     `json:"maxBackups"`
+	Compress         bool     `json:"compress"`
+	RotationInterval duration `json:"rotationInterval"`
+	Perm             string   `json:"perm"`
+	Format           string   `json:"format"`
+	Compression      string   `json:"compression"`
+}
+
+type unixSocketSpec struct {
+	Path         string   `json:"path"`
+	Perm         string   `json:"perm"`
+	WriteTimeout duration `json:"writeTimeout"`
+}
+
+type otlpSpec struct {
+	Endpoint      string            `json:"endpoint"`
+	Protocol      string            `json:"protocol"`
+	Insecure      bool              `json:"insecure"`
+	Headers       map[string]string `json:"headers"`
+	BatchSize     int               `json:"batchSize"`
+	FlushInterval duration          `json:"flushInterval"`
+	QueueSize     *int              `json:"queueSize"`
+}
+
+type kafkaSpec struct {
+	Brokers          []string `json:"brokers"`
+	Topic            string   `json:"topic"`
+	Format           string   `json:"format"`
+	Timeout          duration `json:"timeout"`
+	AutoCreateTopics bool     `json:"autoCreateTopics"`
+}
+
+type natsSpec struct {
+	Servers []string `json:"servers"`
+	Subject string   `json:"subject"`
+	Format  string   `json:"format"`
+	Timeout duration `json:"timeout"`
+}
+
+var pipelineNameRe = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// ParsePipelines parses a YAML list of export pipelines. For example:
+//
+//	# all events to a file, kprobe events without arguments to a unix socket
+//	- name: audit
+//	  file:
+//	    filename: /var/log/tetragon/audit.log
+//	- name: kprobes
+//	  rateLimit: 1000
+//	  request:
+//	    allow_list:
+//	    - event_set: [PROCESS_KPROBE]
+//	    field_filters:
+//	    - fields: args
+//	      action: EXCLUDE
+//	  unixSocket:
+//	    path: /var/run/tetragon/kprobes.sock
+//	- name: bus
+//	  kafka:
+//	    brokers: [kafka:9092]
+//	    topic: tetragon-{event_type}
+//	    format: protobuf
+//
+// The request uses the same representation as the GetEvents gRPC API.
+func ParsePipelines(data []byte, enablePidSetFilters bool) ([]*Pipeline, error) {
+	var specs []pipelineSpec
+	if err := yaml.UnmarshalStrict(data, &specs); err != nil {
+		return nil, fmt.Errorf("failed to parse export pipelines: %w", err)
+	}
+
+	names := map[string]struct{}{}
+	ret := make([]*Pipeline, 0, len(specs))
+	for i := range specs {
+		p, err := specs[i].toPipeline(enablePidSetFilters)
+		if err != nil {
+			return nil, fmt.Errorf("export pipeline %d (%q): %w", i, specs[i].Name, err)
+		}
+		if _, ok := names[p.Name]; ok {
+			return nil, fmt.Errorf("export pipeline %d: duplicate name %q", i, p.Name)
+		}
+		names[p.Name] = struct{}{}
+		ret = append(ret, p)
+	}
+	return ret, nil
+}
+
+func (s *pipelineSpec) toPipeline(enablePidSetFilters bool) (*Pipeline, error) {
+	if !pipelineNameRe.MatchString(s.Name) {
+		return nil, errors.New("name must be non-empty and contain only letters, digits, '_' and '-'")
+	}
+
+	p := &Pipeline{
+		Name:      s.Name,
+		Request:   &tetragon.GetEventsRequest{},
+		RateLimit: -1,
+	}
+	if s.RateLimit != nil {
+		p.RateLimit = *s.RateLimit
+	}
+	if len(s.Request) > 0 {
+		req, err := filters.ParseGetEventsRequest(s.Request, enablePidSetFilters)
+		if err != nil {
+			return nil, err
+		}
+		p.Request = req
+	}
+
+	sinks := 0
+	if s.File != nil {
+		sinks++
+		if s.File.Filename == "" {
+			return nil, errors.New("file sink requires a filename")
+		}
+		p.File = &FileConfig{
+			Filename:         s.File.Filename,
+			MaxSizeMB:        10,
+			MaxBackups:       5,
+			Compress:         s.File.Compress,
+			RotationInterval: time.Duration(s.File.RotationInterval),
+			Perm:             defaults.DefaultLogsPermission,
+			Format:           s.File.Format,
+			Compression:      s.File.Compression,
+		}
+		if s.File.MaxSizeMB != nil {
+			p.File.MaxSizeMB = *s.File.MaxSizeMB
+		}
+		if s.File.MaxBackups != nil {
+			p.File.MaxBackups = *s.File.MaxBackups
+		}
+		if s.File.Perm != "" {
+			p.File.Perm = s.File.Perm
+		}
+		if err := p.File.validate(); err != nil {
+			return nil, err
+		}
+	}
+	if s.UnixSocket != nil {
+		sinks++
+		if s.UnixSocket.Path == "" {
+			return nil, errors.New("unix socket sink requires a path")
+		}
+		p.UnixSocket = &UnixSocketConfig{
+			Path:         s.UnixSocket.Path,
+			Perm:         s.UnixSocket.Perm,
+			WriteTimeout: time.Duration(s.UnixSocket.WriteTimeout),
+		}
+	}
+	if s.OTLP != nil {
+		sinks++
+		conf := otlp.DefaultConfig()
+		conf.Endpoint = s.OTLP.Endpoint
+		conf.Insecure = s.OTLP.Insecure
+		conf.Headers = s.OTLP.Headers
+		if s.OTLP.Protocol != "" {
+			conf.Protocol = s.OTLP.Protocol
+		}
+		if s.OTLP.BatchSize != 0 {
+			conf.BatchSize = s.OTLP.BatchSize
+		}
+		if s.OTLP.FlushInterval != 0 {
+			conf.FlushInterval = time.Duration(s.OTLP.FlushInterval)
+		}
+		if s.OTLP.QueueSize != nil {
+			conf.QueueSize = *s.OTLP.QueueSize
+		}
+		p.OTLP = &conf
+	}
+	if s.Kafka != nil {
+		sinks++
+		if len(s.Kafka.Brokers) == 0 {
+			return nil, errors.New("kafka sink requires brokers")
+		}
+		conf := DefaultKafkaConfig()
+		conf.Brokers = s.Kafka.Brokers
+		conf.AutoCreateTopics = s.Kafka.AutoCreateTopics
+		if s.Kafka.Topic != "" {
+			conf.Topic = s.Kafka.Topic
+		}
+		if s.Kafka.Format != "" {
+			conf.Format = s.Kafka.Format
+		}
+		if s.Kafka.Timeout != 0 {
+			conf.Timeout = time.Duration(s.Kafka.Timeout)
+		}
+		if err := validateMessageBus(conf.Topic, conf.Format); err != nil {
+			return nil, fmt.Errorf("kafka sink: %w", err)
+		}
+		p.Kafka = &conf
+	}
+	if s.NATS != nil {
+		sinks++
+		if len(s.NATS.Servers) == 0 {
+			return nil, errors.New("nats sink requires servers")
+		}
+		conf := DefaultNATSConfig()
+		conf.Servers = s.NATS.Servers
+		if s.NATS.Subject != "" {
+			conf.Subject = s.NATS.Subject
+		}
+		if s.NATS.Format != "" {
+			conf.Format = s.NATS.Format
+		}
+		if s.NATS.Timeout != 0 {
+			conf.Timeout = time.Duration(s.NATS.Timeout)
+		}
+		if err := validateMessageBus(conf.Subject, conf.Format); err != nil {
+			return nil, fmt.Errorf("nats sink: %w", err)
+		}
+		p.NATS = &conf
+	}
+	if sinks != 1 {
+		return nil, errors.New("exactly one of file, unixSocket, otlp, kafka and nats must be set")
+	}
+	return p, nil
+}
+
+func (p *Pipeline) sinkName() string {
+	switch {
+	case p.File != nil:
+		return "file"
+	case p.UnixSocket != nil:
+		return "unixSocket"
+	case p.OTLP != nil:
+		return "otlp"
+	case p.Kafka != nil:
+		return "kafka"
+	case p.NATS != nil:
+		return "nats"
+	}
+	return ""
+}
+
+// newSink creates the encoder and closer of the pipeline's sink.
+func (p *Pipeline) newSink(ctx context.Context) (ExportEncoder, io.Closer, error) {
+	switch {
+	case p.File != nil:
+		return NewFileEncoder(ctx, p.File)
+	case p.UnixSocket != nil:
+		writer, err := NewUnixSocketWriter(p.UnixSocket)
+		if err != nil {
+			return nil, nil, err
+		}
+		return encoder.NewProtojsonEncoder(NewExportedBytesTotalWriter(writer)), writer, nil
+	case p.OTLP != nil:
+		enc, err := otlp.NewEncoder(*p.OTLP)
+		if err != nil {
+			return nil, nil, err
+		}
+		return enc, enc, nil
+	case p.Kafka != nil:
+		enc, err := NewKafkaEncoder(p.Kafka)
+		if err != nil {
+			return nil, nil, err
+		}
+		return enc, enc, nil
+	case p.NATS != nil:
+		enc, err := NewNATSEncoder(p.NATS)
+		if err != nil {
+			return nil, nil, err
+		}
+		return enc, enc, nil
+	}
+	return nil, nil, errors.New("pipeline has no sink")
+}
+
+// StartPipeline starts exporting events from server to the pipeline's sink.
+func StartPipeline(ctx context.Context, server *server.Server, p *Pipeline) error {
+	enc, closer, err := p.newSink(ctx)
+	if err != nil {
+		return fmt.Errorf("export pipeline %q: failed to create %s sink: %w", p.Name, p.sinkName(), err)
+	}
+	var rateLimiter *ratelimit.RateLimiter
+	if p.RateLimit >= 0 {
+		rateLimiter = ratelimit.NewRateLimiter(ctx, 1*time.Minute, p.RateLimit, enc)
+	}
+	logger.GetLogger().Info("Starting export pipeline",
+		"pipeline", p.Name, "sink", p.sinkName(), "rateLimit", p.RateLimit, "request", p.Request)
+	exporter := NewPipelineExporter(ctx, p.Name, p.Request, server, enc, closer, rateLimiter)
+	if sp := server.Spool(); sp != nil {
+		exporter.WithSpool(sp, "pipeline-"+p.Name)
+	}
+	if err := exporter.Start(); err != nil {
+		closer.Close()
+		return fmt.Errorf("export pipeline %q: %w", p.Name, err)
+	}
+	return nil
+}
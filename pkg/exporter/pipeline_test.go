@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package exporter
+
+import (
+	"bufio"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/defaults"
+	"github.com/cilium/tetragon/pkg/exporter/otlp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePipelines(t *testing.T) {
+	pipelines, err := ParsePipelines([]byte(`
+- name: audit
+  file:
+    filename: /var/log/tetragon/audit.log
+    rotationInterval: 1h
+- name: kprobes
+  rateLimit: 100
+  request:
+    allow_list:
+    - event_set: [PROCESS_KPROBE]
+    field_filters:
+    - fields: args
+      action: EXCLUDE
+  unixSocket:
+    path: /var/run/tetragon/kprobes.sock
+    writeTimeout: 500ms
+- name: collector
+  otlp:
+    endpoint: localhost:4317
+    insecure: true
+    batchSize: 10
+`), false)
+	require.NoError(t, err)
+	require.Len(t, pipelines, 3)
+
+	audit := pipelines[0]
+	assert.Equal(t, "audit", audit.Name)
+	assert.Equal(t, -1, audit.RateLimit)
+	assert.Empty(t, audit.Request.AllowList)
+	assert.Equal(t, &FileConfig{
+		Filename:         "/var/log/tetragon/audit.log",
+		MaxSizeMB:        10,
+		MaxBackups:       5,
+		RotationInterval: time.Hour,
+		Perm:             defaults.DefaultLogsPermission,
+	}, audit.File)
+	assert.Nil(t, audit.UnixSocket)
+	assert.Nil(t, audit.OTLP)
+
+	kprobes := pipelines[1]
+	assert.Equal(t, 100, kprobes.RateLimit)
+	require.Len(t, kprobes.Request.AllowList, 1)
+	assert.Equal(t, []tetragon.EventType{tetragon.EventType_PROCESS_KPROBE}, kprobes.Request.AllowList[0].EventSet)
+	require.Len(t, kprobes.Request.FieldFilters, 1)
+	assert.Equal(t, []string{"args"}, kprobes.Request.FieldFilters[0].Fields.Paths)
+	assert.Equal(t, &UnixSocketConfig{
+		Path:         "/var/run/tetragon/kprobes.sock",
+		WriteTimeout: 500 * time.Millisecond,
+	}, kprobes.UnixSocket)
+
+	collector := pipelines[2]
+	require.NotNil(t, collector.OTLP)
+	assert.Equal(t, "localhost:4317", collector.OTLP.Endpoint)
+	assert.Equal(t, otlp.ProtocolGRPC, collector.OTLP.Protocol)
+	assert.True(t, collector.OTLP.Insecure)
+	assert.Equal(t, 10, collector.OTLP.BatchSize)
+	assert.Equal(t, otlp.DefaultConfig().QueueSize, collector.OTLP.QueueSize)
+}
+
+func TestParsePipelinesErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+	}{
+		{"no name", `[{file: {filename: a.log}}]`},
+		{"invalid name", `[{name: "a b", file: {filename: a.log}}]`},
+		{"duplicate name", `[{name: a, file: {filename: a.log}}, {name: a, file: {filename: b.log}}]`},
+		{"no sink", `[{name: a}]`},
+		{"two sinks", `[{name: a, file: {filename: a.log}, unixSocket: {path: a.sock}}]`},
+		{"no filename", `[{name: a, file: {}}]`},
+		{"invalid file format", `[{name: a, file: {filename: a.log, format: avro}}]`},
+		{"zstd and gzip", `[{name: a, file: {filename: a.log, compress: true, compression: zstd}}]`},
+		{"unknown field", `[{name: a, file: {filename: a.log, foo: bar}}]`},
+		{"invalid duration", `[{name: a, file: {filename: a.log, rotationInterval: often}}]`},
+		{"invalid request", `[{name: a, request: {allow_list: [{foo: bar}]}, file: {filename: a.log}}]`},
+		{"pid set filter", `[{name: a, request: {allow_list: [{pid_set: [1]}]}, file: {filename: a.log}}]`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParsePipelines([]byte(tc.yaml), false)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestUnixSocketWriter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.sock")
+	w, err := NewUnixSocketWriter(&UnixSocketConfig{Path: path})
+	require.NoError(t, err)
+	defer w.Close()
+
+	conn, err := net.Dial("unix", path)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// wait for the writer to accept the client
+	require.Eventually(t, func() bool {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+		return len(w.clients) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	_, err = w.Write([]byte("{\"event\":1}\n"))
+	require.NoError(t, err)
+	line, err := bufio.NewReader(conn).ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, "{\"event\":1}\n", line)
+
+	require.NoError(t, w.Close())
+	_, err = net.Dial("unix", path)
+	require.Error(t, err)
+}
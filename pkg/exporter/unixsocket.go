@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package exporter
+
+import (
+	"errors"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/cilium/tetragon/pkg/fileutils"
+	"github.com/cilium/tetragon/pkg/logger"
+	"github.com/cilium/tetragon/pkg/logger/logfields"
+	"github.com/cilium/tetragon/pkg/unixlisten"
+)
+
+// UnixSocketConfig configures a unix socket export sink.
+type UnixSocketConfig struct {
+	Path string
+	// Perm is the mode of the socket file (e.g. "660")
+	Perm string
+	// WriteTimeout is the maximum time to write an event to a client.
+	// Clients that do not keep up are disconnected.
+	WriteTimeout time.Duration
+}
+
+const defaultUnixSocketWriteTimeout = time.Second
+
+// UnixSocketWriter is an io.WriteCloser that writes everything it receives to
+// all clients connected to a unix socket. Clients connecting later only
+// receive what is written after they connected, and clients that cannot keep
+// up are disconnected.
+type UnixSocketWriter struct {
+	listener     net.Listener
+	writeTimeout time.Duration
+
+	mu      sync.Mutex
+	clients map[net.Conn]struct{}
+	closed  bool
+}
+
+// NewUnixSocketWriter creates the unix socket and starts accepting clients.
+func NewUnixSocketWriter(conf *UnixSocketConfig) (*UnixSocketWriter, error) {
+	mode := os.FileMode(0600)
+	if conf.Perm != "" {
+		perms, err := fileutils.RegularFilePerms(conf.Perm)
+		if err != nil {
+			return nil, err
+		}
+		mode = perms
+	}
+	l, err := unixlisten.ListenWithRename(conf.Path, mode)
+	if err != nil {
+		return nil, err
+	}
+	w := &UnixSocketWriter{
+		listener:     l,
+		writeTimeout: conf.WriteTimeout,
+		clients:      map[net.Conn]struct{}{},
+	}
+	if w.writeTimeout <= 0 {
+		w.writeTimeout = defaultUnixSocketWriteTimeout
+	}
+	go w.accept()
+	return w, nil
+}
+
+func (w *UnixSocketWriter) accept() {
+	for {
+		conn, err := w.listener.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				logger.GetLogger().Warn("Export unix socket: accept failed", logfields.Error, err)
+			}
+			return
+		}
+		w.mu.Lock()
+		if w.closed {
+			conn.Close()
+		} else {
+			w.clients[conn] = struct{}{}
+		}
+		w.mu.Unlock()
+	}
+}
+
+// Write implements io.Writer. It never fails: errors are handled by
+// disconnecting the offending client.
+func (w *UnixSocketWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for conn := range w.clients {
+		conn.SetWriteDeadline(time.Now().Add(w.writeTimeout))
+		if _, err := conn.Write(p); err != nil {
+			logger.GetLogger().Info("Export unix socket: disconnecting client", logfields.Error, err)
+			conn.Close()
+			delete(w.clients, conn)
+		}
+	}
+	return len(p), nil
+}
+
+// Close closes the socket and disconnects all clients.
+func (w *UnixSocketWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	for conn := range w.clients {
+		conn.Close()
+	}
+	clear(w.clients)
+	return w.listener.Close()
+}
@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package exporter
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/cilium/tetragon/pkg/logger"
+	"github.com/cilium/tetragon/pkg/logger/logfields"
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	// zstdBlockSize is the amount of uncompressed data compressed into a
+	// single zstd frame.
+	zstdBlockSize = 256 * 1024
+	// zstdFlushInterval is the maximum time data is buffered before being
+	// compressed and written.
+	zstdFlushInterval = time.Second
+)
+
+// zstdBlockWriter compresses data into independent zstd frames and writes
+// each frame with a single Write call. Since the rotating file writer only
+// rotates between writes, every file holds whole frames and is a valid zstd
+// stream on its own, including the current file once flushed.
+type zstdBlockWriter struct {
+	mu  sync.Mutex
+	w   io.WriteCloser
+	enc *zstd.Encoder
+	buf []byte
+	out []byte
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func newZstdBlockWriter(ctx context.Context, w io.WriteCloser) (*zstdBlockWriter, error) {
+	enc, err := zstd.NewWriter(nil,
+		zstd.WithEncoderLevel(zstd.SpeedFastest),
+		zstd.WithEncoderConcurrency(1))
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	zw := &zstdBlockWriter{
+		w:      w,
+		enc:    enc,
+		buf:    make([]byte, 0, zstdBlockSize),
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	go zw.flushLoop(ctx)
+	return zw, nil
+}
+
+func (zw *zstdBlockWriter) flushLoop(ctx context.Context) {
+	defer close(zw.done)
+	ticker := time.NewTicker(zstdFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			zw.mu.Lock()
+			err := zw.flush()
+			zw.mu.Unlock()
+			if err != nil {
+				logger.GetLogger().Warn("Failed to write compressed export data", logfields.Error, err)
+			}
+		}
+	}
+}
+
+// Write buffers p, and compresses and writes the buffered data once it
+// reaches the block size. p is never split across frames.
+func (zw *zstdBlockWriter) Write(p []byte) (int, error) {
+	zw.mu.Lock()
+	defer zw.mu.Unlock()
+	if len(zw.buf) > 0 && len(zw.buf)+len(p) > zstdBlockSize {
+		if err := zw.flush(); err != nil {
+			return 0, err
+		}
+	}
+	zw.buf = append(zw.buf, p...)
+	if len(zw.buf) >= zstdBlockSize {
+		if err := zw.flush(); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// flush compresses the buffered data into a frame and writes it. zw.mu must
+// be held.
+func (zw *zstdBlockWriter) flush() error {
+	if len(zw.buf) == 0 {
+		return nil
+	}
+	zw.out = zw.enc.EncodeAll(zw.buf, zw.out[:0])
+	zw.buf = zw.buf[:0]
+	_, err := zw.w.Write(zw.out)
+	return err
+}
+
+// Close flushes the buffered data and closes the underlying writer.
+func (zw *zstdBlockWriter) Close() error {
+	zw.cancel()
+	<-zw.done
+	zw.mu.Lock()
+	defer zw.mu.Unlock()
+	err := zw.flush()
+	if cerr := zw.w.Close(); err == nil {
+		err = cerr
+	}
+	zw.enc.Close()
+	return err
+}
@@ -61,6 +61,13 @@ func fixupSnakeCaseString(s string, upper bool) string {
 	return builder.String()
 }
 
+// FixupFieldPaths converts a comma-separated list of snake_case field paths
+// (e.g. "process_exec.process.binary") to the camelCase representation that
+// protobuf expects for field masks in JSON.
+func FixupFieldPaths(fields string) string {
+	return fixupSnakeCaseString(fields, false)
+}
+
 // Fixes up a field filter's string representation so that protobuf can unmarshal it from
 // JSON.
 func fixupFieldFilterString(s string) string {
@@ -83,7 +90,7 @@ func fixupFieldFilterString(s string) string {
 			return s
 		}
 
-		dat["fields"] = fixupSnakeCaseString(fields, false)
+		dat["fields"] = FixupFieldPaths(fields)
 		enc.Encode(&dat)
 	}
 
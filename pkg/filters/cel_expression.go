@@ -21,11 +21,11 @@ import (
 
 // compile will parse and check an expression `expr` against a given
 // environment `env` and determine whether the resulting type of the expression
-// matches the `exprType` provided as input.
+// matches one of the `celTypes` provided as input.
 // Copied from
 // https://github.com/google/cel-go/blob/338b3c80e688f7f44661d163c0dbc02eb120dcb7/codelab/solution/codelab.go#LL385C1-L399C2
 // with modifications
-func compile(env *cel.Env, expr string, celType *cel.Type) (*cel.Ast, error) {
+func compile(env *cel.Env, expr string, celTypes ...*cel.Type) (*cel.Ast, error) {
 	ast, iss := env.Compile(expr)
 	if iss.Err() != nil {
 		return nil, iss.Err()
@@ -36,10 +36,10 @@ func compile(env *cel.Env, expr string, celType *cel.Type) (*cel.Ast, error) {
 	if iss.Err() != nil {
 		return nil, iss.Err()
 	}
-	if checked.OutputType() != celType {
+	if !slices.ContainsFunc(celTypes, checked.OutputType().IsExactType) {
 		return nil, fmt.Errorf(
 			"got %q, wanted %q result type",
-			checked.OutputType(), celType)
+			checked.OutputType(), celTypes)
 	}
 	return ast, nil
 }
@@ -0,0 +1,188 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package filters
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/api/v1/tetragon/codegen/helpers"
+	"github.com/cilium/tetragon/pkg/logger"
+	"github.com/cilium/tetragon/pkg/logger/logfields"
+	"github.com/google/cel-go/cel"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+var (
+	computedFieldNameRe = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+	// computedFieldsCEL is the CEL environment of computed fields, shared by
+	// all requests.
+	computedFieldsCEL = sync.OnceValue(func() *CELExpressionFilter {
+		return NewCELExpressionFilter(logger.GetLogger())
+	})
+)
+
+// ParseComputedFields parses a line-separated list of computed fields in JSON,
+// for example:
+//
+//	{"name": "is_shell", "expression": "process_exec.process.binary.endsWith('sh')"}
+func ParseComputedFields(fields string) ([]*tetragon.ComputedField, error) {
+	if fields == "" {
+		return nil, nil
+	}
+	dec := json.NewDecoder(strings.NewReader(fields))
+	var results []*tetragon.ComputedField
+	for {
+		var result tetragon.ComputedField
+		if err := dec.Decode(&result); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, err
+		}
+		results = append(results, &result)
+	}
+	return results, nil
+}
+
+type computedField struct {
+	name       string
+	program    cel.Program
+	eventNames []string
+}
+
+// ComputedFields evaluates the computed fields of a GetEventsRequest, and
+// sets their results in the events.
+type ComputedFields struct {
+	fields []computedField
+	// eventMap is the CEL activation, see filterByCELExpression.
+	eventMap map[string]any
+}
+
+// NewComputedFields compiles the expressions of computed fields. It returns
+// nil if fields is empty.
+func NewComputedFields(fields []*tetragon.ComputedField) (*ComputedFields, error) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	c := computedFieldsCEL()
+	ret := &ComputedFields{
+		eventMap: helpers.ProcessEventMapEmpty(),
+	}
+	names := map[string]struct{}{}
+	for _, f := range fields {
+		if !computedFieldNameRe.MatchString(f.GetName()) {
+			return nil, fmt.Errorf("invalid computed field name %q: it must contain only letters, digits and '_'", f.GetName())
+		}
+		if _, ok := names[f.Name]; ok {
+			return nil, fmt.Errorf("duplicate computed field %q", f.Name)
+		}
+		names[f.Name] = struct{}{}
+		if f.Expression == "" {
+			return nil, fmt.Errorf("computed field %q: empty expression", f.Name)
+		}
+		ast, err := compile(c.celEnv, f.Expression,
+			cel.BoolType, cel.IntType, cel.UintType, cel.DoubleType, cel.StringType, cel.DynType)
+		if err != nil {
+			return nil, fmt.Errorf("computed field %q: error compiling CEL expression: %w", f.Name, err)
+		}
+		program, err := c.celEnv.Program(ast)
+		if err != nil {
+			return nil, fmt.Errorf("computed field %q: error building CEL program: %w", f.Name, err)
+		}
+		var eventNames []string
+		for _, tp := range ast.NativeRep().TypeMap() {
+			if ev, ok := c.evToProto[tp.TypeName()]; ok {
+				eventNames = append(eventNames, ev)
+			}
+		}
+		ret.fields = append(ret.fields, computedField{
+			name:       f.Name,
+			program:    program,
+			eventNames: eventNames,
+		})
+	}
+	return ret, nil
+}
+
+// applies returns true if the field applies to the event in the activation:
+// its expression refers to the type of the event, or to no event type.
+func (f *computedField) applies(eventMap map[string]any) bool {
+	if len(f.eventNames) == 0 {
+		return true
+	}
+	for _, n := range f.eventNames {
+		if !reflect.ValueOf(eventMap[n]).IsNil() {
+			return true
+		}
+	}
+	return false
+}
+
+// Apply evaluates the computed fields on an event, and returns a copy of the
+// event with the results in computed_fields, or the event itself if no field
+// applies to it. The event is not modified, since it might be shared with
+// other listeners.
+func (c *ComputedFields) Apply(ctx context.Context, ev *tetragon.GetEventsResponse) *tetragon.GetEventsResponse {
+	evName, evData, evDefer := helpers.ProcessEventMapTuple(ev)
+	if evName == "" {
+		return ev
+	}
+	c.eventMap[evName] = evData
+	defer func() {
+		c.eventMap[evName] = evDefer
+	}()
+
+	var values map[string]*structpb.Value
+	for i := range c.fields {
+		f := &c.fields[i]
+		if !f.applies(c.eventMap) {
+			continue
+		}
+		value, err := f.eval(ctx, c.eventMap)
+		if err != nil {
+			logger.GetLogger().Debug("Failed to evaluate computed field", "field", f.name, logfields.Error, err)
+			continue
+		}
+		if values == nil {
+			values = make(map[string]*structpb.Value, len(c.fields))
+		}
+		values[f.name] = value
+	}
+	if values == nil {
+		return ev
+	}
+	ret := shallowCopy(ev)
+	ret.ComputedFields = values
+	return ret
+}
+
+func (f *computedField) eval(ctx context.Context, eventMap map[string]any) (*structpb.Value, error) {
+	out, _, err := f.program.ContextEval(ctx, eventMap)
+	if err != nil {
+		return nil, fmt.Errorf("error running CEL program: %w", err)
+	}
+	switch v := out.Value().(type) {
+	case bool:
+		return structpb.NewBoolValue(v), nil
+	case int64:
+		return structpb.NewNumberValue(float64(v)), nil
+	case uint64:
+		return structpb.NewNumberValue(float64(v)), nil
+	case float64:
+		return structpb.NewNumberValue(v), nil
+	case string:
+		return structpb.NewStringValue(v), nil
+	}
+	return nil, errors.New("result is not a bool, a number or a string")
+}
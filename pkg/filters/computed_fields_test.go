@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package filters
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func computedExecEvent(binary string, pid uint32) *tetragon.GetEventsResponse {
+	return &tetragon.GetEventsResponse{
+		Event: &tetragon.GetEventsResponse_ProcessExec{
+			ProcessExec: &tetragon.ProcessExec{Process: &tetragon.Process{Binary: binary, Pid: wrapperspb.UInt32(pid)}},
+		},
+		NodeName: "node1",
+	}
+}
+
+func TestComputedFields(t *testing.T) {
+	cf, err := NewComputedFields([]*tetragon.ComputedField{
+		{Name: "is_shell", Expression: "process_exec.process.binary.endsWith('sh')"},
+		{Name: "pid", Expression: "process_exec.process.pid"},
+		{Name: "binary", Expression: "process_exec.process.binary"},
+		{Name: "exit_status", Expression: "process_exit.status"},
+	})
+	require.NoError(t, err)
+
+	ev := computedExecEvent("/bin/sh", 42)
+	res := cf.Apply(context.Background(), ev)
+	assert.Nil(t, ev.ComputedFields, "the original event must not be modified")
+	assert.Equal(t, "node1", res.NodeName)
+	require.Len(t, res.ComputedFields, 3)
+	assert.True(t, res.ComputedFields["is_shell"].GetBoolValue())
+	assert.InDelta(t, 42, res.ComputedFields["pid"].GetNumberValue(), 0)
+	assert.Equal(t, "/bin/sh", res.ComputedFields["binary"].GetStringValue())
+
+	// fields of other event types do not apply
+	exit := &tetragon.GetEventsResponse{
+		Event: &tetragon.GetEventsResponse_ProcessExit{
+			ProcessExit: &tetragon.ProcessExit{Process: &tetragon.Process{}, Status: 1},
+		},
+	}
+	res = cf.Apply(context.Background(), exit)
+	require.Len(t, res.ComputedFields, 1)
+	assert.InDelta(t, 1, res.ComputedFields["exit_status"].GetNumberValue(), 0)
+
+	// the activation is reset between events
+	res = cf.Apply(context.Background(), computedExecEvent("/usr/bin/cat", 1))
+	assert.False(t, res.ComputedFields["is_shell"].GetBoolValue())
+	assert.NotContains(t, res.ComputedFields, "exit_status")
+}
+
+func TestComputedFieldsEvalError(t *testing.T) {
+	cf, err := NewComputedFields([]*tetragon.ComputedField{
+		{Name: "ratio", Expression: "100 / int(process_exec.process.pid)"},
+	})
+	require.NoError(t, err)
+
+	ev := computedExecEvent("/bin/sh", 0)
+	assert.Same(t, ev, cf.Apply(context.Background(), ev))
+	res := cf.Apply(context.Background(), computedExecEvent("/bin/sh", 4))
+	assert.InDelta(t, 25, res.ComputedFields["ratio"].GetNumberValue(), 0)
+}
+
+func TestComputedFieldsInvalid(t *testing.T) {
+	cf, err := NewComputedFields(nil)
+	require.NoError(t, err)
+	assert.Nil(t, cf)
+
+	for _, fields := range [][]*tetragon.ComputedField{
+		{{Name: "", Expression: "true"}},
+		{{Name: "is-shell", Expression: "true"}},
+		{{Name: "x", Expression: ""}},
+		{{Name: "x", Expression: "true"}, {Name: "x", Expression: "false"}},
+		{{Name: "x", Expression: "process_exec.process.bad_field_name"}},
+		{{Name: "x", Expression: "process_exec.process"}},
+		{{Name: "x", Expression: "[1, 2]"}},
+	} {
+		_, err := NewComputedFields(fields)
+		require.Error(t, err, fields)
+	}
+}
+
+func TestParseComputedFields(t *testing.T) {
+	fields, err := ParseComputedFields("")
+	require.NoError(t, err)
+	assert.Empty(t, fields)
+
+	fields, err = ParseComputedFields(`{"name": "is_shell", "expression": "process_exec.process.binary.endsWith('sh')"}
+{"name": "pid", "expression": "process_exec.process.pid"}`)
+	require.NoError(t, err)
+	require.Len(t, fields, 2)
+	assert.Equal(t, "is_shell", fields[0].Name)
+	assert.Equal(t, "process_exec.process.pid", fields[1].Expression)
+
+	_, err = ParseComputedFields(`{"name": "x"`)
+	require.Error(t, err)
+}
@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package filters
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/fieldfilters"
+	"google.golang.org/protobuf/encoding/protojson"
+	"sigs.k8s.io/yaml"
+)
+
+var errPidSetFilter = errors.New("pidSet filters use a best-effort approach for tracking PIDs and are intended for testing/development, not for production (pass the --enable-pid-set-filter to ignore)")
+
+// ParseGetEventsRequest parses a GetEventsRequest from its YAML (or JSON)
+// representation, i.e., the protojson representation of the message. Field
+// paths in field filters can be written in snake_case, the same way as in
+// the --field-filters option.
+func ParseGetEventsRequest(data []byte, enablePidSetFilters bool) (*tetragon.GetEventsRequest, error) {
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse request: %w", err)
+	}
+
+	var raw map[string]any
+	if err := json.Unmarshal(jsonData, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse request: %w", err)
+	}
+	for _, key := range []string{"field_filters", "fieldFilters"} {
+		ffs, ok := raw[key].([]any)
+		if !ok {
+			continue
+		}
+		for _, ff := range ffs {
+			m, ok := ff.(map[string]any)
+			if !ok {
+				continue
+			}
+			if fields, ok := m["fields"].(string); ok {
+				m["fields"] = fieldfilters.FixupFieldPaths(fields)
+			}
+		}
+	}
+	if jsonData, err = json.Marshal(raw); err != nil {
+		return nil, fmt.Errorf("failed to parse request: %w", err)
+	}
+
+	req := &tetragon.GetEventsRequest{}
+	if err := protojson.Unmarshal(jsonData, req); err != nil {
+		return nil, fmt.Errorf("failed to parse request: %w", err)
+	}
+	if !enablePidSetFilters {
+		for _, f := range append(req.AllowList, req.DenyList...) {
+			if len(f.PidSet) != 0 {
+				return nil, errPidSetFilter
+			}
+		}
+	}
+	return req, nil
+}
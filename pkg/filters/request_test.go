@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package filters
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGetEventsRequest(t *testing.T) {
+	req, err := ParseGetEventsRequest([]byte(`
+allow_list:
+- event_set: [PROCESS_KPROBE]
+  namespace: [default]
+deny_list:
+- health_check: true
+field_filters:
+- event_set: [PROCESS_KPROBE]
+  fields: process_kprobe.args,process_kprobe.parent
+  action: EXCLUDE
+aggregation_options:
+  window_size: 10s
+  channel_buffer_size: 100
+`), false)
+	require.NoError(t, err)
+
+	require.Len(t, req.AllowList, 1)
+	assert.Equal(t, []tetragon.EventType{tetragon.EventType_PROCESS_KPROBE}, req.AllowList[0].EventSet)
+	assert.Equal(t, []string{"default"}, req.AllowList[0].Namespace)
+	require.Len(t, req.DenyList, 1)
+	assert.True(t, req.DenyList[0].HealthCheck.GetValue())
+	require.Len(t, req.FieldFilters, 1)
+	assert.Equal(t, []string{"process_kprobe.args", "process_kprobe.parent"}, req.FieldFilters[0].Fields.Paths)
+	assert.Equal(t, tetragon.FieldFilterAction_EXCLUDE, req.FieldFilters[0].Action)
+	assert.Equal(t, 10*time.Second, req.AggregationOptions.WindowSize.AsDuration())
+	assert.Equal(t, uint64(100), req.AggregationOptions.ChannelBufferSize)
+
+	_, err = ParseGetEventsRequest([]byte(`allow_list: [{pid_set: [1]}]`), false)
+	require.Error(t, err)
+	_, err = ParseGetEventsRequest([]byte(`allow_list: [{pid_set: [1]}]`), true)
+	require.NoError(t, err)
+
+	_, err = ParseGetEventsRequest([]byte(`allow_list: [{no_such_field: true}]`), false)
+	require.Error(t, err)
+}
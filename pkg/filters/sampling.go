@@ -0,0 +1,120 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package filters
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/api/v1/tetragon/codegen/helpers"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Sampler deterministically samples events based on the value of a key, so
+// that all the events with the same key value are either kept or dropped.
+type Sampler struct {
+	key tetragon.SamplingKey
+	// keep returns whether the events with the given key hash are kept
+	keep func(hash uint64) bool
+	rate float64
+}
+
+// NewSampler creates a sampler from the sampling options of a GetEvents
+// request. It returns nil if opts is nil.
+func NewSampler(opts *tetragon.SamplingOptions) (*Sampler, error) {
+	if opts == nil {
+		return nil, nil
+	}
+	if _, ok := tetragon.SamplingKey_name[int32(opts.Key)]; !ok {
+		return nil, fmt.Errorf("invalid sampling key %d", opts.Key)
+	}
+
+	s := &Sampler{key: opts.Key}
+	switch {
+	case opts.OneInN != 0 && opts.Percentage != 0:
+		return nil, errors.New("only one of one_in_n and percentage can be set")
+	case opts.OneInN != 0:
+		n := uint64(opts.OneInN)
+		s.keep = func(hash uint64) bool { return hash%n == 0 }
+		s.rate = 1 / float64(n)
+	case opts.Percentage > 0 && opts.Percentage <= 100:
+		// keep a hash if it falls in the first percentage of the hash space
+		threshold := opts.Percentage / 100
+		s.keep = func(hash uint64) bool { return float64(hash>>11)/(1<<53) < threshold }
+		s.rate = threshold
+	case opts.Percentage != 0:
+		return nil, fmt.Errorf("invalid sampling percentage %v: must be greater than 0 and at most 100", opts.Percentage)
+	default:
+		return nil, errors.New("one of one_in_n and percentage must be set")
+	}
+	return s, nil
+}
+
+// Sample returns whether the event is kept. If it is, it also returns the
+// event with its sampling_info set. The original event is not modified, since
+// it can be shared with other GetEvents listeners.
+func (s *Sampler) Sample(ev *tetragon.GetEventsResponse) (*tetragon.GetEventsResponse, bool) {
+	key := s.keyValue(ev)
+	if key == "" {
+		// events without a value for the key are always kept
+		return ev, true
+	}
+	if !s.keep(hashKey(key)) {
+		return nil, false
+	}
+	ret := shallowCopy(ev)
+	ret.SamplingInfo = &tetragon.SamplingInfo{
+		Rate: s.rate,
+		Key:  s.key,
+	}
+	return ret, true
+}
+
+func (s *Sampler) keyValue(ev *tetragon.GetEventsResponse) string {
+	process := helpers.ResponseGetProcess(ev)
+	if process == nil {
+		return ""
+	}
+	switch s.key {
+	case tetragon.SamplingKey_SAMPLING_KEY_EXEC_ID:
+		return process.ExecId
+	case tetragon.SamplingKey_SAMPLING_KEY_POD:
+		if process.Pod == nil {
+			return ""
+		}
+		return process.Pod.Namespace + "/" + process.Pod.Name
+	case tetragon.SamplingKey_SAMPLING_KEY_BINARY:
+		return process.Binary
+	}
+	return ""
+}
+
+// hashKey returns a hash of the key that is stable across nodes and restarts.
+func hashKey(key string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	// FNV's low bits are poorly distributed for similar keys, so mix them
+	// with the splitmix64 finalizer.
+	x := h.Sum64()
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
+
+// shallowCopy returns a copy of the event that shares its fields with the
+// original one.
+func shallowCopy(ev *tetragon.GetEventsResponse) *tetragon.GetEventsResponse {
+	src := ev.ProtoReflect()
+	dst := src.New()
+	src.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		dst.Set(fd, v)
+		return true
+	})
+	return dst.Interface().(*tetragon.GetEventsResponse)
+}
@@ -0,0 +1,124 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package filters
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func execEvent(execID, binary string, pod *tetragon.Pod) *tetragon.GetEventsResponse {
+	return &tetragon.GetEventsResponse{
+		Event: &tetragon.GetEventsResponse_ProcessExec{
+			ProcessExec: &tetragon.ProcessExec{
+				Process: &tetragon.Process{
+					ExecId: execID,
+					Binary: binary,
+					Pod:    pod,
+				},
+			},
+		},
+		NodeName: "node",
+	}
+}
+
+func TestNewSamplerErrors(t *testing.T) {
+	for _, opts := range []*tetragon.SamplingOptions{
+		{},
+		{OneInN: 10, Percentage: 10},
+		{Percentage: -1},
+		{Percentage: 101},
+		{OneInN: 10, Key: 42},
+	} {
+		_, err := NewSampler(opts)
+		require.Error(t, err, "options: %v", opts)
+	}
+
+	s, err := NewSampler(nil)
+	require.NoError(t, err)
+	assert.Nil(t, s)
+}
+
+func TestSamplerOneInN(t *testing.T) {
+	s, err := NewSampler(&tetragon.SamplingOptions{OneInN: 10})
+	require.NoError(t, err)
+
+	kept := 0
+	for i := range 10000 {
+		ev := execEvent(fmt.Sprintf("exec-%d", i), "/bin/sh", nil)
+		res, keep := s.Sample(ev)
+		if !keep {
+			continue
+		}
+		kept++
+		assert.Equal(t, &tetragon.SamplingInfo{
+			Rate: 0.1,
+			Key:  tetragon.SamplingKey_SAMPLING_KEY_EXEC_ID,
+		}, res.SamplingInfo)
+		assert.Equal(t, "node", res.NodeName)
+		assert.Same(t, ev.GetProcessExec(), res.GetProcessExec())
+		// the original event is not modified
+		assert.Nil(t, ev.SamplingInfo)
+	}
+	assert.InDelta(t, 1000, kept, 100)
+}
+
+func TestSamplerPercentage(t *testing.T) {
+	s, err := NewSampler(&tetragon.SamplingOptions{
+		Percentage: 25,
+		Key:        tetragon.SamplingKey_SAMPLING_KEY_BINARY,
+	})
+	require.NoError(t, err)
+
+	kept := 0
+	for i := range 10000 {
+		binary := fmt.Sprintf("/usr/bin/binary%d", i)
+		_, keep := s.Sample(execEvent("exec", binary, nil))
+		if keep {
+			kept++
+		}
+		// the decision only depends on the key
+		_, keep2 := s.Sample(execEvent("other-exec", binary, nil))
+		assert.Equal(t, keep, keep2)
+	}
+	assert.InDelta(t, 2500, kept, 200)
+
+	s, err = NewSampler(&tetragon.SamplingOptions{Percentage: 100})
+	require.NoError(t, err)
+	for i := range 100 {
+		_, keep := s.Sample(execEvent(fmt.Sprintf("exec-%d", i), "/bin/sh", nil))
+		assert.True(t, keep)
+	}
+}
+
+func TestSamplerPod(t *testing.T) {
+	s, err := NewSampler(&tetragon.SamplingOptions{
+		OneInN: 1000000,
+		Key:    tetragon.SamplingKey_SAMPLING_KEY_POD,
+	})
+	require.NoError(t, err)
+
+	// events without a pod are always kept, and not annotated
+	ev := execEvent("exec", "/bin/sh", nil)
+	res, keep := s.Sample(ev)
+	assert.True(t, keep)
+	assert.Same(t, ev, res)
+	assert.Nil(t, res.SamplingInfo)
+
+	dropped := 0
+	for i := range 100 {
+		_, keep := s.Sample(execEvent("exec", "/bin/sh", &tetragon.Pod{
+			Namespace: "default",
+			Name:      fmt.Sprintf("pod-%d", i),
+		}))
+		if !keep {
+			dropped++
+		}
+	}
+	assert.Positive(t, dropped)
+}
@@ -202,7 +202,7 @@ func (msg *MsgExecveEventUnix) Retry(internal *process.ProcessInternal, ev notif
 	args := tetragonProcess.Arguments
 	nspid := msg.Unix.Process.NSPID
 
-	if option.Config.EnableK8s && containerId != "" {
+	if option.PodMetadataEnabled() && containerId != "" {
 		podInfo = process.GetPodInfo(containerId, filename, args, nspid)
 		if podInfo == nil {
 			eventcache.CacheRetries(eventcache.PodInfo).Inc()
@@ -341,7 +341,7 @@ func (msg *MsgCloneEventUnix) RetryInternal(_ notify.Event, _ uint64) (*process.
 
 func (msg *MsgCloneEventUnix) Retry(internal *process.ProcessInternal, _ notify.Event) error {
 	tetragonProcess := internal.UnsafeGetProcess()
-	if option.Config.EnableK8s && tetragonProcess.Docker != "" && tetragonProcess.Pod == nil {
+	if option.PodMetadataEnabled() && tetragonProcess.Docker != "" && tetragonProcess.Pod == nil {
 		podInfo := process.GetPodInfo(tetragonProcess.Docker, tetragonProcess.Binary, tetragonProcess.Arguments, msg.NSPID)
 		if podInfo == nil {
 			eventcache.CacheRetries(eventcache.PodInfo).Inc()
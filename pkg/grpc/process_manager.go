@@ -22,9 +22,11 @@ import (
 // ProcessManager maintains a cache of processes from tetragon exec events.
 type ProcessManager struct {
 	Server *server.Server
-	// synchronize access to the listeners map.
+	// synchronize access to the listeners map and the sequence number.
 	mux       sync.Mutex
 	listeners map[server.Listener]struct{}
+	// sequence is the sequence number of the last event sent to listeners.
+	sequence uint64
 }
 
 // NewProcessManager returns a pointer to an initialized ProcessManager struct.
@@ -82,6 +84,8 @@ func (pm *ProcessManager) NotifyListener(original interface{}, processed *tetrag
 	pm.mux.Lock()
 	defer pm.mux.Unlock()
 	node.SetCommonFields(processed)
+	pm.sequence++
+	processed.Sequence = pm.sequence
 	for l := range pm.listeners {
 		l.Notify(processed)
 	}
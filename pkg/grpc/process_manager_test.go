@@ -16,6 +16,7 @@ import (
 	"github.com/cilium/tetragon/pkg/option"
 	"github.com/cilium/tetragon/pkg/process"
 	"github.com/cilium/tetragon/pkg/rthooks"
+	"github.com/cilium/tetragon/pkg/server"
 	"github.com/cilium/tetragon/pkg/watcher"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -211,3 +212,26 @@ func TestProcessManager_GetProcessExec(t *testing.T) {
 		},
 		exec.GetProcessExec(pi, false).Process.BinaryProperties)
 }
+
+type sequenceListener struct {
+	sequences []uint64
+}
+
+func (l *sequenceListener) Notify(res *tetragon.GetEventsResponse) {
+	l.sequences = append(l.sequences, res.Sequence)
+}
+
+func TestProcessManager_Sequence(t *testing.T) {
+	pm := &ProcessManager{listeners: make(map[server.Listener]struct{})}
+	l1, l2 := &sequenceListener{}, &sequenceListener{}
+	pm.AddListener(l1)
+	for range 2 {
+		pm.NotifyListener(nil, &tetragon.GetEventsResponse{})
+	}
+	pm.AddListener(l2)
+	for range 2 {
+		pm.NotifyListener(nil, &tetragon.GetEventsResponse{})
+	}
+	assert.Equal(t, []uint64{1, 2, 3, 4}, l1.sequences)
+	assert.Equal(t, []uint64{3, 4}, l2.sequences)
+}
@@ -94,6 +94,8 @@ func kprobeAction(act uint64) tetragon.KprobeAction {
 		return tetragon.KprobeAction_KPROBE_ACTION_NOTIFYENFORCER
 	case tracingapi.ActionCleanupEnforcerNotification:
 		return tetragon.KprobeAction_KPROBE_ACTION_CLEANUPENFORCERNOTIFICATION
+	case tracingapi.ActionSetState:
+		return tetragon.KprobeAction_KPROBE_ACTION_SETSTATE
 	default:
 		return tetragon.KprobeAction_KPROBE_ACTION_UNKNOWN
 	}
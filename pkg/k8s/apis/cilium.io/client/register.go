@@ -39,9 +39,29 @@ var (
 		v1alpha1.PIName,
 		crdsv1Alpha1PodInfo)
 
+	//go:embed crds/v1alpha1/cilium.io_tracingpolicytemplates.yaml
+	crdsv1Alpha1TracingPolicyTemplates []byte
+
+	TracingPolicyTemplateCRD = crdutils.NewCRDBytes(
+		slog.Default(),
+		v1alpha1.TPTemplateCRDName,
+		v1alpha1.TPTemplateName,
+		crdsv1Alpha1TracingPolicyTemplates)
+
+	//go:embed crds/v1alpha1/cilium.io_tracingpolicytemplatebindings.yaml
+	crdsv1Alpha1TracingPolicyTemplateBindings []byte
+
+	TracingPolicyTemplateBindingCRD = crdutils.NewCRDBytes(
+		slog.Default(),
+		v1alpha1.TPTemplateBindingCRDName,
+		v1alpha1.TPTemplateBindingName,
+		crdsv1Alpha1TracingPolicyTemplateBindings)
+
 	AllCRDs = []crdutils.CRD{
 		TracingPolicyCRD,
 		TracingPolicyNamespacedCRD,
 		PodInfoCRD,
+		TracingPolicyTemplateCRD,
+		TracingPolicyTemplateBindingCRD,
 	}
 )
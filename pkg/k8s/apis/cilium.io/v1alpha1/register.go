@@ -21,6 +21,12 @@ const (
 
 	// PICRDName is the full name of the Tetragon Pod Info CRD.
 	PICRDName = PIKindDefinition + "/" + CRDVersion
+
+	// TPTemplateCRDName is the full name of the TracingPolicyTemplate CRD.
+	TPTemplateCRDName = TPTemplateKindDefinition + "/" + CRDVersion
+
+	// TPTemplateBindingCRDName is the full name of the TracingPolicyTemplateBinding CRD.
+	TPTemplateBindingCRDName = TPTemplateBindingKindDefinition + "/" + CRDVersion
 )
 
 // SchemeGroupVersion is group version used to register these objects
@@ -54,6 +60,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&TracingPolicyNamespacedList{},
 		&PodInfo{},
 		&PodInfoList{},
+		&TracingPolicyTemplate{},
+		&TracingPolicyTemplateList{},
+		&TracingPolicyTemplateBinding{},
+		&TracingPolicyTemplateBindingList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	return nil
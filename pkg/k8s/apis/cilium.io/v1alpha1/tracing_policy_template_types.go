@@ -0,0 +1,172 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package v1alpha1
+
+import (
+	ciliumio "github.com/cilium/tetragon/pkg/k8s/apis/cilium.io"
+	slimv1 "github.com/cilium/tetragon/pkg/k8s/slim/k8s/apis/meta/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// Tracing Policy Template (TPT)
+
+	// TPTemplatePluralName is the plural name of Tracing Policy Template
+	TPTemplatePluralName = "tracingpolicytemplates"
+
+	// TPTemplateKindDefinition is the kind name of Tracing Policy Template
+	TPTemplateKindDefinition = "TracingPolicyTemplate"
+
+	// TPTemplateName is the full name of Tracing Policy Template
+	TPTemplateName = TPTemplatePluralName + "." + ciliumio.GroupName
+
+	// TPTemplateBindingPluralName is the plural name of Tracing Policy Template Binding
+	TPTemplateBindingPluralName = "tracingpolicytemplatebindings"
+
+	// TPTemplateBindingKindDefinition is the kind name of Tracing Policy Template Binding
+	TPTemplateBindingKindDefinition = "TracingPolicyTemplateBinding"
+
+	// TPTemplateBindingName is the full name of Tracing Policy Template Binding
+	TPTemplateBindingName = TPTemplateBindingPluralName + "." + ciliumio.GroupName
+)
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:categories={tetragon},singular="tracingpolicytemplate",path="tracingpolicytemplates",scope="Cluster",shortName={tgtpt}
+// +kubebuilder:subresource:status
+type TracingPolicyTemplate struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+	// Tracing policy template specification.
+	Spec TracingPolicyTemplateSpec `json:"spec"`
+	// +kubebuilder:validation:Optional
+	// Tracing policy template status.
+	Status TracingPolicyTemplateStatus `json:"status,omitempty"`
+}
+
+type TracingPolicyTemplateSpec struct {
+	// +kubebuilder:validation:Optional
+	// Parameters of the template.
+	Parameters []TemplateParameter `json:"parameters,omitempty"`
+	// Policy is the tracing policy specification of the template. Its string
+	// values can reference parameters as ${name}. A stringList parameter
+	// must be a whole item of a list, which is replaced by the values of the
+	// parameter.
+	Policy TracingPolicySpec `json:"policy"`
+}
+
+type TemplateParameter struct {
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z_][a-zA-Z0-9_]*$`
+	// Name of the parameter.
+	Name string `json:"name"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=string;int;stringList
+	// +kubebuilder:default=string
+	// Type of the parameter.
+	Type string `json:"type"`
+	// +kubebuilder:validation:Optional
+	// Description of the parameter.
+	Description string `json:"description,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Default value of the parameter. Parameters without a default value
+	// must be set by bindings.
+	Default *TemplateValue `json:"default,omitempty"`
+}
+
+// TemplateValue is the value of a template parameter: value for string and
+// int parameters, values for stringList parameters.
+type TemplateValue struct {
+	// +kubebuilder:validation:Optional
+	// Value of a string or int parameter.
+	Value string `json:"value,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Values of a stringList parameter.
+	Values []string `json:"values,omitempty"`
+}
+
+type TracingPolicyTemplateStatus struct {
+	// +kubebuilder:validation:Optional
+	// The generation of the template the status was computed for.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Policies instantiated from the template by bindings.
+	Policies []TemplatePolicyReference `json:"policies,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Validation error of the template, empty if the template is valid.
+	Error string `json:"error,omitempty"`
+}
+
+type TemplatePolicyReference struct {
+	// Namespace of the policy.
+	Namespace string `json:"namespace"`
+	// Name of the TracingPolicyNamespaced.
+	Name string `json:"name"`
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TracingPolicyTemplateList contains a list of TracingPolicyTemplate
+type TracingPolicyTemplateList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TracingPolicyTemplate `json:"items"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:categories={tetragon},singular="tracingpolicytemplatebinding",path="tracingpolicytemplatebindings",scope="Namespaced",shortName={tgtptb}
+// +kubebuilder:subresource:status
+type TracingPolicyTemplateBinding struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata"`
+	// Tracing policy template binding specification.
+	Spec TracingPolicyTemplateBindingSpec `json:"spec"`
+	// +kubebuilder:validation:Optional
+	// Tracing policy template binding status.
+	Status TracingPolicyTemplateBindingStatus `json:"status,omitempty"`
+}
+
+type TracingPolicyTemplateBindingSpec struct {
+	// Name of the TracingPolicyTemplate to instantiate.
+	TemplateRef string `json:"templateRef"`
+	// +kubebuilder:validation:Optional
+	// Values of the template parameters.
+	Parameters []TemplateParameterValue `json:"parameters,omitempty"`
+	// +kubebuilder:validation:Optional
+	// PodSelector selects the pods of the namespace that the instantiated
+	// policy applies to. It overrides the podSelector of the template.
+	PodSelector *slimv1.LabelSelector `json:"podSelector,omitempty"`
+}
+
+type TemplateParameterValue struct {
+	// Name of the parameter.
+	Name string `json:"name"`
+	// Value of the parameter.
+	TemplateValue `json:",inline"`
+}
+
+type TracingPolicyTemplateBindingStatus struct {
+	// +kubebuilder:validation:Optional
+	// The generation of the binding the status was computed for.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Name of the TracingPolicyNamespaced instantiated by the binding, in the
+	// namespace of the binding.
+	Policy string `json:"policy,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Error instantiating the template, empty if the policy is up to date.
+	Error string `json:"error,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TracingPolicyTemplateBindingList contains a list of TracingPolicyTemplateBinding
+type TracingPolicyTemplateBindingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []TracingPolicyTemplateBinding `json:"items"`
+}
@@ -88,6 +88,9 @@ type TracingPolicySpec struct {
 	// +kubebuilder:validation:Optional
 	// A list of uprobe specs.
 	LsmHooks []LsmHookSpec `json:"lsmhooks,omitempty"`
+	// +kubebuilder:validation:Optional
+	// A list of fentry specs.
+	Fentries []FentrySpec `json:"fentries,omitempty"`
 
 	// +kubebuilder:validation:Optional
 	// PodSelector selects pods that this policy applies to
@@ -100,6 +103,10 @@ type TracingPolicySpec struct {
 	// Currently, only the "name" field is supported.
 	ContainerSelector *slimv1.LabelSelector `json:"containerSelector,omitempty"`
 
+	// +kubebuilder:validation:Optional
+	// PodMode overrides the mode of the policy for the pods it selects.
+	PodMode *PodModeSpec `json:"podMode,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	// A list of list specs.
 	Lists []ListSpec `json:"lists,omitempty"`
@@ -111,6 +118,21 @@ type TracingPolicySpec struct {
 	// +kubebuilder:validation:Optional
 	// A list of overloaded options
 	Options []OptionSpec `json:"options,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// Schedule defines when the policy is enabled. If unset, the policy is
+	// enabled when it is added.
+	Schedule *ScheduleSpec `json:"schedule,omitempty"`
+}
+
+type PodModeSpec struct {
+	// +kubebuilder:validation:Enum=enforce;monitor
+	// Mode of the policy for the selected pods.
+	Mode string `json:"mode"`
+	// PodSelector selects the pods whose mode is overridden. Pods that it
+	// does not select, and processes that do not run in pods, use the mode
+	// of the policy.
+	PodSelector *slimv1.LabelSelector `json:"podSelector"`
 }
 
 func (tp *TracingPolicy) TpName() string {
@@ -103,7 +103,7 @@ type KProbeArg struct {
 }
 
 type BinarySelector struct {
-	// +kubebuilder:validation:Enum=In;NotIn;Prefix;NotPrefix;Postfix;NotPostfix
+	// +kubebuilder:validation:Enum=In;NotIn;Prefix;NotPrefix;Postfix;NotPostfix;Glob;NotGlob;Regex;NotRegex
 	// Filter operation.
 	Operator string `json:"operator"`
 	// Value to compare the argument against.
@@ -147,6 +147,57 @@ type KProbeSelector struct {
 	// +kubebuilder:validation:Optional
 	// IDs for capabilities changes
 	MatchCapabilityChanges []CapabilitiesSelector `json:"matchCapabilityChanges,omitempty"`
+	// +kubebuilder:validation:Optional
+	// A list of process state filters. Process states are set by the SetState
+	// action of the hooks of the same policy.
+	MatchState []StateSelector `json:"matchState,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// A boolean expression of filters, ANDed with the other filters of the
+	// selector. The selector is expanded into one selector per alternative of
+	// the expression.
+	MatchExpression *SelectorExpression `json:"matchExpression,omitempty"`
+}
+
+// SelectorExpression is a boolean expression over selector filters. Exactly
+// one of its fields must be set: either one of the and, or, not operators, or
+// a single filter that uses the same syntax as the KProbeSelector filters.
+type SelectorExpression struct {
+	// +kubebuilder:validation:Optional
+	// All of the sub-expressions must match.
+	And []SelectorExpression `json:"and,omitempty"`
+	// +kubebuilder:validation:Optional
+	// At least one of the sub-expressions must match.
+	Or []SelectorExpression `json:"or,omitempty"`
+	// +kubebuilder:validation:Optional
+	// The sub-expression must not match.
+	Not *SelectorExpression `json:"not,omitempty"`
+	// +kubebuilder:validation:Optional
+	// A process ID filter.
+	MatchPID *PIDSelector `json:"matchPID,omitempty"`
+	// +kubebuilder:validation:Optional
+	// An argument filter.
+	MatchArg *ArgSelector `json:"matchArg,omitempty"`
+	// +kubebuilder:validation:Optional
+	// A binary exec name filter.
+	MatchBinary *BinarySelector `json:"matchBinary,omitempty"`
+	// +kubebuilder:validation:Optional
+	// A namespace filter.
+	MatchNamespace *NamespaceSelector `json:"matchNamespace,omitempty"`
+	// +kubebuilder:validation:Optional
+	// A namespace changes filter.
+	MatchNamespaceChange *NamespaceChangesSelector `json:"matchNamespaceChange,omitempty"`
+	// +kubebuilder:validation:Optional
+	// A capabilities filter.
+	MatchCapability *CapabilitiesSelector `json:"matchCapability,omitempty"`
+	// +kubebuilder:validation:Optional
+	// A capabilities changes filter.
+	MatchCapabilityChange *CapabilitiesSelector `json:"matchCapabilityChange,omitempty"`
+	// +kubebuilder:validation:Optional
+	// A process state filter.
+	MatchState *StateSelector `json:"matchState,omitempty"`
 }
 
 type NamespaceChangesSelector struct {
@@ -185,6 +236,19 @@ type CapabilitiesSelector struct {
 	Values []string `json:"values"`
 }
 
+type StateSelector struct {
+	// +kubebuilder:validation:Enum=In;NotIn
+	// State selector operator. In matches if any of the states is set for the
+	// process, NotIn if none of them is.
+	Operator string `json:"operator"`
+	// Names of the states to match.
+	Values []string `json:"values"`
+	// +kubebuilder:validation:Optional
+	// Only consider the states set within this duration (for example "10s" or
+	// "1m"). By default, states do not expire.
+	Within string `json:"within,omitempty"`
+}
+
 type PIDSelector struct {
 	// +kubebuilder:validation:Enum=In;NotIn
 	// PID selector operator.
@@ -208,7 +272,7 @@ type ArgSelector struct {
 	// +kubebuilder:validation:items:Minimum=0
 	// Position of the operator arguments (in spec file) to apply fhe filter to.
 	Args []uint32 `json:"args,omitempty"`
-	// +kubebuilder:validation:Enum=Equal;NotEqual;Prefix;NotPrefix;Postfix;NotPostfix;GreaterThan;LessThan;GT;LT;Mask;SPort;NotSPort;SPortPriv;NotSportPriv;DPort;NotDPort;DPortPriv;NotDPortPriv;SAddr;NotSAddr;DAddr;NotDAddr;Protocol;Family;State;InMap;NotInMap;CapabilitiesGained
+	// +kubebuilder:validation:Enum=Equal;NotEqual;Prefix;NotPrefix;Postfix;NotPostfix;GreaterThan;LessThan;GT;LT;Mask;SPort;NotSPort;SPortPriv;NotSportPriv;DPort;NotDPort;DPortPriv;NotDPortPriv;SAddr;NotSAddr;DAddr;NotDAddr;Protocol;Family;State;InMap;NotInMap;CapabilitiesGained;Glob;NotGlob;Regex;NotRegex
 	// Filter operation.
 	Operator string `json:"operator"`
 	// Value to compare the argument against.
@@ -216,7 +280,7 @@ type ArgSelector struct {
 }
 
 type ActionSelector struct {
-	// +kubebuilder:validation:Enum=Post;FollowFD;UnfollowFD;Sigkill;CopyFD;Override;GetUrl;DnsLookup;NoPost;Signal;TrackSock;UntrackSock;NotifyEnforcer;CleanupEnforcerNotification
+	// +kubebuilder:validation:Enum=Post;FollowFD;UnfollowFD;Sigkill;CopyFD;Override;GetUrl;DnsLookup;NoPost;Signal;TrackSock;UntrackSock;NotifyEnforcer;CleanupEnforcerNotification;SetState
 	// Action to execute.
 	// NOTE: actions FollowFD, UnfollowFD, and CopyFD are marked as deprecated and planned to
 	// be removed in version 1.5.
@@ -243,6 +307,10 @@ type ActionSelector struct {
 	// An arg index for the sock for trackSock and untrackSock actions
 	ArgSock uint32 `json:"argSock"`
 	// +kubebuilder:validation:Optional
+	// Name of the process state for the setState action. States are shared
+	// between the hooks of a policy and can be matched with matchState.
+	State string `json:"state,omitempty"`
+	// +kubebuilder:validation:Optional
 	// A time period within which repeated messages will not be posted. Can be
 	// specified in seconds (default or with 's' suffix), minutes ('m' suffix)
 	// or hours ('h' suffix). Only valid with the post action.
@@ -301,6 +369,11 @@ type TracepointSpec struct {
 	// +kubebuilder:validation:Optional
 	// Enable raw tracepoint arguments
 	Raw bool `json:"raw,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Attach a BTF tracepoint (tp_btf). Arguments are the raw tracepoint
+	// arguments, with their type taken from BTF, so they can be resolved
+	// like kprobe arguments.
+	Btf bool `json:"btf,omitempty"`
 }
 
 type UProbeSpec struct {
@@ -352,6 +425,43 @@ type LsmHookSpec struct {
 	Tags []string `json:"tags,omitempty"`
 }
 
+// FentrySpec attaches to a kernel function with a BTF trampoline: at its
+// entry (fentry), or at its exit (fexit) if Return is set. Arguments are typed
+// from the BTF prototype of the function.
+type FentrySpec struct {
+	// Name of the function to attach to.
+	Call string `json:"call"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// Indicates whether the function is a syscall. Arguments are then the
+	// ones of the syscall.
+	Syscall bool `json:"syscall"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default=false
+	// Attach at the exit of the function (fexit) instead of its entry, and
+	// include the return value in the event along with the arguments.
+	Return bool `json:"return"`
+	// +kubebuilder:validation:Optional
+	// The return value to include in the trace output. Its type defaults to
+	// the return type of the function.
+	ReturnArg *KProbeArg `json:"returnArg,omitempty"`
+	// +kubebuilder:validation:Optional
+	// A short message of 256 characters max that will be included
+	// in the event output to inform users what is going on.
+	Message string `json:"message"`
+	// +kubebuilder:validation:Optional
+	// A list of function arguments to include in the trace output.
+	Args []KProbeArg `json:"args,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Selectors to apply before producing trace output. Selectors are ORed.
+	Selectors []KProbeSelector `json:"selectors,omitempty"`
+	// +kubebuilder:validation:optional
+	// +kubebuilder:validation:MaxItems=16
+	// Tags to categorize the event, will be include in the event output.
+	// Maximum of 16 Tags are supported.
+	Tags []string `json:"tags,omitempty"`
+}
+
 type ListSpec struct {
 	// Name of the list
 	Name string `json:"name"`
@@ -378,6 +488,35 @@ type OptionSpec struct {
 	Value string `json:"value"`
 }
 
+type ScheduleSpec struct {
+	// +kubebuilder:validation:Optional
+	// Duration after which the policy is disabled, counted from the time the
+	// policy is added (for example "30m" or "2h").
+	TTL string `json:"ttl,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Time windows during which the policy is enabled. The policy is disabled
+	// outside of them. If empty, the policy is enabled until its TTL expires.
+	Windows []ScheduleWindow `json:"windows,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Time zone of the windows, as an IANA time zone name (for example
+	// "Europe/Paris"). Defaults to UTC.
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+type ScheduleWindow struct {
+	// +kubebuilder:validation:Optional
+	// Days of the week of the window (Mon, Tue, Wed, Thu, Fri, Sat, Sun).
+	// Defaults to every day.
+	Days []string `json:"days,omitempty"`
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	// Start time of the window, as HH:MM.
+	Start string `json:"start"`
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	// End time of the window, as HH:MM. If it is not after the start time,
+	// the window ends on the next day.
+	End string `json:"end"`
+}
+
 type PodInfoSpec struct {
 	// Host networking requested for this pod. Use the host's network namespace.
 	// If this option is set, the ports that will be used must be specified.
@@ -117,6 +117,43 @@ func (in *EnforcerSpec) DeepCopy() *EnforcerSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FentrySpec) DeepCopyInto(out *FentrySpec) {
+	*out = *in
+	if in.ReturnArg != nil {
+		in, out := &in.ReturnArg, &out.ReturnArg
+		*out = new(KProbeArg)
+		**out = **in
+	}
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]KProbeArg, len(*in))
+		copy(*out, *in)
+	}
+	if in.Selectors != nil {
+		in, out := &in.Selectors, &out.Selectors
+		*out = make([]KProbeSelector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FentrySpec.
+func (in *FentrySpec) DeepCopy() *FentrySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FentrySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KProbeArg) DeepCopyInto(out *KProbeArg) {
 	*out = *in
@@ -205,6 +242,18 @@ func (in *KProbeSelector) DeepCopyInto(out *KProbeSelector) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.MatchState != nil {
+		in, out := &in.MatchState, &out.MatchState
+		*out = make([]StateSelector, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.MatchExpression != nil {
+		in, out := &in.MatchExpression, &out.MatchExpression
+		*out = new(SelectorExpression)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KProbeSelector.
@@ -517,6 +566,233 @@ func (in *PodInfoStatus) DeepCopy() *PodInfoStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodModeSpec) DeepCopyInto(out *PodModeSpec) {
+	*out = *in
+	if in.PodSelector != nil {
+		in, out := &in.PodSelector, &out.PodSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodModeSpec.
+func (in *PodModeSpec) DeepCopy() *PodModeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PodModeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduleSpec) DeepCopyInto(out *ScheduleSpec) {
+	*out = *in
+	if in.Windows != nil {
+		in, out := &in.Windows, &out.Windows
+		*out = make([]ScheduleWindow, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleSpec.
+func (in *ScheduleSpec) DeepCopy() *ScheduleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduleWindow) DeepCopyInto(out *ScheduleWindow) {
+	*out = *in
+	if in.Days != nil {
+		in, out := &in.Days, &out.Days
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduleWindow.
+func (in *ScheduleWindow) DeepCopy() *ScheduleWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduleWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SelectorExpression) DeepCopyInto(out *SelectorExpression) {
+	*out = *in
+	if in.And != nil {
+		in, out := &in.And, &out.And
+		*out = make([]SelectorExpression, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Or != nil {
+		in, out := &in.Or, &out.Or
+		*out = make([]SelectorExpression, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Not != nil {
+		in, out := &in.Not, &out.Not
+		*out = new(SelectorExpression)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MatchPID != nil {
+		in, out := &in.MatchPID, &out.MatchPID
+		*out = new(PIDSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MatchArg != nil {
+		in, out := &in.MatchArg, &out.MatchArg
+		*out = new(ArgSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MatchBinary != nil {
+		in, out := &in.MatchBinary, &out.MatchBinary
+		*out = new(BinarySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MatchNamespace != nil {
+		in, out := &in.MatchNamespace, &out.MatchNamespace
+		*out = new(NamespaceSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MatchNamespaceChange != nil {
+		in, out := &in.MatchNamespaceChange, &out.MatchNamespaceChange
+		*out = new(NamespaceChangesSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MatchCapability != nil {
+		in, out := &in.MatchCapability, &out.MatchCapability
+		*out = new(CapabilitiesSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MatchCapabilityChange != nil {
+		in, out := &in.MatchCapabilityChange, &out.MatchCapabilityChange
+		*out = new(CapabilitiesSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MatchState != nil {
+		in, out := &in.MatchState, &out.MatchState
+		*out = new(StateSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SelectorExpression.
+func (in *SelectorExpression) DeepCopy() *SelectorExpression {
+	if in == nil {
+		return nil
+	}
+	out := new(SelectorExpression)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StateSelector) DeepCopyInto(out *StateSelector) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StateSelector.
+func (in *StateSelector) DeepCopy() *StateSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(StateSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateParameter) DeepCopyInto(out *TemplateParameter) {
+	*out = *in
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(TemplateValue)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateParameter.
+func (in *TemplateParameter) DeepCopy() *TemplateParameter {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateParameter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateParameterValue) DeepCopyInto(out *TemplateParameterValue) {
+	*out = *in
+	in.TemplateValue.DeepCopyInto(&out.TemplateValue)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateParameterValue.
+func (in *TemplateParameterValue) DeepCopy() *TemplateParameterValue {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateParameterValue)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplatePolicyReference) DeepCopyInto(out *TemplatePolicyReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplatePolicyReference.
+func (in *TemplatePolicyReference) DeepCopy() *TemplatePolicyReference {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplatePolicyReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateValue) DeepCopyInto(out *TemplateValue) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateValue.
+func (in *TemplateValue) DeepCopy() *TemplateValue {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateValue)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TracepointSpec) DeepCopyInto(out *TracepointSpec) {
 	*out = *in
@@ -696,6 +972,13 @@ func (in *TracingPolicySpec) DeepCopyInto(out *TracingPolicySpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Fentries != nil {
+		in, out := &in.Fentries, &out.Fentries
+		*out = make([]FentrySpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.PodSelector != nil {
 		in, out := &in.PodSelector, &out.PodSelector
 		*out = new(v1.LabelSelector)
@@ -706,6 +989,11 @@ func (in *TracingPolicySpec) DeepCopyInto(out *TracingPolicySpec) {
 		*out = new(v1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.PodMode != nil {
+		in, out := &in.PodMode, &out.PodMode
+		*out = new(PodModeSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Lists != nil {
 		in, out := &in.Lists, &out.Lists
 		*out = make([]ListSpec, len(*in))
@@ -725,6 +1013,11 @@ func (in *TracingPolicySpec) DeepCopyInto(out *TracingPolicySpec) {
 		*out = make([]OptionSpec, len(*in))
 		copy(*out, *in)
 	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(ScheduleSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracingPolicySpec.
@@ -737,6 +1030,209 @@ func (in *TracingPolicySpec) DeepCopy() *TracingPolicySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TracingPolicyTemplate) DeepCopyInto(out *TracingPolicyTemplate) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracingPolicyTemplate.
+func (in *TracingPolicyTemplate) DeepCopy() *TracingPolicyTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(TracingPolicyTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TracingPolicyTemplate) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TracingPolicyTemplateBinding) DeepCopyInto(out *TracingPolicyTemplateBinding) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracingPolicyTemplateBinding.
+func (in *TracingPolicyTemplateBinding) DeepCopy() *TracingPolicyTemplateBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(TracingPolicyTemplateBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TracingPolicyTemplateBinding) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TracingPolicyTemplateBindingList) DeepCopyInto(out *TracingPolicyTemplateBindingList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TracingPolicyTemplateBinding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracingPolicyTemplateBindingList.
+func (in *TracingPolicyTemplateBindingList) DeepCopy() *TracingPolicyTemplateBindingList {
+	if in == nil {
+		return nil
+	}
+	out := new(TracingPolicyTemplateBindingList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TracingPolicyTemplateBindingList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TracingPolicyTemplateBindingSpec) DeepCopyInto(out *TracingPolicyTemplateBindingSpec) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make([]TemplateParameterValue, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PodSelector != nil {
+		in, out := &in.PodSelector, &out.PodSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracingPolicyTemplateBindingSpec.
+func (in *TracingPolicyTemplateBindingSpec) DeepCopy() *TracingPolicyTemplateBindingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TracingPolicyTemplateBindingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TracingPolicyTemplateBindingStatus) DeepCopyInto(out *TracingPolicyTemplateBindingStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracingPolicyTemplateBindingStatus.
+func (in *TracingPolicyTemplateBindingStatus) DeepCopy() *TracingPolicyTemplateBindingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TracingPolicyTemplateBindingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TracingPolicyTemplateList) DeepCopyInto(out *TracingPolicyTemplateList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TracingPolicyTemplate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracingPolicyTemplateList.
+func (in *TracingPolicyTemplateList) DeepCopy() *TracingPolicyTemplateList {
+	if in == nil {
+		return nil
+	}
+	out := new(TracingPolicyTemplateList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TracingPolicyTemplateList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TracingPolicyTemplateSpec) DeepCopyInto(out *TracingPolicyTemplateSpec) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make([]TemplateParameter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	in.Policy.DeepCopyInto(&out.Policy)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracingPolicyTemplateSpec.
+func (in *TracingPolicyTemplateSpec) DeepCopy() *TracingPolicyTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TracingPolicyTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TracingPolicyTemplateStatus) DeepCopyInto(out *TracingPolicyTemplateStatus) {
+	*out = *in
+	if in.Policies != nil {
+		in, out := &in.Policies, &out.Policies
+		*out = make([]TemplatePolicyReference, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracingPolicyTemplateStatus.
+func (in *TracingPolicyTemplateStatus) DeepCopy() *TracingPolicyTemplateStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TracingPolicyTemplateStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UProbeSpec) DeepCopyInto(out *UProbeSpec) {
 	*out = *in
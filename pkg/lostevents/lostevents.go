@@ -0,0 +1,35 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+// Package lostevents counts events that are lost before they are sent to
+// GetEvents listeners, so that they can be reported to GetEvents clients in
+// lost_events messages.
+package lostevents
+
+import (
+	"sync/atomic"
+)
+
+var (
+	// RingBuffer counts the events lost by the perf ring buffer, or dropped
+	// because the ring buffer events queue was full.
+	RingBuffer atomic.Uint64
+	// EventCache counts the events dropped by the event cache after
+	// exhausting their retries. Events sent with incomplete process or pod
+	// information are not counted.
+	EventCache atomic.Uint64
+)
+
+// Counts is a snapshot of the lost events counters.
+type Counts struct {
+	RingBuffer uint64
+	EventCache uint64
+}
+
+// Read returns the current values of the lost events counters.
+func Read() Counts {
+	return Counts{
+		RingBuffer: RingBuffer.Load(),
+		EventCache: EventCache.Load(),
+	}
+}
@@ -5,6 +5,7 @@ package policymetrics
 
 import (
 	"context"
+	"strconv"
 	"strings"
 	"time"
 
@@ -42,6 +43,30 @@ var policyKernelMemory = metrics.MustNewCustomGauge(metrics.NewOpts(
 	},
 ))
 
+var selectorLabels = []metrics.UnconstrainedLabel{
+	metrics.LabelPolicy,
+	metrics.LabelPolicyNamespace,
+	{Name: "hook", ExampleValue: consts.ExampleKprobeLabel},
+	{Name: "selector", ExampleValue: "0"},
+}
+
+var actionLabel = metrics.ConstrainedLabel{
+	Name:   "action",
+	Values: []string{"post", "override", "sigkill", "ratelimited"},
+}
+
+var selectorMatches = metrics.MustNewCustomCounter(metrics.NewOpts(
+	consts.MetricsNamespace, "", "tracingpolicy_selector_matches_total",
+	"The number of times a selector of a tracing policy matched.",
+	nil, nil, selectorLabels,
+))
+
+var selectorActions = metrics.MustNewCustomCounter(metrics.NewOpts(
+	consts.MetricsNamespace, "", "tracingpolicy_selector_actions_total",
+	"The number of actions triggered by a selector of a tracing policy, by action.",
+	nil, []metrics.ConstrainedLabel{actionLabel}, selectorLabels,
+))
+
 // This metric collector converts the output of ListTracingPolicies into a few
 // gauges metrics on collection. Thus, it needs a sensor manager to query.
 func NewPolicyCollector() metrics.CollectorWithInit {
@@ -49,6 +74,8 @@ func NewPolicyCollector() metrics.CollectorWithInit {
 		metrics.CustomMetrics{
 			policyState,
 			policyKernelMemory,
+			selectorMatches,
+			selectorActions,
 		},
 		collect,
 		collectForDocs,
@@ -75,6 +102,9 @@ func collect(ch chan<- prometheus.Metric) {
 		state := policy.State
 		counters[state]++
 		ch <- policyKernelMemory.MustMetric(float64(policy.KernelMemoryBytes), policy.Name, policy.Namespace)
+		for _, stats := range policy.SelectorStats {
+			collectSelectorStats(ch, policy, stats)
+		}
 	}
 
 	ch <- policyState.MustMetric(
@@ -95,9 +125,26 @@ func collect(ch chan<- prometheus.Metric) {
 	)
 }
 
+func collectSelectorStats(ch chan<- prometheus.Metric, policy *tetragon.TracingPolicyStatus, stats *tetragon.TracingPolicySelectorStats) {
+	selector := strconv.FormatUint(uint64(stats.Selector), 10)
+	ch <- selectorMatches.MustMetric(float64(stats.MatchCount), policy.Name, policy.Namespace, stats.Hook, selector)
+	for action, count := range map[string]uint64{
+		"post":        stats.PostCount,
+		"override":    stats.OverrideCount,
+		"sigkill":     stats.SigkillCount,
+		"ratelimited": stats.RatelimitedCount,
+	} {
+		ch <- selectorActions.MustMetric(float64(count), action, policy.Name, policy.Namespace, stats.Hook, selector)
+	}
+}
+
 func collectForDocs(ch chan<- prometheus.Metric) {
 	for _, state := range stateLabel.Values {
 		ch <- policyState.MustMetric(0, state)
 	}
 	ch <- policyKernelMemory.MustMetric(0, consts.ExamplePolicyLabel, consts.ExampleNamespace)
+	ch <- selectorMatches.MustMetric(0, consts.ExamplePolicyLabel, consts.ExampleNamespace, consts.ExampleKprobeLabel, "0")
+	for _, action := range actionLabel.Values {
+		ch <- selectorActions.MustMetric(0, action, consts.ExamplePolicyLabel, consts.ExampleNamespace, consts.ExampleKprobeLabel, "0")
+	}
 }
@@ -8,6 +8,7 @@ import (
 
 	"github.com/cilium/tetragon/pkg/eventcache"
 	"github.com/cilium/tetragon/pkg/exporter"
+	"github.com/cilium/tetragon/pkg/exporter/otlp"
 	"github.com/cilium/tetragon/pkg/grpc/tracing"
 	"github.com/cilium/tetragon/pkg/metrics"
 	"github.com/cilium/tetragon/pkg/metrics/cgroupratemetrics"
@@ -22,6 +23,7 @@ import (
 	"github.com/cilium/tetragon/pkg/metrics/watchermetrics"
 	"github.com/cilium/tetragon/pkg/observer"
 	"github.com/cilium/tetragon/pkg/process"
+	"github.com/cilium/tetragon/pkg/spool"
 	"github.com/cilium/tetragon/pkg/version"
 	grpcmetrics "github.com/grpc-ecosystem/go-grpc-middleware/providers/prometheus"
 	"github.com/prometheus/client_golang/prometheus"
@@ -84,6 +86,8 @@ func registerHealthMetrics(group metrics.Group) {
 	group.ExtendInit(tracing.InitMetrics)
 	// exporter metrics
 	exporter.RegisterMetrics(group)
+	otlp.RegisterMetrics(group)
+	spool.RegisterMetrics(group)
 	// cgrup rate metrics
 	cgroupratemetrics.RegisterMetrics(group)
 
@@ -15,6 +15,7 @@ import (
 	"github.com/cilium/ebpf/perf"
 	"github.com/cilium/tetragon/pkg/api/readyapi"
 	"github.com/cilium/tetragon/pkg/logger/logfields"
+	"github.com/cilium/tetragon/pkg/lostevents"
 	"github.com/cilium/tetragon/pkg/option"
 	"github.com/cilium/tetragon/pkg/strutils"
 )
@@ -107,12 +108,14 @@ func (k *Observer) RunEvents(stopCtx context.Context, ready func()) error {
 					default:
 						// eventsQueue channel is full, drop the event
 						queueLost.Inc()
+						lostevents.RingBuffer.Add(1)
 					}
 					RingbufReceived.Inc()
 				}
 
 				if record.LostSamples > 0 {
 					RingbufLost.Add(float64(record.LostSamples))
+					lostevents.RingBuffer.Add(record.LostSamples)
 				}
 			}
 		}
@@ -13,6 +13,7 @@ import (
 	"github.com/cilium/tetragon/pkg/api/readyapi"
 	"github.com/cilium/tetragon/pkg/bpf"
 	"github.com/cilium/tetragon/pkg/logger/logfields"
+	"github.com/cilium/tetragon/pkg/lostevents"
 )
 
 func (observer *Observer) RunEvents(stopCtx context.Context, ready func()) error {
@@ -66,11 +67,13 @@ func (observer *Observer) RunEvents(stopCtx context.Context, ready func()) error
 				default:
 					// drop the event, since channel is full
 					queueLost.Inc()
+					lostevents.RingBuffer.Add(1)
 				}
 				RingbufReceived.Inc()
 			}
 			if record.LostSamples > 0 {
 				RingbufLost.Add(float64(record.LostSamples))
+				lostevents.RingBuffer.Add(record.LostSamples)
 			}
 		}
 	}()
@@ -43,6 +43,7 @@ type config struct {
 	K8sKubeConfigPath string
 
 	DisableKprobeMulti bool
+	DisableKprobeFexit bool
 
 	GopsAddr string
 
@@ -59,6 +60,12 @@ type config struct {
 	ProcessCacheSize       int
 	DataCacheSize          int
 	ProcessCacheGCInterval time.Duration
+	// ProcessCacheMemoryLimit is the memory budget of the process cache in
+	// bytes. If set, it replaces ProcessCacheSize.
+	ProcessCacheMemoryLimit int
+
+	ProcessCacheSnapshotFile     string
+	ProcessCacheSnapshotInterval time.Duration
 
 	MetricsServer      string
 	MetricsLabelFilter metrics.LabelFilter
@@ -73,11 +80,25 @@ type config struct {
 	ExportFileCompress         bool
 	ExportRateLimit            int
 	ExportFilePerm             string
+	ExportFileFormat           string
+	ExportFileCompression      string
 
 	// Export aggregation options
 	EnableExportAggregation     bool
 	ExportAggregationWindowSize time.Duration
 	ExportAggregationBufferSize uint64
+	ExportAggregationKey        []string
+
+	// OpenTelemetry (OTLP) logs export options
+	ExportOTLPEndpoint      string
+	ExportOTLPProtocol      string
+	ExportOTLPInsecure      bool
+	ExportOTLPHeaders       map[string]string
+	ExportOTLPBatchSize     int
+	ExportOTLPFlushInterval time.Duration
+	ExportOTLPQueueSize     int
+
+	ExportPipelines string
 
 	CpuProfile string
 	MemProfile string
@@ -85,6 +106,11 @@ type config struct {
 
 	EventQueueSize uint
 
+	EventSpoolDir           string
+	EventSpoolMaxSizeMB     int
+	EventSpoolSegmentSizeMB int
+	EventSpoolQueueSize     int
+
 	ReleasePinned bool
 
 	EnablePolicyFilter          bool
@@ -112,12 +138,19 @@ type config struct {
 	EnableCRI   bool
 	CRIEndpoint string
 
+	EnableCRIPodMetadata bool
+	KubeletPodsURL       string
+
 	EnableCgIDmap      bool
 	EnableCgIDmapDebug bool
 	EnableCgTrackerID  bool
 
-	EventCacheNumRetries int
-	EventCacheRetryDelay int
+	EventCacheNumRetries        int
+	EventCacheRetryDelay        int
+	EventCacheAdaptive          bool
+	EventCacheMaxRetryDelay     int
+	EventCacheSize              int
+	EventCacheEnrichmentUpdates bool
 
 	CompatibilitySyscall64SizeType bool
 
@@ -143,8 +176,9 @@ var (
 
 		// set default valus for the event cache
 		// mainly used in the case of testing
-		EventCacheNumRetries: defaults.DefaultEventCacheNumRetries,
-		EventCacheRetryDelay: defaults.DefaultEventCacheRetryDelay,
+		EventCacheNumRetries:    defaults.DefaultEventCacheNumRetries,
+		EventCacheRetryDelay:    defaults.DefaultEventCacheRetryDelay,
+		EventCacheMaxRetryDelay: defaults.DefaultEventCacheMaxRetryDelay,
 	}
 )
 
@@ -152,6 +186,12 @@ func CgroupRateEnabled() bool {
 	return Config.CgroupRate.Events != 0 && Config.CgroupRate.Interval != 0
 }
 
+// PodMetadataEnabled returns true if the pod metadata of the processes is
+// resolved, either from the Kubernetes API server or from the CRI.
+func PodMetadataEnabled() bool {
+	return Config.EnableK8s || Config.EnableCRIPodMetadata
+}
+
 // AncestorsEnabled returns the value of the configuration option responsible for
 // enabling process ancestors for events with the specified eventType.
 // If events with the specified eventType don't support ancestors, false is returned.
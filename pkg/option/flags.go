@@ -36,6 +36,10 @@ const (
 	KeyForceLargeProgs        = "force-large-progs"
 	KeyClusterName            = "cluster-name"
 
+	KeyProcessCacheMemoryLimit      = "process-cache-memory-limit"
+	KeyProcessCacheSnapshotFile     = "process-cache-snapshot-file"
+	KeyProcessCacheSnapshotInterval = "process-cache-snapshot-interval"
+
 	KeyLogLevel  = "log-level"
 	KeyLogFormat = "log-format"
 
@@ -66,10 +70,23 @@ const (
 	KeyExportFileCompress         = "export-file-compress"
 	KeyExportRateLimit            = "export-rate-limit"
 	KeyExportFilePerm             = "export-file-perm"
+	KeyExportFileFormat           = "export-file-format"
+	KeyExportFileCompression      = "export-file-compression"
 
 	KeyEnableExportAggregation     = "enable-export-aggregation"
 	KeyExportAggregationWindowSize = "export-aggregation-window-size"
 	KeyExportAggregationBufferSize = "export-aggregation-buffer-size"
+	KeyExportAggregationKey        = "export-aggregation-key"
+
+	KeyExportOTLPEndpoint      = "export-otlp-endpoint"
+	KeyExportOTLPProtocol      = "export-otlp-protocol"
+	KeyExportOTLPInsecure      = "export-otlp-insecure"
+	KeyExportOTLPHeaders       = "export-otlp-headers"
+	KeyExportOTLPBatchSize     = "export-otlp-batch-size"
+	KeyExportOTLPFlushInterval = "export-otlp-flush-interval"
+	KeyExportOTLPQueueSize     = "export-otlp-queue-size"
+
+	KeyExportPipelines = "export-pipelines"
 
 	KeyExportAllowlist = "export-allowlist"
 	KeyExportDenylist  = "export-denylist"
@@ -77,9 +94,12 @@ const (
 	KeyFieldFilters     = "field-filters"
 	KeyRedactionFilters = "redaction-filters"
 
+	KeyExportComputedFields = "export-computed-fields"
+
 	KeyNetnsDir = "netns-dir"
 
 	KeyDisableKprobeMulti = "disable-kprobe-multi"
+	KeyDisableKprobeFexit = "disable-kprobe-fexit"
 	KeyDisableUprobeMulti = "disable-uprobe-multi"
 
 	KeyRBSize      = "rb-size"
@@ -88,6 +108,11 @@ const (
 
 	KeyEventQueueSize = "event-queue-size"
 
+	KeyEventSpoolDir           = "event-spool-dir"
+	KeyEventSpoolMaxSizeMB     = "event-spool-max-size-mb"
+	KeyEventSpoolSegmentSizeMB = "event-spool-segment-size-mb"
+	KeyEventSpoolQueueSize     = "event-spool-queue-size"
+
 	KeyReleasePinnedBPF = "release-pinned-bpf"
 
 	KeyEnablePolicyFilter          = "enable-policy-filter"
@@ -119,12 +144,19 @@ const (
 	KeyEnableCRI   = "enable-cri"
 	KeyCRIEndpoint = "cri-endpoint"
 
+	KeyEnableCRIPodMetadata = "enable-cri-pod-metadata"
+	KeyKubeletPodsURL       = "kubelet-pods-url"
+
 	KeyEnableCgIDmap      = "enable-cgidmap"
 	KeyEnableCgIDmapDebug = "enable-cgidmap-debug"
 	KeyEnableCgTrackerID  = "enable-cgtrackerid"
 
-	KeyEventCacheRetries    = "event-cache-retries"
-	KeyEventCacheRetryDelay = "event-cache-retry-delay"
+	KeyEventCacheRetries           = "event-cache-retries"
+	KeyEventCacheRetryDelay        = "event-cache-retry-delay"
+	KeyEventCacheAdaptive          = "event-cache-adaptive"
+	KeyEventCacheMaxRetryDelay     = "event-cache-max-retry-delay"
+	KeyEventCacheSize              = "event-cache-size"
+	KeyEventCacheEnrichmentUpdates = "event-cache-enrichment-updates"
 
 	KeyCompatibilitySyscall64SizeType = "enable-compatibility-syscall64-size-type"
 
@@ -164,6 +196,7 @@ func ReadAndSetFlags() error {
 	Config.K8sKubeConfigPath = viper.GetString(KeyK8sKubeConfigPath)
 
 	Config.DisableKprobeMulti = viper.GetBool(KeyDisableKprobeMulti)
+	Config.DisableKprobeFexit = viper.GetBool(KeyDisableKprobeFexit)
 
 	var err error
 	var enableAncestors []string
@@ -203,6 +236,19 @@ func ReadAndSetFlags() error {
 		return errors.New("failed to parse process-cache-gc-interval value. Must be >= 0")
 	}
 
+	if Config.ProcessCacheMemoryLimit, err = strutils.ParseSize(viper.GetString(KeyProcessCacheMemoryLimit)); err != nil {
+		return fmt.Errorf("failed to parse process-cache-memory-limit value: %w", err)
+	}
+	if Config.ProcessCacheMemoryLimit < 0 {
+		return errors.New("failed to parse process-cache-memory-limit value. Must be >= 0")
+	}
+
+	Config.ProcessCacheSnapshotFile = viper.GetString(KeyProcessCacheSnapshotFile)
+	Config.ProcessCacheSnapshotInterval = viper.GetDuration(KeyProcessCacheSnapshotInterval)
+	if Config.ProcessCacheSnapshotInterval < 0 {
+		return errors.New("failed to parse process-cache-snapshot-interval value. Must be >= 0")
+	}
+
 	Config.MetricsServer = viper.GetString(KeyMetricsServer)
 	Config.MetricsLabelFilter = DefaultLabelFilter().WithEnabledLabels(ParseMetricsLabelFilter(viper.GetString(KeyMetricsLabelFilter)))
 	Config.ServerAddress = viper.GetString(KeyServerAddress)
@@ -214,10 +260,24 @@ func ReadAndSetFlags() error {
 	Config.ExportFileCompress = viper.GetBool(KeyExportFileCompress)
 	Config.ExportRateLimit = viper.GetInt(KeyExportRateLimit)
 	Config.ExportFilePerm = viper.GetString(KeyExportFilePerm)
+	Config.ExportFileFormat = viper.GetString(KeyExportFileFormat)
+	Config.ExportFileCompression = viper.GetString(KeyExportFileCompression)
 
 	Config.EnableExportAggregation = viper.GetBool(KeyEnableExportAggregation)
 	Config.ExportAggregationWindowSize = viper.GetDuration(KeyExportAggregationWindowSize)
 	Config.ExportAggregationBufferSize = viper.GetUint64(KeyExportAggregationBufferSize)
+	Config.ExportAggregationKey = viper.GetStringSlice(KeyExportAggregationKey)
+
+	Config.ExportOTLPEndpoint = viper.GetString(KeyExportOTLPEndpoint)
+	Config.ExportOTLPProtocol = viper.GetString(KeyExportOTLPProtocol)
+	Config.ExportOTLPInsecure = viper.GetBool(KeyExportOTLPInsecure)
+	if Config.ExportOTLPHeaders, err = parseKeyValueList(viper.GetString(KeyExportOTLPHeaders)); err != nil {
+		return fmt.Errorf("failed to parse %s value: %w", KeyExportOTLPHeaders, err)
+	}
+	Config.ExportOTLPBatchSize = viper.GetInt(KeyExportOTLPBatchSize)
+	Config.ExportOTLPFlushInterval = viper.GetDuration(KeyExportOTLPFlushInterval)
+	Config.ExportOTLPQueueSize = viper.GetInt(KeyExportOTLPQueueSize)
+	Config.ExportPipelines = viper.GetString(KeyExportPipelines)
 
 	Config.CpuProfile = viper.GetString(KeyCpuProfile)
 	Config.MemProfile = viper.GetString(KeyMemProfile)
@@ -225,6 +285,11 @@ func ReadAndSetFlags() error {
 
 	Config.EventQueueSize = viper.GetUint(KeyEventQueueSize)
 
+	Config.EventSpoolDir = viper.GetString(KeyEventSpoolDir)
+	Config.EventSpoolMaxSizeMB = viper.GetInt(KeyEventSpoolMaxSizeMB)
+	Config.EventSpoolSegmentSizeMB = viper.GetInt(KeyEventSpoolSegmentSizeMB)
+	Config.EventSpoolQueueSize = viper.GetInt(KeyEventSpoolQueueSize)
+
 	Config.ReleasePinned = viper.GetBool(KeyReleasePinnedBPF)
 	Config.EnablePolicyFilter = viper.GetBool(KeyEnablePolicyFilter)
 	Config.EnablePolicyFilterCgroupMap = viper.GetBool(KeyEnablePolicyFilterCgroupMap)
@@ -263,6 +328,17 @@ func ReadAndSetFlags() error {
 	Config.EnableCRI = viper.GetBool(KeyEnableCRI)
 	Config.CRIEndpoint = viper.GetString(KeyCRIEndpoint)
 
+	Config.EnableCRIPodMetadata = viper.GetBool(KeyEnableCRIPodMetadata)
+	Config.KubeletPodsURL = viper.GetString(KeyKubeletPodsURL)
+	if Config.EnableCRIPodMetadata {
+		if !Config.EnableCRI {
+			return fmt.Errorf("%s requires %s", KeyEnableCRIPodMetadata, KeyEnableCRI)
+		}
+		if Config.EnableK8s {
+			return fmt.Errorf("%s cannot be used with %s", KeyEnableCRIPodMetadata, KeyEnableK8sAPI)
+		}
+	}
+
 	Config.EnableCgIDmap = viper.GetBool(KeyEnableCgIDmap)
 	Config.EnableCgIDmapDebug = viper.GetBool(KeyEnableCgIDmapDebug)
 	if viper.IsSet(KeyEnableCgTrackerID) {
@@ -274,6 +350,16 @@ func ReadAndSetFlags() error {
 
 	Config.EventCacheNumRetries = viper.GetInt(KeyEventCacheRetries)
 	Config.EventCacheRetryDelay = viper.GetInt(KeyEventCacheRetryDelay)
+	Config.EventCacheAdaptive = viper.GetBool(KeyEventCacheAdaptive)
+	Config.EventCacheMaxRetryDelay = viper.GetInt(KeyEventCacheMaxRetryDelay)
+	if Config.EventCacheAdaptive && Config.EventCacheMaxRetryDelay < Config.EventCacheRetryDelay {
+		return fmt.Errorf("%s must be greater than or equal to %s", KeyEventCacheMaxRetryDelay, KeyEventCacheRetryDelay)
+	}
+	Config.EventCacheSize = viper.GetInt(KeyEventCacheSize)
+	if Config.EventCacheSize < 0 {
+		return errors.New("failed to parse event-cache-size value. Must be >= 0")
+	}
+	Config.EventCacheEnrichmentUpdates = viper.GetBool(KeyEventCacheEnrichmentUpdates)
 
 	Config.CompatibilitySyscall64SizeType = viper.GetBool(KeyCompatibilitySyscall64SizeType)
 
@@ -343,6 +429,23 @@ func stringToSliceHookFunc(sep string) mapstructure.DecodeHookFunc {
 	}
 }
 
+// parseKeyValueList parses a comma-separated list of key=value pairs.
+func parseKeyValueList(s string) (map[string]string, error) {
+	ret := map[string]string{}
+	for _, kv := range strings.Split(s, ",") {
+		kv = strings.TrimSpace(kv)
+		if kv == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok || strings.TrimSpace(k) == "" {
+			return nil, fmt.Errorf("invalid key=value pair %q", kv)
+		}
+		ret[strings.TrimSpace(k)] = strings.TrimSpace(v)
+	}
+	return ret, nil
+}
+
 func AddFlags(flags *pflag.FlagSet) {
 	flags.String(KeyConfigDir, "", "Configuration directory that contains a file for each option")
 	flags.BoolP(KeyDebug, "d", false, "Enable debug messages. Equivalent to '--log-level=debug'")
@@ -356,6 +459,9 @@ func AddFlags(flags *pflag.FlagSet) {
 	flags.Int(KeyProcessCacheSize, 65536, "Size of the process cache")
 	flags.Int(KeyDataCacheSize, 1024, "Size of the data events cache")
 	flags.Duration(KeyProcessCacheGCInterval, defaults.DefaultProcessCacheGCInterval, "Time between checking the process cache for old entries")
+	flags.String(KeyProcessCacheMemoryLimit, "0", "Limit the process cache by the estimated memory of its entries instead of by their number, replacing --process-cache-size (allows K/M/G suffix). Entries of exited processes are evicted first, and ancestors of running processes are never evicted. Disabled by default")
+	flags.String(KeyProcessCacheSnapshotFile, "", "File to save the process cache to on exit, and to restore it from on start when the sensors of the previous instance were kept (see --keep-sensors-on-exit). Disabled by default")
+	flags.Duration(KeyProcessCacheSnapshotInterval, 0, "Time between periodic saves of the process cache to the file set by --process-cache-snapshot-file. If 0, the process cache is only saved on exit")
 	flags.Bool(KeyForceSmallProgs, false, "Force loading small programs, even in kernels with >= 5.3 versions")
 	flags.Bool(KeyForceLargeProgs, false, "Force loading large programs, even in kernels with < 5.3 versions")
 	flags.String(KeyExportFilename, "", "Filename for JSON export. Disabled by default")
@@ -364,6 +470,8 @@ func AddFlags(flags *pflag.FlagSet) {
 	flags.Int(KeyExportFileMaxBackups, 5, "Number of rotated JSON export files to retain")
 	flags.Bool(KeyExportFileCompress, false, "Compress rotated JSON export files")
 	flags.String(KeyExportFilePerm, defaults.DefaultLogsPermission, "Access permissions on JSON export files")
+	flags.String(KeyExportFileFormat, "json", "Format of export files. json (JSON lines) or protobuf (length-delimited binary protobuf)")
+	flags.String(KeyExportFileCompression, "none", "Compression of export files, including the current file. none or zstd (not compatible with --export-file-compress)")
 	flags.Int(KeyExportRateLimit, -1, "Rate limit (per minute) for event export. Set to -1 to disable")
 	flags.String(KeyLogLevel, "info", "Set log level")
 	flags.String(KeyLogFormat, "text", "Set log format")
@@ -376,6 +484,10 @@ func AddFlags(flags *pflag.FlagSet) {
 	flags.Bool(KeyEnableProcessCred, false, "Enable process_cred events")
 	flags.Bool(KeyEnableProcessNs, false, "Enable namespace information in process_exec and process_kprobe events")
 	flags.Uint(KeyEventQueueSize, 10000, "Set the size of the internal event queue.")
+	flags.String(KeyEventSpoolDir, "", "Directory of the on-disk event spool. If set, events are spooled before being exported, and exporters resume from their last exported event after a restart. Disabled by default")
+	flags.Int(KeyEventSpoolMaxSizeMB, 1024, "Maximum size of the event spool in megabytes. The oldest events are removed when the spool grows larger")
+	flags.Int(KeyEventSpoolSegmentSizeMB, 64, "Size in megabytes of the event spool segment files")
+	flags.Int(KeyEventSpoolQueueSize, 10000, "Number of events buffered in memory before being spooled. Events are dropped when the buffer is full")
 	flags.Bool(KeyEnablePodAnnotations, false, "Add pod annotations field to events.")
 	flags.StringSlice(KeyEnableAncestors, []string{}, "Comma-separated list of process event types to enable ancestors for. Supported event types are: base, kprobe, tracepoint, uprobe, lsm. Unknown event types will be ignored. Type 'base' enables ancestors for process_exec and process_exit events and is required by all other supported event types for correct reference counting. An empty string disables ancestors completely")
 
@@ -397,6 +509,18 @@ func AddFlags(flags *pflag.FlagSet) {
 	flags.Bool(KeyEnableExportAggregation, false, "Enable JSON export aggregation")
 	flags.Duration(KeyExportAggregationWindowSize, 15*time.Second, "JSON export aggregation time window")
 	flags.Uint64(KeyExportAggregationBufferSize, 10000, "Aggregator channel buffer size")
+	flags.StringSlice(KeyExportAggregationKey, nil, "Fields identifying aggregated events. One or more of: policy_name, function_name, binary, pod, argN (N is the argument index). Defaults to policy_name,function_name,binary,pod")
+
+	// OpenTelemetry logs export options
+	flags.String(KeyExportOTLPEndpoint, "", "OTLP collector endpoint for logs export (e.g. 'localhost:4317' for grpc or 'http://localhost:4318' for http). Disabled by default")
+	flags.String(KeyExportOTLPProtocol, "grpc", "OTLP protocol used for logs export. One of: grpc, http")
+	flags.Bool(KeyExportOTLPInsecure, false, "Disable TLS for OTLP logs export")
+	flags.String(KeyExportOTLPHeaders, "", "Comma-separated list of key=value headers sent with OTLP logs export requests")
+	flags.Int(KeyExportOTLPBatchSize, 512, "Maximum number of log records per OTLP export request")
+	flags.Duration(KeyExportOTLPFlushInterval, time.Second, "Maximum time a log record is buffered before being exported to the OTLP collector")
+	flags.Int(KeyExportOTLPQueueSize, 10000, "Number of log records buffered while the OTLP collector is unavailable. Records are dropped when the buffer is full")
+
+	flags.String(KeyExportPipelines, "", "YAML list of additional named export pipelines, each with its own filters, field filters, rate limit and sink (file, unixSocket, otlp, kafka or nats)")
 
 	// JSON export filter options
 	flags.String(KeyExportAllowlist, "", "JSON export allowlist")
@@ -408,11 +532,15 @@ func AddFlags(flags *pflag.FlagSet) {
 	// Redaction filters
 	flags.String(KeyRedactionFilters, "", "Redaction filters for events")
 
+	// Computed fields options for export
+	flags.String(KeyExportComputedFields, "", "Line-separated JSON list of named CEL expressions evaluated on exported events, whose results are set in computed_fields")
+
 	// Network namespace options
 	flags.String(KeyNetnsDir, "/var/run/docker/netns/", "Network namespace dir")
 
 	// Allow to disable kprobe multi interface
 	flags.Bool(KeyDisableKprobeMulti, false, "Allow to disable kprobe multi interface")
+	flags.Bool(KeyDisableKprobeFexit, false, "Allow to disable the use of fexit programs in place of kretprobes for kprobes that report return values")
 
 	// Allow to specify perf ring buffer size
 	flags.String(KeyRBSizeTotal, "0", "Set perf ring buffer size in total for all cpus (default 65k per cpu, allows K/M/G suffix)")
@@ -457,12 +585,19 @@ func AddFlags(flags *pflag.FlagSet) {
 	flags.Bool(KeyEnableCRI, false, "enable CRI client for tetragon")
 	flags.String(KeyCRIEndpoint, "", "CRI endpoint")
 
+	flags.Bool(KeyEnableCRIPodMetadata, false, "Resolve pod metadata from the CRI instead of the Kubernetes API server. Requires --enable-cri")
+	flags.String(KeyKubeletPodsURL, "", "URL of the kubelet pod list (e.g., http://localhost:10255/pods) used with --enable-cri-pod-metadata for pod metadata that the CRI does not provide")
+
 	flags.Bool(KeyEnableCgIDmap, false, "enable pod resolution via cgroup ids")
 	flags.Bool(KeyEnableCgIDmapDebug, false, "enable cgidmap debugging info")
 	flags.Bool(KeyEnableCgTrackerID, true, fmt.Sprintf("enable cgroup tracker id (only used if '%s' is set)", KeyEnableCgIDmap))
 
 	flags.Int(KeyEventCacheRetries, defaults.DefaultEventCacheNumRetries, "Number of retries for event cache")
 	flags.Int(KeyEventCacheRetryDelay, defaults.DefaultEventCacheRetryDelay, "Delay in seconds between event cache retries")
+	flags.Bool(KeyEventCacheAdaptive, false, "Adapt the delay between event cache retries of events waiting for pod information to the observed delay of the pod information, up to --event-cache-max-retry-delay")
+	flags.Int(KeyEventCacheMaxRetryDelay, defaults.DefaultEventCacheMaxRetryDelay, "Maximum delay in seconds between event cache retries with --event-cache-adaptive")
+	flags.Int(KeyEventCacheSize, 0, "Maximum number of events in the event cache. When the event cache is full, its oldest event is emitted without waiting for its retries. Set to 0 for no limit")
+	flags.Bool(KeyEventCacheEnrichmentUpdates, false, "Emit a process_update event when the pod information of a process becomes available after events of the process were emitted without it")
 
 	flags.Bool(KeyCompatibilitySyscall64SizeType, false, "syscall64 type will produce output of type size (compatibility flag, will be removed in v1.4)")
 
@@ -23,10 +23,15 @@ const (
 	PolicyConfMapName = "policy_conf"
 )
 
-// NB: if we add more fields here, we would need to modify SetModeInBPFMap to do a read-modify
-// operation to set the mode.
+// NB: should match struct policy_conf in bpf/lib/policy_conf.h
 type PolicyConf struct {
 	Mode Mode
+	// PodMode is the mode of the pods selected by the PodModeFilterID
+	// policy filter
+	PodMode Mode
+	// PodModeFilterID is the policy filter id of the pods whose mode is
+	// PodMode, or zero if the mode of the policy applies to all pods
+	PodModeFilterID uint32
 }
 
 func ParseMode(s string) (Mode, error) {
@@ -67,10 +72,12 @@ func SetModeInBPFMap(fname string, mode Mode) error {
 	}
 	defer m.Close()
 
-	conf := PolicyConf{
-		Mode: mode,
-	}
+	var conf PolicyConf
 	zero := uint32(0)
+	if err = m.Lookup(&zero, &conf); err != nil {
+		return fmt.Errorf("failed to lookup map %q: %w", fname, err)
+	}
+	conf.Mode = mode
 	if err = m.Update(&zero, &conf, ebpf.UpdateExist); err != nil {
 		return fmt.Errorf("failed to update map %q with val %v: %w", fname, conf, err)
 	}
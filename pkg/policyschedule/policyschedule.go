@@ -0,0 +1,192 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+// Package policyschedule implements the schedules of tracing policies, which
+// define when a policy is enabled (see v1alpha1.ScheduleSpec).
+package policyschedule
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+	// the agent image does not ship the time zone database
+	_ "time/tzdata"
+
+	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
+)
+
+var weekdays = map[string]time.Weekday{
+	"Sun": time.Sunday,
+	"Mon": time.Monday,
+	"Tue": time.Tuesday,
+	"Wed": time.Wednesday,
+	"Thu": time.Thursday,
+	"Fri": time.Friday,
+	"Sat": time.Saturday,
+}
+
+type window struct {
+	// days of the window, nil for every day
+	days []time.Weekday
+	// start and end of the window, in minutes since midnight
+	start, end int
+}
+
+// bounds returns the bounds of the window starting on the day of t.
+func (w *window) bounds(t time.Time, loc *time.Location) (time.Time, time.Time) {
+	y, m, d := t.Date()
+	start := time.Date(y, m, d, w.start/60, w.start%60, 0, 0, loc)
+	if w.end > w.start {
+		return start, time.Date(y, m, d, w.end/60, w.end%60, 0, 0, loc)
+	}
+	return start, time.Date(y, m, d+1, w.end/60, w.end%60, 0, 0, loc)
+}
+
+func (w *window) onDay(day time.Weekday) bool {
+	return w.days == nil || slices.Contains(w.days, day)
+}
+
+// Schedule is the schedule of a policy
+type Schedule struct {
+	windows []window
+	loc     *time.Location
+	// expiry is the time the TTL of the policy expires, zero if the policy
+	// has no TTL
+	expiry time.Time
+}
+
+// New returns the schedule of spec, for a policy added at time added. It
+// returns nil if spec is nil.
+func New(spec *v1alpha1.ScheduleSpec, added time.Time) (*Schedule, error) {
+	if spec == nil {
+		return nil, nil
+	}
+
+	s := &Schedule{loc: time.UTC}
+	if spec.TTL != "" {
+		ttl, err := time.ParseDuration(spec.TTL)
+		if err != nil {
+			return nil, fmt.Errorf("schedule: failed to parse ttl %q: %w", spec.TTL, err)
+		}
+		if ttl <= 0 {
+			return nil, fmt.Errorf("schedule: ttl %q is not positive", spec.TTL)
+		}
+		s.expiry = added.Add(ttl)
+	}
+	if spec.TimeZone != "" {
+		loc, err := time.LoadLocation(spec.TimeZone)
+		if err != nil {
+			return nil, fmt.Errorf("schedule: failed to load time zone %q: %w", spec.TimeZone, err)
+		}
+		s.loc = loc
+	}
+	for i := range spec.Windows {
+		w, err := parseWindow(&spec.Windows[i])
+		if err != nil {
+			return nil, fmt.Errorf("schedule: window %d: %w", i, err)
+		}
+		s.windows = append(s.windows, w)
+	}
+	if s.expiry.IsZero() && len(s.windows) == 0 {
+		return nil, errors.New("schedule: neither ttl nor windows are set")
+	}
+	return s, nil
+}
+
+func parseClock(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil || len(s) != len("15:04") {
+		return 0, fmt.Errorf("failed to parse time %q, expected HH:MM", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func parseWindow(spec *v1alpha1.ScheduleWindow) (window, error) {
+	var w window
+	var err error
+	for _, d := range spec.Days {
+		day, ok := weekdays[d]
+		if !ok {
+			return w, fmt.Errorf("invalid day %q", d)
+		}
+		w.days = append(w.days, day)
+	}
+	if w.start, err = parseClock(spec.Start); err != nil {
+		return w, err
+	}
+	if w.end, err = parseClock(spec.End); err != nil {
+		return w, err
+	}
+	return w, nil
+}
+
+// Active returns whether the schedule enables the policy at time t
+func (s *Schedule) Active(t time.Time) bool {
+	if !s.expiry.IsZero() && !t.Before(s.expiry) {
+		return false
+	}
+	if len(s.windows) == 0 {
+		return true
+	}
+
+	t = t.In(s.loc)
+	y, m, d := t.Date()
+	// windows ending after midnight might have started the day before
+	for _, day := range []time.Time{
+		time.Date(y, m, d-1, 0, 0, 0, 0, s.loc),
+		time.Date(y, m, d, 0, 0, 0, 0, s.loc),
+	} {
+		for i := range s.windows {
+			w := &s.windows[i]
+			if !w.onDay(day.Weekday()) {
+				continue
+			}
+			start, end := w.bounds(day, s.loc)
+			if !t.Before(start) && t.Before(end) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Next returns the time of the next transition of the schedule after time t,
+// i.e., the first time after t when Active changes. It returns false if there
+// is no such time.
+func (s *Schedule) Next(t time.Time) (time.Time, bool) {
+	var candidates []time.Time
+	if !s.expiry.IsZero() && t.Before(s.expiry) {
+		candidates = append(candidates, s.expiry)
+	}
+
+	lt := t.In(s.loc)
+	y, m, d := lt.Date()
+	// windows are weekly, so looking at the next week is enough
+	for i := -1; i <= 8; i++ {
+		day := time.Date(y, m, d+i, 0, 0, 0, 0, s.loc)
+		for j := range s.windows {
+			w := &s.windows[j]
+			if !w.onDay(day.Weekday()) {
+				continue
+			}
+			start, end := w.bounds(day, s.loc)
+			candidates = append(candidates, start, end)
+		}
+	}
+
+	slices.SortFunc(candidates, func(a, b time.Time) int { return a.Compare(b) })
+	active := s.Active(t)
+	for _, c := range candidates {
+		if !c.After(t) {
+			continue
+		}
+		if !s.expiry.IsZero() && c.After(s.expiry) {
+			break
+		}
+		if s.Active(c) != active {
+			return c, true
+		}
+	}
+	return time.Time{}, false
+}
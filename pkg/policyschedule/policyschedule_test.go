@@ -0,0 +1,134 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package policyschedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
+)
+
+func date(s string) time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestScheduleNil(t *testing.T) {
+	s, err := New(nil, time.Now())
+	require.NoError(t, err)
+	assert.Nil(t, s)
+}
+
+func TestScheduleErrors(t *testing.T) {
+	tests := []struct {
+		spec v1alpha1.ScheduleSpec
+		err  string
+	}{
+		{v1alpha1.ScheduleSpec{}, "neither ttl nor windows"},
+		{v1alpha1.ScheduleSpec{TTL: "soon"}, "failed to parse ttl"},
+		{v1alpha1.ScheduleSpec{TTL: "-1m"}, "not positive"},
+		{v1alpha1.ScheduleSpec{TTL: "1m", TimeZone: "Mars/Olympus_Mons"}, "time zone"},
+		{v1alpha1.ScheduleSpec{Windows: []v1alpha1.ScheduleWindow{{Start: "9:00", End: "17:00"}}}, "window 0: failed to parse time"},
+		{v1alpha1.ScheduleSpec{Windows: []v1alpha1.ScheduleWindow{{Days: []string{"Monday"}, Start: "09:00", End: "17:00"}}}, "invalid day"},
+	}
+	for _, tc := range tests {
+		_, err := New(&tc.spec, time.Now())
+		require.ErrorContains(t, err, tc.err)
+	}
+}
+
+func TestScheduleTTL(t *testing.T) {
+	added := date("2025-03-10T10:00:00Z")
+	s, err := New(&v1alpha1.ScheduleSpec{TTL: "30m"}, added)
+	require.NoError(t, err)
+
+	assert.True(t, s.Active(added))
+	assert.True(t, s.Active(date("2025-03-10T10:29:59Z")))
+	assert.False(t, s.Active(date("2025-03-10T10:30:00Z")))
+
+	next, ok := s.Next(added)
+	require.True(t, ok)
+	assert.Equal(t, date("2025-03-10T10:30:00Z"), next)
+	_, ok = s.Next(next)
+	assert.False(t, ok)
+}
+
+func TestScheduleWindows(t *testing.T) {
+	s, err := New(&v1alpha1.ScheduleSpec{
+		Windows: []v1alpha1.ScheduleWindow{
+			{Days: []string{"Mon", "Tue", "Wed", "Thu", "Fri"}, Start: "09:00", End: "17:00"},
+			// overnight window, starting on Saturday
+			{Days: []string{"Sat"}, Start: "22:00", End: "02:00"},
+		},
+	}, date("2025-03-10T00:00:00Z"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		t      string
+		active bool
+		next   string
+	}{
+		// Monday
+		{"2025-03-10T08:00:00Z", false, "2025-03-10T09:00:00Z"},
+		{"2025-03-10T09:00:00Z", true, "2025-03-10T17:00:00Z"},
+		{"2025-03-10T17:00:00Z", false, "2025-03-11T09:00:00Z"},
+		// Friday
+		{"2025-03-14T18:00:00Z", false, "2025-03-15T22:00:00Z"},
+		// Saturday and Sunday
+		{"2025-03-15T23:00:00Z", true, "2025-03-16T02:00:00Z"},
+		{"2025-03-16T01:59:00Z", true, "2025-03-16T02:00:00Z"},
+		{"2025-03-16T02:00:00Z", false, "2025-03-17T09:00:00Z"},
+	}
+	for _, tc := range tests {
+		now := date(tc.t)
+		assert.Equal(t, tc.active, s.Active(now), tc.t)
+		next, ok := s.Next(now)
+		require.True(t, ok, tc.t)
+		assert.Equal(t, date(tc.next), next.UTC(), tc.t)
+	}
+}
+
+func TestScheduleWindowsTTL(t *testing.T) {
+	s, err := New(&v1alpha1.ScheduleSpec{
+		TTL:      "24h",
+		TimeZone: "Europe/Paris",
+		Windows:  []v1alpha1.ScheduleWindow{{Start: "09:00", End: "17:00"}},
+	}, date("2025-03-10T12:00:00Z"))
+	require.NoError(t, err)
+
+	// 09:00 in Paris is 08:00 UTC in March
+	assert.True(t, s.Active(date("2025-03-10T12:00:00Z")))
+	next, ok := s.Next(date("2025-03-10T12:00:00Z"))
+	require.True(t, ok)
+	assert.Equal(t, date("2025-03-10T16:00:00Z"), next.UTC())
+	next, ok = s.Next(date("2025-03-10T16:00:00Z"))
+	require.True(t, ok)
+	assert.Equal(t, date("2025-03-11T08:00:00Z"), next.UTC())
+
+	// the TTL expires at noon
+	next, ok = s.Next(date("2025-03-11T08:00:00Z"))
+	require.True(t, ok)
+	assert.Equal(t, date("2025-03-11T12:00:00Z"), next.UTC())
+	assert.False(t, s.Active(next))
+	_, ok = s.Next(next)
+	assert.False(t, ok)
+}
+
+func TestScheduleAlwaysActive(t *testing.T) {
+	s, err := New(&v1alpha1.ScheduleSpec{
+		Windows: []v1alpha1.ScheduleWindow{{Start: "00:00", End: "00:00"}},
+	}, time.Now())
+	require.NoError(t, err)
+	now := date("2025-03-10T12:00:00Z")
+	assert.True(t, s.Active(now))
+	_, ok := s.Next(now)
+	assert.False(t, ok)
+}
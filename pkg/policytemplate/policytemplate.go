@@ -0,0 +1,255 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+// Package policytemplate instantiates tracing policy templates (see
+// v1alpha1.TracingPolicyTemplate) into tracing policy specifications.
+package policytemplate
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+
+	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
+)
+
+const (
+	StringType     = "string"
+	IntType        = "int"
+	StringListType = "stringList"
+)
+
+// refRe matches the references to parameters in the string values of a
+// template
+var refRe = regexp.MustCompile(`\$\{([^}]*)\}`)
+
+// placeholders are the values of the parameters used to validate a template
+var placeholders = map[string]v1alpha1.TemplateValue{
+	StringType:     {Value: "placeholder"},
+	IntType:        {Value: "0"},
+	StringListType: {Values: []string{"placeholder"}},
+}
+
+func parameterType(p *v1alpha1.TemplateParameter) string {
+	if p.Type == "" {
+		return StringType
+	}
+	return p.Type
+}
+
+func isSet(v *v1alpha1.TemplateValue) bool {
+	return v.Value != "" || len(v.Values) > 0
+}
+
+func checkValue(p *v1alpha1.TemplateParameter, v *v1alpha1.TemplateValue) error {
+	switch typ := parameterType(p); typ {
+	case StringType, IntType:
+		if len(v.Values) > 0 {
+			return fmt.Errorf("parameter %q: %s parameters take a value, not values", p.Name, typ)
+		}
+		if typ == IntType {
+			if _, err := strconv.ParseInt(v.Value, 0, 64); err != nil {
+				return fmt.Errorf("parameter %q: %q is not an int", p.Name, v.Value)
+			}
+		}
+	case StringListType:
+		if v.Value != "" {
+			return fmt.Errorf("parameter %q: %s parameters take values, not a value", p.Name, typ)
+		}
+	default:
+		return fmt.Errorf("parameter %q: invalid type %q", p.Name, p.Type)
+	}
+	return nil
+}
+
+// parameters returns the parameters of tmpl by name
+func parameters(tmpl *v1alpha1.TracingPolicyTemplateSpec) (map[string]*v1alpha1.TemplateParameter, error) {
+	ret := make(map[string]*v1alpha1.TemplateParameter, len(tmpl.Parameters))
+	for i := range tmpl.Parameters {
+		p := &tmpl.Parameters[i]
+		if _, ok := ret[p.Name]; ok {
+			return nil, fmt.Errorf("parameter %q is declared more than once", p.Name)
+		}
+		if p.Default != nil && isSet(p.Default) {
+			if err := checkValue(p, p.Default); err != nil {
+				return nil, fmt.Errorf("invalid default: %w", err)
+			}
+		} else if _, ok := placeholders[parameterType(p)]; !ok {
+			return nil, fmt.Errorf("parameter %q: invalid type %q", p.Name, p.Type)
+		}
+		ret[p.Name] = p
+	}
+	return ret, nil
+}
+
+// Validate checks that the parameters of tmpl are valid, and that its policy
+// only references declared parameters.
+func Validate(tmpl *v1alpha1.TracingPolicyTemplateSpec) error {
+	params, err := parameters(tmpl)
+	if err != nil {
+		return err
+	}
+	values := make(map[string]value, len(params))
+	for name, p := range params {
+		values[name] = value{typ: parameterType(p), TemplateValue: placeholders[parameterType(p)]}
+	}
+	_, err = substitute(&tmpl.Policy, values)
+	return err
+}
+
+// Instantiate returns the policy of tmpl, where the references to parameters
+// are replaced by their values in vals, or by their default values.
+func Instantiate(tmpl *v1alpha1.TracingPolicyTemplateSpec, vals []v1alpha1.TemplateParameterValue) (*v1alpha1.TracingPolicySpec, error) {
+	params, err := parameters(tmpl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+
+	values := make(map[string]value, len(params))
+	seen := make(map[string]bool, len(vals))
+	for i := range vals {
+		v := &vals[i]
+		p, ok := params[v.Name]
+		if !ok {
+			return nil, fmt.Errorf("parameter %q is not declared by the template", v.Name)
+		}
+		if seen[v.Name] {
+			return nil, fmt.Errorf("parameter %q is set more than once", v.Name)
+		}
+		seen[v.Name] = true
+		// parameters listed without a value use their default value
+		if !isSet(&v.TemplateValue) {
+			continue
+		}
+		if err := checkValue(p, &v.TemplateValue); err != nil {
+			return nil, err
+		}
+		values[v.Name] = value{typ: parameterType(p), TemplateValue: v.TemplateValue}
+	}
+	for name, p := range params {
+		if _, ok := values[name]; ok {
+			continue
+		}
+		if p.Default == nil || !isSet(p.Default) {
+			return nil, fmt.Errorf("parameter %q is not set and has no default", name)
+		}
+		values[name] = value{typ: parameterType(p), TemplateValue: *p.Default}
+	}
+
+	return substitute(&tmpl.Policy, values)
+}
+
+type value struct {
+	typ string
+	v1alpha1.TemplateValue
+}
+
+// substitute replaces the references to parameters in the string values of
+// policy. It works on the JSON representation of policy, so that it does not
+// need to know about the fields of the specification.
+func substitute(policy *v1alpha1.TracingPolicySpec, values map[string]value) (*v1alpha1.TracingPolicySpec, error) {
+	b, err := json.Marshal(policy)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	// keep numbers as is, instead of converting them to float64
+	dec.UseNumber()
+	var tree any
+	if err := dec.Decode(&tree); err != nil {
+		return nil, err
+	}
+
+	s := substitution{values: values, invalid: map[string]bool{}}
+	tree = s.node(tree)
+	if err := errors.Join(s.errs...); err != nil {
+		return nil, err
+	}
+
+	b, err = json.Marshal(tree)
+	if err != nil {
+		return nil, err
+	}
+	ret := &v1alpha1.TracingPolicySpec{}
+	if err := json.Unmarshal(b, ret); err != nil {
+		return nil, err
+	}
+	return ret, nil
+}
+
+type substitution struct {
+	values map[string]value
+	errs   []error
+	// invalid holds the parameters with a reported error
+	invalid map[string]bool
+}
+
+func (s *substitution) node(n any) any {
+	switch n := n.(type) {
+	case map[string]any:
+		// sorted, for the errors to be reported in a stable order
+		for _, k := range slices.Sorted(maps.Keys(n)) {
+			n[k] = s.node(n[k])
+		}
+		return n
+	case []any:
+		ret := make([]any, 0, len(n))
+		for _, v := range n {
+			if list, ok := s.list(v); ok {
+				for _, item := range list {
+					ret = append(ret, item)
+				}
+				continue
+			}
+			ret = append(ret, s.node(v))
+		}
+		return ret
+	case string:
+		return s.str(n)
+	}
+	return n
+}
+
+// list returns the values of the stringList parameter if n is a string
+// referencing one, and nothing else.
+func (s *substitution) list(n any) ([]string, bool) {
+	str, ok := n.(string)
+	if !ok {
+		return nil, false
+	}
+	m := refRe.FindStringSubmatch(str)
+	if m == nil || m[0] != str {
+		return nil, false
+	}
+	v, ok := s.values[m[1]]
+	if !ok || v.typ != StringListType {
+		return nil, false
+	}
+	return v.Values, true
+}
+
+func (s *substitution) str(str string) string {
+	return refRe.ReplaceAllStringFunc(str, func(ref string) string {
+		name := refRe.FindStringSubmatch(ref)[1]
+		v, ok := s.values[name]
+		var err error
+		switch {
+		case !ok:
+			err = fmt.Errorf("parameter %q is not declared by the template", name)
+		case v.typ == StringListType:
+			err = fmt.Errorf("parameter %q is a stringList and can only be a whole item of a list", name)
+		default:
+			return v.Value
+		}
+		if !s.invalid[name] {
+			s.invalid[name] = true
+			s.errs = append(s.errs, err)
+		}
+		return ref
+	})
+}
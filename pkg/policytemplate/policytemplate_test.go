@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package policytemplate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+
+	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
+)
+
+const template = `
+parameters:
+- name: binaries
+  type: stringList
+- name: dir
+  default:
+    value: /etc
+- name: fd
+  type: int
+  default:
+    value: "3"
+policy:
+  kprobes:
+  - call: "security_file_open"
+    syscall: false
+    args:
+    - index: 0
+      type: "file"
+    selectors:
+    - matchArgs:
+      - index: 0
+        operator: "Prefix"
+        values:
+        - "${dir}/"
+        - "/proc/self/fd/${fd}"
+      matchBinaries:
+      - operator: "In"
+        values:
+        - "${binaries}"
+        - "/usr/bin/cat"
+`
+
+func parseTemplate(t *testing.T, s string) *v1alpha1.TracingPolicyTemplateSpec {
+	var tmpl v1alpha1.TracingPolicyTemplateSpec
+	require.NoError(t, yaml.UnmarshalStrict([]byte(s), &tmpl))
+	return &tmpl
+}
+
+func TestInstantiate(t *testing.T) {
+	tmpl := parseTemplate(t, template)
+	require.NoError(t, Validate(tmpl))
+
+	spec, err := Instantiate(tmpl, []v1alpha1.TemplateParameterValue{
+		{Name: "binaries", TemplateValue: v1alpha1.TemplateValue{Values: []string{"/usr/bin/vi", "/usr/bin/less"}}},
+		{Name: "fd", TemplateValue: v1alpha1.TemplateValue{Value: "7"}},
+		// no value, uses the default
+		{Name: "dir"},
+	})
+	require.NoError(t, err)
+	require.Len(t, spec.KProbes, 1)
+	sel := spec.KProbes[0].Selectors[0]
+	assert.Equal(t, []string{"/etc/", "/proc/self/fd/7"}, sel.MatchArgs[0].Values)
+	assert.Equal(t, []string{"/usr/bin/vi", "/usr/bin/less", "/usr/bin/cat"}, sel.MatchBinaries[0].Values)
+	assert.Equal(t, "security_file_open", spec.KProbes[0].Call)
+	assert.False(t, spec.KProbes[0].Syscall)
+
+	// the template is left untouched
+	assert.Equal(t, "${binaries}", tmpl.Policy.KProbes[0].Selectors[0].MatchBinaries[0].Values[0])
+}
+
+func TestInstantiateErrors(t *testing.T) {
+	tmpl := parseTemplate(t, template)
+	binaries := v1alpha1.TemplateParameterValue{Name: "binaries", TemplateValue: v1alpha1.TemplateValue{Values: []string{"/usr/bin/vi"}}}
+
+	tests := []struct {
+		values []v1alpha1.TemplateParameterValue
+		err    string
+	}{
+		{nil, `parameter "binaries" is not set and has no default`},
+		{[]v1alpha1.TemplateParameterValue{binaries, binaries}, `parameter "binaries" is set more than once`},
+		{[]v1alpha1.TemplateParameterValue{binaries, {Name: "paths"}}, `parameter "paths" is not declared by the template`},
+		{[]v1alpha1.TemplateParameterValue{{Name: "binaries", TemplateValue: v1alpha1.TemplateValue{Value: "/usr/bin/vi"}}}, "take values, not a value"},
+		{[]v1alpha1.TemplateParameterValue{binaries, {Name: "fd", TemplateValue: v1alpha1.TemplateValue{Value: "three"}}}, `"three" is not an int`},
+	}
+	for _, tc := range tests {
+		_, err := Instantiate(tmpl, tc.values)
+		require.ErrorContains(t, err, tc.err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		template string
+		err      string
+	}{
+		{`
+parameters:
+- name: dir
+- name: dir
+policy: {}
+`, `parameter "dir" is declared more than once`},
+		{`
+parameters:
+- name: fd
+  type: int
+  default:
+    value: stdin
+policy: {}
+`, `invalid default: parameter "fd": "stdin" is not an int`},
+		{`
+policy:
+  kprobes:
+  - call: "security_file_open"
+    message: "opened ${dir}"
+`, `parameter "dir" is not declared by the template`},
+		{`
+parameters:
+- name: binaries
+  type: stringList
+policy:
+  kprobes:
+  - call: "security_file_open"
+    message: "opened by ${binaries}"
+`, `parameter "binaries" is a stringList and can only be a whole item of a list`},
+	}
+	for _, tc := range tests {
+		err := Validate(parseTemplate(t, tc.template))
+		require.ErrorContains(t, err, tc.err)
+	}
+}
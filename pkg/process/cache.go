@@ -6,7 +6,10 @@ package process
 import (
 	"fmt"
 	"maps"
+	"math"
 	"path/filepath"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/cilium/ebpf"
@@ -21,8 +24,21 @@ import (
 )
 
 type Cache struct {
-	cache      *lru.Cache[string, *ProcessInternal]
-	size       int
+	cache *lru.Cache[string, *ProcessInternal]
+	size  int
+	// memoryLimit is the memory budget of the cache in bytes. If set, the
+	// number of entries is not limited, and entries are evicted by
+	// evictToMemoryLimit instead.
+	memoryLimit int
+	// memory is the estimated memory used by the entries of the cache
+	memory atomic.Int64
+	// evictRetryMemory is the memory from which evictToMemoryLimit runs
+	// again after it could not reach its target, or 0
+	evictRetryMemory atomic.Int64
+	evictLock        sync.Mutex
+	// addLock serializes additions, so that the memory of a replaced entry
+	// is accounted for by the addition that replaced it
+	addLock    sync.Mutex
 	deleteChan chan *ProcessInternal
 	stopChan   chan bool
 }
@@ -81,7 +97,10 @@ func (pc *Cache) cacheGarbageCollector(intervalGC time.Duration) {
 					}
 					if p.color == deleteReady {
 						p.color = deleted
-						pc.remove(p.process)
+						// the entry may have been evicted already
+						if e, ok := pc.cache.Peek(p.process.ExecId); ok && e == p {
+							pc.remove(p.process)
+						}
 					} else {
 						newQueue = append(newQueue, p)
 						p.color = deleteReady
@@ -145,19 +164,37 @@ func NewCache(
 	processCacheSize int,
 	GCInterval time.Duration,
 ) (*Cache, error) {
+	return newCache(processCacheSize, 0, GCInterval)
+}
+
+// NewCacheWithMemoryLimit returns a cache that is limited by the estimated
+// memory of its entries, in bytes, instead of by their number.
+func NewCacheWithMemoryLimit(
+	memoryLimit int,
+	GCInterval time.Duration,
+) (*Cache, error) {
+	if memoryLimit <= 0 {
+		return nil, fmt.Errorf("invalid process cache memory limit: %d", memoryLimit)
+	}
+	return newCache(math.MaxInt32, memoryLimit, GCInterval)
+}
+
+func newCache(processCacheSize, memoryLimit int, GCInterval time.Duration) (*Cache, error) {
+	pm := &Cache{
+		size:        processCacheSize,
+		memoryLimit: memoryLimit,
+	}
 	lruCache, err := lru.NewWithEvict(
 		processCacheSize,
-		func(_ string, _ *ProcessInternal) {
-			processCacheEvictions.Inc()
+		// called for evictions and removals
+		func(_ string, p *ProcessInternal) {
+			pm.memory.Add(-p.memSize.Swap(0))
 		},
 	)
 	if err != nil {
 		return nil, err
 	}
-	pm := &Cache{
-		cache: lruCache,
-		size:  processCacheSize,
-	}
+	pm.cache = lruCache
 	pm.cacheGarbageCollector(GCInterval)
 	return pm, nil
 }
@@ -175,10 +212,29 @@ func (pc *Cache) get(processID string) (*ProcessInternal, error) {
 // Add a ProcessInternal structure to the cache. Must be called only from
 // clone or execve events
 func (pc *Cache) add(process *ProcessInternal) bool {
+	size := process.memoryUsage()
+	pc.addLock.Lock()
+	// replaced entries are not passed to the eviction callback. The swap
+	// makes sure that the memory of an entry is subtracted once, if it is
+	// removed at the same time.
+	if old, ok := pc.cache.Peek(process.process.ExecId); ok {
+		pc.memory.Add(-old.memSize.Swap(0))
+	}
+	process.memSize.Store(size)
+	pc.memory.Add(size)
 	evicted := pc.cache.Add(process.process.ExecId, process)
-	if !evicted {
+	pc.addLock.Unlock()
+	if evicted {
+		processCacheEvictions.WithLabelValues(evictionReasonSize).Inc()
+	} else {
 		processCacheTotal.Inc()
 	}
+	if pc.memoryLimit > 0 {
+		memory := pc.memory.Load()
+		if memory > int64(pc.memoryLimit) && memory >= pc.evictRetryMemory.Load() {
+			pc.evictToMemoryLimit()
+		}
+	}
 	return evicted
 }
 
@@ -4,10 +4,13 @@
 package process
 
 import (
+	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/cilium/tetragon/api/v1/tetragon"
 	"github.com/cilium/tetragon/pkg/defaults"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/types/known/wrapperspb"
@@ -45,3 +48,127 @@ func TestProcessCache(t *testing.T) {
 	_, err = cache.get(proc.process.ExecId)
 	require.Error(t, err)
 }
+
+func addMemoryLimitProcess(pc *Cache, execID, parentExecID string, refcnt uint32, exited bool) {
+	pi := &ProcessInternal{
+		process: &tetragon.Process{
+			ExecId:       execID,
+			Pid:          &wrapperspb.UInt32Value{Value: 1000},
+			ParentExecId: parentExecID,
+		},
+		refcntOps: map[string]int32{"process++": 1},
+	}
+	if exited {
+		pi.refcntOps["process--"] = 1
+	}
+	pi.refcnt.Store(refcnt)
+	pc.add(pi)
+}
+
+func TestProcessCacheMemoryLimit(t *testing.T) {
+	cache, err := NewCacheWithMemoryLimit(1<<20, defaults.DefaultProcessCacheGCInterval)
+	require.NoError(t, err)
+	t.Cleanup(cache.purge)
+
+	evictions := func(reason string) float64 {
+		return testutil.ToFloat64(processCacheEvictions.WithLabelValues(reason))
+	}
+	zeroRefcnt, exited, running := evictions(evictionReasonZeroRefcnt), evictions(evictionReasonExited), evictions(evictionReasonRunning)
+
+	addMemoryLimitProcess(cache, "init", "", 2, false)
+	addMemoryLimitProcess(cache, "bash", "init", 3, false)
+	addMemoryLimitProcess(cache, "cat0", "init", 0, true)
+	addMemoryLimitProcess(cache, "ls00", "bash", 1, true)
+	addMemoryLimitProcess(cache, "sle1", "bash", 1, false)
+	assert.Equal(t, 5, cache.len())
+
+	// going over the limit evicts 2 entries to get below 90% of it,
+	// starting with exited processes
+	cache.memoryLimit = int(cache.memory.Load())
+	addMemoryLimitProcess(cache, "sle2", "bash", 1, false)
+	assert.ElementsMatch(t, []string{"init", "bash", "sle1", "sle2"}, cache.cache.Keys())
+	assert.InDelta(t, zeroRefcnt+1, evictions(evictionReasonZeroRefcnt), 0)
+	assert.InDelta(t, exited+1, evictions(evictionReasonExited), 0)
+
+	// then running processes, but not the ancestors of running processes
+	cache.memoryLimit = int(cache.memory.Load())
+	addMemoryLimitProcess(cache, "sle3", "bash", 1, false)
+	assert.ElementsMatch(t, []string{"init", "bash", "sle3"}, cache.cache.Keys())
+	assert.InDelta(t, running+2, evictions(evictionReasonRunning), 0)
+
+	// the memory of the remaining entries is accounted for
+	var memory int64
+	for _, p := range cache.cache.Values() {
+		memory += p.memoryUsage()
+	}
+	assert.Equal(t, memory, cache.memory.Load())
+}
+
+func TestProcessCacheMemoryLimitExceeded(t *testing.T) {
+	cache, err := NewCacheWithMemoryLimit(1<<20, defaults.DefaultProcessCacheGCInterval)
+	require.NoError(t, err)
+	t.Cleanup(cache.purge)
+
+	exceeded := func() float64 {
+		return testutil.ToFloat64(processCacheMemoryLimitExceeded.WithLabelValues())
+	}
+	start := exceeded()
+
+	// a chain of running processes, whose entries are all ancestors of
+	// running processes once they have a child
+	parent := ""
+	for i := range 25 {
+		execID := fmt.Sprintf("p%02d", i)
+		addMemoryLimitProcess(cache, execID, parent, 1, false)
+		parent = execID
+	}
+	cache.memoryLimit = int(cache.memory.Load()) - 1
+
+	// only the new process can be evicted, so the limit is not reached
+	addMemoryLimitProcess(cache, "leaf1", parent, 1, false)
+	assert.NotContains(t, cache.cache.Keys(), "leaf1")
+	assert.InDelta(t, start+1, exceeded(), 0)
+	memory := cache.memory.Load()
+	assert.Equal(t, memory+int64(cache.memoryLimit)*memoryRetryPercent/100, cache.evictRetryMemory.Load())
+
+	// evictions are not retried until the memory grew enough
+	addMemoryLimitProcess(cache, "leaf2", parent, 1, false)
+	assert.Contains(t, cache.cache.Keys(), "leaf2")
+	assert.InDelta(t, start+1, exceeded(), 0)
+
+	addMemoryLimitProcess(cache, "leaf3", parent, 1, false)
+	assert.NotContains(t, cache.cache.Keys(), "leaf2")
+	assert.NotContains(t, cache.cache.Keys(), "leaf3")
+	assert.InDelta(t, start+2, exceeded(), 0)
+	assert.Equal(t, memory, cache.memory.Load())
+}
+
+func TestProcessCacheMemoryReplace(t *testing.T) {
+	cache, err := NewCacheWithMemoryLimit(1<<20, defaults.DefaultProcessCacheGCInterval)
+	require.NoError(t, err)
+	t.Cleanup(cache.purge)
+
+	// concurrent additions of the same process replace each other, and
+	// the memory of the replaced entries is subtracted once
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range 100 {
+				cache.add(&ProcessInternal{
+					process: &tetragon.Process{
+						ExecId: "process1",
+						Binary: fmt.Sprintf("/bin/%d/%d", i, j),
+					},
+				})
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, 1, cache.len())
+	p, err := cache.get("process1")
+	require.NoError(t, err)
+	assert.Equal(t, p.memoryUsage(), cache.memory.Load())
+}
@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package process
+
+// reasons of the evictions of process cache entries
+const (
+	// the cache reached its size
+	evictionReasonSize = "size"
+	// the process exited, and had no more references
+	evictionReasonZeroRefcnt = "zero_refcnt"
+	// the process exited, and was not an ancestor of a running process
+	evictionReasonExited = "exited"
+	// the process was running, and was not an ancestor of a running
+	// process
+	evictionReasonRunning = "running"
+)
+
+// entryOverhead is an estimate of the memory used by a cache entry in
+// addition to the strings of its process: the ProcessInternal struct, its
+// process, capabilities, credentials and namespaces messages, its refcntOps
+// map, and the LRU list element and map bucket.
+const entryOverhead = 2048
+
+// memoryLowWatermark is the percentage of the memory limit that
+// evictToMemoryLimit evicts entries down to, so that it does not run on every
+// addition once the limit is reached.
+const memoryLowWatermark = 90
+
+// memoryRetryPercent is the percentage of the memory limit the estimated
+// memory has to grow by, after evictToMemoryLimit could not reach its target,
+// before it runs again. Until then, it would only go through the same entries
+// that cannot be evicted.
+const memoryRetryPercent = 5
+
+// memoryUsage returns an estimate of the memory used by the entry of pi in the
+// cache. It does not use proto.Size, which would modify the size cache of the
+// messages.
+func (pi *ProcessInternal) memoryUsage() int64 {
+	pi.mu.Lock()
+	p := pi.process
+	size := len(p.ExecId) + len(p.ParentExecId) + len(p.Binary) + len(p.Arguments) +
+		len(p.Cwd) + len(p.Docker) + len(p.Flags)
+	if pod := p.Pod; pod != nil {
+		size += len(pod.Namespace) + len(pod.Name) + len(pod.Workload) + len(pod.WorkloadKind)
+		for k, v := range pod.PodLabels {
+			size += len(k) + len(v)
+		}
+	}
+	pi.mu.Unlock()
+	return int64(size + entryOverhead)
+}
+
+// exited returns true if the exit of the process was received.
+func (pi *ProcessInternal) exited() bool {
+	pi.refcntOpsLock.Lock()
+	defer pi.refcntOpsLock.Unlock()
+	return pi.refcntOps["process++"] <= pi.refcntOps["process--"]
+}
+
+// evictToMemoryLimit evicts entries until the estimated memory of the cache
+// is below memoryLowWatermark percent of its limit. Entries are evicted by
+// order of preference, and in LRU order for the same preference:
+//   - exited processes without references, which are pending removal,
+//   - exited processes,
+//   - running processes.
+//
+// Ancestors of running processes are never evicted, since the events of their
+// descendants need them, so the limit might not be reached. In that case, the
+// next evictions are delayed until the memory grew by memoryRetryPercent of the
+// limit.
+func (pc *Cache) evictToMemoryLimit() {
+	// another addition is already evicting entries
+	if !pc.evictLock.TryLock() {
+		return
+	}
+	defer pc.evictLock.Unlock()
+
+	target := int64(pc.memoryLimit) * memoryLowWatermark / 100
+	// oldest first
+	entries := pc.cache.Values()
+	byID := make(map[string]*ProcessInternal, len(entries))
+	for _, p := range entries {
+		byID[p.process.ExecId] = p
+	}
+	protected := make(map[string]bool)
+	for _, p := range entries {
+		if p.exited() {
+			continue
+		}
+		for a := byID[p.process.ParentExecId]; a != nil && !protected[a.process.ExecId]; a = byID[a.process.ParentExecId] {
+			protected[a.process.ExecId] = true
+		}
+	}
+
+	reason := func(p *ProcessInternal) string {
+		switch {
+		case protected[p.process.ExecId]:
+			return ""
+		case p.refcnt.Load() == 0:
+			return evictionReasonZeroRefcnt
+		case p.exited():
+			return evictionReasonExited
+		default:
+			return evictionReasonRunning
+		}
+	}
+	for _, r := range []string{evictionReasonZeroRefcnt, evictionReasonExited, evictionReasonRunning} {
+		for _, p := range entries {
+			if pc.memory.Load() <= target {
+				pc.evictRetryMemory.Store(0)
+				return
+			}
+			if reason(p) != r {
+				continue
+			}
+			// the entry may have been replaced since
+			if e, ok := pc.cache.Peek(p.process.ExecId); !ok || e != p {
+				continue
+			}
+			if pc.cache.Remove(p.process.ExecId) {
+				processCacheTotal.Dec()
+				processCacheEvictions.WithLabelValues(r).Inc()
+			}
+		}
+	}
+
+	memory := pc.memory.Load()
+	if memory <= target {
+		pc.evictRetryMemory.Store(0)
+		return
+	}
+	pc.evictRetryMemory.Store(memory + int64(pc.memoryLimit)*memoryRetryPercent/100)
+	if memory > int64(pc.memoryLimit) {
+		processCacheMemoryLimitExceeded.WithLabelValues().Inc()
+	}
+}
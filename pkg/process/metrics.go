@@ -14,6 +14,10 @@ var (
 		Name:   "operation",
 		Values: []string{"get", "remove"},
 	}
+	evictionReasonLabel = metrics.ConstrainedLabel{
+		Name:   "reason",
+		Values: []string{evictionReasonSize, evictionReasonZeroRefcnt, evictionReasonExited, evictionReasonRunning},
+	}
 )
 
 var (
@@ -25,14 +29,29 @@ var (
 	})
 	processCacheCapacity = metrics.MustNewCustomGauge(metrics.NewOpts(
 		consts.MetricsNamespace, "", "process_cache_capacity",
-		"The capacity of the process cache. Expected to be constant.",
+		"The capacity of the process cache, or 0 if it is limited by memory. Expected to be constant.",
 		nil, nil, nil,
 	))
-	processCacheEvictions = prometheus.NewCounter(prometheus.CounterOpts{
-		Namespace: consts.MetricsNamespace,
-		Name:      "process_cache_evictions_total",
-		Help:      "Number of process cache LRU evictions.",
-	})
+	processCacheMemoryLimit = metrics.MustNewCustomGauge(metrics.NewOpts(
+		consts.MetricsNamespace, "", "process_cache_memory_limit_bytes",
+		"The memory limit of the process cache in bytes, if it is limited by memory instead of size. Expected to be constant.",
+		nil, nil, nil,
+	))
+	processCacheMemory = metrics.MustNewCustomGauge(metrics.NewOpts(
+		consts.MetricsNamespace, "", "process_cache_memory_bytes",
+		"The estimated memory used by the entries of the process cache in bytes.",
+		nil, nil, nil,
+	))
+	processCacheEvictions = metrics.MustNewCounter(metrics.NewOpts(
+		consts.MetricsNamespace, "", "process_cache_evictions_total",
+		"Number of process cache evictions, by reason. The size reason is for LRU evictions when the cache is full, the others are for evictions to stay within the memory limit.",
+		nil, []metrics.ConstrainedLabel{evictionReasonLabel}, nil,
+	), nil)
+	processCacheMemoryLimitExceeded = metrics.MustNewCounter(metrics.NewOpts(
+		consts.MetricsNamespace, "", "process_cache_memory_limit_exceeded_total",
+		"Number of times the process cache could not evict enough entries to stay within its memory limit, because the remaining entries are ancestors of running processes.",
+		nil, nil, nil,
+	), nil)
 	processCacheMisses = metrics.MustNewCounter(metrics.NewOpts(
 		consts.MetricsNamespace, "", "process_cache_misses_total",
 		"Number of process cache misses.",
@@ -42,13 +61,19 @@ var (
 
 func newCacheCollector() prometheus.Collector {
 	return metrics.NewCustomCollector(
-		metrics.CustomMetrics{processCacheCapacity},
+		metrics.CustomMetrics{processCacheCapacity, processCacheMemoryLimit, processCacheMemory},
 		func(ch chan<- prometheus.Metric) {
-			capacity := 0
+			capacity, memoryLimit, memory := 0, 0, int64(0)
 			if procCache != nil {
-				capacity = procCache.size
+				if procCache.memoryLimit == 0 {
+					capacity = procCache.size
+				}
+				memoryLimit = procCache.memoryLimit
+				memory = procCache.memory.Load()
 			}
 			ch <- processCacheCapacity.MustMetric(float64(capacity))
+			ch <- processCacheMemoryLimit.MustMetric(float64(memoryLimit))
+			ch <- processCacheMemory.MustMetric(float64(memory))
 		},
 		nil,
 	)
@@ -58,6 +83,7 @@ func RegisterMetrics(group metrics.Group) {
 	group.MustRegister(
 		processCacheTotal,
 		processCacheEvictions,
+		processCacheMemoryLimitExceeded,
 		processCacheMisses,
 	)
 	group.MustRegister(newCacheCollector())
@@ -59,6 +59,8 @@ type ProcessInternal struct {
 	refcntOps map[string]int32
 	// protects the refcntOps map
 	refcntOpsLock sync.Mutex
+	// estimated memory of the entry, set when it is added to the cache
+	memSize atomic.Int64
 }
 
 var (
@@ -78,7 +80,11 @@ func InitCache(w watcher.PodAccessor, size int, GCInterval time.Duration) error
 	}
 
 	k8s = w
-	procCache, err = NewCache(size, GCInterval)
+	if option.Config.ProcessCacheMemoryLimit > 0 {
+		procCache, err = NewCacheWithMemoryLimit(option.Config.ProcessCacheMemoryLimit, GCInterval)
+	} else {
+		procCache, err = NewCache(size, GCInterval)
+	}
 	if err != nil {
 		k8s = nil
 	}
@@ -125,6 +131,28 @@ func (pi *ProcessInternal) AddPodInfo(pod *tetragon.Pod) {
 	pi.mu.Unlock()
 }
 
+// RefreshPodInfo looks up the pod of the process, if it runs in a container
+// and its pod is not known yet. It returns true if the pod of the process is
+// known. The pid of the process in its container is not set in the pod.
+func (pi *ProcessInternal) RefreshPodInfo() bool {
+	pi.mu.Lock()
+	known := pi.process.Pod != nil
+	docker, binary, args := pi.process.Docker, pi.process.Binary, pi.process.Arguments
+	pi.mu.Unlock()
+	if known {
+		return true
+	}
+	if !option.PodMetadataEnabled() || docker == "" {
+		return false
+	}
+	podInfo := GetPodInfo(docker, binary, args, 0)
+	if podInfo == nil {
+		return false
+	}
+	pi.AddPodInfo(podInfo)
+	return true
+}
+
 func (pi *ProcessInternal) getProcess() *tetragon.Process {
 	pi.mu.Lock()
 	return pi.process
@@ -442,7 +470,7 @@ func initProcessInternalClone(event *tetragonAPI.MsgCloneEvent,
 		// Set the pid inside the container
 		pi.process.Pod.Container.Pid = &wrapperspb.UInt32Value{Value: event.NSPID}
 	}
-	if option.Config.EnableK8s && pi.process.Docker != "" && pi.process.Pod == nil {
+	if option.PodMetadataEnabled() && pi.process.Docker != "" && pi.process.Pod == nil {
 		if podInfo := GetPodInfo(pi.process.Docker, pi.process.Binary, pi.process.Arguments, event.NSPID); podInfo != nil {
 			pi.AddPodInfo(podInfo)
 		}
@@ -0,0 +1,202 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package process
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/constants"
+	"github.com/cilium/tetragon/pkg/option"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// snapshotVersion is the version of the format of the process cache
+// snapshots.
+const snapshotVersion = 1
+
+// snapshot is the format of the process cache snapshots. Processes are
+// tetragon.ProcessInternal messages encoded in JSON, where the internal
+// fields of ProcessInternal (capabilities, credentials, namespaces and binary
+// properties) are stored in their respective fields of the process.
+type snapshot struct {
+	Version   int               `json:"version"`
+	Processes []json.RawMessage `json:"processes"`
+}
+
+func (pc *Cache) snapshot() ([]json.RawMessage, error) {
+	var ret []json.RawMessage
+	for _, v := range pc.cache.Values() {
+		// entries without references are pending removal
+		if v.refcnt.Load() == 0 {
+			continue
+		}
+		pi := v.apiProcessInternal()
+		b, err := protojson.Marshal(pi)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal process %s: %w", pi.Process.ExecId, err)
+		}
+		ret = append(ret, b)
+	}
+	return ret, nil
+}
+
+// SaveSnapshot writes the entries of the process cache to the file at path,
+// so that they can be restored by RestoreSnapshot after a restart. The file is
+// replaced atomically.
+func SaveSnapshot(path string) error {
+	if procCache == nil {
+		return errors.New("process cache is not initialized")
+	}
+	procs, err := procCache.snapshot()
+	if err != nil {
+		return err
+	}
+	b, err := json.Marshal(&snapshot{Version: snapshotVersion, Processes: procs})
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// snapshotRefDec drops a reference of a process restored from a snapshot,
+// before it is added to the cache.
+func (pi *ProcessInternal) snapshotRefDec(reason string) {
+	pi.refcntOps[reason+"--"]++
+	if pi.refcnt.Load() > 0 {
+		pi.refcnt.Add(^uint32(0))
+	}
+}
+
+func readSnapshot(path string) (map[string]*ProcessInternal, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var s snapshot
+	if err := json.Unmarshal(b, &s); err != nil {
+		return nil, fmt.Errorf("invalid process cache snapshot: %w", err)
+	}
+	if s.Version != snapshotVersion {
+		return nil, fmt.Errorf("unsupported process cache snapshot version %d", s.Version)
+	}
+
+	procs := make(map[string]*ProcessInternal, len(s.Processes))
+	for _, raw := range s.Processes {
+		var entry tetragon.ProcessInternal
+		if err := protojson.Unmarshal(raw, &entry); err != nil {
+			return nil, fmt.Errorf("invalid process in process cache snapshot: %w", err)
+		}
+		p := entry.Process
+		if p == nil || p.ExecId == "" || p.Pid == nil {
+			continue
+		}
+		pi := &ProcessInternal{
+			process:       p,
+			capabilities:  p.Cap,
+			apiCreds:      p.ProcessCredentials,
+			namespaces:    p.Ns,
+			apiBinaryProp: p.BinaryProperties,
+			refcntOps:     entry.RefcntOps,
+		}
+		// the internal fields are added back to the process by
+		// AnnotateProcess, depending on the configuration
+		p.Cap = nil
+		p.ProcessCredentials = nil
+		p.Ns = nil
+		p.BinaryProperties = nil
+		p.Refcnt = 0
+		if pi.refcntOps == nil {
+			pi.refcntOps = map[string]int32{}
+		}
+		pi.refcnt.Store(entry.Refcnt.GetValue())
+		procs[p.ExecId] = pi
+	}
+	return procs, nil
+}
+
+// RestoreSnapshot adds the processes of the snapshot at path, written by
+// SaveSnapshot, to the process cache. Only the processes for which running
+// returns true, and their ancestors, are restored.
+//
+// The processes of the snapshot that were running when it was taken but are
+// not running anymore are handled as if their exit was received, so that the
+// refcounts of their ancestors stay consistent.
+//
+// It returns the exec IDs of the restored processes.
+func RestoreSnapshot(path string, running func(execID string) bool) ([]string, error) {
+	if procCache == nil {
+		return nil, errors.New("process cache is not initialized")
+	}
+	procs, err := readSnapshot(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// ancestors returns the ancestors of a process after its parent, as
+	// GetAncestorProcessesInternal does.
+	ancestors := func(parent *ProcessInternal) []*ProcessInternal {
+		var ret []*ProcessInternal
+		for p := parent; p.process.Pid.Value > constants.OLDEST_ANCESTOR_PID && len(ret) < len(procs); {
+			if p = procs[p.process.ParentExecId]; p == nil {
+				break
+			}
+			ret = append(ret, p)
+		}
+		return ret
+	}
+
+	// replay the exits that were missed while the agent was not running
+	for id, pi := range procs {
+		if running(id) || pi.refcntOps["process++"] <= pi.refcntOps["process--"] {
+			continue
+		}
+		parent := procs[pi.process.ParentExecId]
+		if parent != nil && option.Config.EnableProcessAncestors && pi.NeededAncestors() {
+			for _, ancestor := range ancestors(parent) {
+				ancestor.snapshotRefDec("ancestor")
+			}
+		}
+		if parent != nil {
+			parent.snapshotRefDec("parent")
+		}
+		pi.snapshotRefDec("process")
+	}
+
+	restore := make(map[string]*ProcessInternal)
+	for id, pi := range procs {
+		if !running(id) {
+			continue
+		}
+		for p := pi; p != nil && p.refcnt.Load() > 0; p = procs[p.process.ParentExecId] {
+			if _, ok := restore[p.process.ExecId]; ok {
+				break
+			}
+			restore[p.process.ExecId] = p
+		}
+	}
+	ret := make([]string, 0, len(restore))
+	for id, pi := range restore {
+		procCache.add(pi)
+		ret = append(ret, id)
+	}
+	return ret, nil
+}
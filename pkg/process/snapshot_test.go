@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package process
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/defaults"
+	"github.com/cilium/tetragon/pkg/watcher"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func addSnapshotProcess(pid uint32, execID, parentExecID string, ops map[string]int32) {
+	pi := &ProcessInternal{
+		process: &tetragon.Process{
+			ExecId:       execID,
+			Pid:          &wrapperspb.UInt32Value{Value: pid},
+			ParentExecId: parentExecID,
+			Pod:          &tetragon.Pod{Namespace: "default", Name: "pod"},
+		},
+		capabilities: &tetragon.Capabilities{
+			Effective: []tetragon.CapabilitiesType{tetragon.CapabilitiesType_CAP_SYS_ADMIN},
+		},
+		refcntOps: ops,
+	}
+	var ref int32
+	for op, n := range ops {
+		if strings.HasSuffix(op, "++") {
+			ref += n
+		} else {
+			ref -= n
+		}
+	}
+	pi.refcnt.Store(uint32(ref))
+	procCache.add(pi)
+}
+
+func TestSnapshot(t *testing.T) {
+	require.NoError(t, InitCache(watcher.NewFakeK8sWatcher(nil), 10, defaults.DefaultProcessCacheGCInterval))
+	t.Cleanup(FreeCache)
+
+	addSnapshotProcess(1, "init", "", map[string]int32{"process++": 1, "parent++": 1})
+	// exited, but referenced by its children
+	addSnapshotProcess(100, "bash", "init", map[string]int32{"process++": 1, "process--": 1, "parent++": 2})
+	addSnapshotProcess(101, "sleep", "bash", map[string]int32{"process++": 1})
+	// exits while the agent is not running
+	addSnapshotProcess(102, "ls", "bash", map[string]int32{"process++": 1})
+	// exited, and pending removal
+	addSnapshotProcess(103, "cat", "init", map[string]int32{"process++": 1, "process--": 1})
+
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	require.NoError(t, SaveSnapshot(path))
+
+	require.NoError(t, InitCache(watcher.NewFakeK8sWatcher(nil), 10, defaults.DefaultProcessCacheGCInterval))
+	ids, err := RestoreSnapshot(path, func(execID string) bool {
+		return execID == "init" || execID == "sleep"
+	})
+	require.NoError(t, err)
+	slices.Sort(ids)
+	assert.Equal(t, []string{"bash", "init", "sleep"}, ids)
+
+	bash, err := Get("bash")
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), bash.RefGet())
+	assert.Equal(t, int32(1), bash.refcntOps["parent--"])
+	assert.Equal(t, "init", bash.process.ParentExecId)
+	assert.Equal(t, "pod", bash.process.Pod.Name)
+	// internal fields are not part of the process
+	assert.Equal(t, []tetragon.CapabilitiesType{tetragon.CapabilitiesType_CAP_SYS_ADMIN}, bash.capabilities.Effective)
+	assert.Nil(t, bash.process.Cap)
+
+	sleep, err := Get("sleep")
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), sleep.RefGet())
+
+	_, err = Get("ls")
+	require.Error(t, err)
+	_, err = Get("cat")
+	require.Error(t, err)
+}
+
+func TestSnapshotVersion(t *testing.T) {
+	require.NoError(t, InitCache(watcher.NewFakeK8sWatcher(nil), 10, defaults.DefaultProcessCacheGCInterval))
+	t.Cleanup(FreeCache)
+
+	path := filepath.Join(t.TempDir(), "snapshot.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"version":2,"processes":[]}`), 0o600))
+	_, err := RestoreSnapshot(path, func(string) bool { return true })
+	require.ErrorContains(t, err, "unsupported process cache snapshot version 2")
+}
@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package process
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/constants"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// apiProcessInternal returns a copy of pi, where the internal fields
+// (capabilities, credentials, namespaces and binary properties) are set in
+// the process.
+func (pi *ProcessInternal) apiProcessInternal() *tetragon.ProcessInternal {
+	pi.mu.Lock()
+	p := proto.Clone(pi.process).(*tetragon.Process)
+	pi.mu.Unlock()
+	p.Cap = pi.capabilities
+	p.ProcessCredentials = pi.apiCreds
+	p.Ns = pi.namespaces
+	p.BinaryProperties = pi.apiBinaryProp
+	ref := pi.refcnt.Load()
+	p.Refcnt = ref
+	pi.refcntOpsLock.Lock()
+	ops := maps.Clone(pi.refcntOps)
+	pi.refcntOpsLock.Unlock()
+	return &tetragon.ProcessInternal{
+		Process:   p,
+		Refcnt:    &wrapperspb.UInt32Value{Value: ref},
+		RefcntOps: ops,
+		Color:     colorStr[pi.color],
+	}
+}
+
+// LookupProcess returns a copy of the process of the cache with the given exec
+// ID, with its internal fields.
+func LookupProcess(execID string) (*tetragon.ProcessInternal, error) {
+	pi, err := procCache.get(execID)
+	if err != nil {
+		return nil, err
+	}
+	return pi.apiProcessInternal(), nil
+}
+
+// GetProcessTree returns copies of the processes of the cache for which match
+// returns true, along with their ancestors, ordered by start time so that
+// parents come before their children. match is called with the process, its
+// parent and its ancestors, as in its process_exec event. The processes that
+// exited and are pending removal from the cache are only returned if
+// includeExited is set.
+func GetProcessTree(match func(process, parent *tetragon.Process, ancestors []*tetragon.Process) bool, includeExited bool) []*tetragon.ProcessInternal {
+	procs := make(map[string]*tetragon.ProcessInternal)
+	for _, pi := range procCache.cache.Values() {
+		if !includeExited && pi.refcnt.Load() == 0 {
+			continue
+		}
+		procs[pi.process.ExecId] = pi.apiProcessInternal()
+	}
+
+	// parent returns the parent of p in procs, if any
+	parent := func(p *tetragon.Process) *tetragon.Process {
+		if pp, ok := procs[p.ParentExecId]; ok && pp.Process.ExecId != p.ExecId {
+			return pp.Process
+		}
+		return nil
+	}
+
+	matched := make(map[string]*tetragon.ProcessInternal)
+	for id, pi := range procs {
+		if _, ok := matched[id]; ok {
+			continue
+		}
+		p := pi.Process
+		pp := parent(p)
+		var ancestors []*tetragon.Process
+		// same as GetAncestorProcessesInternal
+		for a := pp; a != nil && a.Pid.GetValue() > constants.OLDEST_ANCESTOR_PID && len(ancestors) < len(procs); {
+			if a = parent(a); a != nil {
+				ancestors = append(ancestors, a)
+			}
+		}
+		if !match(p, pp, ancestors) {
+			continue
+		}
+		matched[id] = pi
+		for a := pp; a != nil; a = parent(a) {
+			if _, ok := matched[a.ExecId]; ok {
+				// and so are its ancestors
+				break
+			}
+			matched[a.ExecId] = procs[a.ExecId]
+		}
+	}
+
+	ret := slices.Collect(maps.Values(matched))
+	slices.SortFunc(ret, func(a, b *tetragon.ProcessInternal) int {
+		if c := a.Process.StartTime.AsTime().Compare(b.Process.StartTime.AsTime()); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Process.Pid.GetValue(), b.Process.Pid.GetValue())
+	})
+	return ret
+}
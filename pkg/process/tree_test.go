@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package process
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/defaults"
+	"github.com/cilium/tetragon/pkg/watcher"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func addTreeProcess(pid uint32, execID, parentExecID, binary string, refcnt uint32) {
+	pi := &ProcessInternal{
+		process: &tetragon.Process{
+			ExecId:       execID,
+			Pid:          &wrapperspb.UInt32Value{Value: pid},
+			ParentExecId: parentExecID,
+			Binary:       binary,
+			StartTime:    timestamppb.New(time.Unix(int64(pid), 0)),
+		},
+		capabilities: &tetragon.Capabilities{
+			Effective: []tetragon.CapabilitiesType{tetragon.CapabilitiesType_CAP_SYS_ADMIN},
+		},
+		refcntOps: map[string]int32{"process++": 1},
+	}
+	pi.refcnt.Store(refcnt)
+	procCache.add(pi)
+}
+
+func execIDs(procs []*tetragon.ProcessInternal) []string {
+	var ret []string
+	for _, p := range procs {
+		ret = append(ret, p.Process.ExecId)
+	}
+	return ret
+}
+
+func TestGetProcessTree(t *testing.T) {
+	require.NoError(t, InitCache(watcher.NewFakeK8sWatcher(nil), 10, defaults.DefaultProcessCacheGCInterval))
+	t.Cleanup(FreeCache)
+
+	addTreeProcess(1, "init", "", "/sbin/init", 3)
+	addTreeProcess(100, "bash", "init", "/bin/bash", 2)
+	addTreeProcess(101, "sleep", "bash", "/usr/bin/sleep", 1)
+	addTreeProcess(102, "cat", "init", "/usr/bin/cat", 1)
+	addTreeProcess(103, "ls", "init", "/usr/bin/ls", 0)
+
+	all := func(_, _ *tetragon.Process, _ []*tetragon.Process) bool { return true }
+	assert.Equal(t, []string{"init", "bash", "sleep", "cat"}, execIDs(GetProcessTree(all, false)))
+	assert.Equal(t, []string{"init", "bash", "sleep", "cat", "ls"}, execIDs(GetProcessTree(all, true)))
+
+	// the matching processes are returned with their ancestors
+	var ancestors []*tetragon.Process
+	procs := GetProcessTree(func(p, parent *tetragon.Process, a []*tetragon.Process) bool {
+		if p.Binary != "/usr/bin/sleep" {
+			return false
+		}
+		assert.Equal(t, "bash", parent.ExecId)
+		ancestors = a
+		return true
+	}, false)
+	assert.Equal(t, []string{"init", "bash", "sleep"}, execIDs(procs))
+	require.Len(t, ancestors, 1)
+	assert.Equal(t, "init", ancestors[0].ExecId)
+
+	// the internal fields are set in the returned copies
+	assert.Equal(t, []tetragon.CapabilitiesType{tetragon.CapabilitiesType_CAP_SYS_ADMIN}, procs[2].Process.Cap.Effective)
+	assert.Equal(t, uint32(1), procs[2].Refcnt.GetValue())
+	pi, err := Get("sleep")
+	require.NoError(t, err)
+	assert.Nil(t, pi.process.Cap)
+
+	p, err := LookupProcess("bash")
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), p.Process.Refcnt)
+	_, err = LookupProcess("vi")
+	require.Error(t, err)
+}
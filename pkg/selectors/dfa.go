@@ -0,0 +1,326 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+//go:build !windows
+
+package selectors
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"regexp/syntax"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+const (
+	// StringDFAMaxLength is the maximum length of the strings matched by the
+	// Glob and Regex operators. Longer strings do not match.
+	StringDFAMaxLength = 255
+	// StringDFAMaxStates is the maximum number of states of the automaton
+	// compiled from the values of a Glob or Regex filter.
+	StringDFAMaxStates = 256
+	// StringDFAAcceptState is the target of the transition on the 0 character
+	// that marks the accepting states.
+	StringDFAAcceptState = 0xffff
+)
+
+// StringDFAKey returns the key of the transition from state on character c in
+// the string DFA maps.
+func StringDFAKey(state uint16, c byte) uint32 {
+	return uint32(state)<<8 | uint32(c)
+}
+
+// globToRegex translates a glob pattern into an equivalent regular expression.
+// '*' matches any sequence of characters other than '/', '**' any sequence of
+// characters, '?' any character other than '/', and '[...]' (or '[!...]' for
+// the negation) a character class. '\' escapes the next character.
+func globToRegex(glob string) (string, error) {
+	var sb strings.Builder
+	for i := 0; i < len(glob); i++ {
+		switch c := glob[i]; c {
+		case '*':
+			if i+1 < len(glob) && glob[i+1] == '*' {
+				sb.WriteString("(?s:.*)")
+				i++
+			} else {
+				sb.WriteString("[^/]*")
+			}
+		case '?':
+			sb.WriteString("[^/]")
+		case '[':
+			j := i + 1
+			if j < len(glob) && (glob[j] == '!' || glob[j] == '^') {
+				j++
+			}
+			// a ']' right after the opening bracket is part of the class
+			if j < len(glob) && glob[j] == ']' {
+				j++
+			}
+			end := strings.IndexByte(glob[j:], ']')
+			if end < 0 {
+				return "", fmt.Errorf("unterminated character class at offset %d", i)
+			}
+			end += j
+			sb.WriteByte('[')
+			class := glob[i+1 : end]
+			if class[0] == '!' || class[0] == '^' {
+				sb.WriteByte('^')
+				class = class[1:]
+			}
+			for k := range len(class) {
+				if strings.IndexByte(`\[]^`, class[k]) >= 0 {
+					sb.WriteByte('\\')
+				}
+				sb.WriteByte(class[k])
+			}
+			sb.WriteByte(']')
+			i = end
+		case '\\':
+			if i+1 == len(glob) {
+				return "", errors.New("trailing escape character")
+			}
+			sb.WriteString(regexp.QuoteMeta(glob[i+1 : i+2]))
+			i++
+		default:
+			sb.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		}
+	}
+	return sb.String(), nil
+}
+
+// checkRegex verifies that the regular expression only uses features that can
+// be compiled into a DFA over bytes.
+func checkRegex(re *syntax.Regexp) error {
+	switch re.Op {
+	case syntax.OpLiteral:
+		for _, r := range re.Rune {
+			if r > unicode.MaxASCII {
+				return fmt.Errorf("non-ASCII character %q is not supported", r)
+			}
+		}
+	case syntax.OpCharClass:
+		// bytes above 0x7f match the ranges including all the non-ASCII
+		// characters, as in negated classes, so any other range must be
+		// ASCII only
+		for i := 0; i+1 < len(re.Rune); i += 2 {
+			lo, hi := re.Rune[i], re.Rune[i+1]
+			if hi > unicode.MaxASCII && (lo > unicode.MaxASCII+1 || hi != unicode.MaxRune) {
+				return fmt.Errorf("non-ASCII character class %q is not supported", re.String())
+			}
+		}
+	case syntax.OpBeginLine, syntax.OpEndLine, syntax.OpWordBoundary, syntax.OpNoWordBoundary:
+		return fmt.Errorf("%q is not supported", re.String())
+	}
+	for _, sub := range re.Sub {
+		if err := checkRegex(sub); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parseRegex(expr string) (*syntax.Regexp, error) {
+	re, err := syntax.Parse(expr, syntax.Perl)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkRegex(re); err != nil {
+		return nil, err
+	}
+	return re, nil
+}
+
+// dfaBuilder builds a DFA over bytes from a regexp program with the subset
+// construction. Each DFA state is the set of program instructions reachable
+// after reading the string so far.
+type dfaBuilder struct {
+	prog   *syntax.Prog
+	ids    map[string]uint16
+	states [][]uint32
+	trans  map[uint32]uint16
+}
+
+// closure returns the sorted instructions reachable from pcs without
+// consuming input. atStart and atEnd tell whether we are at the beginning or
+// the end of the string, for the ^ and $ assertions.
+func (b *dfaBuilder) closure(pcs []uint32, atStart, atEnd bool) []uint32 {
+	seen := make(map[uint32]bool)
+	var ret []uint32
+	stack := slices.Clone(pcs)
+	for len(stack) > 0 {
+		pc := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if seen[pc] {
+			continue
+		}
+		seen[pc] = true
+
+		inst := &b.prog.Inst[pc]
+		switch inst.Op {
+		case syntax.InstAlt, syntax.InstAltMatch:
+			stack = append(stack, inst.Out, inst.Arg)
+		case syntax.InstCapture, syntax.InstNop:
+			stack = append(stack, inst.Out)
+		case syntax.InstEmptyWidth:
+			op := syntax.EmptyOp(inst.Arg)
+			if op&syntax.EmptyBeginText != 0 && !atStart {
+				continue
+			}
+			if op&syntax.EmptyEndText != 0 && !atEnd {
+				// keep it, it might be passed at the end of the string
+				ret = append(ret, pc)
+				continue
+			}
+			stack = append(stack, inst.Out)
+		default:
+			ret = append(ret, pc)
+		}
+	}
+	slices.Sort(ret)
+	return ret
+}
+
+func (b *dfaBuilder) state(pcs []uint32) (uint16, bool, error) {
+	var key strings.Builder
+	for _, pc := range pcs {
+		fmt.Fprintf(&key, "%d,", pc)
+	}
+	if id, ok := b.ids[key.String()]; ok {
+		return id, false, nil
+	}
+	if len(b.states) >= StringDFAMaxStates {
+		return 0, false, fmt.Errorf("pattern is too complex: more than %d states", StringDFAMaxStates)
+	}
+	id := uint16(len(b.states))
+	b.ids[key.String()] = id
+	b.states = append(b.states, pcs)
+	return id, true, nil
+}
+
+// matchByte returns whether the instruction consumes byte c. Bytes above
+// 0x7f, which are part of multi-byte UTF-8 characters, match the instructions
+// matching any non-ASCII character.
+func matchByte(inst *syntax.Inst, c byte) bool {
+	r := rune(c)
+	if c > unicode.MaxASCII {
+		r = unicode.MaxRune
+	}
+	switch inst.Op {
+	case syntax.InstRune, syntax.InstRune1:
+		return inst.MatchRune(r)
+	case syntax.InstRuneAny:
+		return true
+	case syntax.InstRuneAnyNotNL:
+		return c != '\n'
+	}
+	return false
+}
+
+func (b *dfaBuilder) build() error {
+	start, _, err := b.state(b.closure([]uint32{uint32(b.prog.Start)}, true, false))
+	if err != nil {
+		return err
+	}
+	for queue := []uint16{start}; len(queue) > 0; queue = queue[1:] {
+		id := queue[0]
+		pcs := b.states[id]
+
+		for _, pc := range b.closure(pcs, false, true) {
+			if b.prog.Inst[pc].Op == syntax.InstMatch {
+				b.trans[StringDFAKey(id, 0)] = StringDFAAcceptState
+				break
+			}
+		}
+
+		for c := 1; c <= 0xff; c++ {
+			var next []uint32
+			for _, pc := range pcs {
+				if inst := &b.prog.Inst[pc]; matchByte(inst, byte(c)) {
+					next = append(next, inst.Out)
+				}
+			}
+			next = b.closure(next, false, false)
+			if len(next) == 0 {
+				continue
+			}
+			nid, added, err := b.state(next)
+			if err != nil {
+				return err
+			}
+			if added {
+				queue = append(queue, nid)
+			}
+			b.trans[StringDFAKey(id, byte(c))] = nid
+		}
+	}
+	return nil
+}
+
+// compileStringDFA compiles the regular expressions into a single DFA matching
+// strings that fully match any of them. The DFA is returned as a map of
+// transitions, see StringDFAKey. The start state is 0.
+func compileStringDFA(regexes []string) (map[uint32]uint16, error) {
+	if len(regexes) == 0 {
+		return nil, errors.New("no values")
+	}
+	subs := make([]*syntax.Regexp, 0, len(regexes))
+	for _, expr := range regexes {
+		re, err := parseRegex(expr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q: %w", expr, err)
+		}
+		subs = append(subs, re)
+	}
+	re := subs[0]
+	if len(subs) > 1 {
+		re = &syntax.Regexp{Op: syntax.OpAlternate, Sub: subs}
+	}
+	prog, err := syntax.Compile(re.Simplify())
+	if err != nil {
+		return nil, err
+	}
+
+	b := &dfaBuilder{
+		prog:  prog,
+		ids:   make(map[string]uint16),
+		trans: make(map[uint32]uint16),
+	}
+	if err := b.build(); err != nil {
+		return nil, err
+	}
+	return b.trans, nil
+}
+
+// compileGlobDFA compiles the glob patterns into a single DFA, see
+// compileStringDFA.
+func compileGlobDFA(globs []string) (map[uint32]uint16, error) {
+	regexes := make([]string, 0, len(globs))
+	for _, glob := range globs {
+		re, err := globToRegex(glob)
+		if err != nil {
+			return nil, fmt.Errorf("invalid glob %q: %w", glob, err)
+		}
+		regexes = append(regexes, re)
+	}
+	return compileStringDFA(regexes)
+}
+
+// matchStringDFA runs the DFA over s, like the BPF side does. It is used for
+// testing the compiled DFAs.
+func matchStringDFA(trans map[uint32]uint16, s string) bool {
+	if len(s) > StringDFAMaxLength {
+		return false
+	}
+	state := uint16(0)
+	for i := range len(s) {
+		next, ok := trans[StringDFAKey(state, s[i])]
+		if !ok {
+			return false
+		}
+		state = next
+	}
+	return trans[StringDFAKey(state, 0)] == StringDFAAcceptState
+}
@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+//go:build !windows
+
+package selectors
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGlobDFA(t *testing.T) {
+	tests := []struct {
+		globs    []string
+		match    []string
+		notMatch []string
+	}{{
+		globs:    []string{"/home/*/.ssh/id_*"},
+		match:    []string{"/home/alice/.ssh/id_rsa", "/home/bob/.ssh/id_", "/home//.ssh/id_ed25519.pub"},
+		notMatch: []string{"/home/alice/x/.ssh/id_rsa", "/home/alice/.ssh/known_hosts", "/root/.ssh/id_rsa", ""},
+	}, {
+		globs:    []string{"/etc/**"},
+		match:    []string{"/etc/", "/etc/passwd", "/etc/ssh/sshd_config"},
+		notMatch: []string{"/etc", "/usr/etc/passwd"},
+	}, {
+		globs:    []string{"/tmp/?.[ch]", "/var/log/[!a-c]*"},
+		match:    []string{"/tmp/a.c", "/tmp/b.h", "/var/log/syslog", "/var/log/dmesg"},
+		notMatch: []string{"/tmp/ab.c", "/tmp//.c", "/tmp/a.o", "/var/log/auth.log", "/var/log/"},
+	}, {
+		globs:    []string{`/a\*b`, "/x.y"},
+		match:    []string{"/a*b", "/x.y"},
+		notMatch: []string{"/aab", "/xzy"},
+	}, {
+		globs:    []string{"*"},
+		match:    []string{"", "abc", "\xc3\xa9t\xc3\xa9"},
+		notMatch: []string{"a/b", strings.Repeat("a", StringDFAMaxLength+1)},
+	}}
+
+	for _, test := range tests {
+		dfa, err := compileGlobDFA(test.globs)
+		require.NoError(t, err, test.globs)
+		for _, s := range test.match {
+			assert.True(t, matchStringDFA(dfa, s), "%v should match %q", test.globs, s)
+		}
+		for _, s := range test.notMatch {
+			assert.False(t, matchStringDFA(dfa, s), "%v should not match %q", test.globs, s)
+		}
+	}
+}
+
+func TestRegexDFA(t *testing.T) {
+	dfa, err := compileStringDFA([]string{`/usr/s?bin/(python|perl)[0-9.]*`, `^/tmp/[^/]+\.sh$`})
+	require.NoError(t, err)
+	for _, s := range []string{"/usr/bin/python3.11", "/usr/sbin/perl", "/tmp/x.sh"} {
+		assert.True(t, matchStringDFA(dfa, s), s)
+	}
+	for _, s := range []string{"/usr/bin/python3 ", "/usr/local/bin/perl", "/tmp/a/x.sh", "x/usr/bin/perl"} {
+		assert.False(t, matchStringDFA(dfa, s), s)
+	}
+
+	// matching is on bytes, '.' matches any byte of a multi-byte character
+	dfa, err = compileStringDFA([]string{`caf..`})
+	require.NoError(t, err)
+	assert.True(t, matchStringDFA(dfa, "caf\xc3\xa9"))
+	assert.False(t, matchStringDFA(dfa, "cafe"))
+
+	// negated classes match any byte above 0x7f
+	dfa, err = compileStringDFA([]string{`caf[^e]+`})
+	require.NoError(t, err)
+	assert.True(t, matchStringDFA(dfa, "caf\xc3\xa9"))
+	assert.False(t, matchStringDFA(dfa, "cafe"))
+
+	for _, expr := range []string{`(`, `café`, `[é]`, `[a-é]`, `[^é]`, `\bfoo`, `(?m)^foo`} {
+		_, err := compileStringDFA([]string{expr})
+		require.Error(t, err, expr)
+	}
+
+	_, err = compileStringDFA([]string{`[ab]*a[ab]{8}`})
+	require.ErrorContains(t, err, "too complex")
+
+	for _, glob := range []string{`/tmp/[a-`, `/tmp\`} {
+		_, err := compileGlobDFA([]string{glob})
+		require.Error(t, err, glob)
+	}
+}
@@ -0,0 +1,339 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+//go:build !windows
+
+package selectors
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
+)
+
+// MaxSelectors is the maximum number of selectors per hook supported by the
+// kernel side (MAX_SELECTORS in bpf/process/types/basic.h).
+const MaxSelectors = 5
+
+// negatedOps maps an operator to the operator matching exactly when the
+// former does not. Operators without an entry (e.g., GreaterThan or Mask)
+// cannot be negated.
+var negatedOps = map[uint32]uint32{
+	SelectorOpEQ:           SelectorOpNEQ,
+	SelectorOpNEQ:          SelectorOpEQ,
+	SelectorOpIn:           SelectorOpNotIn,
+	SelectorOpNotIn:        SelectorOpIn,
+	SelectorOpPrefix:       SelectorOpNotPrefix,
+	SelectorOpNotPrefix:    SelectorOpPrefix,
+	SelectorOpPostfix:      SelectorOpNotPostfix,
+	SelectorOpNotPostfix:   SelectorOpPostfix,
+	SelectorInMap:          SelectorNotInMap,
+	SelectorNotInMap:       SelectorInMap,
+	SelectorOpSaddr:        SelectorOpNotSaddr,
+	SelectorOpNotSaddr:     SelectorOpSaddr,
+	SelectorOpDaddr:        SelectorOpNotDaddr,
+	SelectorOpNotDaddr:     SelectorOpDaddr,
+	SelectorOpSport:        SelectorOpNotSport,
+	SelectorOpNotSport:     SelectorOpSport,
+	SelectorOpDport:        SelectorOpNotDport,
+	SelectorOpNotDport:     SelectorOpDport,
+	SelectorOpSportPriv:    SelectorOpNotSportPriv,
+	SelectorOpNotSportPriv: SelectorOpSportPriv,
+	SelectorOpDportPriv:    SelectorOpNotDportPriv,
+	SelectorOpNotDportPriv: SelectorOpDportPriv,
+	SelectorOpGlob:         SelectorOpNotGlob,
+	SelectorOpNotGlob:      SelectorOpGlob,
+	SelectorOpRegex:        SelectorOpNotRegex,
+	SelectorOpNotRegex:     SelectorOpRegex,
+}
+
+func negateOp(op string) (string, error) {
+	id, err := SelectorOp(op)
+	if err != nil {
+		return "", err
+	}
+	neg, ok := negatedOps[id]
+	if !ok {
+		return "", fmt.Errorf("operator '%s' cannot be negated", op)
+	}
+	return selectorOpStringTable[neg], nil
+}
+
+// ExpandSpecExpressions returns the policy spec with the selectors that use
+// matchExpression expanded into plain selectors. If no selector uses an
+// expression, spec is returned as is, otherwise a copy is returned.
+func ExpandSpecExpressions(spec *v1alpha1.TracingPolicySpec) (*v1alpha1.TracingPolicySpec, error) {
+	if !specHasExpressions(spec) {
+		return spec, nil
+	}
+
+	var err error
+	ret := spec.DeepCopy()
+	for i := range ret.KProbes {
+		path := fmt.Sprintf("kprobes[%d]", i)
+		if ret.KProbes[i].Selectors, err = ExpandSelectors(path, ret.KProbes[i].Selectors); err != nil {
+			return nil, err
+		}
+	}
+	for i := range ret.Tracepoints {
+		path := fmt.Sprintf("tracepoints[%d]", i)
+		if ret.Tracepoints[i].Selectors, err = ExpandSelectors(path, ret.Tracepoints[i].Selectors); err != nil {
+			return nil, err
+		}
+	}
+	for i := range ret.LsmHooks {
+		path := fmt.Sprintf("lsmhooks[%d]", i)
+		if ret.LsmHooks[i].Selectors, err = ExpandSelectors(path, ret.LsmHooks[i].Selectors); err != nil {
+			return nil, err
+		}
+	}
+	for i := range ret.UProbes {
+		path := fmt.Sprintf("uprobes[%d]", i)
+		if ret.UProbes[i].Selectors, err = ExpandSelectors(path, ret.UProbes[i].Selectors); err != nil {
+			return nil, err
+		}
+	}
+	for i := range ret.Fentries {
+		path := fmt.Sprintf("fentries[%d]", i)
+		if ret.Fentries[i].Selectors, err = ExpandSelectors(path, ret.Fentries[i].Selectors); err != nil {
+			return nil, err
+		}
+	}
+	return ret, nil
+}
+
+func specHasExpressions(spec *v1alpha1.TracingPolicySpec) bool {
+	has := func(selectors []v1alpha1.KProbeSelector) bool {
+		for i := range selectors {
+			if selectors[i].MatchExpression != nil {
+				return true
+			}
+		}
+		return false
+	}
+	for i := range spec.KProbes {
+		if has(spec.KProbes[i].Selectors) {
+			return true
+		}
+	}
+	for i := range spec.Tracepoints {
+		if has(spec.Tracepoints[i].Selectors) {
+			return true
+		}
+	}
+	for i := range spec.LsmHooks {
+		if has(spec.LsmHooks[i].Selectors) {
+			return true
+		}
+	}
+	for i := range spec.UProbes {
+		if has(spec.UProbes[i].Selectors) {
+			return true
+		}
+	}
+	for i := range spec.Fentries {
+		if has(spec.Fentries[i].Selectors) {
+			return true
+		}
+	}
+	return false
+}
+
+// ExpandSelectors expands the selectors that use matchExpression into plain
+// selectors, one per alternative of the expression in disjunctive normal form.
+// Each expanded selector keeps the other filters and the actions of the
+// original one. Since selectors are ORed, the result matches the same events.
+// Errors are prefixed with the path (under hook) of the offending
+// sub-expression.
+func ExpandSelectors(hook string, selectors []v1alpha1.KProbeSelector) ([]v1alpha1.KProbeSelector, error) {
+	ret := make([]v1alpha1.KProbeSelector, 0, len(selectors))
+	expanded := false
+	for i := range selectors {
+		sel := &selectors[i]
+		if sel.MatchExpression == nil {
+			ret = append(ret, *sel)
+			continue
+		}
+
+		expanded = true
+		path := fmt.Sprintf("%s.selectors[%d].matchExpression", hook, i)
+		terms, err := expandExpression(path, sel.MatchExpression, false)
+		if err != nil {
+			return nil, err
+		}
+		for _, term := range terms {
+			s := sel.DeepCopy()
+			s.MatchExpression = nil
+			if err := mergeFilters(s, &term); err != nil {
+				return nil, fmt.Errorf("%s: %w", path, err)
+			}
+			ret = append(ret, *s)
+		}
+	}
+
+	if expanded && len(ret) > MaxSelectors {
+		return nil, fmt.Errorf("%s.selectors: expressions expand to %d selectors, but at most %d are supported",
+			hook, len(ret), MaxSelectors)
+	}
+	return ret, nil
+}
+
+// expandExpression returns the expression (or its negation, if negate is
+// set) in disjunctive normal form: each returned selector holds a conjunction
+// of filters, and the selectors are ORed.
+func expandExpression(path string, e *v1alpha1.SelectorExpression, negate bool) ([]v1alpha1.KProbeSelector, error) {
+	if n := exprFields(e); n != 1 {
+		return nil, fmt.Errorf("%s: exactly one of and, or, not, or a filter must be set, found %d", path, n)
+	}
+
+	switch {
+	case e.And != nil || e.Or != nil:
+		op, subs := "and", e.And
+		if e.Or != nil {
+			op, subs = "or", e.Or
+		}
+		if len(subs) == 0 {
+			return nil, fmt.Errorf("%s.%s: no sub-expressions", path, op)
+		}
+		// De Morgan: the negation of an and is an or of the negations, and
+		// vice versa.
+		conjunction := (op == "and") != negate
+		var ret []v1alpha1.KProbeSelector
+		for i := range subs {
+			subPath := fmt.Sprintf("%s.%s[%d]", path, op, i)
+			terms, err := expandExpression(subPath, &subs[i], negate)
+			if err != nil {
+				return nil, err
+			}
+			if !conjunction || i == 0 {
+				ret = append(ret, terms...)
+			} else if ret, err = productTerms(ret, terms); err != nil {
+				return nil, fmt.Errorf("%s: %w", subPath, err)
+			}
+			if len(ret) > MaxSelectors {
+				return nil, fmt.Errorf("%s.%s: expands to more than %d selectors", path, op, MaxSelectors)
+			}
+		}
+		return ret, nil
+	case e.Not != nil:
+		return expandExpression(path+".not", e.Not, !negate)
+	}
+
+	term, err := leafFilter(e, negate)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return []v1alpha1.KProbeSelector{term}, nil
+}
+
+func exprFields(e *v1alpha1.SelectorExpression) int {
+	n := 0
+	for _, set := range []bool{
+		e.And != nil, e.Or != nil, e.Not != nil,
+		e.MatchPID != nil, e.MatchArg != nil, e.MatchBinary != nil,
+		e.MatchNamespace != nil, e.MatchNamespaceChange != nil,
+		e.MatchCapability != nil, e.MatchCapabilityChange != nil,
+		e.MatchState != nil,
+	} {
+		if set {
+			n++
+		}
+	}
+	return n
+}
+
+// leafFilter returns a selector holding the single filter of e, negated if
+// negate is set.
+func leafFilter(e *v1alpha1.SelectorExpression, negate bool) (v1alpha1.KProbeSelector, error) {
+	var ret v1alpha1.KProbeSelector
+
+	op := func(name, operator string) (string, error) {
+		if !negate {
+			return operator, nil
+		}
+		neg, err := negateOp(operator)
+		if err != nil {
+			return "", fmt.Errorf("%s: %w", name, err)
+		}
+		return neg, nil
+	}
+
+	var err error
+	switch {
+	case e.MatchPID != nil:
+		f := *e.MatchPID.DeepCopy()
+		f.Operator, err = op("matchPID", f.Operator)
+		ret.MatchPIDs = []v1alpha1.PIDSelector{f}
+	case e.MatchArg != nil:
+		f := *e.MatchArg.DeepCopy()
+		// For integer arguments, NotEqual matches if any of the values
+		// differs, so it is only the negation of Equal for a single value.
+		if id, _ := SelectorOp(f.Operator); negate && (id == SelectorOpEQ || id == SelectorOpNEQ) && len(f.Values) != 1 {
+			return ret, fmt.Errorf("matchArg: operator '%s' can only be negated with a single value", f.Operator)
+		}
+		f.Operator, err = op("matchArg", f.Operator)
+		ret.MatchArgs = []v1alpha1.ArgSelector{f}
+	case e.MatchBinary != nil:
+		f := *e.MatchBinary.DeepCopy()
+		f.Operator, err = op("matchBinary", f.Operator)
+		ret.MatchBinaries = []v1alpha1.BinarySelector{f}
+	case e.MatchNamespace != nil:
+		f := *e.MatchNamespace.DeepCopy()
+		f.Operator, err = op("matchNamespace", f.Operator)
+		ret.MatchNamespaces = []v1alpha1.NamespaceSelector{f}
+	case e.MatchNamespaceChange != nil:
+		f := *e.MatchNamespaceChange.DeepCopy()
+		f.Operator, err = op("matchNamespaceChange", f.Operator)
+		ret.MatchNamespaceChanges = []v1alpha1.NamespaceChangesSelector{f}
+	case e.MatchCapability != nil:
+		f := *e.MatchCapability.DeepCopy()
+		f.Operator, err = op("matchCapability", f.Operator)
+		ret.MatchCapabilities = []v1alpha1.CapabilitiesSelector{f}
+	case e.MatchCapabilityChange != nil:
+		f := *e.MatchCapabilityChange.DeepCopy()
+		f.Operator, err = op("matchCapabilityChange", f.Operator)
+		ret.MatchCapabilityChanges = []v1alpha1.CapabilitiesSelector{f}
+	case e.MatchState != nil:
+		f := *e.MatchState.DeepCopy()
+		f.Operator, err = op("matchState", f.Operator)
+		ret.MatchState = []v1alpha1.StateSelector{f}
+	}
+	return ret, err
+}
+
+// productTerms returns the conjunction of two expressions in disjunctive
+// normal form.
+func productTerms(a, b []v1alpha1.KProbeSelector) ([]v1alpha1.KProbeSelector, error) {
+	ret := make([]v1alpha1.KProbeSelector, 0, len(a)*len(b))
+	for i := range a {
+		for j := range b {
+			term := *a[i].DeepCopy()
+			if err := mergeFilters(&term, &b[j]); err != nil {
+				return nil, err
+			}
+			ret = append(ret, term)
+		}
+	}
+	return ret, nil
+}
+
+// mergeFilters adds the filters of src to dst, so that dst matches when both
+// did.
+func mergeFilters(dst, src *v1alpha1.KProbeSelector) error {
+	if len(dst.MatchBinaries)+len(src.MatchBinaries) > 1 {
+		return errors.New("only one matchBinary filter per selector is supported, and it is combined with another one")
+	}
+	if len(dst.MatchState)+len(src.MatchState) > 1 {
+		return errors.New("only one matchState filter per selector is supported, and it is combined with another one")
+	}
+	dst.MatchPIDs = append(dst.MatchPIDs, src.MatchPIDs...)
+	dst.MatchArgs = append(dst.MatchArgs, src.MatchArgs...)
+	dst.MatchBinaries = append(dst.MatchBinaries, src.MatchBinaries...)
+	dst.MatchNamespaces = append(dst.MatchNamespaces, src.MatchNamespaces...)
+	dst.MatchNamespaceChanges = append(dst.MatchNamespaceChanges, src.MatchNamespaceChanges...)
+	dst.MatchCapabilities = append(dst.MatchCapabilities, src.MatchCapabilities...)
+	dst.MatchCapabilityChanges = append(dst.MatchCapabilityChanges, src.MatchCapabilityChanges...)
+	dst.MatchState = append(dst.MatchState, src.MatchState...)
+	return nil
+}
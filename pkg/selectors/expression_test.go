@@ -0,0 +1,159 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+//go:build !windows
+
+package selectors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
+	"github.com/cilium/tetragon/pkg/tracingpolicy"
+)
+
+func TestExpandSelectorsYAML(t *testing.T) {
+	// (binary A OR binary B) AND NOT (arg0 prefix /tmp)
+	policy := `
+apiVersion: cilium.io/v1alpha1
+kind: TracingPolicy
+metadata:
+  name: "expression"
+spec:
+  kprobes:
+  - call: "security_file_open"
+    syscall: false
+    args:
+    - index: 0
+      type: "file"
+    selectors:
+    - matchExpression:
+        and:
+        - or:
+          - matchBinary:
+              operator: "In"
+              values: ["/usr/bin/cat"]
+          - matchBinary:
+              operator: "In"
+              values: ["/usr/bin/tail"]
+        - not:
+            matchArg:
+              index: 0
+              operator: "Prefix"
+              values: ["/tmp"]
+      matchActions:
+      - action: Post
+`
+	tp, err := tracingpolicy.FromYAML(policy)
+	require.NoError(t, err)
+
+	spec, err := ExpandSpecExpressions(tp.TpSpec())
+	require.NoError(t, err)
+	require.NotSame(t, tp.TpSpec(), spec)
+	require.NotNil(t, tp.TpSpec().KProbes[0].Selectors[0].MatchExpression, "original spec should not be modified")
+
+	notTmp := []v1alpha1.ArgSelector{{Index: 0, Operator: "NotPrefix", Values: []string{"/tmp"}}}
+	post := []v1alpha1.ActionSelector{{Action: "Post"}}
+	require.Equal(t, []v1alpha1.KProbeSelector{
+		{
+			MatchBinaries: []v1alpha1.BinarySelector{{Operator: "In", Values: []string{"/usr/bin/cat"}}},
+			MatchArgs:     notTmp,
+			MatchActions:  post,
+		},
+		{
+			MatchBinaries: []v1alpha1.BinarySelector{{Operator: "In", Values: []string{"/usr/bin/tail"}}},
+			MatchArgs:     notTmp,
+			MatchActions:  post,
+		},
+	}, spec.KProbes[0].Selectors)
+}
+
+func TestExpandSelectors(t *testing.T) {
+	arg := func(op string, values ...string) *v1alpha1.SelectorExpression {
+		return &v1alpha1.SelectorExpression{MatchArg: &v1alpha1.ArgSelector{Index: 1, Operator: op, Values: values}}
+	}
+	pid := func(op string, pid uint32) *v1alpha1.SelectorExpression {
+		return &v1alpha1.SelectorExpression{MatchPID: &v1alpha1.PIDSelector{Operator: op, Values: []uint32{pid}}}
+	}
+
+	// selectors without expressions are kept as they are
+	plain := []v1alpha1.KProbeSelector{{MatchPIDs: []v1alpha1.PIDSelector{{Operator: "In", Values: []uint32{1}}}}}
+	ret, err := ExpandSelectors("kprobes[0]", plain)
+	require.NoError(t, err)
+	require.Equal(t, plain, ret)
+
+	// not (a and b) == (not a) or (not b), ANDed with the other filters of the selector
+	ret, err = ExpandSelectors("kprobes[0]", []v1alpha1.KProbeSelector{{
+		MatchPIDs: []v1alpha1.PIDSelector{{Operator: "NotIn", Values: []uint32{1}}},
+		MatchExpression: &v1alpha1.SelectorExpression{Not: &v1alpha1.SelectorExpression{
+			And: []v1alpha1.SelectorExpression{*arg("Equal", "3"), *pid("In", 42)},
+		}},
+	}})
+	require.NoError(t, err)
+	require.Equal(t, []v1alpha1.KProbeSelector{
+		{
+			MatchPIDs: []v1alpha1.PIDSelector{{Operator: "NotIn", Values: []uint32{1}}},
+			MatchArgs: []v1alpha1.ArgSelector{{Index: 1, Operator: "NotEqual", Values: []string{"3"}}},
+		},
+		{
+			MatchPIDs: []v1alpha1.PIDSelector{
+				{Operator: "NotIn", Values: []uint32{1}},
+				{Operator: "NotIn", Values: []uint32{42}},
+			},
+		},
+	}, ret)
+
+	// double negation
+	ret, err = ExpandSelectors("kprobes[0]", []v1alpha1.KProbeSelector{{
+		MatchExpression: &v1alpha1.SelectorExpression{Not: &v1alpha1.SelectorExpression{Not: arg("Postfix", "a")}},
+	}})
+	require.NoError(t, err)
+	require.Equal(t, []v1alpha1.KProbeSelector{
+		{MatchArgs: []v1alpha1.ArgSelector{{Index: 1, Operator: "Postfix", Values: []string{"a"}}}},
+	}, ret)
+}
+
+func TestExpandSelectorsErrors(t *testing.T) {
+	arg := func(op string, values ...string) v1alpha1.SelectorExpression {
+		return v1alpha1.SelectorExpression{MatchArg: &v1alpha1.ArgSelector{Index: 1, Operator: op, Values: values}}
+	}
+	binary := func(v string) v1alpha1.SelectorExpression {
+		return v1alpha1.SelectorExpression{MatchBinary: &v1alpha1.BinarySelector{Operator: "In", Values: []string{v}}}
+	}
+	expand := func(e *v1alpha1.SelectorExpression) error {
+		_, err := ExpandSelectors("kprobes[1]", []v1alpha1.KProbeSelector{{}, {MatchExpression: e}})
+		return err
+	}
+
+	err := expand(&v1alpha1.SelectorExpression{})
+	require.ErrorContains(t, err, "kprobes[1].selectors[1].matchExpression: exactly one of")
+
+	greater := arg("GT", "3")
+	err = expand(&v1alpha1.SelectorExpression{Or: []v1alpha1.SelectorExpression{arg("Equal", "1"), {Not: &greater}}})
+	require.ErrorContains(t, err, "kprobes[1].selectors[1].matchExpression.or[1].not: matchArg: operator 'GT' cannot be negated")
+
+	multi := arg("Equal", "1", "2")
+	err = expand(&v1alpha1.SelectorExpression{Not: &multi})
+	require.ErrorContains(t, err, "matchExpression.not: matchArg: operator 'Equal' can only be negated with a single value")
+
+	err = expand(&v1alpha1.SelectorExpression{And: []v1alpha1.SelectorExpression{binary("/a"), binary("/b")}})
+	require.ErrorContains(t, err, "matchExpression.and[1]: only one matchBinary")
+
+	err = expand(&v1alpha1.SelectorExpression{And: []v1alpha1.SelectorExpression{}})
+	require.ErrorContains(t, err, "matchExpression.and: no sub-expressions")
+
+	// 2 * 3 alternatives, over the selectors limit
+	err = expand(&v1alpha1.SelectorExpression{And: []v1alpha1.SelectorExpression{
+		{Or: []v1alpha1.SelectorExpression{arg("Equal", "1"), arg("Equal", "2")}},
+		{Or: []v1alpha1.SelectorExpression{arg("Prefix", "a"), arg("Prefix", "b"), arg("Prefix", "c")}},
+	}})
+	require.ErrorContains(t, err, "matchExpression.and: expands to more than 5 selectors")
+
+	// 1 plain selector + 5 alternatives
+	err = expand(&v1alpha1.SelectorExpression{Or: []v1alpha1.SelectorExpression{
+		arg("Equal", "1"), arg("Equal", "2"), arg("Equal", "3"), arg("Equal", "4"), arg("Equal", "5"),
+	}})
+	require.ErrorContains(t, err, "kprobes[1].selectors: expressions expand to 6 selectors")
+}
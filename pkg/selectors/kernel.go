@@ -43,6 +43,7 @@ const (
 	ActionTypeUntrackSock                 = 11
 	ActionTypeNotifyEnforcer              = 12
 	ActionTypeCleanupEnforcerNotification = 13
+	ActionTypeSetState                    = 14
 )
 
 var actionTypeTable = map[string]uint32{
@@ -60,6 +61,7 @@ var actionTypeTable = map[string]uint32{
 	"untracksock":                 ActionTypeUntrackSock,
 	"notifyenforcer":              ActionTypeNotifyEnforcer,
 	"cleanupenforcernotification": ActionTypeCleanupEnforcerNotification,
+	"setstate":                    ActionTypeSetState,
 }
 
 var actionTypeStringTable = map[uint32]string{
@@ -76,6 +78,7 @@ var actionTypeStringTable = map[uint32]string{
 	ActionTypeTrackSock:                   "tracksock",
 	ActionTypeUntrackSock:                 "untracksock",
 	ActionTypeCleanupEnforcerNotification: "cleanupenforcernotification",
+	ActionTypeSetState:                    "setstate",
 }
 
 const (
@@ -202,6 +205,11 @@ const (
 	SelectorOpState  = 29
 	// capabilities
 	SelectorOpCapabilitiesGained = 30
+	// more string ops
+	SelectorOpGlob     = 31
+	SelectorOpNotGlob  = 32
+	SelectorOpRegex    = 33
+	SelectorOpNotRegex = 34
 )
 
 var selectorOpStringTable = map[uint32]string{
@@ -234,6 +242,10 @@ var selectorOpStringTable = map[uint32]string{
 	SelectorOpFamily:             "Family",
 	SelectorOpState:              "State",
 	SelectorOpCapabilitiesGained: "CapabilitiesGained",
+	SelectorOpGlob:               "Glob",
+	SelectorOpNotGlob:            "NotGlob",
+	SelectorOpRegex:              "Regex",
+	SelectorOpNotRegex:           "NotRegex",
 }
 
 func SelectorOp(op string) (uint32, error) {
@@ -296,6 +308,14 @@ func SelectorOp(op string) (uint32, error) {
 		return SelectorOpState, nil
 	case "CapabilitiesGained":
 		return SelectorOpCapabilitiesGained, nil
+	case "glob", "Glob":
+		return SelectorOpGlob, nil
+	case "notglob", "NotGlob":
+		return SelectorOpNotGlob, nil
+	case "regex", "Regex":
+		return SelectorOpRegex, nil
+	case "notregex", "NotRegex":
+		return SelectorOpNotRegex, nil
 	}
 
 	return 0, fmt.Errorf("unknown op '%s'", op)
@@ -608,6 +628,47 @@ func capsStrToUint64(values []string) (uint64, error) {
 	return caps, nil
 }
 
+// parseMatchValue parses a matchArgs value for an argument of type ty. Values
+// of 32-bit types are returned in the lower 32 bits.
+func parseMatchValue(v string, ty, op uint32) (uint64, error) {
+	base := getBase(v)
+	switch ty {
+	case gt.GenericIntType, gt.GenericS32Type, gt.GenericSizeType:
+		i, err := strconv.ParseInt(v, base, 32)
+		if err != nil {
+			return 0, fmt.Errorf("MatchArgs value %s invalid: %w", v, err)
+		}
+		return uint64(uint32(i)), nil
+	case gt.GenericU32Type:
+		i, err := strconv.ParseUint(v, base, 32)
+		if err != nil {
+			return 0, fmt.Errorf("MatchArgs value %s invalid: %w", v, err)
+		}
+		return i, nil
+	case gt.GenericS64Type, gt.GenericSyscall64:
+		i, err := strconv.ParseInt(v, base, 64)
+		if err != nil {
+			return 0, fmt.Errorf("MatchArgs value %s invalid: %w", v, err)
+		}
+		return uint64(i), nil
+	case gt.GenericU64Type:
+		i, err := strconv.ParseUint(v, base, 64)
+		if err != nil {
+			return 0, fmt.Errorf("MatchArgs value %s invalid: %w", v, err)
+		}
+		return i, nil
+	case gt.GenericSockType, gt.GenericSkbType, gt.GenericSockaddrType, gt.GenericSocketType, gt.GenericNetDev:
+		return 0, fmt.Errorf("MatchArgs type sock, socket, skb, sockaddr and net_device do not support operator %s", selectorOpStringTable[op])
+	case gt.GenericKernelCap, gt.GenericCapInheritable, gt.GenericCapPermitted, gt.GenericCapEffective:
+		mask, err := parseCapabilitiesMask(v)
+		if err != nil {
+			return 0, fmt.Errorf("MatchArgs capabilities mask value %s invalid: %w", v, err)
+		}
+		return mask, nil
+	}
+	return 0, fmt.Errorf("MatchArgs type %s unsupported", gt.GenericTypeString(int(ty)))
+}
+
 func writeMatchValues(k *KernelSelectorState, values []string, ty, op uint32) error {
 	// NB: maxMatchValues should match MAX_MATCH_VALUES in bpf/process/types/basic.h
 	maxMatchValues := 4
@@ -616,43 +677,15 @@ func writeMatchValues(k *KernelSelectorState, values []string, ty, op uint32) er
 	}
 
 	for _, v := range values {
-		base := getBase(v)
+		val, err := parseMatchValue(v, ty, op)
+		if err != nil {
+			return err
+		}
 		switch ty {
-
-		case gt.GenericIntType, gt.GenericS32Type, gt.GenericSizeType:
-			i, err := strconv.ParseInt(v, base, 32)
-			if err != nil {
-				return fmt.Errorf("MatchArgs value %s invalid: %w", v, err)
-			}
-			WriteSelectorInt32(&k.data, int32(i))
-		case gt.GenericU32Type:
-			i, err := strconv.ParseUint(v, base, 32)
-			if err != nil {
-				return fmt.Errorf("MatchArgs value %s invalid: %w", v, err)
-			}
-			WriteSelectorUint32(&k.data, uint32(i))
-		case gt.GenericS64Type, gt.GenericSyscall64:
-			i, err := strconv.ParseInt(v, base, 64)
-			if err != nil {
-				return fmt.Errorf("MatchArgs value %s invalid: %w", v, err)
-			}
-			WriteSelectorInt64(&k.data, int64(i))
-		case gt.GenericU64Type:
-			i, err := strconv.ParseUint(v, base, 64)
-			if err != nil {
-				return fmt.Errorf("MatchArgs value %s invalid: %w", v, err)
-			}
-			WriteSelectorUint64(&k.data, uint64(i))
-		case gt.GenericSockType, gt.GenericSkbType, gt.GenericSockaddrType, gt.GenericSocketType, gt.GenericNetDev:
-			return fmt.Errorf("MatchArgs type sock, socket, skb, sockaddr and net_device do not support operator %s", selectorOpStringTable[op])
-		case gt.GenericKernelCap, gt.GenericCapInheritable, gt.GenericCapPermitted, gt.GenericCapEffective:
-			mask, err := parseCapabilitiesMask(v)
-			if err != nil {
-				return fmt.Errorf("MatchArgs capabilities mask value %s invalid: %w", v, err)
-			}
-			WriteSelectorUint64(&k.data, uint64(mask))
+		case gt.GenericIntType, gt.GenericS32Type, gt.GenericSizeType, gt.GenericU32Type:
+			WriteSelectorUint32(&k.data, uint32(val))
 		default:
-			return fmt.Errorf("MatchArgs type %s unsupported", gt.GenericTypeString(int(ty)))
+			WriteSelectorUint64(&k.data, val)
 		}
 	}
 	return nil
@@ -759,9 +792,38 @@ func writePostfixStrings(k *KernelSelectorState, values []string, ty uint32) err
 	return nil
 }
 
+// writeDFA compiles the Glob or Regex values into a DFA map and returns its id
+func writeDFA(k *KernelSelectorState, values []string, op uint32, selector string) (uint32, error) {
+	var trans map[uint32]uint16
+	var err error
+	if op == SelectorOpGlob || op == SelectorOpNotGlob {
+		trans, err = compileGlobDFA(values)
+	} else {
+		trans, err = compileStringDFA(values)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%s %s values invalid: %w", selector, selectorOpStringTable[op], err)
+	}
+	return k.newStringDFAMap(trans), nil
+}
+
+func writeDFABinaries(k *KernelSelectorState, values []string, op uint32) (uint32, error) {
+	return writeDFA(k, values, op, "MatchBinaries")
+}
+
+func writeDFAStrings(k *KernelSelectorState, values []string, op uint32) error {
+	mid, err := writeDFA(k, values, op, "MatchArgs")
+	if err != nil {
+		return err
+	}
+	WriteSelectorUint32(&k.data, mid)
+	return nil
+}
+
 func checkOp(op uint32) error {
 	switch op {
-	case SelectorOpGT, SelectorOpLT, SelectorOpCapabilitiesGained:
+	case SelectorOpGT, SelectorOpLT, SelectorOpCapabilitiesGained,
+		SelectorOpGlob, SelectorOpNotGlob, SelectorOpRegex, SelectorOpNotRegex:
 		if !config.EnableLargeProgs() {
 			opName := selectorOpStringTable[op]
 			return fmt.Errorf(
@@ -855,6 +917,16 @@ func ParseMatchArg(k *KernelSelectorState, arg *v1alpha1.ArgSelector, sig []v1al
 		if err != nil {
 			return fmt.Errorf("writePostfixStrings error: %w", err)
 		}
+	case SelectorOpGlob, SelectorOpNotGlob, SelectorOpRegex, SelectorOpNotRegex:
+		switch ty {
+		case gt.GenericFdType, gt.GenericFileType, gt.GenericPathType, gt.GenericStringType, gt.GenericCharBuffer, gt.GenericLinuxBinprmType, gt.GenericDataLoc, gt.GenericNetDev:
+		default:
+			return fmt.Errorf("%s operator is only supported for string and path arguments", arg.Operator)
+		}
+		err := writeDFAStrings(k, arg.Values, op)
+		if err != nil {
+			return fmt.Errorf("writeDFAStrings error: %w", err)
+		}
 	case SelectorOpSport, SelectorOpDport, SelectorOpNotSport, SelectorOpNotDport, SelectorOpProtocol, SelectorOpFamily, SelectorOpState:
 		if ty != gt.GenericSockType && ty != gt.GenericSkbType && ty != gt.GenericSockaddrType && ty != gt.GenericSocketType {
 			return errors.New("sock/socket/skb/sockaddr operators specified for non-sock/socket/skb/sockaddr type")
@@ -1054,6 +1126,14 @@ func ParseMatchAction(k *KernelSelectorState, action *v1alpha1.ActionSelector, a
 		WriteSelectorUint32(&k.data, actionArgIndex)
 	case ActionTypeCleanupEnforcerNotification:
 		// no arguments
+	case ActionTypeSetState:
+		if !config.EnableLargeProgs() {
+			return errors.New("setState action is only supported in kernels supporting large programs (normally versions >= 5.3)")
+		}
+		if action.State == "" {
+			return errors.New("setState action requires a state name")
+		}
+		WriteSelectorUint32(&k.data, StateID(action.State))
 	default:
 		return fmt.Errorf("ParseMatchAction: act %d (%s) is missing a handler", act, actionTypeStringTable[act])
 	}
@@ -1307,8 +1387,16 @@ func ParseMatchBinary(k *KernelSelectorState, b *v1alpha1.BinarySelector, selIdx
 		if err != nil {
 			return fmt.Errorf("failed to write the prefix operator for the matchBinaries selector: %w", err)
 		}
+	case SelectorOpGlob, SelectorOpNotGlob, SelectorOpRegex, SelectorOpNotRegex:
+		if !config.EnableLargeProgs() {
+			return fmt.Errorf("matchBinary error: \"%s\" operator needs large BPF progs (kernel>5.3)", b.Operator)
+		}
+		sel.MapID, err = writeDFABinaries(k, b.Values, op)
+		if err != nil {
+			return fmt.Errorf("failed to write the %s operator for the matchBinaries selector: %w", b.Operator, err)
+		}
 	default:
-		return errors.New("matchBinary error: Only \"In\", \"NotIn\", \"Prefix\", \"NotPrefix\", \"Postfix\" and \"NotPostfix\" operators are supported")
+		return errors.New("matchBinary error: Only \"In\", \"NotIn\", \"Prefix\", \"NotPrefix\", \"Postfix\", \"NotPostfix\", \"Glob\", \"NotGlob\", \"Regex\" and \"NotRegex\" operators are supported")
 	}
 
 	k.AddMatchBinaries(selIdx, sel)
@@ -1382,6 +1470,7 @@ func InitKernelReturnSelectors(selectors []v1alpha1.KProbeSelector, returnArg *v
 func createKernelSelectorState(selectors []v1alpha1.KProbeSelector, listReader ValueReader, maps *KernelSelectorMaps,
 	parseSelector func(k *KernelSelectorState, selectors *v1alpha1.KProbeSelector, selIdx int) error) (*KernelSelectorState, error) {
 	state := NewKernelSelectorState(listReader, maps)
+	state.selectors = len(selectors)
 
 	WriteSelectorUint32(&state.data, uint32(len(selectors)))
 	soff := make([]uint32, len(selectors))
@@ -1421,6 +1510,9 @@ func InitKernelSelectorState(selectors []v1alpha1.KProbeSelector, args []v1alpha
 		if err := ParseMatchBinaries(k, selectors.MatchBinaries, selIdx); err != nil {
 			return fmt.Errorf("parseMatchBinaries error: %w", err)
 		}
+		if err := ParseMatchStates(k, selectors.MatchState, selIdx); err != nil {
+			return fmt.Errorf("parseMatchState error: %w", err)
+		}
 		if err := ParseMatchArgs(k, selectors.MatchArgs, args); err != nil {
 			return fmt.Errorf("parseMatchArgs  error: %w", err)
 		}
@@ -1117,3 +1117,32 @@ func TestParseCapabilityMask(t *testing.T) {
 	_, err = parseCapabilitiesMask("CAP_PIZZA")
 	assert.Error(t, err)
 }
+
+func TestParseMatchArgDFA(t *testing.T) {
+	sig := []v1alpha1.KProbeArg{
+		{Index: 0, Type: "file"},
+		{Index: 1, Type: "int"},
+	}
+
+	k := NewKernelSelectorState(nil, nil)
+	glob := &v1alpha1.ArgSelector{Index: 0, Operator: "Glob", Values: []string{"/home/*/.ssh/*"}}
+	err := ParseMatchArg(k, glob, sig)
+	if !config.EnableLargeProgs() {
+		require.ErrorContains(t, err, "only supported in kernels supporting large programs")
+		return
+	}
+	require.NoError(t, err)
+	require.Len(t, k.StringDFAMaps(), 1)
+	require.True(t, matchStringDFA(k.StringDFAMaps()[0], "/home/alice/.ssh/id_rsa"))
+	require.False(t, matchStringDFA(k.StringDFAMaps()[0], "/home/alice/work/.ssh/id_rsa"))
+
+	regex := &v1alpha1.ArgSelector{Index: 0, Operator: "NotRegex", Values: []string{"/etc/(passwd|shadow)"}}
+	require.NoError(t, ParseMatchArg(k, regex, sig))
+	require.Len(t, k.StringDFAMaps(), 2)
+
+	err = ParseMatchArg(k, &v1alpha1.ArgSelector{Index: 0, Operator: "Regex", Values: []string{"(foo"}}, sig)
+	require.ErrorContains(t, err, "MatchArgs Regex values invalid")
+
+	err = ParseMatchArg(k, &v1alpha1.ArgSelector{Index: 1, Operator: "Glob", Values: []string{"1*"}}, sig)
+	require.Error(t, err)
+}
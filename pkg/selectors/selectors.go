@@ -93,6 +93,8 @@ type KernelSelectorMaps struct {
 	stringPrefixMaps []map[KernelLPMTrieStringPrefix]struct{}
 	// stringPostfixMaps are used to populate string and char buf postfix matches
 	stringPostfixMaps []map[KernelLPMTrieStringPostfix]struct{}
+	// stringDFAMaps are used to populate string and char buf glob and regex matches
+	stringDFAMaps []map[uint32]uint16
 }
 
 type MatchBinariesSelectorOptions struct {
@@ -122,6 +124,11 @@ type KernelSelectorState struct {
 	matchBinaries      map[int]MatchBinariesSelectorOptions
 	matchBinariesPaths map[int][][processapi.BINARY_PATH_MAX_LEN]byte
 
+	matchState map[int]MatchStateSelectorOptions
+
+	// selectors is the number of selectors
+	selectors int
+
 	listReader ValueReader
 
 	maps *KernelSelectorMaps
@@ -134,6 +141,7 @@ func NewKernelSelectorState(listReader ValueReader, maps *KernelSelectorMaps) *K
 	return &KernelSelectorState{
 		matchBinaries:      make(map[int]MatchBinariesSelectorOptions),
 		matchBinariesPaths: make(map[int][][processapi.BINARY_PATH_MAX_LEN]byte),
+		matchState:         make(map[int]MatchStateSelectorOptions),
 		listReader:         listReader,
 		maps:               maps,
 	}
@@ -147,6 +155,18 @@ func (k *KernelSelectorState) AddMatchBinaries(i int, sel MatchBinariesSelectorO
 	k.matchBinaries[i] = sel
 }
 
+func (k KernelSelectorState) MatchState() map[int]MatchStateSelectorOptions {
+	return k.matchState
+}
+
+func (k *KernelSelectorState) AddMatchState(i int, sel MatchStateSelectorOptions) {
+	k.matchState[i] = sel
+}
+
+func (k KernelSelectorState) Selectors() int {
+	return k.selectors
+}
+
 func (k KernelSelectorState) MatchBinariesPaths() map[int][][processapi.BINARY_PATH_MAX_LEN]byte {
 	return k.matchBinariesPaths
 }
@@ -196,6 +216,10 @@ func (k *KernelSelectorState) StringPostfixMaps() []map[KernelLPMTrieStringPostf
 	return k.maps.stringPostfixMaps
 }
 
+func (k *KernelSelectorState) StringDFAMaps() []map[uint32]uint16 {
+	return k.maps.stringDFAMaps
+}
+
 // ValueMapsMaxEntries returns the maximum entries over all maps
 func (k *KernelSelectorState) ValueMapsMaxEntries() int {
 	maxEntries := 1
@@ -262,6 +286,17 @@ func (k *KernelSelectorState) StringPostfixMapsMaxEntries() int {
 	return maxEntries
 }
 
+// StringDFAMapsMaxEntries returns the maximum entries over all maps
+func (k *KernelSelectorState) StringDFAMapsMaxEntries() int {
+	maxEntries := 1
+	for _, vm := range k.maps.stringDFAMaps {
+		if l := len(vm); l > maxEntries {
+			maxEntries = l
+		}
+	}
+	return maxEntries
+}
+
 func WriteSelectorInt32(k *KernelSelectorData, v int32) {
 	binary.LittleEndian.PutUint32(k.e[k.off:], uint32(v))
 	k.off += 4
@@ -493,3 +528,9 @@ func (k *KernelSelectorState) newStringPostfixMap() (uint32, map[KernelLPMTrieSt
 	k.maps.stringPostfixMaps = append(k.maps.stringPostfixMaps, map[KernelLPMTrieStringPostfix]struct{}{})
 	return uint32(mapid), k.maps.stringPostfixMaps[mapid]
 }
+
+func (k *KernelSelectorState) newStringDFAMap(trans map[uint32]uint16) uint32 {
+	mapid := len(k.maps.stringDFAMaps)
+	k.maps.stringDFAMaps = append(k.maps.stringDFAMaps, trans)
+	return uint32(mapid)
+}
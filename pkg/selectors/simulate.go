@@ -0,0 +1,779 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+//go:build !windows
+
+package selectors
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/sys/unix"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/arch"
+	gt "github.com/cilium/tetragon/pkg/generictypes"
+	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
+	"github.com/cilium/tetragon/pkg/policyconf"
+)
+
+// SimulationResult is the outcome of a hook of a policy for an event, as
+// computed by Simulator.
+type SimulationResult struct {
+	// Hook is the name of the hook, as reported in the selector counters
+	Hook string
+	// Match tells whether a selector of the hook matched. It is always true
+	// for hooks without selectors.
+	Match bool
+	// Selector is the index of the matching selector, or -1
+	Selector int
+	// Post tells whether the event would have been posted
+	Post bool
+	// Override tells whether the hooked function would have been overridden
+	Override bool
+	// Sigkill tells whether the process would have been killed
+	Sigkill bool
+}
+
+// Simulator evaluates the selectors of a tracing policy against events in user
+// space, the same way the BPF programs do when the policy is loaded. It
+// supports the matchArgs, matchBinaries, matchNamespaces and
+// matchCapabilities filters, and the actions that decide whether an event is
+// posted, overridden or killed. Rate limits are not applied.
+type Simulator struct {
+	hooks []simHook
+}
+
+type simHookKind int
+
+const (
+	simKprobe simHookKind = iota
+	simTracepoint
+	simLsm
+	simUprobe
+)
+
+// simEvent is the part of an event used by the simulation
+type simEvent struct {
+	kind simHookKind
+	// hook is the name of the hook the event comes from
+	hook string
+	// function is the function name of kprobe and lsm events
+	function string
+	// path, symbol and offset identify the hook of uprobe events
+	path    string
+	symbol  string
+	offset  uint64
+	process *tetragon.Process
+	args    []*tetragon.KprobeArgument
+}
+
+type simFilter func(ev *simEvent) (bool, error)
+
+type simSelector struct {
+	filters  []simFilter
+	post     bool
+	override bool
+	sigkill  bool
+}
+
+type simHook struct {
+	// path is the hook path in the policy spec, e.g. kprobes[0]
+	path      string
+	match     func(ev *simEvent) bool
+	selectors []simSelector
+}
+
+// NewSimulator returns a simulator for the policy spec. Enforcement actions
+// are ignored in monitor mode. It fails if the policy uses features that
+// cannot be simulated.
+func NewSimulator(spec *v1alpha1.TracingPolicySpec, mode policyconf.Mode) (*Simulator, error) {
+	spec, err := ExpandSpecExpressions(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	enforce := mode != policyconf.MonitorMode
+	s := &Simulator{}
+	add := func(path string, match func(ev *simEvent) bool, selectors []v1alpha1.KProbeSelector, args []v1alpha1.KProbeArg) error {
+		sels, err := simSelectors(selectors, args, enforce)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		s.hooks = append(s.hooks, simHook{path: path, match: match, selectors: sels})
+		return nil
+	}
+
+	for i := range spec.KProbes {
+		kp := &spec.KProbes[i]
+		path := fmt.Sprintf("kprobes[%d]", i)
+		if strings.HasPrefix(kp.Call, "list:") {
+			return nil, fmt.Errorf("%s: lists are not supported", path)
+		}
+		if err := add(path, matchCall(kp.Call), kp.Selectors, kp.Args); err != nil {
+			return nil, err
+		}
+	}
+	for i := range spec.Fentries {
+		fe := &spec.Fentries[i]
+		path := fmt.Sprintf("fentries[%d]", i)
+		if err := add(path, matchCall(fe.Call), fe.Selectors, fe.Args); err != nil {
+			return nil, err
+		}
+	}
+	for i := range spec.Tracepoints {
+		tp := &spec.Tracepoints[i]
+		name := tp.Subsystem + "/" + tp.Event
+		match := func(ev *simEvent) bool {
+			return ev.kind == simTracepoint && ev.hook == name
+		}
+		if err := add(fmt.Sprintf("tracepoints[%d]", i), match, tp.Selectors, tp.Args); err != nil {
+			return nil, err
+		}
+	}
+	for i := range spec.LsmHooks {
+		lsm := &spec.LsmHooks[i]
+		match := func(ev *simEvent) bool {
+			return ev.kind == simLsm && ev.function == lsm.Hook
+		}
+		if err := add(fmt.Sprintf("lsmhooks[%d]", i), match, lsm.Selectors, lsm.Args); err != nil {
+			return nil, err
+		}
+	}
+	for i := range spec.UProbes {
+		up := &spec.UProbes[i]
+		match := func(ev *simEvent) bool {
+			if ev.kind != simUprobe || ev.path != up.Path {
+				return false
+			}
+			if ev.symbol != "" {
+				return slices.Contains(up.Symbols, ev.symbol)
+			}
+			return slices.Contains(up.Offsets, ev.offset)
+		}
+		if err := add(fmt.Sprintf("uprobes[%d]", i), match, up.Selectors, up.Args); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// matchCall matches the kprobe and fentry events of the function call,
+// ignoring the arch specific prefix of syscalls.
+func matchCall(call string) func(ev *simEvent) bool {
+	_, name := arch.CutSyscallPrefix(call)
+	return func(ev *simEvent) bool {
+		if ev.kind != simKprobe {
+			return false
+		}
+		_, fn := arch.CutSyscallPrefix(ev.function)
+		return fn == name
+	}
+}
+
+// Simulate evaluates the hooks of the policy that the event comes from. It
+// returns a result per such hook, so nothing for events of other hooks or
+// events other than kprobe, tracepoint, lsm and uprobe events.
+func (s *Simulator) Simulate(res *tetragon.GetEventsResponse) ([]SimulationResult, error) {
+	ev := newSimEvent(res)
+	if ev == nil {
+		return nil, nil
+	}
+
+	var ret []SimulationResult
+	for i := range s.hooks {
+		h := &s.hooks[i]
+		if !h.match(ev) {
+			continue
+		}
+		r, err := h.simulate(ev)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, r)
+	}
+	return ret, nil
+}
+
+func newSimEvent(res *tetragon.GetEventsResponse) *simEvent {
+	switch e := res.Event.(type) {
+	case *tetragon.GetEventsResponse_ProcessKprobe:
+		kp := e.ProcessKprobe
+		return &simEvent{
+			kind:     simKprobe,
+			hook:     kp.FunctionName,
+			function: kp.FunctionName,
+			process:  kp.Process,
+			args:     kp.Args,
+		}
+	case *tetragon.GetEventsResponse_ProcessTracepoint:
+		tp := e.ProcessTracepoint
+		return &simEvent{
+			kind:    simTracepoint,
+			hook:    tp.Subsys + "/" + tp.Event,
+			process: tp.Process,
+			args:    tp.Args,
+		}
+	case *tetragon.GetEventsResponse_ProcessLsm:
+		lsm := e.ProcessLsm
+		return &simEvent{
+			kind:     simLsm,
+			hook:     lsm.FunctionName,
+			function: lsm.FunctionName,
+			process:  lsm.Process,
+			args:     lsm.Args,
+		}
+	case *tetragon.GetEventsResponse_ProcessUprobe:
+		up := e.ProcessUprobe
+		hook := up.Path + ":" + up.Symbol
+		if up.Symbol == "" {
+			hook = fmt.Sprintf("%s:0x%x", up.Path, up.Offset)
+		}
+		return &simEvent{
+			kind:    simUprobe,
+			hook:    hook,
+			path:    up.Path,
+			symbol:  up.Symbol,
+			offset:  up.Offset,
+			process: up.Process,
+			args:    up.Args,
+		}
+	}
+	return nil
+}
+
+// simulate applies the first selector whose filters all match, like
+// generic_process_filter and filter_read_arg do.
+func (h *simHook) simulate(ev *simEvent) (SimulationResult, error) {
+	ret := SimulationResult{Hook: ev.hook, Selector: -1}
+	if len(h.selectors) == 0 {
+		ret.Match = true
+		ret.Post = true
+		return ret, nil
+	}
+
+	for i := range h.selectors {
+		sel := &h.selectors[i]
+		match, err := sel.match(ev)
+		if err != nil {
+			return ret, fmt.Errorf("%s: selector %d: %w", h.path, i, err)
+		}
+		if match {
+			ret.Match = true
+			ret.Selector = i
+			ret.Post = sel.post
+			ret.Override = sel.override
+			ret.Sigkill = sel.sigkill
+			break
+		}
+	}
+	return ret, nil
+}
+
+func (sel *simSelector) match(ev *simEvent) (bool, error) {
+	for _, f := range sel.filters {
+		match, err := f(ev)
+		if err != nil || !match {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func simSelectors(selectors []v1alpha1.KProbeSelector, args []v1alpha1.KProbeArg, enforce bool) ([]simSelector, error) {
+	ret := make([]simSelector, 0, len(selectors))
+	for i := range selectors {
+		sel, err := newSimSelector(&selectors[i], args, enforce)
+		if err != nil {
+			return nil, fmt.Errorf("selector %d: %w", i, err)
+		}
+		ret = append(ret, sel)
+	}
+	return ret, nil
+}
+
+func newSimSelector(s *v1alpha1.KProbeSelector, args []v1alpha1.KProbeArg, enforce bool) (simSelector, error) {
+	var sel simSelector
+
+	switch {
+	case len(s.MatchPIDs) > 0:
+		return sel, errors.New("matchPIDs is not supported")
+	case len(s.MatchNamespaceChanges) > 0:
+		return sel, errors.New("matchNamespaceChanges is not supported")
+	case len(s.MatchCapabilityChanges) > 0:
+		return sel, errors.New("matchCapabilityChanges is not supported")
+	case len(s.MatchState) > 0:
+		return sel, errors.New("matchState is not supported")
+	case len(s.MatchReturnArgs) > 0, len(s.MatchReturnActions) > 0:
+		return sel, errors.New("return selectors are not supported")
+	}
+
+	if len(s.MatchBinaries) > 1 {
+		return sel, errors.New("only support a single matchBinaries per selector")
+	}
+	for i := range s.MatchBinaries {
+		f, err := simBinaryFilter(&s.MatchBinaries[i])
+		if err != nil {
+			return sel, err
+		}
+		sel.filters = append(sel.filters, f)
+	}
+	for i := range s.MatchNamespaces {
+		f, err := simNamespaceFilter(&s.MatchNamespaces[i])
+		if err != nil {
+			return sel, err
+		}
+		sel.filters = append(sel.filters, f)
+	}
+	for i := range s.MatchCapabilities {
+		f, err := simCapabilitiesFilter(&s.MatchCapabilities[i])
+		if err != nil {
+			return sel, err
+		}
+		sel.filters = append(sel.filters, f)
+	}
+	for i := range s.MatchArgs {
+		f, err := simArgFilter(&s.MatchArgs[i], args)
+		if err != nil {
+			return sel, err
+		}
+		sel.filters = append(sel.filters, f)
+	}
+
+	// see do_actions in bpf/process/generic_calls.h
+	sel.post = true
+	for _, a := range s.MatchActions {
+		act, ok := actionTypeTable[strings.ToLower(a.Action)]
+		if !ok {
+			return sel, fmt.Errorf("ActionType %s unknown", a.Action)
+		}
+		switch act {
+		case ActionTypeNoPost:
+			sel.post = false
+		case ActionTypeSigKill:
+			sel.sigkill = sel.sigkill || enforce
+		case ActionTypeSignal:
+			sel.sigkill = sel.sigkill || (enforce && a.ArgSig == uint32(unix.SIGKILL))
+		case ActionTypeOverride:
+			sel.override = sel.override || enforce
+		case ActionTypeNotifyEnforcer:
+			sel.sigkill = sel.sigkill || (enforce && a.ArgSig == uint32(unix.SIGKILL))
+			sel.override = sel.override || (enforce && a.ArgError != 0)
+		}
+	}
+	return sel, nil
+}
+
+func isNotOperator(op uint32) bool {
+	switch op {
+	case SelectorOpNEQ, SelectorOpNotIn, SelectorOpNotPrefix, SelectorOpNotPostfix, SelectorOpNotGlob, SelectorOpNotRegex:
+		return true
+	}
+	return false
+}
+
+// stringMatcher returns a function matching strings with the operator and
+// values, see filter_char_buf in bpf/process/types/basic.h.
+func stringMatcher(op uint32, values []string) (func(s string) bool, error) {
+	var match func(s string) bool
+
+	switch op {
+	case SelectorOpEQ, SelectorOpNEQ, SelectorOpIn, SelectorOpNotIn:
+		match = func(s string) bool {
+			return slices.Contains(values, s)
+		}
+	case SelectorOpPrefix, SelectorOpNotPrefix:
+		match = func(s string) bool {
+			return slices.ContainsFunc(values, func(v string) bool { return strings.HasPrefix(s, v) })
+		}
+	case SelectorOpPostfix, SelectorOpNotPostfix:
+		match = func(s string) bool {
+			return slices.ContainsFunc(values, func(v string) bool { return strings.HasSuffix(s, v) })
+		}
+	case SelectorOpGlob, SelectorOpNotGlob, SelectorOpRegex, SelectorOpNotRegex:
+		var trans map[uint32]uint16
+		var err error
+		if op == SelectorOpGlob || op == SelectorOpNotGlob {
+			trans, err = compileGlobDFA(values)
+		} else {
+			trans, err = compileStringDFA(values)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s values invalid: %w", selectorOpStringTable[op], err)
+		}
+		match = func(s string) bool {
+			return matchStringDFA(trans, s)
+		}
+	default:
+		return nil, fmt.Errorf("operator %s is not supported", selectorOpStringTable[op])
+	}
+
+	if isNotOperator(op) {
+		return func(s string) bool { return !match(s) }, nil
+	}
+	return match, nil
+}
+
+func simBinaryFilter(b *v1alpha1.BinarySelector) (simFilter, error) {
+	op, err := SelectorOp(b.Operator)
+	if err != nil {
+		return nil, fmt.Errorf("matchBinary error: %w", err)
+	}
+	if b.FollowChildren {
+		return nil, errors.New("matchBinary followChildren is not supported")
+	}
+	switch op {
+	case SelectorOpEQ, SelectorOpNEQ:
+		return nil, fmt.Errorf("matchBinary operator %s is not supported", b.Operator)
+	}
+	match, err := stringMatcher(op, b.Values)
+	if err != nil {
+		return nil, fmt.Errorf("matchBinary error: %w", err)
+	}
+	return func(ev *simEvent) (bool, error) {
+		if ev.process == nil {
+			return false, errors.New("event has no process")
+		}
+		return match(ev.process.Binary), nil
+	}, nil
+}
+
+func eventNamespace(ns *tetragon.Namespaces, nstype string) *tetragon.Namespace {
+	switch nstype {
+	case "uts":
+		return ns.Uts
+	case "ipc":
+		return ns.Ipc
+	case "mnt":
+		return ns.Mnt
+	case "pid":
+		return ns.Pid
+	case "pidforchildren":
+		return ns.PidForChildren
+	case "net":
+		return ns.Net
+	case "time":
+		return ns.Time
+	case "timeforchildren":
+		return ns.TimeForChildren
+	case "cgroup":
+		return ns.Cgroup
+	case "user":
+		return ns.User
+	}
+	return nil
+}
+
+// simNamespaceFilter matches the namespaces of the process, see
+// process_filter_namespace in bpf/process/pfilter.h. The "host_ns" value
+// matches the namespaces that events report as host namespaces.
+func simNamespaceFilter(sel *v1alpha1.NamespaceSelector) (simFilter, error) {
+	nstype := strings.ToLower(sel.Namespace)
+	if _, ok := namespaceTypeTable[nstype]; !ok {
+		return nil, fmt.Errorf("matchNamespace: namespace %s unknown", sel.Namespace)
+	}
+	op, err := SelectorOp(sel.Operator)
+	if err != nil {
+		return nil, fmt.Errorf("matchNamespace error: %w", err)
+	}
+	if op != SelectorOpIn && op != SelectorOpNotIn {
+		return nil, errors.New("matchNamespace supports only In and NotIn operators")
+	}
+
+	var inums []uint32
+	hostNs := false
+	for _, v := range sel.Values {
+		if v == "host_ns" {
+			hostNs = true
+			continue
+		}
+		val, err := strconv.ParseUint(v, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("values for matchNamespace can only be numeric or \"host_ns\". (%w)", err)
+		}
+		inums = append(inums, uint32(val))
+	}
+
+	return func(ev *simEvent) (bool, error) {
+		if ev.process.GetNs() == nil {
+			return false, errors.New("event has no namespaces, they are reported with --enable-process-ns")
+		}
+		ns := eventNamespace(ev.process.Ns, nstype)
+		match := slices.Contains(inums, ns.GetInum()) || (hostNs && ns.GetIsHost())
+		return match == (op == SelectorOpIn), nil
+	}, nil
+}
+
+func capabilitiesMask(caps []tetragon.CapabilitiesType) uint64 {
+	mask := uint64(0)
+	for _, c := range caps {
+		mask |= 1 << c
+	}
+	return mask
+}
+
+// simCapabilitiesFilter matches the capabilities of the process, see
+// process_filter_capabilities in bpf/process/pfilter.h.
+func simCapabilitiesFilter(sel *v1alpha1.CapabilitiesSelector) (simFilter, error) {
+	ty := strings.ToLower(sel.Type)
+	if _, ok := capabilitiesTypeTable[ty]; !ok {
+		return nil, fmt.Errorf("matchCapabilities: type %s unknown", sel.Type)
+	}
+	op, err := SelectorOp(sel.Operator)
+	if err != nil {
+		return nil, fmt.Errorf("matchCapabilities error: %w", err)
+	}
+	if op != SelectorOpIn && op != SelectorOpNotIn {
+		return nil, errors.New("matchCapabilities supports only In and NotIn operators")
+	}
+	mask, err := capsStrToUint64(sel.Values)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ev *simEvent) (bool, error) {
+		caps := ev.process.GetCap()
+		if caps == nil {
+			return false, errors.New("event has no capabilities, they are reported with --enable-process-cred")
+		}
+		if sel.IsNamespaceCapability {
+			if ev.process.GetNs() == nil {
+				return false, errors.New("event has no namespaces, they are reported with --enable-process-ns")
+			}
+			if ev.process.Ns.GetUser().GetIsHost() {
+				return false, nil
+			}
+		}
+		var set []tetragon.CapabilitiesType
+		switch ty {
+		case "effective":
+			set = caps.Effective
+		case "inheritable":
+			set = caps.Inheritable
+		case "permitted":
+			set = caps.Permitted
+		}
+		match := capabilitiesMask(set)&mask != 0
+		return match == (op == SelectorOpIn), nil
+	}, nil
+}
+
+func eventArg(ev *simEvent, index uint32) (*tetragon.KprobeArgument, error) {
+	if int(index) >= len(ev.args) {
+		return nil, fmt.Errorf("event has no argument %d", index)
+	}
+	return ev.args[index], nil
+}
+
+func eventArgString(arg *tetragon.KprobeArgument) (string, error) {
+	switch a := arg.GetArg().(type) {
+	case *tetragon.KprobeArgument_StringArg:
+		return a.StringArg, nil
+	case *tetragon.KprobeArgument_BytesArg:
+		return string(a.BytesArg), nil
+	case *tetragon.KprobeArgument_TruncatedBytesArg:
+		return string(a.TruncatedBytesArg.GetBytesArg()), nil
+	case *tetragon.KprobeArgument_PathArg:
+		return a.PathArg.GetPath(), nil
+	case *tetragon.KprobeArgument_FileArg:
+		return a.FileArg.GetPath(), nil
+	case *tetragon.KprobeArgument_LinuxBinprmArg:
+		return a.LinuxBinprmArg.GetPath(), nil
+	case *tetragon.KprobeArgument_NetDevArg:
+		return a.NetDevArg.GetName(), nil
+	}
+	return "", fmt.Errorf("argument %T is not a string", arg.GetArg())
+}
+
+func eventArgUint64(arg *tetragon.KprobeArgument) (uint64, error) {
+	switch a := arg.GetArg().(type) {
+	case *tetragon.KprobeArgument_IntArg:
+		return uint64(a.IntArg), nil
+	case *tetragon.KprobeArgument_UintArg:
+		return uint64(a.UintArg), nil
+	case *tetragon.KprobeArgument_SizeArg:
+		return a.SizeArg, nil
+	case *tetragon.KprobeArgument_LongArg:
+		return uint64(a.LongArg), nil
+	case *tetragon.KprobeArgument_SyscallId:
+		return uint64(a.SyscallId.GetId()), nil
+	case *tetragon.KprobeArgument_BpfCmdArg:
+		return uint64(a.BpfCmdArg), nil
+	case *tetragon.KprobeArgument_KernelCapTArg:
+		return strconv.ParseUint(a.KernelCapTArg, 16, 64)
+	case *tetragon.KprobeArgument_CapInheritableArg:
+		return strconv.ParseUint(a.CapInheritableArg, 16, 64)
+	case *tetragon.KprobeArgument_CapPermittedArg:
+		return strconv.ParseUint(a.CapPermittedArg, 16, 64)
+	case *tetragon.KprobeArgument_CapEffectiveArg:
+		return strconv.ParseUint(a.CapEffectiveArg, 16, 64)
+	}
+	return 0, fmt.Errorf("argument %T is not a number", arg.GetArg())
+}
+
+// simArgFilter matches an argument of the event, see selector_arg_offset in
+// bpf/process/types/basic.h.
+func simArgFilter(sel *v1alpha1.ArgSelector, args []v1alpha1.KProbeArg) (simFilter, error) {
+	index, ty, err := argIndexType(sel, args)
+	if err != nil {
+		return nil, err
+	}
+	op, err := SelectorOp(sel.Operator)
+	if err != nil {
+		return nil, fmt.Errorf("matcharg error: %w", err)
+	}
+
+	switch ty {
+	case gt.GenericFdType, gt.GenericFileType, gt.GenericPathType, gt.GenericDentryType, gt.GenericLinuxBinprmType,
+		gt.GenericStringType, gt.GenericCharBuffer, gt.GenericDataLoc, gt.GenericNetDev:
+		match, err := stringMatcher(op, sel.Values)
+		if err != nil {
+			return nil, fmt.Errorf("matcharg error: %w", err)
+		}
+		isFile := ty != gt.GenericStringType && ty != gt.GenericCharBuffer && ty != gt.GenericDataLoc && ty != gt.GenericNetDev
+		return func(ev *simEvent) (bool, error) {
+			arg, err := eventArg(ev, index)
+			if err != nil {
+				return false, err
+			}
+			s, err := eventArgString(arg)
+			if err != nil {
+				return false, err
+			}
+			// files without a path, e.g. pipes, never match
+			if isFile && s == "" {
+				return false, nil
+			}
+			return match(s), nil
+		}, nil
+	}
+
+	if op == SelectorOpCapabilitiesGained {
+		if len(sel.Args) != 2 {
+			return nil, errors.New("CapabilitiesGained operator requires two args: the new and the old capability")
+		}
+		index2, ty2, err := argIndexTypeFromArgs(sel, 1, args)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get second argument for CapabilitiesGained operator: %w", err)
+		}
+		if !isCapabilityType(ty) || !isCapabilityType(ty2) {
+			return nil, errors.New("CapabilitiesGained operator requires capability type arguments")
+		}
+		return func(ev *simEvent) (bool, error) {
+			capsOld, err := simArgUint64(ev, index)
+			if err != nil {
+				return false, err
+			}
+			capsNew, err := simArgUint64(ev, index2)
+			if err != nil {
+				return false, err
+			}
+			return (capsOld^capsNew)&capsNew != 0, nil
+		}, nil
+	}
+
+	var width int
+	var signed bool
+	switch ty {
+	case gt.GenericIntType, gt.GenericS32Type:
+		width, signed = 32, true
+	case gt.GenericSizeType, gt.GenericU32Type:
+		width = 32
+	case gt.GenericS64Type:
+		width, signed = 64, true
+	case gt.GenericU64Type, gt.GenericSyscall64,
+		gt.GenericKernelCap, gt.GenericCapInheritable, gt.GenericCapPermitted, gt.GenericCapEffective:
+		width = 64
+	default:
+		return nil, fmt.Errorf("matcharg on type %s is not supported", gt.GenericTypeString(int(ty)))
+	}
+
+	var match func(v uint64) bool
+	switch op {
+	case SelectorInMap, SelectorNotInMap:
+		k := NewKernelSelectorState(nil, nil)
+		if err := writeMatchValuesInMap(k, sel.Values, ty, op); err != nil {
+			return nil, fmt.Errorf("writeMatchValuesInMap error: %w", err)
+		}
+		data := k.valueMaps[0].Data
+		// see filter_32ty_map and filter_64ty_map
+		match = func(v uint64) bool {
+			var key [8]byte
+			if width == 32 {
+				v = uint64(uint32(v))
+			}
+			binary.LittleEndian.PutUint64(key[:], v)
+			_, ok := data[key]
+			return ok == (op == SelectorInMap)
+		}
+	case SelectorOpGT, SelectorOpLT, SelectorOpEQ, SelectorOpNEQ, SelectorOpMASK:
+		values := make([]uint64, 0, len(sel.Values))
+		for _, v := range sel.Values {
+			val, err := parseMatchValue(v, ty, op)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, val)
+		}
+		match = func(v uint64) bool {
+			return slices.ContainsFunc(values, func(w uint64) bool {
+				return compareValue(op, v, w, width, signed)
+			})
+		}
+	default:
+		return nil, fmt.Errorf("operator %s is not supported for type %s", sel.Operator, gt.GenericTypeString(int(ty)))
+	}
+
+	return func(ev *simEvent) (bool, error) {
+		v, err := simArgUint64(ev, index)
+		if err != nil {
+			return false, err
+		}
+		return match(v), nil
+	}, nil
+}
+
+func simArgUint64(ev *simEvent, index uint32) (uint64, error) {
+	arg, err := eventArg(ev, index)
+	if err != nil {
+		return 0, err
+	}
+	return eventArgUint64(arg)
+}
+
+// compareValue compares an argument value v with a selector value w, see
+// filter_32ty_selector_val and filter_64ty_selector_val. Note that NotEqual
+// matches if v differs from any of the values.
+func compareValue(op uint32, v, w uint64, width int, signed bool) bool {
+	if width == 32 {
+		if signed {
+			v, w = uint64(int64(int32(v))), uint64(int64(int32(w)))
+		} else {
+			v, w = uint64(uint32(v)), uint64(uint32(w))
+		}
+	}
+	switch op {
+	case SelectorOpGT:
+		if signed {
+			return int64(v) > int64(w)
+		}
+		return v > w
+	case SelectorOpLT:
+		if signed {
+			return int64(v) < int64(w)
+		}
+		return v < w
+	case SelectorOpEQ:
+		return v == w
+	case SelectorOpNEQ:
+		return v != w
+	case SelectorOpMASK:
+		return v&w != 0
+	}
+	return false
+}
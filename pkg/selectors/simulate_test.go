@@ -0,0 +1,199 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+//go:build !windows
+
+package selectors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
+	"github.com/cilium/tetragon/pkg/policyconf"
+	"github.com/cilium/tetragon/pkg/testutils/policysim"
+	"github.com/cilium/tetragon/pkg/tracingpolicy"
+)
+
+// TestSimulateConformance runs the conformance cases, which are also run
+// against the BPF programs by TestKprobeSelectorsConformance.
+func TestSimulateConformance(t *testing.T) {
+	for _, c := range policysim.Cases {
+		t.Run(c.Name, func(t *testing.T) {
+			sim, err := NewSimulator(c.Spec(), policyconf.EnforceMode)
+			require.NoError(t, err)
+
+			for i, call := range c.Calls {
+				ev := policysim.Event(call, "__x64_sys_lseek", "/usr/bin/tetragon-test")
+				res, err := sim.Simulate(ev)
+				require.NoError(t, err)
+				require.Len(t, res, 1)
+				got := policysim.Outcome{Post: res[0].Post, Override: res[0].Override}
+				require.Equal(t, c.Expected[i], got, "call %+v", call)
+			}
+		})
+	}
+}
+
+func kprobeEvent(function, binary string, args ...*tetragon.KprobeArgument) *tetragon.GetEventsResponse {
+	return &tetragon.GetEventsResponse{
+		Event: &tetragon.GetEventsResponse_ProcessKprobe{ProcessKprobe: &tetragon.ProcessKprobe{
+			Process:      &tetragon.Process{Binary: binary},
+			FunctionName: function,
+			Args:         args,
+		}},
+	}
+}
+
+func fileArg(path string) *tetragon.KprobeArgument {
+	return &tetragon.KprobeArgument{Arg: &tetragon.KprobeArgument_FileArg{FileArg: &tetragon.KprobeFile{Path: path}}}
+}
+
+func TestSimulateYAML(t *testing.T) {
+	policy := `
+apiVersion: cilium.io/v1alpha1
+kind: TracingPolicy
+metadata:
+  name: "simulate"
+spec:
+  kprobes:
+  - call: "security_file_open"
+    syscall: false
+    args:
+    - index: 0
+      type: "file"
+    selectors:
+    - matchBinaries:
+      - operator: "Prefix"
+        values: ["/usr/sbin/"]
+      matchArgs:
+      - index: 0
+        operator: "Glob"
+        values: ["/etc/**"]
+      matchActions:
+      - action: Sigkill
+    - matchArgs:
+      - index: 0
+        operator: "Prefix"
+        values: ["/etc/"]
+`
+	tp, err := tracingpolicy.FromYAML(policy)
+	require.NoError(t, err)
+
+	tests := []struct {
+		binary string
+		path   string
+		want   SimulationResult
+	}{
+		{"/usr/sbin/sshd", "/etc/shadow", SimulationResult{Hook: "security_file_open", Match: true, Selector: 0, Post: true, Sigkill: true}},
+		{"/usr/bin/cat", "/etc/shadow", SimulationResult{Hook: "security_file_open", Match: true, Selector: 1, Post: true}},
+		{"/usr/bin/cat", "/tmp/x", SimulationResult{Hook: "security_file_open", Selector: -1}},
+		// files without a path never match
+		{"/usr/bin/cat", "", SimulationResult{Hook: "security_file_open", Selector: -1}},
+	}
+	for _, tc := range tests {
+		for _, mode := range []policyconf.Mode{policyconf.EnforceMode, policyconf.MonitorMode} {
+			sim, err := NewSimulator(tp.TpSpec(), mode)
+			require.NoError(t, err)
+			res, err := sim.Simulate(kprobeEvent("security_file_open", tc.binary, fileArg(tc.path)))
+			require.NoError(t, err)
+			want := tc.want
+			if mode == policyconf.MonitorMode {
+				want.Sigkill = false
+			}
+			require.Equal(t, []SimulationResult{want}, res, "binary %s path %s", tc.binary, tc.path)
+		}
+	}
+
+	// events of other hooks are ignored
+	sim, err := NewSimulator(tp.TpSpec(), policyconf.EnforceMode)
+	require.NoError(t, err)
+	res, err := sim.Simulate(kprobeEvent("security_bprm_check", "/usr/bin/cat", fileArg("/etc/shadow")))
+	require.NoError(t, err)
+	require.Empty(t, res)
+}
+
+func TestSimulateNamespacesCapabilities(t *testing.T) {
+	spec := &v1alpha1.TracingPolicySpec{
+		Tracepoints: []v1alpha1.TracepointSpec{{
+			Subsystem: "syscalls",
+			Event:     "sys_enter_lseek",
+			Selectors: []v1alpha1.KProbeSelector{{
+				MatchNamespaces: []v1alpha1.NamespaceSelector{{
+					Namespace: "Mnt",
+					Operator:  "NotIn",
+					Values:    []string{"host_ns"},
+				}},
+				MatchCapabilities: []v1alpha1.CapabilitiesSelector{{
+					Type:     "Effective",
+					Operator: "In",
+					Values:   []string{"CAP_SYS_ADMIN"},
+				}},
+			}},
+		}},
+	}
+	sim, err := NewSimulator(spec, policyconf.EnforceMode)
+	require.NoError(t, err)
+
+	event := func(hostMnt bool, caps ...tetragon.CapabilitiesType) *tetragon.GetEventsResponse {
+		return &tetragon.GetEventsResponse{
+			Event: &tetragon.GetEventsResponse_ProcessTracepoint{ProcessTracepoint: &tetragon.ProcessTracepoint{
+				Process: &tetragon.Process{
+					Ns:  &tetragon.Namespaces{Mnt: &tetragon.Namespace{Inum: 4026531841, IsHost: hostMnt}},
+					Cap: &tetragon.Capabilities{Effective: caps},
+				},
+				Subsys: "syscalls",
+				Event:  "sys_enter_lseek",
+			}},
+		}
+	}
+
+	tests := []struct {
+		ev    *tetragon.GetEventsResponse
+		match bool
+	}{
+		{event(false, tetragon.CapabilitiesType_CAP_SYS_ADMIN), true},
+		{event(true, tetragon.CapabilitiesType_CAP_SYS_ADMIN), false},
+		{event(false, tetragon.CapabilitiesType_CAP_CHOWN), false},
+	}
+	for i, tc := range tests {
+		res, err := sim.Simulate(tc.ev)
+		require.NoError(t, err)
+		require.Len(t, res, 1)
+		require.Equal(t, "syscalls/sys_enter_lseek", res[0].Hook)
+		require.Equal(t, tc.match, res[0].Match, "test %d", i)
+	}
+
+	// events without namespaces cannot be simulated
+	ev := event(false)
+	ev.GetProcessTracepoint().Process.Ns = nil
+	_, err = sim.Simulate(ev)
+	require.ErrorContains(t, err, "--enable-process-ns")
+}
+
+func TestSimulateErrors(t *testing.T) {
+	tests := []struct {
+		sel v1alpha1.KProbeSelector
+		err string
+	}{
+		{v1alpha1.KProbeSelector{MatchPIDs: []v1alpha1.PIDSelector{{Operator: "In", Values: []uint32{1}}}}, "matchPIDs is not supported"},
+		{v1alpha1.KProbeSelector{MatchBinaries: []v1alpha1.BinarySelector{{Operator: "In", Values: []string{"/bin/sh"}, FollowChildren: true}}}, "followChildren is not supported"},
+		{v1alpha1.KProbeSelector{MatchArgs: []v1alpha1.ArgSelector{{Index: 0, Operator: "InMap", Values: []string{"list:syscalls"}}}}, "list values"},
+		{v1alpha1.KProbeSelector{MatchArgs: []v1alpha1.ArgSelector{{Index: 1, Operator: "Equal", Values: []string{"0"}}}}, "unknown index"},
+	}
+	for _, tc := range tests {
+		spec := &v1alpha1.TracingPolicySpec{
+			KProbes: []v1alpha1.KProbeSpec{{
+				Call:      "sys_lseek",
+				Syscall:   true,
+				Args:      []v1alpha1.KProbeArg{{Index: 0, Type: "int"}},
+				Selectors: []v1alpha1.KProbeSelector{tc.sel},
+			}},
+		}
+		_, err := NewSimulator(spec, policyconf.EnforceMode)
+		require.ErrorContains(t, err, tc.err)
+		require.ErrorContains(t, err, "kprobes[0]: selector 0")
+	}
+}
@@ -0,0 +1,173 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+//go:build !windows
+
+package selectors
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+
+	"github.com/cilium/tetragon/pkg/config"
+	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
+)
+
+// MaxMatchStateValues is the maximum number of states in a matchState filter
+// (PROCESS_STATE_MAX_VALUES in bpf/process/process_state.h).
+const MaxMatchStateValues = 4
+
+// MatchStateSelectorOptions holds the matchState filter of a selector, see
+// struct match_state_sel_opts in bpf/process/process_state.h.
+type MatchStateSelectorOptions struct {
+	Op     uint32
+	Len    uint32
+	Within uint64 // in nanoseconds, 0 if states do not expire
+	States [MaxMatchStateValues]uint32
+}
+
+// StateID returns the identifier of the process state name in the BPF maps.
+// Identifiers only depend on the name, so that all the hooks of a policy
+// agree on them.
+func StateID(name string) uint32 {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	return h.Sum32()
+}
+
+func isSetStateAction(action *v1alpha1.ActionSelector) bool {
+	return actionTypeTable[strings.ToLower(action.Action)] == ActionTypeSetState
+}
+
+// forEachSelectors calls fn with the selectors of each hook of the spec.
+func forEachSelectors(spec *v1alpha1.TracingPolicySpec, fn func(hook string, selectors []v1alpha1.KProbeSelector)) {
+	for i := range spec.KProbes {
+		fn(fmt.Sprintf("kprobes[%d]", i), spec.KProbes[i].Selectors)
+	}
+	for i := range spec.Tracepoints {
+		fn(fmt.Sprintf("tracepoints[%d]", i), spec.Tracepoints[i].Selectors)
+	}
+	for i := range spec.LsmHooks {
+		fn(fmt.Sprintf("lsmhooks[%d]", i), spec.LsmHooks[i].Selectors)
+	}
+	for i := range spec.UProbes {
+		fn(fmt.Sprintf("uprobes[%d]", i), spec.UProbes[i].Selectors)
+	}
+	for i := range spec.Fentries {
+		fn(fmt.Sprintf("fentries[%d]", i), spec.Fentries[i].Selectors)
+	}
+}
+
+// HasSetStateAction returns whether a hook of the spec sets process states.
+func HasSetStateAction(spec *v1alpha1.TracingPolicySpec) bool {
+	ret := false
+	forEachSelectors(spec, func(_ string, selectors []v1alpha1.KProbeSelector) {
+		for i := range selectors {
+			for j := range selectors[i].MatchActions {
+				ret = ret || isSetStateAction(&selectors[i].MatchActions[j])
+			}
+			for j := range selectors[i].MatchReturnActions {
+				ret = ret || isSetStateAction(&selectors[i].MatchReturnActions[j])
+			}
+		}
+	})
+	return ret
+}
+
+// ValidateSpecStates checks the process states of a spec, after its selector
+// expressions were expanded: every state matched by a matchState filter must
+// be set by a SetState action of the policy, and state names must have
+// distinct identifiers.
+func ValidateSpecStates(spec *v1alpha1.TracingPolicySpec) error {
+	set := make(map[string]bool)
+	matched := make(map[string]string)
+	forEachSelectors(spec, func(hook string, selectors []v1alpha1.KProbeSelector) {
+		for i := range selectors {
+			for _, actions := range [][]v1alpha1.ActionSelector{selectors[i].MatchActions, selectors[i].MatchReturnActions} {
+				for j := range actions {
+					if isSetStateAction(&actions[j]) {
+						set[actions[j].State] = true
+					}
+				}
+			}
+			for j := range selectors[i].MatchState {
+				for _, name := range selectors[i].MatchState[j].Values {
+					if _, ok := matched[name]; !ok {
+						matched[name] = fmt.Sprintf("%s.selectors[%d].matchState", hook, i)
+					}
+				}
+			}
+		}
+	})
+
+	ids := make(map[uint32]string)
+	for name := range set {
+		if other, ok := ids[StateID(name)]; ok {
+			return fmt.Errorf("states %q and %q have the same identifier, please rename one of them", name, other)
+		}
+		ids[StateID(name)] = name
+	}
+	for name, path := range matched {
+		if !set[name] {
+			return fmt.Errorf("%s: state %q is not set by any SetState action of the policy", path, name)
+		}
+	}
+	return nil
+}
+
+func ParseMatchState(k *KernelSelectorState, state *v1alpha1.StateSelector, selIdx int) error {
+	if !config.EnableLargeProgs() {
+		return errors.New("matchState is only supported in kernels supporting large programs (normally versions >= 5.3)")
+	}
+
+	op, err := SelectorOp(state.Operator)
+	if err != nil {
+		return fmt.Errorf("matchState error: %w", err)
+	}
+	if op != SelectorOpIn && op != SelectorOpNotIn {
+		return fmt.Errorf("matchState error: only In and NotIn operators are supported, got %s", state.Operator)
+	}
+	if len(state.Values) == 0 || len(state.Values) > MaxMatchStateValues {
+		return fmt.Errorf("matchState error: between 1 and %d values are supported (current number of values is %d)",
+			MaxMatchStateValues, len(state.Values))
+	}
+
+	sel := MatchStateSelectorOptions{
+		Op:  op,
+		Len: uint32(len(state.Values)),
+	}
+	for i, name := range state.Values {
+		if name == "" {
+			return errors.New("matchState error: empty state name")
+		}
+		sel.States[i] = StateID(name)
+	}
+	if state.Within != "" {
+		within, err := time.ParseDuration(state.Within)
+		if err != nil {
+			return fmt.Errorf("matchState error: invalid within value: %w", err)
+		}
+		if within <= 0 {
+			return fmt.Errorf("matchState error: within value %s must be positive", state.Within)
+		}
+		sel.Within = uint64(within.Nanoseconds())
+	}
+
+	k.AddMatchState(selIdx, sel)
+	return nil
+}
+
+func ParseMatchStates(k *KernelSelectorState, states []v1alpha1.StateSelector, selIdx int) error {
+	if len(states) > 1 {
+		return errors.New("only support a single matchState per selector")
+	}
+	for _, s := range states {
+		if err := ParseMatchState(k, &s, selIdx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
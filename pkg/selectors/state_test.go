@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+//go:build !windows
+
+package selectors
+
+import (
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cilium/tetragon/pkg/config"
+	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
+	"github.com/cilium/tetragon/pkg/tracingpolicy"
+)
+
+func TestParseMatchState(t *testing.T) {
+	if !config.EnableLargeProgs() {
+		t.Skip("matchState requires large programs")
+	}
+
+	k := NewKernelSelectorState(nil, nil)
+	err := ParseMatchStates(k, []v1alpha1.StateSelector{
+		{Operator: "NotIn", Values: []string{"a", "b"}, Within: "10s"},
+	}, 2)
+	require.NoError(t, err)
+	require.Equal(t, map[int]MatchStateSelectorOptions{
+		2: {
+			Op:     SelectorOpNotIn,
+			Len:    2,
+			Within: uint64(10 * time.Second),
+			States: [MaxMatchStateValues]uint32{StateID("a"), StateID("b")},
+		},
+	}, k.MatchState())
+
+	for _, tc := range []struct {
+		states []v1alpha1.StateSelector
+		err    string
+	}{
+		{[]v1alpha1.StateSelector{{Operator: "In", Values: []string{"a"}}, {Operator: "In", Values: []string{"b"}}}, "single matchState"},
+		{[]v1alpha1.StateSelector{{Operator: "Prefix", Values: []string{"a"}}}, "only In and NotIn"},
+		{[]v1alpha1.StateSelector{{Operator: "In"}}, "between 1 and 4 values"},
+		{[]v1alpha1.StateSelector{{Operator: "In", Values: []string{"a", "b", "c", "d", "e"}}}, "between 1 and 4 values"},
+		{[]v1alpha1.StateSelector{{Operator: "In", Values: []string{"a"}, Within: "soon"}}, "invalid within value"},
+		{[]v1alpha1.StateSelector{{Operator: "In", Values: []string{"a"}, Within: "-1s"}}, "must be positive"},
+	} {
+		err := ParseMatchStates(NewKernelSelectorState(nil, nil), tc.states, 0)
+		require.ErrorContains(t, err, tc.err)
+	}
+}
+
+func TestParseMatchActionSetState(t *testing.T) {
+	if !config.EnableLargeProgs() {
+		t.Skip("setState requires large programs")
+	}
+
+	k := NewKernelSelectorState(nil, nil)
+	require.NoError(t, ParseMatchAction(k, &v1alpha1.ActionSelector{Action: "SetState", State: "a"}, nil))
+	require.Equal(t, uint32(8), k.data.off)
+	require.Equal(t, uint32(ActionTypeSetState), binary.LittleEndian.Uint32(k.data.e[0:]))
+	require.Equal(t, StateID("a"), binary.LittleEndian.Uint32(k.data.e[4:]))
+
+	err := ParseMatchAction(NewKernelSelectorState(nil, nil), &v1alpha1.ActionSelector{Action: "SetState"}, nil)
+	require.ErrorContains(t, err, "requires a state name")
+}
+
+func TestValidateSpecStates(t *testing.T) {
+	policy := `
+apiVersion: cilium.io/v1alpha1
+kind: TracingPolicy
+metadata:
+  name: "states"
+spec:
+  kprobes:
+  - call: "security_file_open"
+    syscall: false
+    selectors:
+    - matchActions:
+      - action: SetState
+        state: "opened"
+  - call: "tcp_connect"
+    syscall: false
+    selectors:
+    - matchState:
+      - operator: In
+        values: ["opened"]
+      - operator: NotIn
+        values: ["connected"]
+`
+	tp, err := tracingpolicy.FromYAML(policy)
+	require.NoError(t, err)
+	spec := tp.TpSpec()
+	require.True(t, HasSetStateAction(spec))
+
+	err = ValidateSpecStates(spec)
+	require.ErrorContains(t, err, `kprobes[1].selectors[0].matchState: state "connected" is not set`)
+
+	spec.KProbes[1].Selectors[0].MatchReturnActions = []v1alpha1.ActionSelector{{Action: "SetState", State: "connected"}}
+	require.NoError(t, ValidateSpecStates(spec))
+
+	spec.KProbes[0].Selectors[0].MatchActions = nil
+	spec.KProbes[1].Selectors[0].MatchReturnActions = nil
+	require.False(t, HasSetStateAction(spec))
+}
+
+func TestExpandSelectorsState(t *testing.T) {
+	state := func(op string) v1alpha1.SelectorExpression {
+		return v1alpha1.SelectorExpression{MatchState: &v1alpha1.StateSelector{Operator: op, Values: []string{"a"}}}
+	}
+
+	notIn := state("NotIn")
+	ret, err := ExpandSelectors("kprobes[0]", []v1alpha1.KProbeSelector{{
+		MatchExpression: &v1alpha1.SelectorExpression{Not: &notIn},
+	}})
+	require.NoError(t, err)
+	require.Equal(t, []v1alpha1.KProbeSelector{
+		{MatchState: []v1alpha1.StateSelector{{Operator: "In", Values: []string{"a"}}}},
+	}, ret)
+
+	_, err = ExpandSelectors("kprobes[0]", []v1alpha1.KProbeSelector{{
+		MatchExpression: &v1alpha1.SelectorExpression{And: []v1alpha1.SelectorExpression{state("In"), state("NotIn")}},
+	}})
+	require.ErrorContains(t, err, "only one matchState")
+}
@@ -7,11 +7,14 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/cilium/tetragon/api/v1/tetragon"
 	"github.com/cilium/tetragon/pkg/policyconf"
+	"github.com/cilium/tetragon/pkg/policyschedule"
 	"github.com/cilium/tetragon/pkg/tracingpolicy"
 	"go.uber.org/multierr"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type TracingPolicyState int
@@ -70,8 +73,15 @@ type collection struct {
 	tracingpolicyID uint64
 	// if this is not zero, then the policy is filtered
 	policyfilterID uint64
+	// if this is not zero, then the pods it filters use the pod mode of
+	// the policy
+	podModeFilterID uint64
 	// state indicates the state of the collection
 	state TracingPolicyState
+	// schedule of the policy, nil if the policy has no schedule
+	schedule *policyschedule.Schedule
+	// scheduleTimer fires at the next transition of the schedule
+	scheduleTimer *time.Timer
 }
 
 type collectionMap struct {
@@ -182,6 +192,27 @@ func (c *collection) unload(unpin bool) error {
 	return nil
 }
 
+// scheduleToProto returns the schedule status of the collection at time now
+func (c *collection) scheduleToProto(now time.Time) *tetragon.TracingPolicySchedule {
+	if c.schedule == nil {
+		return nil
+	}
+	ret := &tetragon.TracingPolicySchedule{
+		Active: c.schedule.Active(now),
+	}
+	if next, ok := c.schedule.Next(now); ok {
+		ret.NextTransitionTime = timestamppb.New(next)
+	}
+	return ret
+}
+
+func (c *collection) stopSchedule() {
+	if c.scheduleTimer != nil {
+		c.scheduleTimer.Stop()
+		c.scheduleTimer = nil
+	}
+}
+
 // destroy will attempt to destroy all the sensors in a collection
 func (c *collection) destroy(unpin bool) {
 	for _, s := range c.sensors {
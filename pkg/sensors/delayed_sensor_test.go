@@ -35,6 +35,10 @@ func (tds TestDelayedSensor) Overhead() ([]ProgOverhead, bool) {
 	return []ProgOverhead{}, false
 }
 
+func (tds TestDelayedSensor) SelectorStats() []SelectorStats {
+	return nil
+}
+
 func (tds TestDelayedSensor) TotalMemlock() int {
 	return 0
 }
@@ -4,7 +4,9 @@
 package procevents
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"sort"
 	"unicode/utf8"
 
@@ -17,6 +19,7 @@ import (
 	"github.com/cilium/tetragon/pkg/logger/logfields"
 	"github.com/cilium/tetragon/pkg/observer"
 	"github.com/cilium/tetragon/pkg/option"
+	"github.com/cilium/tetragon/pkg/process"
 	"github.com/cilium/tetragon/pkg/reader/proc"
 	"github.com/cilium/tetragon/pkg/sensors/exec/execvemap"
 	"github.com/cilium/tetragon/pkg/sensors/exec/userinfo"
@@ -281,7 +284,10 @@ func procToKeyValue(p procs, inInitTree map[uint32]struct{}) (*execvemap.ExecveK
 	return k, v
 }
 
-func pushEvents(ps []procs) {
+// pushEvents writes the processes to the execve_map and pushes their exec
+// events, except for the processes in restored, which are already in the
+// process cache.
+func pushEvents(ps []procs, restored map[string]struct{}) {
 	inInitTreeMap := writeExecveMap(ps)
 
 	sort.Slice(ps, func(i, j int) bool {
@@ -289,17 +295,90 @@ func pushEvents(ps []procs) {
 	})
 	ps = append([]procs{procKernel()}, ps...)
 	for _, p := range ps {
+		if _, ok := restored[process.GetProcessID(p.pid, p.ktime)]; ok {
+			continue
+		}
 		pushExecveEvents(p, inInitTreeMap)
 	}
 }
 
+// reconcileExecveMap sets the keys of the processes read from procfs to the
+// keys recorded for them in the execve_map of the previous instance, so that
+// their exec IDs do not change. An entry is only used if its ktime is not
+// before the start time read from procfs, which is rounded down to the clock
+// tick: an older entry belongs to an exited process whose pid was reused.
+func reconcileExecveMap(ps []procs, old map[uint32]execvemap.ExecveValue) {
+	for i := range ps {
+		p := &ps[i]
+		if v, ok := old[p.pid]; ok && v.Process.Ktime >= p.ktime {
+			p.ktime = v.Process.Ktime
+		}
+		if v, ok := old[p.ppid]; ok && v.Process.Ktime >= p.pktime {
+			p.pktime = v.Process.Ktime
+		}
+	}
+}
+
+// restoreProcessCache restores the process cache from the snapshot at path,
+// and returns the exec IDs of the restored processes.
+func restoreProcessCache(ps []procs, path string) map[string]struct{} {
+	running := make(map[string]struct{}, len(ps)+1)
+	for _, p := range append([]procs{procKernel()}, ps...) {
+		running[process.GetProcessID(p.pid, p.ktime)] = struct{}{}
+	}
+	ids, err := process.RestoreSnapshot(path, func(execID string) bool {
+		_, ok := running[execID]
+		return ok
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		logger.GetLogger().Info("No process cache snapshot to restore", "file", path)
+		return nil
+	}
+	if err != nil {
+		logger.GetLogger().Warn("Failed to restore process cache snapshot", "file", path, logfields.Error, err)
+		return nil
+	}
+
+	restored := make(map[string]struct{}, len(ids))
+	for _, id := range ids {
+		restored[id] = struct{}{}
+	}
+	logger.GetLogger().Info("Restored process cache snapshot", "file", path, "processes", len(ids))
+	return restored
+}
+
 func GetRunningProcs() error {
+	return RestoreRunningProcs("", "")
+}
+
+// RestoreRunningProcs is like GetRunningProcs, when the sensors of a previous
+// instance, whose BPF directory was moved to oldBpfDir, were kept running (see
+// --keep-sensors-on-exit). The running processes keep the exec IDs of the
+// previous instance, and the ones that are in the process cache snapshot at
+// snapshotPath are restored with their ancestors, instead of being reported
+// again.
+func RestoreRunningProcs(oldBpfDir, snapshotPath string) error {
 	procs, err := listRunningProcs(option.Config.ProcFS)
 	if err != nil {
 		logger.GetLogger().Error(fmt.Sprintf("Failed to list running processes from '%s'", option.Config.ProcFS), logfields.Error, err)
 		return err
 	}
 
-	pushEvents(procs)
+	var restored map[string]struct{}
+	if oldBpfDir != "" && !sensorsKept(oldBpfDir) {
+		logger.GetLogger().Info("Sensors of the previous instance were not kept running, exec IDs will change", "bpf-dir", oldBpfDir)
+	} else if oldBpfDir != "" {
+		old, err := readExecveMap(oldBpfDir)
+		if err != nil {
+			logger.GetLogger().Warn("Failed to read the execve_map of the previous instance, exec IDs will change", "bpf-dir", oldBpfDir, logfields.Error, err)
+		} else {
+			reconcileExecveMap(procs, old)
+			if snapshotPath != "" {
+				restored = restoreProcessCache(procs, snapshotPath)
+			}
+		}
+	}
+
+	pushEvents(procs, restored)
 	return nil
 }
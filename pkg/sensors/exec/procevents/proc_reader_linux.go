@@ -25,6 +25,7 @@ import (
 	"github.com/cilium/tetragon/pkg/sensors"
 	"github.com/cilium/tetragon/pkg/sensors/base"
 	"github.com/cilium/tetragon/pkg/sensors/exec/execvemap"
+	"github.com/cilium/tetragon/pkg/sensors/program"
 )
 
 const (
@@ -138,6 +139,36 @@ func writeExecveMap(procs []procs) map[uint32]struct{} {
 	return inInitTree
 }
 
+// sensorsKept returns true if the links of the base sensor programs that
+// maintain the execve_map are still pinned in the BPF directory dir of a
+// previous instance, that is, if they kept running after it exited.
+func sensorsKept(dir string) bool {
+	for _, p := range []*program.Program{base.Execve, base.Exit, base.Fork} {
+		if _, err := os.Stat(filepath.Join(dir, p.PinPath, "link")); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// readExecveMap reads the execve_map pinned in the BPF directory dir
+func readExecveMap(dir string) (map[uint32]execvemap.ExecveValue, error) {
+	m, err := ebpf.LoadPinnedMap(filepath.Join(dir, base.GetExecveMap().Name), &ebpf.LoadPinOptions{ReadOnly: true})
+	if err != nil {
+		return nil, err
+	}
+	defer m.Close()
+
+	ret := make(map[uint32]execvemap.ExecveValue)
+	var k execvemap.ExecveKey
+	var v execvemap.ExecveValue
+	iter := m.Iterate()
+	for iter.Next(&k, &v) {
+		ret[k.Pid] = v
+	}
+	return ret, iter.Err()
+}
+
 func listRunningProcs(procPath string) ([]procs, error) {
 	var processes []procs
 
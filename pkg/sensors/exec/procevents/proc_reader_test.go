@@ -12,7 +12,9 @@ import (
 	"time"
 
 	"github.com/cilium/tetragon/pkg/api"
+	"github.com/cilium/tetragon/pkg/api/processapi"
 	"github.com/cilium/tetragon/pkg/observer/observertesthelper/docker"
+	"github.com/cilium/tetragon/pkg/sensors/exec/execvemap"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -60,3 +62,30 @@ func TestInInitTreeProcfs(t *testing.T) {
 		}
 	}
 }
+
+func TestReconcileExecveMap(t *testing.T) {
+	ps := []procs{
+		{pid: 1, ktime: 1000, ppid: 0, pktime: 1},
+		{pid: 100, ktime: 2000, ppid: 1, pktime: 1000},
+		// missing from the execve_map, only its parent is reconciled
+		{pid: 101, ktime: 3000, ppid: 1, pktime: 1000},
+		// reused the pid of an exited process still in the execve_map
+		{pid: 102, ktime: 4000, ppid: 1, pktime: 1000},
+		{pid: 103, ktime: 5000, ppid: 102, pktime: 4000},
+	}
+	old := map[uint32]execvemap.ExecveValue{
+		0:   {Process: processapi.MsgExecveKey{Pid: 0, Ktime: 1}},
+		1:   {Process: processapi.MsgExecveKey{Pid: 1, Ktime: 1005}, Parent: processapi.MsgExecveKey{Pid: 0, Ktime: 1}},
+		100: {Process: processapi.MsgExecveKey{Pid: 100, Ktime: 2500}, Parent: processapi.MsgExecveKey{Pid: 1, Ktime: 1005}},
+		102: {Process: processapi.MsgExecveKey{Pid: 102, Ktime: 3500}, Parent: processapi.MsgExecveKey{Pid: 1, Ktime: 1005}},
+	}
+
+	reconcileExecveMap(ps, old)
+	assert.Equal(t, []procs{
+		{pid: 1, ktime: 1005, ppid: 0, pktime: 1},
+		{pid: 100, ktime: 2500, ppid: 1, pktime: 1005},
+		{pid: 101, ktime: 3000, ppid: 1, pktime: 1005},
+		{pid: 102, ktime: 4000, ppid: 1, pktime: 1005},
+		{pid: 103, ktime: 5000, ppid: 102, pktime: 4000},
+	}, ps)
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/cilium/tetragon/pkg/logger"
 	"github.com/cilium/tetragon/pkg/option"
 	"github.com/cilium/tetragon/pkg/reader/proc"
+	"github.com/cilium/tetragon/pkg/sensors/exec/execvemap"
 )
 
 type ProcessBasicInfo64 struct {
@@ -246,6 +247,14 @@ func writeExecveMap(_ []procs) map[uint32]struct{} {
 	return make(map[uint32]struct{})
 }
 
+func sensorsKept(_ string) bool {
+	return false
+}
+
+func readExecveMap(_ string) (map[uint32]execvemap.ExecveValue, error) {
+	return nil, errors.New("execve_map is not supported on windows")
+}
+
 func getProcessParamsFromHandle64(handle windows.Handle) (RtlUserProcessParams64, error) {
 	pebAddress, err := queryPebAddress(syscall.Handle(handle), false)
 	if err != nil {
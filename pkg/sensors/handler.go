@@ -7,10 +7,15 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/cilium/tetragon/api/v1/tetragon"
 	slimv1 "github.com/cilium/tetragon/pkg/k8s/slim/k8s/apis/meta/v1"
+	"github.com/cilium/tetragon/pkg/ktime"
+	"github.com/cilium/tetragon/pkg/logger"
+	"github.com/cilium/tetragon/pkg/logger/logfields"
 	"github.com/cilium/tetragon/pkg/policyfilter"
+	"github.com/cilium/tetragon/pkg/policyschedule"
 	"github.com/cilium/tetragon/pkg/tracingpolicy"
 )
 
@@ -60,7 +65,7 @@ func (h *handler) allocPolicyID() uint64 {
 
 // revive:disable:exported
 func SensorsFromPolicy(tp tracingpolicy.TracingPolicy, filterID policyfilter.PolicyID) ([]SensorIface, error) {
-	return sensorsFromPolicyHandlers(tp, filterID)
+	return sensorsFromPolicyHandlers(tp, filterID, policyfilter.NoFilterID)
 }
 
 // revive:enable:exported
@@ -108,14 +113,38 @@ func (h *handler) updatePolicyFilter(tp tracingpolicy.TracingPolicy, tpID uint64
 	return filterID, nil
 }
 
+// updatePodModeFilter adds the pods selected by the pod mode of the policy
+// to the policyfilter state, under a new policy filter id.
+//
+// It returns:
+//
+//	policyfilter.NoFilterID, nil if the policy has no pod mode
+//	the policy filter id of the pods, nil if the policyfilter has been successfully set up
+//	_, err if an error occurred
+func (h *handler) updatePodModeFilter(tp tracingpolicy.TracingPolicy) (policyfilter.PolicyID, error) {
+	podMode := tp.TpSpec().PodMode
+	if podMode == nil {
+		return policyfilter.NoFilterID, nil
+	}
+
+	filterID := policyfilter.PolicyID(h.allocPolicyID())
+	if err := h.pfState.AddPolicy(filterID, tracingpolicy.Namespace(tp), podMode.PodSelector, nil); err != nil {
+		return policyfilter.NoFilterID, fmt.Errorf("failed to set up pod mode: %w", err)
+	}
+	return filterID, nil
+}
+
 func (h *handler) addTracingPolicy(op *tracingPolicyAdd) error {
 	h.collections.mu.Lock()
 	defer h.collections.mu.Unlock()
 	collections := h.collections.c
 	// allow overriding existing policy collection that resulted in an error
 	// during the loading state
-	if col, exists := collections[op.ck]; exists && col.state != LoadErrorState {
-		return fmt.Errorf("failed to add tracing policy %s, a sensor collection with the key already exists", op.ck)
+	if col, exists := collections[op.ck]; exists {
+		if col.state != LoadErrorState {
+			return fmt.Errorf("failed to add tracing policy %s, a sensor collection with the key already exists", op.ck)
+		}
+		col.stopSchedule()
 	}
 	tpID := h.allocPolicyID()
 
@@ -126,6 +155,15 @@ func (h *handler) addTracingPolicy(op *tracingPolicyAdd) error {
 	}
 	collections[op.ck] = &col
 
+	now := time.Now()
+	schedule, err := policyschedule.New(op.tp.TpSpec().Schedule, now)
+	if err != nil {
+		col.err = err
+		col.state = LoadErrorState
+		return err
+	}
+	col.schedule = schedule
+
 	// update policy filter state before loading the sensors of the policy.
 	//
 	// The filterID is set to a non-zero value only if we need to apply
@@ -143,7 +181,15 @@ func (h *handler) addTracingPolicy(op *tracingPolicyAdd) error {
 	}
 	col.policyfilterID = uint64(filterID)
 
-	sensors, err := sensorsFromPolicyHandlers(op.tp, filterID)
+	podModeFilterID, err := h.updatePodModeFilter(op.tp)
+	if err != nil {
+		col.err = err
+		col.state = LoadErrorState
+		return err
+	}
+	col.podModeFilterID = uint64(podModeFilterID)
+
+	sensors, err := sensorsFromPolicyHandlers(op.tp, filterID, podModeFilterID)
 	if err != nil {
 		col.err = err
 		col.state = LoadErrorState
@@ -151,6 +197,14 @@ func (h *handler) addTracingPolicy(op *tracingPolicyAdd) error {
 	}
 	col.sensors = make([]SensorIface, 0, len(sensors))
 	col.sensors = append(col.sensors, sensors...)
+
+	// policies that are not enabled by their schedule yet are loaded at the
+	// next transition of the schedule
+	if schedule != nil && !schedule.Active(now) {
+		col.state = DisabledState
+		h.armSchedule(op.ck, &col, now)
+		return nil
+	}
 	col.state = LoadingState
 
 	// unlock so that policyLister can access the collections (read-only) while we are loading.
@@ -164,6 +218,9 @@ func (h *handler) addTracingPolicy(op *tracingPolicyAdd) error {
 		return err
 	}
 	col.state = EnabledState
+	if schedule != nil {
+		h.armSchedule(op.ck, &col, now)
+	}
 	return nil
 }
 
@@ -176,6 +233,7 @@ func (h *handler) deleteTracingPolicy(op *tracingPolicyDelete) error {
 		return fmt.Errorf("tracing policy %s does not exist", op.ck)
 	}
 	delete(collections, op.ck)
+	col.stopSchedule()
 	// we have removed the collection, so unlock the map so that the lister can quickly view
 	// that the collection is gone
 	h.collections.mu.Unlock()
@@ -188,6 +246,12 @@ func (h *handler) deleteTracingPolicy(op *tracingPolicyDelete) error {
 		return fmt.Errorf("failed to remove from policyfilter: %w", err)
 	}
 
+	podModeFilterID := policyfilter.PolicyID(col.podModeFilterID)
+	err = h.pfState.DelPolicy(podModeFilterID)
+	if err != nil {
+		return fmt.Errorf("failed to remove pod mode from policyfilter: %w", err)
+	}
+
 	return nil
 }
 
@@ -237,6 +301,50 @@ func (h *handler) doEnableTracingPolicy(col *collection) error {
 	return nil
 }
 
+// armSchedule arms the timer of the first transition of the schedule of col
+// after time t.
+//
+// should be called with h.collections.mu locked (for writing)
+func (h *handler) armSchedule(ck collectionKey, col *collection, t time.Time) {
+	col.scheduleTimer = nil
+	next, ok := col.schedule.Next(t)
+	if !ok {
+		return
+	}
+	col.scheduleTimer = time.AfterFunc(time.Until(next), func() {
+		h.applySchedule(ck, col, next)
+	})
+}
+
+// applySchedule enables or disables col as its schedule defines at time t,
+// and arms the timer of the next transition.
+func (h *handler) applySchedule(ck collectionKey, col *collection, t time.Time) {
+	h.collections.mu.Lock()
+	defer h.collections.mu.Unlock()
+	if h.collections.c[ck] != col {
+		// the policy was deleted or replaced
+		return
+	}
+
+	var err error
+	if col.schedule.Active(t) {
+		if col.state == DisabledState {
+			err = h.doEnableTracingPolicy(col)
+		}
+	} else if col.state == EnabledState {
+		err = h.doDisableTracingPolicy(col)
+	}
+	if err != nil {
+		logger.GetLogger().Warn("Failed to apply tracing policy schedule", "policy", ck.String(), logfields.Error, err)
+	}
+
+	// the lock is released while loading or unloading the policy, so check
+	// again that it was not deleted
+	if h.collections.c[ck] == col {
+		h.armSchedule(ck, col, t)
+	}
+}
+
 func (h *handler) configureTracingPolicy(
 	ck collectionKey,
 	mode *tetragon.TracingPolicyMode,
@@ -290,6 +398,7 @@ func removeAllSensors(h *handler, unpin bool) {
 	defer h.collections.mu.Unlock()
 	collections := h.collections.c
 	for ck, col := range collections {
+		col.stopSchedule()
 		col.destroy(unpin)
 		delete(collections, ck)
 	}
@@ -394,6 +503,7 @@ func (h *handler) listPolicies() []*tetragon.TracingPolicyStatus {
 	defer h.collections.mu.RUnlock()
 	collections := h.collections.c
 
+	now := time.Now()
 	ret := make([]*tetragon.TracingPolicyStatus, 0, len(collections))
 	for ck, col := range collections {
 		if col.tracingpolicy == nil {
@@ -407,6 +517,7 @@ func (h *handler) listPolicies() []*tetragon.TracingPolicyStatus {
 			FilterId: col.policyfilterID,
 			State:    col.state.ToTetragonState(),
 			Mode:     col.mode(),
+			Schedule: col.scheduleToProto(now),
 		}
 
 		if col.err != nil {
@@ -418,6 +529,9 @@ func (h *handler) listPolicies() []*tetragon.TracingPolicyStatus {
 		for _, sens := range col.sensors {
 			pol.Sensors = append(pol.Sensors, sens.GetName())
 			pol.KernelMemoryBytes += uint64(sens.TotalMemlock())
+			for _, stats := range sens.SelectorStats() {
+				pol.SelectorStats = append(pol.SelectorStats, selectorStatsToProto(&stats))
+			}
 		}
 
 		ret = append(ret, &pol)
@@ -426,10 +540,26 @@ func (h *handler) listPolicies() []*tetragon.TracingPolicyStatus {
 	return ret
 }
 
-func sensorsFromPolicyHandlers(tp tracingpolicy.TracingPolicy, filterID policyfilter.PolicyID) ([]SensorIface, error) {
+func selectorStatsToProto(stats *SelectorStats) *tetragon.TracingPolicySelectorStats {
+	ret := &tetragon.TracingPolicySelectorStats{
+		Hook:             stats.Hook,
+		Selector:         stats.Selector,
+		MatchCount:       stats.Match,
+		PostCount:        stats.Post,
+		OverrideCount:    stats.Override,
+		SigkillCount:     stats.Sigkill,
+		RatelimitedCount: stats.Ratelimited,
+	}
+	if stats.LastMatch != 0 {
+		ret.LastMatchTime = ktime.ToProto(stats.LastMatch)
+	}
+	return ret
+}
+
+func sensorsFromPolicyHandlers(tp tracingpolicy.TracingPolicy, filterID, podModeFilterID policyfilter.PolicyID) ([]SensorIface, error) {
 	var sensors []SensorIface
 	for n, s := range registeredPolicyHandlers {
-		sensor, err := s.PolicyHandler(tp, filterID)
+		sensor, err := s.PolicyHandler(tp, filterID, podModeFilterID)
 		if err != nil {
 			return nil, fmt.Errorf("policy handler '%s' failed loading policy '%s': %w", n, tp.TpName(), err)
 		}
@@ -27,7 +27,7 @@ type dummyHandler struct {
 	e error
 }
 
-func (d *dummyHandler) PolicyHandler(_ tracingpolicy.TracingPolicy, _ policyfilter.PolicyID) (SensorIface, error) {
+func (d *dummyHandler) PolicyHandler(_ tracingpolicy.TracingPolicy, _, _ policyfilter.PolicyID) (SensorIface, error) {
 	return d.s, d.e
 }
 
@@ -162,6 +162,19 @@ func TestPolicyFilterDisabled(t *testing.T) {
 	err = mgr.AddTracingPolicy(ctx, &namespacedPolicy)
 	require.Error(t, err)
 
+	// policy with pod mode should fail
+	policy.Spec.PodMode = &v1alpha1.PodModeSpec{
+		Mode: "monitor",
+		PodSelector: &slimv1.LabelSelector{
+			MatchLabels: map[string]string{"canary": "true"},
+		},
+	}
+	err = mgr.AddTracingPolicy(ctx, &policy)
+	require.Error(t, err)
+	err = mgr.DeleteTracingPolicy(ctx, policyName, policyNamespace)
+	require.NoError(t, err)
+	policy.Spec.PodMode = nil
+
 	// policy with pod selector should fail
 	policy.Spec.PodSelector = &slimv1.LabelSelector{
 		MatchExpressions: []slimv1.LabelSelectorRequirement{{
@@ -173,6 +186,70 @@ func TestPolicyFilterDisabled(t *testing.T) {
 	require.Error(t, err)
 }
 
+// podModeHandler records the pod mode filter id of the last policy
+type podModeHandler struct {
+	podModeFilterID policyfilter.PolicyID
+}
+
+func (d *podModeHandler) PolicyHandler(_ tracingpolicy.TracingPolicy, _, podModeFilterID policyfilter.PolicyID) (SensorIface, error) {
+	d.podModeFilterID = podModeFilterID
+	return &Sensor{Name: "dummy-sensor"}, nil
+}
+
+// podModeState is a policyfilter state that records the policies it holds
+type podModeState struct {
+	policyfilter.State
+	policies map[policyfilter.PolicyID]*slimv1.LabelSelector
+}
+
+func (s *podModeState) AddPolicy(polID policyfilter.PolicyID, _ string, podSelector *slimv1.LabelSelector, _ *slimv1.LabelSelector) error {
+	s.policies[polID] = podSelector
+	return nil
+}
+
+func (s *podModeState) DelPolicy(polID policyfilter.PolicyID) error {
+	delete(s.policies, polID)
+	return nil
+}
+
+func TestPolicyPodMode(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	handler := &podModeHandler{}
+	RegisterPolicyHandlerAtInit("pod-mode", handler)
+	t.Cleanup(func() {
+		delete(registeredPolicyHandlers, "pod-mode")
+	})
+
+	pfState := &podModeState{
+		State:    policyfilter.DisabledState(),
+		policies: map[policyfilter.PolicyID]*slimv1.LabelSelector{},
+	}
+	mgr, err := StartSensorManagerWithPF("", pfState)
+	require.NoError(t, err)
+
+	podSelector := &slimv1.LabelSelector{MatchLabels: map[string]string{"canary": "true"}}
+	policy := v1alpha1.TracingPolicy{}
+	policy.Name = "test-policy"
+	policy.Spec.PodMode = &v1alpha1.PodModeSpec{Mode: "enforce", PodSelector: podSelector}
+	err = mgr.AddTracingPolicy(ctx, &policy)
+	require.NoError(t, err)
+
+	require.NotEqual(t, policyfilter.NoFilterID, handler.podModeFilterID)
+	assert.Equal(t, podSelector, pfState.policies[handler.podModeFilterID])
+
+	err = mgr.DeleteTracingPolicy(ctx, policy.Name, "")
+	require.NoError(t, err)
+	assert.NotContains(t, pfState.policies, handler.podModeFilterID)
+
+	// a policy without pod mode gets no pod mode filter
+	policy.Spec.PodMode = nil
+	err = mgr.AddTracingPolicy(ctx, &policy)
+	require.NoError(t, err)
+	assert.Equal(t, policyfilter.NoFilterID, handler.podModeFilterID)
+}
+
 func TestPolicyStates(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
@@ -227,6 +304,93 @@ func TestPolicyStates(t *testing.T) {
 	})
 }
 
+// TestPolicySchedule tests that policies are enabled and disabled by their schedule
+func TestPolicySchedule(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	registerDummy := func(t *testing.T) {
+		RegisterPolicyHandlerAtInit("dummy", &dummyHandler{s: &Sensor{Name: "dummy-sensor"}})
+		t.Cleanup(func() {
+			delete(registeredPolicyHandlers, "dummy")
+		})
+	}
+
+	t.Run("Window", func(t *testing.T) {
+		registerDummy(t)
+		now := time.Now().UTC()
+		policy := v1alpha1.TracingPolicy{}
+		policy.Name = "test-policy"
+		policy.Spec.Schedule = &v1alpha1.ScheduleSpec{
+			Windows: []v1alpha1.ScheduleWindow{{
+				Start: now.Add(2 * time.Hour).Format("15:04"),
+				End:   now.Add(3 * time.Hour).Format("15:04"),
+			}},
+		}
+		mgr, err := StartSensorManager("")
+		require.NoError(t, err)
+		err = mgr.AddTracingPolicy(ctx, &policy)
+		require.NoError(t, err)
+
+		l, err := mgr.ListTracingPolicies(ctx)
+		require.NoError(t, err)
+		require.Len(t, l.Policies, 1)
+		assert.Equal(t, DisabledState.ToTetragonState(), l.Policies[0].State)
+		require.NotNil(t, l.Policies[0].Schedule)
+		assert.False(t, l.Policies[0].Schedule.Active)
+		next := l.Policies[0].Schedule.NextTransitionTime.AsTime()
+		assert.WithinDuration(t, now.Add(2*time.Hour), next, time.Minute)
+
+		err = mgr.DeleteTracingPolicy(ctx, policy.Name, policy.Namespace)
+		require.NoError(t, err)
+	})
+
+	t.Run("TTL", func(t *testing.T) {
+		registerDummy(t)
+		policy := v1alpha1.TracingPolicy{}
+		policy.Name = "test-policy"
+		policy.Spec.Schedule = &v1alpha1.ScheduleSpec{TTL: "200ms"}
+		mgr, err := StartSensorManager("")
+		require.NoError(t, err)
+		err = mgr.AddTracingPolicy(ctx, &policy)
+		require.NoError(t, err)
+
+		l, err := mgr.ListTracingPolicies(ctx)
+		require.NoError(t, err)
+		require.Len(t, l.Policies, 1)
+		assert.Equal(t, EnabledState.ToTetragonState(), l.Policies[0].State)
+		require.NotNil(t, l.Policies[0].Schedule)
+		assert.True(t, l.Policies[0].Schedule.Active)
+		assert.NotNil(t, l.Policies[0].Schedule.NextTransitionTime)
+
+		require.Eventually(t, func() bool {
+			l, err := mgr.ListTracingPolicies(ctx)
+			require.NoError(t, err)
+			return l.Policies[0].State == DisabledState.ToTetragonState()
+		}, 2*time.Second, 10*time.Millisecond)
+		l, err = mgr.ListTracingPolicies(ctx)
+		require.NoError(t, err)
+		assert.False(t, l.Policies[0].Schedule.Active)
+		assert.Nil(t, l.Policies[0].Schedule.NextTransitionTime)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		registerDummy(t)
+		policy := v1alpha1.TracingPolicy{}
+		policy.Name = "test-policy"
+		policy.Spec.Schedule = &v1alpha1.ScheduleSpec{TTL: "forever"}
+		mgr, err := StartSensorManager("")
+		require.NoError(t, err)
+		err = mgr.AddTracingPolicy(ctx, &policy)
+		require.ErrorContains(t, err, "schedule")
+
+		l, err := mgr.ListTracingPolicies(ctx)
+		require.NoError(t, err)
+		require.Len(t, l.Policies, 1)
+		assert.Equal(t, LoadErrorState.ToTetragonState(), l.Policies[0].State)
+	})
+}
+
 // TestPolicyLoadErrorOverride tests the fact that you can add a TracingPolicy
 // with the same name as an existing one if it's in a LoadError state
 func TestPolicyLoadErrorOverride(t *testing.T) {
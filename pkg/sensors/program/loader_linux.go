@@ -404,6 +404,35 @@ func LSMOpen(load *Program) OpenFunc {
 	}
 }
 
+// FentryOpen attaches all the programs of a generic fentry or fexit object to
+// the same function, since tail calls between tracing programs require them to
+// have the same attach target.
+func FentryOpen(load *Program) OpenFunc {
+	return func(coll *ebpf.CollectionSpec) error {
+		for _, prog := range coll.Programs {
+			if prog.AttachType != ebpf.AttachTraceFEntry && prog.AttachType != ebpf.AttachTraceFExit {
+				return errors.New("only fentry and fexit programs are supported for generic_fentry programs")
+			}
+			prog.AttachTo = load.Attach
+		}
+		return nil
+	}
+}
+
+// TpBtfOpen attaches all the programs of a generic BTF tracepoint object to
+// the same tracepoint, for the same reason as FentryOpen.
+func TpBtfOpen(load *Program) OpenFunc {
+	return func(coll *ebpf.CollectionSpec) error {
+		for _, prog := range coll.Programs {
+			if prog.AttachType != ebpf.AttachTraceRawTp {
+				return errors.New("only tp_btf programs are supported for generic BTF tracepoint programs")
+			}
+			prog.AttachTo = load.Attach
+		}
+		return nil
+	}
+}
+
 func LSMAttach() AttachFunc {
 	return func(_ *ebpf.Collection, _ *ebpf.CollectionSpec,
 		prog *ebpf.Program, spec *ebpf.ProgramSpec) (unloader.Unloader, error) {
@@ -624,6 +653,24 @@ func LoadTracingProgram(bpfDir string, load *Program, maps []*Map, verbose int)
 	return loadProgram(bpfDir, load, opts, verbose)
 }
 
+func LoadTpBtfProgram(bpfDir string, load *Program, maps []*Map, verbose int) error {
+	opts := &LoadOpts{
+		Attach: TracingAttach(load, bpfDir),
+		Open:   TpBtfOpen(load),
+		Maps:   maps,
+	}
+	return loadProgram(bpfDir, load, opts, verbose)
+}
+
+func LoadFentryProgram(bpfDir string, load *Program, maps []*Map, verbose int) error {
+	opts := &LoadOpts{
+		Attach: TracingAttach(load, bpfDir),
+		Open:   FentryOpen(load),
+		Maps:   maps,
+	}
+	return loadProgram(bpfDir, load, opts, verbose)
+}
+
 func LoadLSMProgram(bpfDir string, load *Program, maps []*Map, verbose int) error {
 	opts := &LoadOpts{
 		Attach: LSMAttach(),
@@ -71,6 +71,9 @@ type Sensor struct {
 	// when removing the sensor, sensor cannot be loaded again after this hook
 	// being triggered and must be recreated.
 	DestroyHook SensorHook
+	// SelectorStatsFn can optionally contain a pointer to a function
+	// returning the per-selector counters of the sensor's hooks.
+	SelectorStatsFn func() []SelectorStats
 }
 
 func (s *Sensor) AddPostUnloadHook(hook SensorHook) {
@@ -101,6 +104,22 @@ type ProgOverhead struct {
 	RunCnt  uint64
 }
 
+// SelectorStats are the counters of a selector of a sensor's hook.
+type SelectorStats struct {
+	// Hook is the name of the hook, e.g. the kprobe function.
+	Hook string
+	// Selector is the index of the selector in the hook.
+	Selector    uint32
+	Match       uint64
+	Post        uint64
+	Override    uint64
+	Sigkill     uint64
+	Ratelimited uint64
+	// LastMatch is the ktime of the last match, 0 if the selector never
+	// matched.
+	LastMatch uint64
+}
+
 // SensorIface is an interface for sensors.Sensor that allows implementing sensors for testing.
 type SensorIface interface {
 	GetName() string
@@ -112,6 +131,8 @@ type SensorIface interface {
 	// the sensor's programs.
 	TotalMemlock() int
 	Overhead() ([]ProgOverhead, bool)
+	// SelectorStats returns the per-selector counters of the sensor's hooks.
+	SelectorStats() []SelectorStats
 }
 
 func (s *Sensor) Overhead() ([]ProgOverhead, bool) {
@@ -139,6 +160,13 @@ func (s *Sensor) Overhead() ([]ProgOverhead, bool) {
 	return list, len(list) != 0
 }
 
+func (s *Sensor) SelectorStats() []SelectorStats {
+	if !s.Loaded || s.SelectorStatsFn == nil {
+		return nil
+	}
+	return s.SelectorStatsFn()
+}
+
 func (s *Sensor) GetName() string {
 	return s.Name
 }
@@ -203,7 +231,9 @@ type policyHandler interface {
 	// PolicyHandler returns a Sensor for a given policy
 	// sensors that support policyfilter can use the filterID to implement filtering.
 	// sensors that do not support policyfilter need to return an error if filterID != policyfilter.NoFilterID
-	PolicyHandler(policy tracingpolicy.TracingPolicy, filterID policyfilter.PolicyID) (SensorIface, error)
+	// podModeFilterID is the policy filter id of the pods selected by the pod mode of the policy
+	// (spec.podMode), or policyfilter.NoFilterID if the policy has no pod mode.
+	PolicyHandler(policy tracingpolicy.TracingPolicy, filterID, podModeFilterID policyfilter.PolicyID) (SensorIface, error)
 }
 
 type probeLoader interface {
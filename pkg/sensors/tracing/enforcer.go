@@ -85,7 +85,7 @@ func (kp *enforcerPolicy) enforcerDel(name string) bool {
 
 func (kp *enforcerPolicy) PolicyHandler(
 	policy tracingpolicy.TracingPolicy,
-	_ policyfilter.PolicyID,
+	_, _ policyfilter.PolicyID,
 ) (sensors.SensorIface, error) {
 
 	spec := policy.TpSpec()
@@ -626,16 +626,16 @@ spec:
 	option.Config.HubbleLib = tus.Conf().TetragonLib
 	tus.LoadInitialSensor(t)
 
-	sensor1, err := gEnforcerPolicy.PolicyHandler(policy1, policyfilter.NoFilterID)
+	sensor1, err := gEnforcerPolicy.PolicyHandler(policy1, policyfilter.NoFilterID, policyfilter.NoFilterID)
 	require.NoError(t, err)
 
-	sensor2, err := policyHandler{}.PolicyHandler(policy1, policyfilter.NoFilterID)
+	sensor2, err := policyHandler{}.PolicyHandler(policy1, policyfilter.NoFilterID, policyfilter.NoFilterID)
 	require.NoError(t, err)
 
-	sensor3, err := gEnforcerPolicy.PolicyHandler(policy2, policyfilter.NoFilterID)
+	sensor3, err := gEnforcerPolicy.PolicyHandler(policy2, policyfilter.NoFilterID, policyfilter.NoFilterID)
 	require.NoError(t, err)
 
-	sensor4, err := policyHandler{}.PolicyHandler(policy2, policyfilter.NoFilterID)
+	sensor4, err := policyHandler{}.PolicyHandler(policy2, policyfilter.NoFilterID, policyfilter.NoFilterID)
 	require.NoError(t, err)
 
 	// Loading all policies
@@ -41,8 +41,16 @@ func resolveBTFArg(hook string, arg v1alpha1.KProbeArg, tp bool) (*ebtf.Type, [a
 		return nil, btfArg, err
 	}
 
-	rootType := param.Type
-	if rootTy, isPointer := param.Type.(*ebtf.Pointer); isPointer {
+	return resolveBTFArgFromType(param.Type, arg)
+}
+
+// resolveBTFArgFromType resolves the arg.Resolve path from an argument of BTF
+// type paramType.
+func resolveBTFArgFromType(paramType ebtf.Type, arg v1alpha1.KProbeArg) (*ebtf.Type, [api.MaxBTFArgDepth]api.ConfigBTFArg, error) {
+	btfArg := [api.MaxBTFArgDepth]api.ConfigBTFArg{}
+
+	rootType := paramType
+	if rootTy, isPointer := paramType.(*ebtf.Pointer); isPointer {
 		rootType = rootTy.Target
 	}
 
@@ -0,0 +1,488 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+//go:build !windows
+
+package tracing
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/cilium/ebpf"
+	ebtf "github.com/cilium/ebpf/btf"
+	api "github.com/cilium/tetragon/pkg/api/tracingapi"
+	"github.com/cilium/tetragon/pkg/arch"
+	"github.com/cilium/tetragon/pkg/bpf"
+	"github.com/cilium/tetragon/pkg/btf"
+	"github.com/cilium/tetragon/pkg/cgtracker"
+	"github.com/cilium/tetragon/pkg/config"
+	gt "github.com/cilium/tetragon/pkg/generictypes"
+	"github.com/cilium/tetragon/pkg/idtable"
+	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
+	"github.com/cilium/tetragon/pkg/kernels"
+	"github.com/cilium/tetragon/pkg/logger"
+	"github.com/cilium/tetragon/pkg/option"
+	"github.com/cilium/tetragon/pkg/selectors"
+	"github.com/cilium/tetragon/pkg/sensors"
+	"github.com/cilium/tetragon/pkg/sensors/base"
+	"github.com/cilium/tetragon/pkg/sensors/program"
+)
+
+// Generic fentry programs produce the same events as generic kprobes: their
+// entries live in the genericKprobeTable and their events are handled by
+// handleGenericKprobe. Only the programs, and how they are loaded, differ.
+
+type observerFentrySensor struct {
+	name string
+}
+
+func init() {
+	fentry := &observerFentrySensor{
+		name: "fentry sensor",
+	}
+	sensors.RegisterProbeType("generic_fentry", fentry)
+}
+
+func (k *observerFentrySensor) LoadProbe(args sensors.LoadProbeArgs) error {
+	id, ok := args.Load.LoaderData.(idtable.EntryID)
+	if !ok {
+		return fmt.Errorf("invalid loadData type: expecting idtable.EntryID and got: %T (%v)",
+			args.Load.LoaderData, args.Load.LoaderData)
+	}
+	gk, err := genericKprobeTableGet(id)
+	if err != nil {
+		return err
+	}
+
+	args.Load.MapLoad = append(args.Load.MapLoad, selectorsMaploads(gk.loadArgs.selectors.entry, 0)...)
+
+	var configData bytes.Buffer
+	binary.Write(&configData, binary.LittleEndian, gk.loadArgs.config)
+	config := &program.MapLoad{
+		Name: "config_map",
+		Load: func(m *ebpf.Map, _ string) error {
+			return m.Update(uint32(0), configData.Bytes()[:], ebpf.UpdateAny)
+		},
+	}
+	args.Load.MapLoad = append(args.Load.MapLoad, config)
+
+	if err := program.LoadFentryProgram(args.BPFDir, args.Load, args.Maps, args.Verbose); err != nil {
+		return err
+	}
+	logger.GetLogger().Info(fmt.Sprintf("Loaded generic fentry program: %s -> %s", args.Load.Name, args.Load.Attach))
+	return nil
+}
+
+// hasFentrySupport returns true if generic fentry and fexit programs can be
+// loaded.
+func hasFentrySupport() bool {
+	return config.EnableLargeProgs() && kernels.MinKernelVersion("5.11") && bpf.HasFentry()
+}
+
+func isValidFentrySelectors(selectors []v1alpha1.KProbeSelector) error {
+	for _, s := range selectors {
+		if len(s.MatchReturnArgs) > 0 {
+			return errors.New("MatchReturnArgs selector is not supported")
+		}
+		if len(s.MatchReturnActions) > 0 {
+			return errors.New("MatchReturnActions selector is not supported")
+		}
+		for _, a := range s.MatchActions {
+			if strings.ToLower(a.Action) == "override" {
+				return errors.New("override action is not supported")
+			}
+			if (a.KernelStackTrace || a.UserStackTrace) && a.Action != "Post" {
+				return errors.New("kernelStackTrace or userStackTrace can only be used along Post action")
+			}
+		}
+	}
+	return nil
+}
+
+// fentryArgType returns the generic type of an argument of a fentry. The
+// "auto" type is resolved from the BTF prototype of the function, which is
+// not available for syscalls since their functions only take the registers.
+func fentryArgType(proto *ebtf.FuncProto, ty string, index uint32) (int, int, error) {
+	if ty != "" && ty != "auto" {
+		userArgType := gt.GenericUserTypeFromString(ty)
+		if userArgType != gt.GenericInvalidType {
+			return gt.GenericUserToKernelType(userArgType), userArgType, nil
+		}
+		argType := gt.GenericTypeFromString(ty)
+		if argType == gt.GenericInvalidType || argType == gt.GenericSyscall64 {
+			return gt.GenericInvalidType, gt.GenericInvalidType, fmt.Errorf("type '%s' unsupported", ty)
+		}
+		return argType, gt.GenericInvalidType, nil
+	}
+	if proto == nil {
+		return gt.GenericInvalidType, gt.GenericInvalidType, errors.New("type 'auto' can't be used for syscalls")
+	}
+	if int(index) >= len(proto.Params) {
+		return gt.GenericInvalidType, gt.GenericInvalidType,
+			fmt.Errorf("index %d is out of range, the function has %d arguments", index, len(proto.Params))
+	}
+	argType := btf.GenericTypeFromFuncArg(proto.Params[index].Type)
+	if argType == gt.GenericInvalidType {
+		return gt.GenericInvalidType, gt.GenericInvalidType,
+			fmt.Errorf("no generic type for BTF type '%s'", proto.Params[index].Type.TypeName())
+	}
+	return argType, gt.GenericInvalidType, nil
+}
+
+// fentryReturnType returns the generic type of the return value of a fexit.
+func fentryReturnType(proto *ebtf.FuncProto, ret *v1alpha1.KProbeArg) (int, error) {
+	if ret != nil && ret.Type != "" && ret.Type != "auto" {
+		argType := gt.GenericTypeFromString(ret.Type)
+		if argType == gt.GenericInvalidType {
+			return argType, fmt.Errorf("ReturnArg type '%s' unsupported", ret.Type)
+		}
+		return argType, nil
+	}
+	if proto == nil {
+		// syscalls return a long
+		return gt.GenericS64Type, nil
+	}
+	if _, ok := proto.Return.(*ebtf.Void); ok {
+		return gt.GenericInvalidType, errors.New("ReturnArg can't be used on a function returning void")
+	}
+	argType := btf.GenericTypeFromFuncArg(proto.Return)
+	if argType == gt.GenericInvalidType {
+		return argType, fmt.Errorf("no generic type for BTF return type '%s'", proto.Return.TypeName())
+	}
+	return argType, nil
+}
+
+// addFentry creates a generic kprobe entry for a fentry spec and adds it to
+// the genericKprobeTable. The caller should make sure that this entry is
+// properly removed on sensor removal.
+func addFentry(f *v1alpha1.FentrySpec, instance int, in *addKprobeIn) (id idtable.EntryID, err error) {
+	var argSigPrinters []argPrinter
+	var argReturnPrinters []argPrinter
+	var proto *ebtf.FuncProto
+	var allBTFArgs [api.EventConfigMaxArgs][api.MaxBTFArgDepth]api.ConfigBTFArg
+
+	errFn := func(err error) (idtable.EntryID, error) {
+		return idtable.UninitializedEntryID, err
+	}
+
+	if err := isValidFentrySelectors(f.Selectors); err != nil {
+		return errFn(err)
+	}
+
+	funcName := f.Call
+	if f.Syscall {
+		funcName, err = arch.AddSyscallPrefix(f.Call)
+		if err != nil {
+			return errFn(err)
+		}
+	} else {
+		proto, err = btf.FindFuncProto(funcName)
+		if err != nil {
+			return errFn(err)
+		}
+	}
+
+	eventConfig := initEventConfig()
+	eventConfig.PolicyID = uint32(in.policyID)
+
+	msgField, err := getPolicyMessage(f.Message)
+	if errors.Is(err, ErrMsgSyntaxShort) || errors.Is(err, ErrMsgSyntaxEscape) {
+		return errFn(fmt.Errorf("error: '%w'", err))
+	} else if errors.Is(err, ErrMsgSyntaxLong) {
+		logger.GetLogger().Warn(fmt.Sprintf("TracingPolicy 'message' field too long, truncated to %d characters", TpMaxMessageLen), "policy-name", in.policyName)
+	}
+
+	tagsField, err := getPolicyTags(f.Tags)
+	if err != nil {
+		return errFn(fmt.Errorf("error: '%w'", err))
+	}
+
+	// Selectors find the type of the arguments in their spec, so pass them
+	// the types resolved from BTF.
+	args := make([]v1alpha1.KProbeArg, len(f.Args))
+
+	// Parse Arguments
+	for j, a := range f.Args {
+		if a.Index > 4 {
+			return errFn(fmt.Errorf("error add arg: ArgType %s Index %d out of bounds",
+				a.Type, int(a.Index)))
+		}
+		argType, userArgType, err := fentryArgType(proto, a.Type, a.Index)
+		if err != nil {
+			return errFn(fmt.Errorf("Arg(%d): %w", j, err))
+		}
+
+		if a.Resolve != "" && j < api.EventConfigMaxArgs {
+			if f.Syscall {
+				return errFn(errors.New("error: Resolve flag can't be used for syscalls"))
+			}
+			lastBTFType, btfArg, err := resolveBTFArg(funcName, a, false)
+			if err != nil {
+				return errFn(fmt.Errorf("error on hook %q for index %d : %w", funcName, a.Index, err))
+			}
+			allBTFArgs[j] = btfArg
+			argType = findTypeFromBTFType(a, lastBTFType)
+			if argType == gt.GenericInvalidType {
+				return errFn(fmt.Errorf("Arg(%d) type '%s' unsupported", j, a.Type))
+			}
+		}
+
+		if a.MaxData && argType != gt.GenericCharBuffer {
+			logger.GetLogger().Warn("maxData flag is ignored (supported for char_buf type)")
+		}
+		argMValue, err := getMetaValue(&a)
+		if err != nil {
+			return errFn(err)
+		}
+		if argReturnCopy(argMValue) {
+			return errFn(fmt.Errorf("Arg(%d): returnCopy is not supported, use fexit to read the arguments at the function return", j))
+		}
+		eventConfig.ArgType[j] = int32(argType)
+		eventConfig.ArgMeta[j] = uint32(argMValue)
+		eventConfig.ArgIndex[j] = int32(a.Index)
+
+		argP := argPrinter{index: int(a.Index), ty: argType, userType: userArgType, maxData: a.MaxData, label: a.Label}
+		argSigPrinters = append(argSigPrinters, argP)
+
+		args[j] = a
+		if a.Resolve == "" {
+			args[j].Type, _ = gt.GenericTypeToString(argType)
+		}
+
+		pathArgWarning(a.Index, argType, f.Selectors)
+	}
+	eventConfig.BTFArg = allBTFArgs
+
+	if f.Return {
+		argType, err := fentryReturnType(proto, f.ReturnArg)
+		if err != nil {
+			return errFn(err)
+		}
+		eventConfig.ArgReturn = int32(argType)
+		argP := argPrinter{index: api.ReturnArgIndex, ty: argType}
+		if f.ReturnArg != nil {
+			argP.label = f.ReturnArg.Label
+		}
+		argReturnPrinters = append(argReturnPrinters, argP)
+	} else if f.ReturnArg != nil {
+		return errFn(errors.New("ReturnArg can only be used with Return=true"))
+	}
+
+	if f.Syscall {
+		eventConfig.Syscall = 1
+	}
+
+	kprobeEntry := genericKprobe{
+		loadArgs: kprobeLoadArgs{
+			syscall: f.Syscall,
+			fexit:   f.Return,
+			config:  eventConfig,
+		},
+		argSigPrinters:    argSigPrinters,
+		argReturnPrinters: argReturnPrinters,
+		funcName:          funcName,
+		instance:          instance,
+		tableId:           idtable.UninitializedEntryID,
+		policyName:        in.policyName,
+		customHandler:     in.customHandler,
+		message:           msgField,
+		tags:              tagsField,
+		hasStackTrace:     selectorsHaveStackTrace(f.Selectors),
+		hasRatelimit:      selectorsHaveRateLimit(f.Selectors),
+	}
+
+	// Parse Filters into kernel filter logic
+	kprobeEntry.loadArgs.selectors.entry, err = selectors.InitKernelSelectorState(f.Selectors, args, &kprobeEntry.actionArgs, nil, nil)
+	if err != nil {
+		return errFn(err)
+	}
+
+	genericKprobeTable.AddEntry(&kprobeEntry)
+	eventConfig.FuncId = uint32(kprobeEntry.tableId.ID)
+
+	logger.GetLogger().Info("Added fentry", "return", f.Return, "function", kprobeEntry.funcName)
+
+	return kprobeEntry.tableId, nil
+}
+
+func createGenericFentrySensor(
+	spec *v1alpha1.TracingPolicySpec,
+	name string,
+	polInfo *policyInfo,
+) (*sensors.Sensor, error) {
+	var progs []*program.Program
+	var maps []*program.Map
+	var ids []idtable.EntryID
+
+	if !hasFentrySupport() {
+		return nil, errors.New("fentries require fexit programs with the bpf_get_func_arg and bpf_get_func_ret helpers (kernel >= 5.17)")
+	}
+
+	in := addKprobeIn{
+		sensorPath:    name,
+		policyID:      polInfo.policyID,
+		policyName:    polInfo.name,
+		customHandler: polInfo.customHandler,
+	}
+
+	has := hasMaps{
+		enforcer: len(spec.Enforcers) != 0,
+	}
+	dups := make(map[string]int)
+
+	for i := range spec.Fentries {
+		f := &spec.Fentries[i]
+		has.fdInstall = has.fdInstall || selectorsHaveFDInstall(f.Selectors)
+		has.rateLimit = has.rateLimit || selectorsHaveRateLimit(f.Selectors)
+
+		instance, ok := dups[f.Call]
+		if ok {
+			instance = instance + 1
+		}
+		dups[f.Call] = instance
+
+		id, err := addFentry(f, instance, &in)
+		if err != nil {
+			return nil, fmt.Errorf("fentry %q: %w", f.Call, err)
+		}
+		ids = append(ids, id)
+	}
+
+	for _, id := range ids {
+		gk, err := genericKprobeTableGet(id)
+		if err != nil {
+			return nil, err
+		}
+		gk.data = &genericKprobeData{}
+		has.stackTrace = gk.hasStackTrace
+		progs, maps = createFentrySensorFromEntry(polInfo, gk, progs, maps, has)
+	}
+
+	maps = append(maps, program.MapUserFrom(base.ExecveMap))
+
+	return &sensors.Sensor{
+		Name:            name,
+		Progs:           progs,
+		Maps:            maps,
+		Policy:          polInfo.name,
+		Namespace:       polInfo.namespace,
+		SelectorStatsFn: polInfo.selectorStatsFn(),
+		DestroyHook: func() error {
+			var errs error
+
+			for _, id := range ids {
+				gk, err := genericKprobeTableGet(id)
+				if err != nil {
+					errs = errors.Join(errs, err)
+					continue
+				}
+
+				if err = selectors.CleanupKernelSelectorState(gk.loadArgs.selectors.entry); err != nil {
+					errs = errors.Join(errs, err)
+				}
+
+				_, err = genericKprobeTable.RemoveEntry(id)
+				if err != nil {
+					errs = errors.Join(errs, err)
+				}
+			}
+			return errs
+		},
+	}, nil
+}
+
+// createFentrySensorFromEntry adds the fentry, or fexit, program of the entry
+// and its maps. It is used for fentries and for kprobes that use fexit in
+// place of a kretprobe.
+func createFentrySensorFromEntry(polInfo *policyInfo, kprobeEntry *genericKprobe,
+	progs []*program.Program, maps []*program.Map, has hasMaps) ([]*program.Program, []*program.Map) {
+
+	fentryProgName, fexitProgName := config.GenericFentryObjs()
+	loadProgName, label, tailCallsName := fentryProgName, "fentry/generic_fentry", "fentry_calls"
+	if kprobeEntry.loadArgs.fexit {
+		loadProgName, label, tailCallsName = fexitProgName, "fexit/generic_fexit", "fexit_calls"
+	}
+
+	pinProg := kprobeEntry.hookName()
+
+	load := program.Builder(
+		path.Join(option.Config.HubbleLib, loadProgName),
+		kprobeEntry.funcName,
+		label,
+		pinProg,
+		"generic_fentry").
+		SetLoaderData(kprobeEntry.tableId).
+		SetPolicy(kprobeEntry.policyName)
+	progs = append(progs, load)
+
+	fdinstall := program.MapBuilderSensor("fdinstall_map", load)
+	if has.fdInstall {
+		fdinstall.SetMaxEntries(fdInstallMapMaxEntries)
+	}
+	maps = append(maps, fdinstall)
+
+	configMap := program.MapBuilderProgram("config_map", load)
+	maps = append(maps, configMap)
+
+	tailCalls := program.MapBuilderProgram(tailCallsName, load)
+	maps = append(maps, tailCalls)
+
+	filterMap := program.MapBuilderProgram("filter_map", load)
+	maps = append(maps, filterMap)
+
+	maps = append(maps, filterMaps(load, kprobeEntry)...)
+
+	callHeap := program.MapBuilderSensor("process_call_heap", load)
+	maps = append(maps, callHeap)
+
+	selMatchBinariesMap := program.MapBuilderProgram("tg_mb_sel_opts", load)
+	maps = append(maps, selMatchBinariesMap)
+
+	selMatchStateMap := program.MapBuilderProgram("tg_ms_sel_opts", load)
+	maps = append(maps, selMatchStateMap)
+
+	maps = append(maps, polInfo.selectorStatsMap(load,
+		newSelectorStatsHook(kprobeEntry.hookName(), kprobeEntry.loadArgs.selectors.entry)))
+
+	matchBinariesPaths := program.MapBuilderProgram("tg_mb_paths", load)
+	maps = append(maps, matchBinariesPaths)
+
+	stackTraceMap := program.MapBuilderProgram("stack_trace_map", load)
+	if has.stackTrace {
+		stackTraceMap.SetMaxEntries(stackTraceMapMaxEntries)
+	}
+	maps = append(maps, stackTraceMap)
+	kprobeEntry.data.stackTraceMap = stackTraceMap
+
+	socktrack := program.MapBuilderSensor("socktrack_map", load)
+	maps = append(maps, socktrack)
+
+	ratelimitMap := program.MapBuilderSensor("ratelimit_map", load)
+	if has.rateLimit {
+		ratelimitMap.SetMaxEntries(ratelimitMapMaxEntries)
+	}
+	maps = append(maps, ratelimitMap)
+
+	if has.enforcer {
+		maps = append(maps, enforcerMapsUser(load)...)
+	}
+
+	if option.Config.EnableCgTrackerID {
+		maps = append(maps, program.MapUser(cgtracker.MapName, load))
+	}
+
+	overrideTasksMap := program.MapBuilderProgram("override_tasks", load)
+	maps = append(maps, overrideTasksMap)
+
+	maps = append(maps, polInfo.policyConfMap(load))
+	maps = append(maps, polInfo.processStateMap(load))
+
+	logger.GetLogger().Info(fmt.Sprintf("Added generic fentry sensor: %s -> %s", load.Name, load.Attach),
+		"fexit", kprobeEntry.loadArgs.fexit)
+	return progs, maps
+}
@@ -0,0 +1,161 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+//go:build !windows
+
+package tracing
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/cilium/ebpf/btf"
+	api "github.com/cilium/tetragon/pkg/api/tracingapi"
+	gt "github.com/cilium/tetragon/pkg/generictypes"
+	"github.com/cilium/tetragon/pkg/grpc/tracing"
+	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFentryArgType(t *testing.T) {
+	proto := &btf.FuncProto{
+		Return: &btf.Int{Name: "int", Size: 4, Encoding: btf.Signed},
+		Params: []btf.FuncParam{
+			{Name: "file", Type: &btf.Pointer{Target: &btf.Struct{Name: "file"}}},
+			{Name: "mask", Type: &btf.Int{Name: "unsigned int", Size: 4}},
+		},
+	}
+
+	ty, _, err := fentryArgType(proto, "auto", 0)
+	require.NoError(t, err)
+	assert.Equal(t, gt.GenericFileType, ty)
+
+	ty, _, err = fentryArgType(proto, "", 1)
+	require.NoError(t, err)
+	assert.Equal(t, gt.GenericU32Type, ty)
+
+	// explicit types are used as is
+	ty, _, err = fentryArgType(proto, "uint64", 0)
+	require.NoError(t, err)
+	assert.Equal(t, gt.GenericU64Type, ty)
+
+	ty, userTy, err := fentryArgType(proto, "bpf_cmd", 1)
+	require.NoError(t, err)
+	assert.Equal(t, gt.GenericIntType, ty)
+	assert.Equal(t, gt.GenericUserBpfCmdType, userTy)
+
+	_, _, err = fentryArgType(proto, "auto", 2)
+	require.ErrorContains(t, err, "out of range")
+
+	_, _, err = fentryArgType(nil, "auto", 0)
+	require.ErrorContains(t, err, "can't be used for syscalls")
+
+	_, _, err = fentryArgType(proto, "syscall64", 0)
+	require.Error(t, err)
+
+	_, _, err = fentryArgType(proto, "invalid", 0)
+	require.Error(t, err)
+}
+
+func TestFentryReturnType(t *testing.T) {
+	proto := &btf.FuncProto{
+		Return: &btf.Int{Name: "int", Size: 4, Encoding: btf.Signed},
+	}
+
+	ty, err := fentryReturnType(proto, nil)
+	require.NoError(t, err)
+	assert.Equal(t, gt.GenericS32Type, ty)
+
+	ty, err = fentryReturnType(proto, &v1alpha1.KProbeArg{Type: "uint32"})
+	require.NoError(t, err)
+	assert.Equal(t, gt.GenericU32Type, ty)
+
+	// syscalls
+	ty, err = fentryReturnType(nil, &v1alpha1.KProbeArg{Type: "auto"})
+	require.NoError(t, err)
+	assert.Equal(t, gt.GenericS64Type, ty)
+
+	_, err = fentryReturnType(&btf.FuncProto{Return: &btf.Void{}}, nil)
+	require.Error(t, err)
+}
+
+func TestFentryValidSelectors(t *testing.T) {
+	require.NoError(t, isValidFentrySelectors([]v1alpha1.KProbeSelector{
+		{MatchActions: []v1alpha1.ActionSelector{{Action: "Post", KernelStackTrace: true}, {Action: "Sigkill"}}},
+	}))
+	require.Error(t, isValidFentrySelectors([]v1alpha1.KProbeSelector{
+		{MatchActions: []v1alpha1.ActionSelector{{Action: "Override", ArgError: -1}}},
+	}))
+	require.Error(t, isValidFentrySelectors([]v1alpha1.KProbeSelector{
+		{MatchReturnArgs: []v1alpha1.ArgSelector{{Operator: "Equal", Values: []string{"0"}}}},
+	}))
+	require.Error(t, isValidFentrySelectors([]v1alpha1.KProbeSelector{
+		{MatchReturnActions: []v1alpha1.ActionSelector{{Action: "Post"}}},
+	}))
+}
+
+func TestHandleMsgGenericKprobeFexit(t *testing.T) {
+	gk := &genericKprobe{
+		loadArgs: kprobeLoadArgs{fexit: true},
+		argSigPrinters: []argPrinter{
+			{index: 0, ty: gt.GenericS32Type, userType: gt.GenericInvalidType},
+			{index: 1, ty: gt.GenericU64Type, userType: gt.GenericInvalidType},
+		},
+		argReturnPrinters: []argPrinter{
+			{index: api.ReturnArgIndex, ty: gt.GenericS32Type, userType: gt.GenericInvalidType},
+		},
+		funcName: "security_file_permission",
+		data:     &genericKprobeData{},
+	}
+
+	// the return value comes before the arguments
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.LittleEndian, int32(-13))
+	binary.Write(&buf, binary.LittleEndian, int32(3))
+	binary.Write(&buf, binary.LittleEndian, uint64(42))
+
+	events, err := handleMsgGenericKprobe(&api.MsgGenericKprobe{}, gk, bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	ev, ok := events[0].(*tracing.MsgGenericKprobeUnix)
+	require.True(t, ok)
+	assert.Equal(t, "security_file_permission", ev.FuncName)
+	assert.Equal(t, []api.MsgGenericKprobeArg{
+		api.MsgGenericKprobeArgInt{Index: 0, Value: 3},
+		api.MsgGenericKprobeArgSize{Index: 1, Value: 42},
+		api.MsgGenericKprobeArgInt{Index: api.ReturnArgIndex, Value: -13},
+	}, ev.Args)
+}
+
+func TestFexitScalarArg(t *testing.T) {
+	for _, typ := range []string{"int", "uint32", "uint64", "size_t", "int16", "uint8"} {
+		assert.True(t, fexitScalarArg(&v1alpha1.KProbeArg{Type: typ}), typ)
+	}
+	for _, typ := range []string{"string", "char_buf", "file", "path", "fd", "syscall64", "linux_binprm"} {
+		assert.False(t, fexitScalarArg(&v1alpha1.KProbeArg{Type: typ}), typ)
+	}
+	assert.False(t, fexitScalarArg(&v1alpha1.KProbeArg{Type: "int", Resolve: "f_inode.i_ino"}))
+}
+
+func TestFexitSelector(t *testing.T) {
+	assert.True(t, fexitSelector(&v1alpha1.KProbeSelector{}))
+	assert.True(t, fexitSelector(&v1alpha1.KProbeSelector{
+		MatchPIDs:    []v1alpha1.PIDSelector{{Operator: "In", Values: []uint32{1}}},
+		MatchArgs:    []v1alpha1.ArgSelector{{Index: 0, Operator: "Equal", Values: []string{"1"}}},
+		MatchActions: []v1alpha1.ActionSelector{{Action: "Post"}, {Action: "NoPost"}},
+	}))
+	for name, s := range map[string]v1alpha1.KProbeSelector{
+		"matchReturnArgs":        {MatchReturnArgs: []v1alpha1.ArgSelector{{Index: 0, Operator: "Equal", Values: []string{"1"}}}},
+		"matchReturnActions":     {MatchReturnActions: []v1alpha1.ActionSelector{{Action: "Post"}}},
+		"matchBinaries":          {MatchBinaries: []v1alpha1.BinarySelector{{Operator: "In", Values: []string{"/usr/bin/cat"}}}},
+		"matchCapabilities":      {MatchCapabilities: []v1alpha1.CapabilitiesSelector{{Type: "Effective", Operator: "In", Values: []string{"CAP_SYS_ADMIN"}}}},
+		"matchCapabilityChanges": {MatchCapabilityChanges: []v1alpha1.CapabilitiesSelector{{Type: "Effective", Operator: "In", Values: []string{"CAP_SYS_ADMIN"}}}},
+		"matchNamespaces":        {MatchNamespaces: []v1alpha1.NamespaceSelector{{Namespace: "Pid", Operator: "In", Values: []string{"host_ns"}}}},
+		"matchNamespaceChanges":  {MatchNamespaceChanges: []v1alpha1.NamespaceChangesSelector{{Operator: "In", Values: []string{"Mnt"}}}},
+		"sigkill":                {MatchActions: []v1alpha1.ActionSelector{{Action: "Sigkill"}}},
+	} {
+		assert.False(t, fexitSelector(&s), name)
+	}
+}
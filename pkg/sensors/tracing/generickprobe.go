@@ -69,7 +69,10 @@ type kprobeLoadArgs struct {
 	selectors kprobeSelectors
 	retprobe  bool
 	syscall   bool
-	config    *api.EventConfig
+	// fexit is set when a fexit program reports the arguments and the
+	// return value in a single event, in place of a kprobe and a kretprobe.
+	fexit  bool
+	config *api.EventConfig
 }
 
 type pendingEventKey struct {
@@ -143,6 +146,15 @@ func (g *genericKprobe) SetID(id idtable.EntryID) {
 	g.tableId = id
 }
 
+// hookName returns the name of the kprobe, with its instance for duplicate
+// functions.
+func (g *genericKprobe) hookName() string {
+	if g.instance != 0 {
+		return fmt.Sprintf("%s:%d", g.funcName, g.instance)
+	}
+	return g.funcName
+}
+
 var (
 	// genericKprobeTable is a global table that maintains information for generic kprobes
 	genericKprobeTable idtable.Table
@@ -246,6 +258,15 @@ func filterMaps(load *program.Program, kprobeEntry *genericKprobe) []*program.Ma
 	}
 	maps = append(maps, stringPostfixFilterMaps)
 
+	stringDFAFilterMaps := program.MapBuilderProgram("string_dfa_maps", load)
+	if state != nil && !kernels.MinKernelVersion("5.9") {
+		// Versions before 5.9 do not allow inner maps to have different sizes.
+		// See: https://lore.kernel.org/bpf/20200828011800.1970018-1-kafai@fb.com/
+		maxEntries := state.StringDFAMapsMaxEntries()
+		stringDFAFilterMaps.SetInnerMaxEntries(maxEntries)
+	}
+	maps = append(maps, stringDFAFilterMaps)
+
 	return maps
 }
 
@@ -253,6 +274,7 @@ func createMultiKprobeSensor(polInfo *policyInfo, multiIDs []idtable.EntryID, ha
 	var multiRetIDs []idtable.EntryID
 	var progs []*program.Program
 	var maps []*program.Map
+	var statsHooks []selectorStatsHook
 
 	data := &genericKprobeData{}
 
@@ -265,6 +287,7 @@ func createMultiKprobeSensor(polInfo *policyInfo, multiIDs []idtable.EntryID, ha
 			multiRetIDs = append(multiRetIDs, id)
 		}
 		gk.data = data
+		statsHooks = append(statsHooks, newSelectorStatsHook(gk.hookName(), gk.loadArgs.selectors.entry))
 
 		has.stackTrace = has.stackTrace || gk.hasStackTrace
 		has.override = has.override || gk.hasOverride
@@ -308,6 +331,11 @@ func createMultiKprobeSensor(polInfo *policyInfo, multiIDs []idtable.EntryID, ha
 	selMatchBinariesMap := program.MapBuilderProgram("tg_mb_sel_opts", load)
 	maps = append(maps, selMatchBinariesMap)
 
+	selMatchStateMap := program.MapBuilderProgram("tg_ms_sel_opts", load)
+	maps = append(maps, selMatchStateMap)
+
+	maps = append(maps, polInfo.selectorStatsMap(load, statsHooks...))
+
 	matchBinariesPaths := program.MapBuilderProgram("tg_mb_paths", load)
 	maps = append(maps, matchBinariesPaths)
 
@@ -350,6 +378,7 @@ func createMultiKprobeSensor(polInfo *policyInfo, multiIDs []idtable.EntryID, ha
 	maps = append(maps, overrideTasksMap)
 
 	maps = append(maps, polInfo.policyConfMap(load))
+	maps = append(maps, polInfo.processStateMap(load))
 
 	if len(multiRetIDs) != 0 {
 		loadret := program.Builder(
@@ -386,6 +415,8 @@ func createMultiKprobeSensor(polInfo *policyInfo, multiIDs []idtable.EntryID, ha
 		socktrack := program.MapBuilderSensor("socktrack_map", loadret)
 		maps = append(maps, socktrack)
 
+		maps = append(maps, polInfo.processStateMap(loadret))
+
 		tailCalls := program.MapBuilderSensor("retkprobe_calls", loadret)
 		maps = append(maps, tailCalls)
 
@@ -554,6 +585,7 @@ func preValidateKprobes(log logger.FieldLogger, kprobes []v1alpha1.KProbeSpec, l
 
 type addKprobeIn struct {
 	useMulti      bool
+	useFexit      bool
 	sensorPath    string
 	policyName    string
 	policyID      policyfilter.PolicyID
@@ -595,8 +627,10 @@ func createGenericKprobeSensor(
 	var progs []*program.Program
 	var maps []*program.Map
 	var ids []idtable.EntryID
+	var kprobeIDs, fexitIDs []idtable.EntryID
 	var useMulti bool
 	var selMaps *selectors.KernelSelectorMaps
+	var btfSpec *btf.Spec
 
 	kprobes := spec.KProbes
 
@@ -621,6 +655,24 @@ func createGenericKprobeSensor(
 		selMaps:       selMaps,
 	}
 
+	// kprobes using fexit get a single program each, with its own maps
+	fexitIn := in
+	fexitIn.useMulti = false
+	fexitIn.useFexit = true
+	fexitIn.selMaps = nil
+
+	// fexit can only attach to functions that have BTF
+	hasFuncBTF := func(sym string) bool {
+		if btfSpec == nil {
+			var err error
+			if btfSpec, err = btf.NewBTF(); err != nil {
+				return false
+			}
+		}
+		var fn *ciliumbtf.Func
+		return btfSpec.TypeByName(sym, &fn) == nil
+	}
+
 	has := hasMapsSetup(spec)
 	dups := make(map[string]int)
 
@@ -632,6 +684,7 @@ func createGenericKprobeSensor(
 		syscall := valInfo[i].syscall
 		// Syscall flag might be changed in list definition
 		kprobes[i].Syscall = syscall
+		useFexit := kprobeUseFexit(&kprobes[i])
 
 		for _, sym := range syms {
 			// Make sure duplicate symbols got non zero instance value
@@ -641,33 +694,57 @@ func createGenericKprobeSensor(
 			}
 			dups[sym] = instance
 
+			if useFexit && hasFuncBTF(sym) {
+				id, err := addKprobe(sym, instance, &kprobes[i], &fexitIn)
+				if err != nil {
+					return nil, err
+				}
+				fexitIDs = append(fexitIDs, id)
+				ids = append(ids, id)
+				continue
+			}
+
 			id, err := addKprobe(sym, instance, &kprobes[i], &in)
 			if err != nil {
 				return nil, err
 			}
+			kprobeIDs = append(kprobeIDs, id)
 			ids = append(ids, id)
 		}
 	}
 
 	var err error
-	if useMulti {
-		progs, maps, err = createMultiKprobeSensor(polInfo, ids, has)
-	} else {
-		progs, maps, err = createSingleKprobeSensor(polInfo, ids, has)
+	if len(kprobeIDs) > 0 {
+		if useMulti {
+			progs, maps, err = createMultiKprobeSensor(polInfo, kprobeIDs, has)
+		} else {
+			progs, maps, err = createSingleKprobeSensor(polInfo, kprobeIDs, has)
+		}
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	if err != nil {
-		return nil, err
+	for _, id := range fexitIDs {
+		gk, err := genericKprobeTableGet(id)
+		if err != nil {
+			return nil, err
+		}
+		gk.data = &genericKprobeData{}
+		has.stackTrace = gk.hasStackTrace
+		has.override = false
+		progs, maps = createFentrySensorFromEntry(polInfo, gk, progs, maps, has)
 	}
 
 	maps = append(maps, program.MapUserFrom(base.ExecveMap))
 
 	return &sensors.Sensor{
-		Name:      name,
-		Progs:     progs,
-		Maps:      maps,
-		Policy:    polInfo.name,
-		Namespace: polInfo.namespace,
+		Name:            name,
+		Progs:           progs,
+		Maps:            maps,
+		Policy:          polInfo.name,
+		Namespace:       polInfo.namespace,
+		SelectorStatsFn: polInfo.selectorStatsFn(),
 		DestroyHook: func() error {
 			var errs error
 
@@ -692,6 +769,73 @@ func createGenericKprobeSensor(
 	}, nil
 }
 
+// fexitScalarArg returns true if the argument is a scalar value, which fexit
+// reports as the kprobe would. The contents of the memory that pointer
+// arguments reference, such as strings, buffers, files or resolved fields,
+// can be modified by the function before fexit reads them.
+func fexitScalarArg(a *v1alpha1.KProbeArg) bool {
+	if a.Resolve != "" {
+		return false
+	}
+	switch gt.GenericTypeFromString(a.Type) {
+	case gt.GenericIntType, gt.GenericU64Type, gt.GenericU32Type, gt.GenericS64Type,
+		gt.GenericS32Type, gt.GenericSizeType, gt.GenericS16Type, gt.GenericU16Type,
+		gt.GenericS8Type, gt.GenericU8Type:
+		return true
+	default:
+		return false
+	}
+}
+
+// fexitSelector returns true if selector s gives the same result when it is
+// evaluated at the function return. Selectors on the binary, capabilities or
+// namespaces of the process are not, since the function may change them, for
+// example on execve, setuid or setns.
+func fexitSelector(s *v1alpha1.KProbeSelector) bool {
+	if len(s.MatchReturnArgs) > 0 || len(s.MatchReturnActions) > 0 {
+		return false
+	}
+	if len(s.MatchBinaries) > 0 || len(s.MatchCapabilities) > 0 || len(s.MatchCapabilityChanges) > 0 ||
+		len(s.MatchNamespaces) > 0 || len(s.MatchNamespaceChanges) > 0 {
+		return false
+	}
+	for _, a := range s.MatchActions {
+		switch strings.ToLower(a.Action) {
+		case "post", "nopost":
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// kprobeUseFexit returns true if a kprobe with a return value can use a fexit
+// program, that reports the arguments and the return value in a single event,
+// in place of a kprobe and a kretprobe. Since fexit runs after the function,
+// this is only done when all the arguments are scalar values, the selectors
+// do not depend on process properties the function may change, all the
+// actions of the kprobe can be delayed to the function return and nothing
+// depends on the kretprobe.
+func kprobeUseFexit(f *v1alpha1.KProbeSpec) bool {
+	if !f.Return || option.Config.DisableKprobeFexit || !hasFentrySupport() {
+		return false
+	}
+	if len(f.ReturnArgAction) > 0 || selectors.HasOverride(f) {
+		return false
+	}
+	for i := range f.Args {
+		if !fexitScalarArg(&f.Args[i]) {
+			return false
+		}
+	}
+	for i := range f.Selectors {
+		if !fexitSelector(&f.Selectors[i]) {
+			return false
+		}
+	}
+	return true
+}
+
 func initEventConfig() *api.EventConfig {
 	return &api.EventConfig{
 		ArgIndex: [api.EventConfigMaxArgs]int32{-1, -1, -1, -1, -1},
@@ -867,6 +1011,12 @@ func addKprobe(funcName string, instance int, f *v1alpha1.KProbeSpec, in *addKpr
 		setRetprobe = f.Return
 	}
 
+	// fexit reports the return value with the arguments, no need to
+	// merge the events of a kretprobe
+	if in.useFexit {
+		setRetprobe = false
+	}
+
 	if f.Syscall {
 		eventConfig.Syscall = 1
 	} else {
@@ -879,6 +1029,7 @@ func addKprobe(funcName string, instance int, f *v1alpha1.KProbeSpec, in *addKpr
 		loadArgs: kprobeLoadArgs{
 			retprobe: setRetprobe,
 			syscall:  f.Syscall,
+			fexit:    in.useFexit,
 			config:   eventConfig,
 		},
 		argSigPrinters:    argSigPrinters,
@@ -902,7 +1053,7 @@ func addKprobe(funcName string, instance int, f *v1alpha1.KProbeSpec, in *addKpr
 		return errFn(err)
 	}
 
-	if f.Return {
+	if setRetprobe && f.Return {
 		kprobeEntry.loadArgs.selectors.retrn, err = selectors.InitKernelReturnSelectorState(f.Selectors, f.ReturnArg,
 			&kprobeEntry.actionArgs, nil, in.selMaps)
 		if err != nil {
@@ -919,7 +1070,7 @@ func addKprobe(funcName string, instance int, f *v1alpha1.KProbeSpec, in *addKpr
 	eventConfig.FuncId = uint32(kprobeEntry.tableId.ID)
 
 	logger.GetLogger().
-		Info("Added kprobe", "return", setRetprobe, "function", kprobeEntry.funcName, "override", kprobeEntry.hasOverride)
+		Info("Added kprobe", "return", setRetprobe, "fexit", in.useFexit, "function", kprobeEntry.funcName, "override", kprobeEntry.hasOverride)
 
 	return kprobeEntry.tableId, nil
 }
@@ -930,10 +1081,7 @@ func createKprobeSensorFromEntry(polInfo *policyInfo, kprobeEntry *genericKprobe
 	loadProgName, loadProgRetName := config.GenericKprobeObjs(false)
 	isSecurityFunc := strings.HasPrefix(kprobeEntry.funcName, "security_")
 
-	pinProg := kprobeEntry.funcName
-	if kprobeEntry.instance != 0 {
-		pinProg = fmt.Sprintf("%s:%d", kprobeEntry.funcName, kprobeEntry.instance)
-	}
+	pinProg := kprobeEntry.hookName()
 
 	load := program.Builder(
 		path.Join(option.Config.HubbleLib, loadProgName),
@@ -975,6 +1123,12 @@ func createKprobeSensorFromEntry(polInfo *policyInfo, kprobeEntry *genericKprobe
 	selMatchBinariesMap := program.MapBuilderProgram("tg_mb_sel_opts", load)
 	maps = append(maps, selMatchBinariesMap)
 
+	selMatchStateMap := program.MapBuilderProgram("tg_ms_sel_opts", load)
+	maps = append(maps, selMatchStateMap)
+
+	maps = append(maps, polInfo.selectorStatsMap(load,
+		newSelectorStatsHook(kprobeEntry.hookName(), kprobeEntry.loadArgs.selectors.entry)))
+
 	matchBinariesPaths := program.MapBuilderProgram("tg_mb_paths", load)
 	if !kernels.MinKernelVersion("5.9") {
 		// Versions before 5.9 do not allow inner maps to have different sizes.
@@ -1025,6 +1179,7 @@ func createKprobeSensorFromEntry(polInfo *policyInfo, kprobeEntry *genericKprobe
 	maps = append(maps, overrideTasksMap)
 
 	maps = append(maps, polInfo.policyConfMap(load))
+	maps = append(maps, polInfo.processStateMap(load))
 
 	if kprobeEntry.loadArgs.retprobe {
 		pinRetProg := sensors.PathJoin(kprobeEntry.funcName + "_return")
@@ -1070,6 +1225,8 @@ func createKprobeSensorFromEntry(polInfo *policyInfo, kprobeEntry *genericKprobe
 			socktrack := program.MapBuilderSensor("socktrack_map", loadret)
 			maps = append(maps, socktrack)
 		}
+
+		maps = append(maps, polInfo.processStateMap(loadret))
 	}
 
 	logger.GetLogger().Info(fmt.Sprintf("Added generic kprobe sensor: %s -> %s", load.Name, load.Attach),
@@ -1298,6 +1455,16 @@ func handleMsgGenericKprobe(m *api.MsgGenericKprobe, gk *genericKprobe, r *bytes
 		}
 	}
 
+	// fexit events hold the return value before the arguments
+	var retArgs []api.MsgGenericKprobeArg
+	if gk.loadArgs.fexit {
+		for _, a := range gk.argReturnPrinters {
+			if arg := getArg(r, a); arg != nil {
+				retArgs = append(retArgs, arg)
+			}
+		}
+	}
+
 	// Get argument objects for specific printers/types
 	for _, a := range printers {
 		arg := getArg(r, a)
@@ -1307,6 +1474,7 @@ func handleMsgGenericKprobe(m *api.MsgGenericKprobe, gk *genericKprobe, r *bytes
 		}
 		unix.Args = append(unix.Args, arg)
 	}
+	unix.Args = append(unix.Args, retArgs...)
 
 	// Cache return value on merge and run return filters below before
 	// passing up to notify hooks.
@@ -369,9 +369,10 @@ func createGenericLsmSensor(
 	maps = append(maps, program.MapUserFrom(base.ExecveMap))
 
 	return &sensors.Sensor{
-		Name:  name,
-		Progs: progs,
-		Maps:  maps,
+		Name:            name,
+		Progs:           progs,
+		Maps:            maps,
+		SelectorStatsFn: polInfo.selectorStatsFn(),
 		DestroyHook: func() error {
 			var errs error
 			for _, id := range ids {
@@ -511,6 +512,11 @@ func createLsmSensorFromEntry(polInfo *policyInfo, lsmEntry *genericLsm,
 	selMatchBinariesMap := program.MapBuilderProgram("tg_mb_sel_opts", load)
 	maps = append(maps, selMatchBinariesMap)
 
+	selMatchStateMap := program.MapBuilderProgram("tg_ms_sel_opts", load)
+	maps = append(maps, selMatchStateMap)
+
+	maps = append(maps, polInfo.selectorStatsMap(load, newSelectorStatsHook(lsmEntry.hook, lsmEntry.selectors)))
+
 	matchBinariesPaths := program.MapBuilderProgram("tg_mb_paths", load)
 	if !kernels.MinKernelVersion("5.9") {
 		// Versions before 5.9 do not allow inner maps to have different sizes.
@@ -525,6 +531,7 @@ func createLsmSensorFromEntry(polInfo *policyInfo, lsmEntry *genericLsm,
 	maps = append(maps, overrideTasksMapOutput)
 
 	maps = append(maps, polInfo.policyConfMap(load))
+	maps = append(maps, polInfo.processStateMap(load))
 
 	logger.GetLogger().
 		Info(fmt.Sprintf("Added generic lsm sensor: %s -> %s", load.Name, load.Attach))
@@ -596,5 +603,14 @@ func filterMapsForLsm(load *program.Program, lsmEntry *genericLsm) []*program.Ma
 	}
 	maps = append(maps, stringPostfixFilterMaps)
 
+	stringDFAFilterMaps := program.MapBuilderProgram("string_dfa_maps", load)
+	if !kernels.MinKernelVersion("5.9") {
+		// Versions before 5.9 do not allow inner maps to have different sizes.
+		// See: https://lore.kernel.org/bpf/20200828011800.1970018-1-kafai@fb.com/
+		maxEntries := lsmEntry.selectors.StringDFAMapsMaxEntries()
+		stringDFAFilterMaps.SetInnerMaxEntries(maxEntries)
+	}
+	maps = append(maps, stringDFAFilterMaps)
+
 	return maps
 }
@@ -13,9 +13,11 @@ import (
 	"path"
 
 	"github.com/cilium/ebpf"
+	ebtf "github.com/cilium/ebpf/btf"
 	"github.com/cilium/tetragon/pkg/api/ops"
 	"github.com/cilium/tetragon/pkg/api/tracingapi"
 	"github.com/cilium/tetragon/pkg/bpf"
+	"github.com/cilium/tetragon/pkg/btf"
 	"github.com/cilium/tetragon/pkg/cgtracker"
 	"github.com/cilium/tetragon/pkg/config"
 	"github.com/cilium/tetragon/pkg/eventhandler"
@@ -96,6 +98,9 @@ type genericTracepoint struct {
 
 	// is raw tracepoint
 	raw bool
+
+	// is BTF tracepoint (tp_btf), implies raw
+	btf bool
 }
 
 func (tp *genericTracepoint) SetID(id idtable.EntryID) {
@@ -225,7 +230,14 @@ func (out *genericTracepointArg) getGenericTypeId() (int, error) {
 	return gt.GenericInvalidType, fmt.Errorf("unknown type: %T", out.format.Field.Type)
 }
 
-func buildGenericTracepointArgs(tp *tracepoint.Tracepoint, specArgs []v1alpha1.KProbeArg, raw bool) ([]genericTracepointArg, error) {
+func buildGenericTracepointArgs(tp *tracepoint.Tracepoint, specArgs []v1alpha1.KProbeArg, raw, isBtf bool) ([]genericTracepointArg, error) {
+	if isBtf {
+		proto, err := btf.FindTracepointProto(tp.Event)
+		if err != nil {
+			return nil, fmt.Errorf("BTF tracepoint %s/%s not supported: %w", tp.Subsys, tp.Event, err)
+		}
+		return buildArgsBTF(tp, specArgs, proto)
+	}
 	if raw {
 		return buildArgsRaw(tp, specArgs)
 	}
@@ -359,6 +371,63 @@ func buildArgsRaw(info *tracepoint.Tracepoint, specArgs []v1alpha1.KProbeArg) ([
 	return ret, nil
 }
 
+// buildArgsBTF builds the arguments of a BTF tracepoint. The arguments are read
+// like raw tracepoint arguments, but their types come from the tracepoint
+// prototype in BTF, whose first parameter is the tracepoint data.
+func buildArgsBTF(info *tracepoint.Tracepoint, specArgs []v1alpha1.KProbeArg, proto *ebtf.FuncProto) ([]genericTracepointArg, error) {
+	ret := make([]genericTracepointArg, 0, len(specArgs))
+	nargs := max(len(proto.Params)-1, 0)
+	for i, tpArg := range specArgs {
+		var btfArg [tracingapi.MaxBTFArgDepth]tracingapi.ConfigBTFArg
+
+		if tpArg.Index > 5 {
+			return nil, fmt.Errorf("BTF tracepoint (%s/%s) can read up to %d arguments, but %d was requested",
+				info.Subsys, info.Event, 5, tpArg.Index)
+		}
+		if int(tpArg.Index) >= nargs {
+			return nil, fmt.Errorf("BTF tracepoint %s/%s has %d arguments but argument %d was requested",
+				info.Subsys, info.Event, nargs, tpArg.Index)
+		}
+		paramType := proto.Params[tpArg.Index+1].Type
+
+		arg := genericTracepointArg{
+			ArgIdx:   uint32(i),
+			TpIdx:    int(tpArg.Index),
+			MetaTp:   getTracepointMetaValue(&tpArg),
+			userType: tpArg.Type,
+		}
+
+		var argType int
+		if tpArg.Type == "" || tpArg.Type == "auto" {
+			argType = btf.GenericTypeFromFuncArg(paramType)
+		} else {
+			argType = gt.GenericTypeFromString(tpArg.Type)
+		}
+
+		if tpArg.Resolve != "" {
+			lastBTFType, resolved, err := resolveBTFArgFromType(paramType, tpArg)
+			if err != nil {
+				return nil, fmt.Errorf("error on BTF tracepoint %s/%s for index %d : %w",
+					info.Subsys, info.Event, tpArg.Index, err)
+			}
+			btfArg = resolved
+			argType = findTypeFromBTFType(tpArg, lastBTFType)
+			if argType == gt.GenericInvalidType {
+				argType = btf.GenericTypeFromFuncArg(*lastBTFType)
+			}
+		}
+
+		if argType == gt.GenericInvalidType {
+			return nil, fmt.Errorf("output argument %v unsupported: type %q", tpArg, tpArg.Type)
+		}
+
+		arg.btf = btfArg
+		arg.genericTypeId = argType
+		ret = append(ret, arg)
+	}
+	return ret, nil
+}
+
 // createGenericTracepoint creates the genericTracepoint information based on
 // the user-provided configuration
 func createGenericTracepoint(
@@ -387,7 +456,11 @@ func createGenericTracepoint(
 		return nil, err
 	}
 
-	tpArgs, err := buildGenericTracepointArgs(&tp, conf.Args, conf.Raw)
+	if conf.Btf && !kernels.MinKernelVersion("5.11") {
+		return nil, fmt.Errorf("BTF tracepoint %s/%s not supported: requires kernel 5.11 or higher", conf.Subsystem, conf.Event)
+	}
+
+	tpArgs, err := buildGenericTracepointArgs(&tp, conf.Args, conf.Raw, conf.Btf)
 	if err != nil {
 		return nil, err
 	}
@@ -402,7 +475,8 @@ func createGenericTracepoint(
 		customHandler: polInfo.customHandler,
 		message:       msgField,
 		tags:          tagsField,
-		raw:           conf.Raw,
+		raw:           conf.Raw || conf.Btf,
+		btf:           conf.Btf,
 	}
 
 	genericTracepointTable.AddEntry(ret)
@@ -510,11 +584,16 @@ func createGenericTracepointSensor(
 		pinProg := sensors.PathJoin(fmt.Sprintf("%s:%s", tp.Info.Subsys, tp.Info.Event))
 		attach := fmt.Sprintf("%s/%s", tp.Info.Subsys, tp.Info.Event)
 		label := "tracepoint/generic_tracepoint"
-		if tp.raw {
+		objs := config.GenericTracepointObjs(tp.raw)
+		if tp.btf {
+			attach = tp.Info.Event
+			label = "tp_btf/generic_tracepoint"
+			objs = config.GenericTpBtfObjs()
+		} else if tp.raw {
 			label = "raw_tp/generic_tracepoint"
 		}
 		prog0 := program.Builder(
-			path.Join(option.Config.HubbleLib, config.GenericTracepointObjs(tp.raw)),
+			path.Join(option.Config.HubbleLib, objs),
 			attach,
 			label,
 			pinProg,
@@ -603,6 +682,15 @@ func createGenericTracepointSensor(
 		}
 		maps = append(maps, stringPostfixFilterMaps)
 
+		stringDFAFilterMaps := program.MapBuilderProgram("string_dfa_maps", prog0)
+		if !kernels.MinKernelVersion("5.9") {
+			// Versions before 5.9 do not allow inner maps to have different sizes.
+			// See: https://lore.kernel.org/bpf/20200828011800.1970018-1-kafai@fb.com/
+			maxEntries := tp.selectors.StringDFAMapsMaxEntries()
+			stringDFAFilterMaps.SetInnerMaxEntries(maxEntries)
+		}
+		maps = append(maps, stringDFAFilterMaps)
+
 		matchBinariesPaths := program.MapBuilderProgram("tg_mb_paths", prog0)
 		if !kernels.MinKernelVersion("5.9") {
 			// Versions before 5.9 do not allow inner maps to have different sizes.
@@ -622,13 +710,21 @@ func createGenericTracepointSensor(
 		selMatchBinariesMap := program.MapBuilderProgram("tg_mb_sel_opts", prog0)
 		maps = append(maps, selMatchBinariesMap)
 
+		selMatchStateMap := program.MapBuilderProgram("tg_ms_sel_opts", prog0)
+		maps = append(maps, selMatchStateMap)
+
+		maps = append(maps, polInfo.selectorStatsMap(prog0,
+			newSelectorStatsHook(fmt.Sprintf("%s/%s", tp.Info.Subsys, tp.Info.Event), tp.selectors)))
+
 		maps = append(maps, polInfo.policyConfMap(prog0))
+		maps = append(maps, polInfo.processStateMap(prog0))
 	}
 
 	maps = append(maps, program.MapUserFrom(base.ExecveMap))
 
 	ret.Progs = progs
 	ret.Maps = maps
+	ret.SelectorStatsFn = polInfo.selectorStatsFn()
 
 	ret.DestroyHook = func() error {
 		var errs error
@@ -768,7 +864,9 @@ func LoadGenericTracepointSensor(bpfDir string, load *program.Program, maps []*p
 	}
 	load.MapLoad = append(load.MapLoad, cfg)
 
-	if tp.raw {
+	if tp.btf {
+		err = program.LoadTpBtfProgram(bpfDir, load, maps, verbose)
+	} else if tp.raw {
 		err = program.LoadRawTracepointProgram(bpfDir, load, maps, verbose)
 	} else {
 		err = program.LoadTracepointProgram(bpfDir, load, maps, verbose)
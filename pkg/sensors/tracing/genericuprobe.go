@@ -61,6 +61,14 @@ func (g *genericUprobe) SetID(id idtable.EntryID) {
 	g.tableId = id
 }
 
+// hookName returns the binary path with the symbol or the offset of the uprobe.
+func (g *genericUprobe) hookName() string {
+	if g.symbol != "" {
+		return g.path + ":" + g.symbol
+	}
+	return fmt.Sprintf("%s:0x%x", g.path, g.address)
+}
+
 func init() {
 	uprobe := &observerUprobeSensor{
 		name: "uprobe sensor",
@@ -280,23 +288,27 @@ func createGenericUprobeSensor(
 	}
 
 	if in.useMulti {
-		progs, maps, err = createMultiUprobeSensor(name, ids, polInfo.name)
+		progs, maps, err = createMultiUprobeSensor(name, ids, polInfo)
 	} else {
-		progs, maps, err = createSingleUprobeSensor(ids)
+		progs, maps, err = createSingleUprobeSensor(polInfo, ids)
 	}
 
 	if err != nil {
 		return nil, err
 	}
 
+	if len(progs) > 0 {
+		maps = append(maps, polInfo.processStateMap(progs[0]))
+	}
 	maps = append(maps, program.MapUserFrom(base.ExecveMap))
 
 	return &sensors.Sensor{
-		Name:      name,
-		Progs:     progs,
-		Maps:      maps,
-		Policy:    polInfo.name,
-		Namespace: polInfo.namespace,
+		Name:            name,
+		Progs:           progs,
+		Maps:            maps,
+		SelectorStatsFn: polInfo.selectorStatsFn(),
+		Policy:          polInfo.name,
+		Namespace:       polInfo.namespace,
 		DestroyHook: func() error {
 			var errs error
 
@@ -453,9 +465,18 @@ func multiUprobePinPath(sensorPath string) string {
 	return sensors.PathJoin(sensorPath, "multi_kprobe")
 }
 
-func createMultiUprobeSensor(sensorPath string, multiIDs []idtable.EntryID, policyName string) ([]*program.Program, []*program.Map, error) {
+func createMultiUprobeSensor(sensorPath string, multiIDs []idtable.EntryID, polInfo *policyInfo) ([]*program.Program, []*program.Map, error) {
 	var progs []*program.Program
 	var maps []*program.Map
+	var statsHooks []selectorStatsHook
+
+	for _, id := range multiIDs {
+		uprobeEntry, err := genericUprobeTableGet(id)
+		if err != nil {
+			return nil, nil, err
+		}
+		statsHooks = append(statsHooks, newSelectorStatsHook(uprobeEntry.hookName(), uprobeEntry.selectors))
+	}
 
 	loadProgName := config.GenericUprobeObjs(true)
 
@@ -468,22 +489,23 @@ func createMultiUprobeSensor(sensorPath string, multiIDs []idtable.EntryID, poli
 		pinPath,
 		"generic_uprobe").
 		SetLoaderData(multiIDs).
-		SetPolicy(policyName)
+		SetPolicy(polInfo.name)
 
 	progs = append(progs, load)
 
 	configMap := program.MapBuilderProgram("config_map", load)
 	tailCalls := program.MapBuilderProgram("uprobe_calls", load)
 	filterMap := program.MapBuilderProgram("filter_map", load)
+	selectorStats := polInfo.selectorStatsMap(load, statsHooks...)
 
-	maps = append(maps, configMap, tailCalls, filterMap)
+	maps = append(maps, configMap, tailCalls, filterMap, selectorStats)
 
 	filterMap.SetMaxEntries(len(multiIDs))
 	configMap.SetMaxEntries(len(multiIDs))
 	return progs, maps, nil
 }
 
-func createSingleUprobeSensor(ids []idtable.EntryID) ([]*program.Program, []*program.Map, error) {
+func createSingleUprobeSensor(polInfo *policyInfo, ids []idtable.EntryID) ([]*program.Program, []*program.Map, error) {
 	var progs []*program.Program
 	var maps []*program.Map
 
@@ -492,13 +514,13 @@ func createSingleUprobeSensor(ids []idtable.EntryID) ([]*program.Program, []*pro
 		if err != nil {
 			return nil, nil, err
 		}
-		progs, maps = createUprobeSensorFromEntry(uprobeEntry, progs, maps)
+		progs, maps = createUprobeSensorFromEntry(polInfo, uprobeEntry, progs, maps)
 	}
 
 	return progs, maps, nil
 }
 
-func createUprobeSensorFromEntry(uprobeEntry *genericUprobe,
+func createUprobeSensorFromEntry(polInfo *policyInfo, uprobeEntry *genericUprobe,
 	progs []*program.Program, maps []*program.Map) ([]*program.Program, []*program.Map) {
 
 	loadProgName := config.GenericUprobeObjs(false)
@@ -526,6 +548,8 @@ func createUprobeSensorFromEntry(uprobeEntry *genericUprobe,
 	tailCalls := program.MapBuilderProgram("uprobe_calls", load)
 	filterMap := program.MapBuilderProgram("filter_map", load)
 	selMatchBinariesMap := program.MapBuilderProgram("tg_mb_sel_opts", load)
-	maps = append(maps, configMap, tailCalls, filterMap, selMatchBinariesMap)
+	selMatchStateMap := program.MapBuilderProgram("tg_ms_sel_opts", load)
+	selectorStats := polInfo.selectorStatsMap(load, newSelectorStatsHook(uprobeEntry.hookName(), uprobeEntry.selectors))
+	maps = append(maps, configMap, tailCalls, filterMap, selMatchBinariesMap, selMatchStateMap, selectorStats)
 	return progs, maps
 }
@@ -11,6 +11,7 @@ import (
 
 	"github.com/cilium/ebpf"
 	"github.com/cilium/tetragon/pkg/bpf"
+	"github.com/cilium/tetragon/pkg/config"
 	"github.com/cilium/tetragon/pkg/observer/observertesthelper"
 	"github.com/cilium/tetragon/pkg/sensors"
 	tus "github.com/cilium/tetragon/pkg/testutils/sensors"
@@ -233,6 +234,52 @@ spec:
 `)
 	})
 
+	t.Run("process_state_map", func(t *testing.T) {
+		if !config.EnableLargeProgs() {
+			t.Skip("skipping test, process states require large programs")
+		}
+
+		run(t, []testMap{
+			{"fdinstall_map", 1},
+			{"stack_trace_map", 1},
+			{"ratelimit_map", 1},
+			{"process_state_map", processStateMaxEntries},
+		}, `
+apiVersion: cilium.io/v1alpha1
+kind: TracingPolicy
+metadata:
+  name: "shadow-then-connect"
+spec:
+  kprobes:
+  - call: "security_file_open"
+    syscall: false
+    args:
+    - index: 0
+      type: "file"
+    selectors:
+    - matchArgs:
+      - index: 0
+        operator: "Equal"
+        values:
+        - "/etc/shadow"
+      matchActions:
+      - action: SetState
+        state: "shadow_read"
+      - action: NoPost
+  - call: "tcp_connect"
+    syscall: false
+    args:
+    - index: 0
+      type: "sock"
+    selectors:
+    - matchState:
+      - operator: In
+        values:
+        - "shadow_read"
+        within: "10s"
+`)
+	})
+
 	t.Run("override_tasks", func(t *testing.T) {
 		if !bpf.HasOverrideHelper() {
 			t.Skip("skipping test, neither bpf_override_return nor fmod_ret for syscalls is available")
@@ -0,0 +1,167 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+//go:build !windows
+
+package tracing
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/cilium/tetragon/pkg/api/tracingapi"
+	"github.com/cilium/tetragon/pkg/arch"
+	"github.com/cilium/tetragon/pkg/config"
+	"github.com/cilium/tetragon/pkg/grpc/tracing"
+	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
+	"github.com/cilium/tetragon/pkg/logger"
+	"github.com/cilium/tetragon/pkg/observer"
+	"github.com/cilium/tetragon/pkg/observer/observertesthelper"
+	"github.com/cilium/tetragon/pkg/option"
+	"github.com/cilium/tetragon/pkg/testutils"
+	tuo "github.com/cilium/tetragon/pkg/testutils/observer"
+	"github.com/cilium/tetragon/pkg/testutils/perfring"
+	tus "github.com/cilium/tetragon/pkg/testutils/sensors"
+	"github.com/cilium/tetragon/pkg/tracingpolicy"
+)
+
+// processStatePolicy returns a policy where sys_linkat sets the "linked" state
+// of the test process without posting events, and sys_symlinkat only posts
+// events when the state is set, within the given duration if not empty.
+func processStatePolicy(within string) *tracingpolicy.GenericTracingPolicy {
+	pid := observertesthelper.GetMyPid()
+	matchPIDs := []v1alpha1.PIDSelector{{
+		Operator:    "In",
+		FollowForks: true,
+		Values:      []uint32{pid},
+	}}
+	return &tracingpolicy.GenericTracingPolicy{
+		Metadata: v1.ObjectMeta{
+			Name: "process-state",
+		},
+		Spec: v1alpha1.TracingPolicySpec{
+			KProbes: []v1alpha1.KProbeSpec{
+				{
+					Call:    "sys_linkat",
+					Syscall: true,
+					Selectors: []v1alpha1.KProbeSelector{
+						{
+							MatchPIDs: matchPIDs,
+							MatchActions: []v1alpha1.ActionSelector{
+								{Action: "SetState", State: "linked"},
+								{Action: "NoPost"},
+							},
+						},
+					},
+				},
+				{
+					Call:    "sys_symlinkat",
+					Syscall: true,
+					Args: []v1alpha1.KProbeArg{
+						{Index: 0, Type: "string"},
+					},
+					Selectors: []v1alpha1.KProbeSelector{
+						{
+							MatchPIDs: matchPIDs,
+							MatchState: []v1alpha1.StateSelector{
+								{Operator: "In", Values: []string{"linked"}, Within: within},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// testProcessState runs ops with the process state policy loaded, and returns
+// the first argument of the sys_symlinkat events that matched the state.
+func testProcessState(t *testing.T, within string, ops func(dir string)) []string {
+	if !config.EnableLargeProgs() {
+		t.Skip("kernels without large progs do not support process states")
+	}
+
+	testutils.CaptureLog(t, logger.GetLogger())
+	ctx, cancel := context.WithTimeout(context.Background(), tus.Conf().CmdWaitTime)
+	defer cancel()
+
+	if err := observer.InitDataCache(1024); err != nil {
+		t.Fatalf("observertesthelper.InitDataCache: %s", err)
+	}
+
+	option.Config.HubbleLib = tus.Conf().TetragonLib
+	tus.LoadInitialSensor(t)
+	sm := tuo.GetTestSensorManager(t)
+
+	err := sm.Manager.AddTracingPolicy(ctx, processStatePolicy(within))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		sm.Manager.DeleteTracingPolicy(ctx, "process-state", "")
+	})
+
+	dir := t.TempDir()
+	events := perfring.RunTestEvents(t, ctx, func() { ops(dir) })
+
+	var targets []string
+	for _, ev := range events {
+		kprobe, ok := ev.(*tracing.MsgGenericKprobeUnix)
+		if !ok || kprobe.FuncName != arch.AddSyscallPrefixTestHelper(t, "sys_symlinkat") {
+			continue
+		}
+		require.NotEmpty(t, kprobe.Args)
+		arg, ok := kprobe.Args[0].(api.MsgGenericKprobeArgString)
+		require.True(t, ok)
+		targets = append(targets, filepath.Base(arg.Value))
+	}
+	return targets
+}
+
+// stateLink sets the state, the call failing does not matter since the kprobe
+// fires on the function entry.
+func stateLink(dir string) {
+	unix.Linkat(unix.AT_FDCWD, filepath.Join(dir, "missing"), unix.AT_FDCWD, filepath.Join(dir, "link"), 0)
+}
+
+// stateSymlink matches the state, name identifies the call in the events.
+func stateSymlink(dir string, name string) {
+	unix.Symlinkat(filepath.Join(dir, name), unix.AT_FDCWD, filepath.Join(dir, name+".symlink"))
+}
+
+func TestKprobeProcessState(t *testing.T) {
+	t.Run("SetThenMatch", func(t *testing.T) {
+		targets := testProcessState(t, "", func(dir string) {
+			stateLink(dir)
+			stateSymlink(dir, "after-link")
+		})
+		assert.Equal(t, []string{"after-link"}, targets)
+	})
+
+	t.Run("MatchThenSet", func(t *testing.T) {
+		targets := testProcessState(t, "", func(dir string) {
+			stateSymlink(dir, "before-link")
+			stateLink(dir)
+		})
+		assert.Empty(t, targets)
+	})
+
+	t.Run("Within", func(t *testing.T) {
+		targets := testProcessState(t, "1s", func(dir string) {
+			stateLink(dir)
+			stateSymlink(dir, "in-time")
+			// the state expires 1s after it was set
+			time.Sleep(2 * time.Second)
+			stateSymlink(dir, "expired")
+			// setting the state again renews it
+			stateLink(dir)
+			stateSymlink(dir, "renewed")
+		})
+		assert.Equal(t, []string{"in-time", "renewed"}, targets)
+	})
+}
@@ -18,6 +18,7 @@ import (
 	"os/signal"
 	"path"
 	"path/filepath"
+	"regexp"
 	"slices"
 	"strconv"
 	"strings"
@@ -1447,6 +1448,102 @@ spec:
 	testKprobeObjectFiltered(t, readHook, getOpenatChecker(t, longDir), false, longDir, false, syscall.O_RDWR, 0x770)
 }
 
+const skipDFA = "kernels without large progs do not support Glob/NotGlob/Regex/NotRegex"
+
+func testKprobeObjectDFAOpenHook(pidStr string, operator string, value string) string {
+	return `
+  apiVersion: cilium.io/v1alpha1
+  kind: TracingPolicy
+  metadata:
+    name: "sys-read"
+  spec:
+    kprobes:
+    - call: "sys_openat"
+      return: false
+      syscall: true
+      args:
+      - index: 0
+        type: int
+      - index: 1
+        type: "string"
+      - index: 2
+        type: "int"
+      selectors:
+      - matchPIDs:
+        - operator: In
+          followForks: true
+          values:
+          - ` + pidStr + `
+        matchArgs:
+        - index: 1
+          operator: "` + operator + `"
+          values:
+          - '` + value + `'
+  `
+}
+
+func TestKprobeObjectGlobOpen(t *testing.T) {
+	if !config.EnableLargeProgs() {
+		t.Skip(skipDFA)
+	}
+	pidStr := strconv.Itoa(int(observertesthelper.GetMyPid()))
+	dir := t.TempDir()
+	readHook := testKprobeObjectDFAOpenHook(pidStr, "Glob", dir+"/test*")
+	testKprobeObjectFiltered(t, readHook, getOpenatChecker(t, dir), false, dir, false, syscall.O_RDWR, 0x770)
+}
+
+func TestKprobeObjectGlobDoubleStarOpen(t *testing.T) {
+	if !config.EnableLargeProgs() {
+		t.Skip(skipDFA)
+	}
+	pidStr := strconv.Itoa(int(observertesthelper.GetMyPid()))
+	dir := t.TempDir()
+	readHook := testKprobeObjectDFAOpenHook(pidStr, "Glob", filepath.Dir(dir)+"/**/test?ile")
+	testKprobeObjectFiltered(t, readHook, getOpenatChecker(t, dir), false, dir, false, syscall.O_RDWR, 0x770)
+}
+
+func TestKprobeObjectGlobMissOpen(t *testing.T) {
+	if !config.EnableLargeProgs() {
+		t.Skip(skipDFA)
+	}
+	pidStr := strconv.Itoa(int(observertesthelper.GetMyPid()))
+	dir := t.TempDir()
+	// '*' does not match '/'
+	readHook := testKprobeObjectDFAOpenHook(pidStr, "Glob", filepath.Dir(dir)+"/*")
+	testKprobeObjectFiltered(t, readHook, getAnyChecker(), false, dir, true, syscall.O_RDWR, 0x770)
+}
+
+func TestKprobeObjectNotGlobOpen(t *testing.T) {
+	if !config.EnableLargeProgs() {
+		t.Skip(skipDFA)
+	}
+	pidStr := strconv.Itoa(int(observertesthelper.GetMyPid()))
+	dir := t.TempDir()
+	readHook := testKprobeObjectDFAOpenHook(pidStr, "NotGlob", "/foo/**")
+	testKprobeObjectFiltered(t, readHook, getOpenatChecker(t, dir), false, dir, false, syscall.O_RDWR, 0x770)
+}
+
+func TestKprobeObjectRegexOpen(t *testing.T) {
+	if !config.EnableLargeProgs() {
+		t.Skip(skipDFA)
+	}
+	pidStr := strconv.Itoa(int(observertesthelper.GetMyPid()))
+	dir := t.TempDir()
+	readHook := testKprobeObjectDFAOpenHook(pidStr, "Regex", regexp.QuoteMeta(dir)+"/test(file|dir)")
+	testKprobeObjectFiltered(t, readHook, getOpenatChecker(t, dir), false, dir, false, syscall.O_RDWR, 0x770)
+}
+
+func TestKprobeObjectRegexMissOpen(t *testing.T) {
+	if !config.EnableLargeProgs() {
+		t.Skip(skipDFA)
+	}
+	pidStr := strconv.Itoa(int(observertesthelper.GetMyPid()))
+	dir := t.TempDir()
+	// the regular expression has to match the whole argument
+	readHook := testKprobeObjectDFAOpenHook(pidStr, "Regex", regexp.QuoteMeta(dir)+"/test")
+	testKprobeObjectFiltered(t, readHook, getAnyChecker(), false, dir, true, syscall.O_RDWR, 0x770)
+}
+
 func testKprobeObjectFilterModeOpenHook(pidStr string, mode int, valueFmt string) string {
 	return `
   apiVersion: cilium.io/v1alpha1
@@ -4068,6 +4165,18 @@ func TestKprobeMatchBinariesPerfring(t *testing.T) {
 		}
 		matchBinariesPerfringTest(t, "Postfix", []string{"tail"})
 	})
+	t.Run("Glob", func(t *testing.T) {
+		if !config.EnableLargeProgs() {
+			t.Skip(skipDFA)
+		}
+		matchBinariesPerfringTest(t, "Glob", []string{"/usr/*/ta?l"})
+	})
+	t.Run("Regex", func(t *testing.T) {
+		if !config.EnableLargeProgs() {
+			t.Skip(skipDFA)
+		}
+		matchBinariesPerfringTest(t, "Regex", []string{"/usr/bin/t[a-z]+"})
+	})
 }
 
 // TestKprobeMatchBinariesEarlyExec checks that the matchBinaries can filter
@@ -116,7 +116,7 @@ func hasLoaderEvents() bool {
 	return bpf.HasBuildId() && kernels.MinKernelVersion("5.19.0")
 }
 
-func (k *loaderSensor) PolicyHandler(p tracingpolicy.TracingPolicy, fid policyfilter.PolicyID) (sensors.SensorIface, error) {
+func (k *loaderSensor) PolicyHandler(p tracingpolicy.TracingPolicy, fid, _ policyfilter.PolicyID) (sensors.SensorIface, error) {
 	spec := p.TpSpec()
 	// NB: no loader section is the spec, so nothing to do
 	if !spec.Loader {
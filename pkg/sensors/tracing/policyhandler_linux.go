@@ -13,6 +13,7 @@ import (
 	"github.com/cilium/tetragon/pkg/logger"
 	"github.com/cilium/tetragon/pkg/policyconf"
 	"github.com/cilium/tetragon/pkg/policyfilter"
+	"github.com/cilium/tetragon/pkg/selectors"
 	"github.com/cilium/tetragon/pkg/sensors"
 	"github.com/cilium/tetragon/pkg/sensors/program"
 	"github.com/cilium/tetragon/pkg/tracingpolicy"
@@ -24,26 +25,37 @@ type policyInfo struct {
 	policyID      policyfilter.PolicyID
 	customHandler eventhandler.Handler
 	policyConf    *program.Map
+	processState  *program.Map
+	setsState     bool
+	selectorStats []selectorStatsMap
 	specOpts      *specOptions
+	// podMode is the mode of the pods filtered by podModeFilterID
+	podMode         policyconf.Mode
+	podModeFilterID policyfilter.PolicyID
 }
 
 func newPolicyInfo(
 	policy tracingpolicy.TracingPolicy,
 	policyID policyfilter.PolicyID,
+	podModeFilterID policyfilter.PolicyID,
 ) (*policyInfo, error) {
 	namespace := ""
 	if tpn, ok := policy.(tracingpolicy.TracingPolicyNamespaced); ok {
 		namespace = tpn.TpNamespace()
 	}
 
-	return newPolicyInfoFromSpec(
+	pi, err := newPolicyInfoFromSpec(
 		namespace,
 		policy.TpName(),
 		policyID,
 		policy.TpSpec(),
 		eventhandler.GetCustomEventhandler(policy),
 	)
-
+	if err != nil {
+		return nil, err
+	}
+	pi.podModeFilterID = podModeFilterID
+	return pi, nil
 }
 
 func newPolicyInfoFromSpec(
@@ -56,13 +68,22 @@ func newPolicyInfoFromSpec(
 	if err != nil {
 		return nil, err
 	}
+	podMode := policyconf.EnforceMode
+	if spec.PodMode != nil {
+		podMode, err = policyconf.ParseMode(spec.PodMode.Mode)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pod mode: %w", err)
+		}
+	}
 	return &policyInfo{
 		name:          name,
 		namespace:     namespace,
 		policyID:      policyID,
 		customHandler: customHandler,
 		policyConf:    nil,
+		setsState:     selectors.HasSetStateAction(spec),
 		specOpts:      opts,
+		podMode:       podMode,
 	}, nil
 }
 
@@ -79,7 +100,9 @@ func (pi *policyInfo) policyConfMap(prog *program.Program) *program.Map {
 				mode = pi.specOpts.policyMode
 			}
 			conf := policyconf.PolicyConf{
-				Mode: mode,
+				Mode:            mode,
+				PodMode:         pi.podMode,
+				PodModeFilterID: uint32(pi.podModeFilterID),
 			}
 			key := uint32(0)
 			return m.Update(key, &conf, ebpf.UpdateAny)
@@ -88,12 +111,34 @@ func (pi *policyInfo) policyConfMap(prog *program.Program) *program.Map {
 	return pi.policyConf
 }
 
+// processStateMap returns the map holding the process states set by the
+// SetState action, which is shared by all the programs of the policy.
+func (pi *policyInfo) processStateMap(prog *program.Program) *program.Map {
+	if pi.processState != nil {
+		return program.MapUserFrom(pi.processState)
+	}
+	pi.processState = program.MapBuilderPolicy("process_state_map", prog)
+	if pi.setsState {
+		// the map is created with a max entry of 1 to reduce the memory
+		// footprint, expand it if the policy sets states.
+		pi.processState.SetMaxEntries(processStateMaxEntries)
+	}
+	return pi.processState
+}
+
 func (h policyHandler) PolicyHandler(
 	policy tracingpolicy.TracingPolicy,
 	policyID policyfilter.PolicyID,
+	podModeFilterID policyfilter.PolicyID,
 ) (sensors.SensorIface, error) {
 
-	spec := policy.TpSpec()
+	spec, err := selectors.ExpandSpecExpressions(policy.TpSpec())
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector expression: %w", err)
+	}
+	if err := selectors.ValidateSpecStates(spec); err != nil {
+		return nil, fmt.Errorf("invalid process states: %w", err)
+	}
 	sections := 0
 	if len(spec.KProbes) > 0 {
 		sections++
@@ -107,11 +152,14 @@ func (h policyHandler) PolicyHandler(
 	if len(spec.UProbes) > 0 {
 		sections++
 	}
+	if len(spec.Fentries) > 0 {
+		sections++
+	}
 	if sections > 1 {
-		return nil, errors.New("tracing policies with multiple sections of kprobes, tracepoints, lsm hooks, or uprobes are currently not supported")
+		return nil, errors.New("tracing policies with multiple sections of kprobes, tracepoints, lsm hooks, uprobes, or fentries are currently not supported")
 	}
 
-	polInfo, err := newPolicyInfo(policy, policyID)
+	polInfo, err := newPolicyInfo(policy, policyID, podModeFilterID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse options: %w", err)
 	}
@@ -142,5 +190,8 @@ func (h policyHandler) PolicyHandler(
 	if len(spec.UProbes) > 0 {
 		return createGenericUprobeSensor(spec, "generic_uprobe", polInfo)
 	}
+	if len(spec.Fentries) > 0 {
+		return createGenericFentrySensor(spec, "generic_fentry", polInfo)
+	}
 	return nil, nil
 }
@@ -12,7 +12,7 @@ import (
 
 func (h policyHandler) PolicyHandler(
 	_ tracingpolicy.TracingPolicy,
-	_ policyfilter.PolicyID,
+	_, _ policyfilter.PolicyID,
 ) (sensors.SensorIface, error) {
 	return nil, constants.ErrWindowsNotSupported
 }
@@ -46,6 +46,11 @@ func selectorsMaploads(ks *selectors.KernelSelectorState, index uint32) []*progr
 			Load: func(outerMap *ebpf.Map, _ string) error {
 				return populateMatchBinariesMaps(ks, outerMap)
 			},
+		}, {
+			Name: "tg_ms_sel_opts",
+			Load: func(outerMap *ebpf.Map, _ string) error {
+				return populateMatchStateMaps(ks, outerMap)
+			},
 		}, {
 			Name: "tg_mb_paths",
 			Load: func(outerMap *ebpf.Map, pinPathPrefix string) error {
@@ -61,6 +66,11 @@ func selectorsMaploads(ks *selectors.KernelSelectorState, index uint32) []*progr
 			Load: func(outerMap *ebpf.Map, pinPathPrefix string) error {
 				return populateStringPostfixFilterMaps(ks, pinPathPrefix, outerMap)
 			},
+		}, {
+			Name: "string_dfa_maps",
+			Load: func(outerMap *ebpf.Map, pinPathPrefix string) error {
+				return populateStringDFAFilterMaps(ks, pinPathPrefix, outerMap)
+			},
 		}, {
 			Name: "string_maps_0",
 			Load: func(outerMap *ebpf.Map, pinPathPrefix string) error {
@@ -383,6 +393,18 @@ func populateMatchBinariesMaps(
 	return nil
 }
 
+func populateMatchStateMaps(
+	ks *selectors.KernelSelectorState,
+	bpfMap *ebpf.Map,
+) error {
+	for selID, sel := range ks.MatchState() {
+		if err := bpfMap.Update(uint32(selID), sel, ebpf.UpdateAny); err != nil {
+			return fmt.Errorf("failed to insert %v: %w", sel, err)
+		}
+	}
+	return nil
+}
+
 func populateMatchBinariesPathsMaps(
 	k *selectors.KernelSelectorState,
 	pinPathPrefix string,
@@ -554,3 +576,61 @@ func populateStringPostfixFilterMap(
 
 	return nil
 }
+
+func populateStringDFAFilterMaps(
+	k *selectors.KernelSelectorState,
+	pinPathPrefix string,
+	outerMap *ebpf.Map,
+) error {
+	maxEntries := k.StringDFAMapsMaxEntries()
+	for i, am := range k.StringDFAMaps() {
+		nrEntries := uint32(len(am))
+		// Versions before 5.9 do not allow inner maps to have different sizes.
+		// See: https://lore.kernel.org/bpf/20200828011800.1970018-1-kafai@fb.com/
+		if !kernels.MinKernelVersion("5.9") {
+			nrEntries = uint32(maxEntries)
+		}
+		err := populateStringDFAFilterMap(pinPathPrefix, outerMap, uint32(i), am, nrEntries)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func populateStringDFAFilterMap(
+	pinPathPrefix string,
+	outerMap *ebpf.Map,
+	innerID uint32,
+	innerData map[uint32]uint16,
+	maxEntries uint32,
+) error {
+	innerName := fmt.Sprintf("string_dfa_map_%d", innerID)
+	innerSpec := &ebpf.MapSpec{
+		Name:       innerName,
+		Type:       ebpf.Hash,
+		KeySize:    uint32(4), // NB: state << 8 | character, see selectors.StringDFAKey
+		ValueSize:  uint32(2),
+		MaxEntries: maxEntries,
+	}
+	innerMap, err := ebpf.NewMapWithOptions(innerSpec, ebpf.MapOptions{
+		PinPath: sensors.PathJoin(pinPathPrefix, innerName),
+	})
+	if err != nil {
+		return fmt.Errorf("creating innerMap %s failed: %w", innerName, err)
+	}
+	defer innerMap.Close()
+
+	for key, state := range innerData {
+		err := innerMap.Update(key, state, 0)
+		if err != nil {
+			return fmt.Errorf("failed to insert value into %s: %w", innerName, err)
+		}
+	}
+
+	if err := outerMap.Update(uint32(innerID), uint32(innerMap.FD()), 0); err != nil {
+		return fmt.Errorf("failed to insert %s: %w", innerName, err)
+	}
+
+	return nil
+}
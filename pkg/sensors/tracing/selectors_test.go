@@ -11,13 +11,18 @@ package tracing
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
+	"slices"
 	"strconv"
 	"testing"
 	"time"
 
 	"github.com/cilium/tetragon/pkg/api/tracingapi"
 	"github.com/cilium/tetragon/pkg/arch"
+	"github.com/cilium/tetragon/pkg/bpf"
+	"github.com/cilium/tetragon/pkg/config"
 	"github.com/cilium/tetragon/pkg/grpc/tracing"
 	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
 	"github.com/cilium/tetragon/pkg/logger"
@@ -30,6 +35,7 @@ import (
 	testsensor "github.com/cilium/tetragon/pkg/sensors/test"
 	"github.com/cilium/tetragon/pkg/testutils"
 	"github.com/cilium/tetragon/pkg/testutils/perfring"
+	"github.com/cilium/tetragon/pkg/testutils/policysim"
 	tus "github.com/cilium/tetragon/pkg/testutils/sensors"
 	"github.com/cilium/tetragon/pkg/tracingpolicy"
 	"github.com/google/go-cmp/cmp"
@@ -452,3 +458,84 @@ func TestMultipleInactiveSelectors(t *testing.T) {
 	require.Equal(t, 1, eventCounter)
 
 }
+
+// runLseekCase loads the policy of the case, makes its calls, and checks their
+// outcomes.
+func runLseekCase(t *testing.T, ctx context.Context, c *policysim.Case) {
+	if c.HasOverride() && !bpf.HasOverrideHelper() {
+		t.Skip("skipping override case, bpf_override_return helper not available")
+	}
+	loadGenericSensorTest(t, c.Spec())
+
+	mypid := observertesthelper.GetMyPid()
+	got := make([]policysim.Outcome, len(c.Calls))
+	ops := func(_ *testing.T) {
+		for i, call := range c.Calls {
+			_, err := unix.Seek(int(call.Fd), call.Offset, int(call.Whence))
+			got[i].Override = errors.Is(err, unix.Errno(-policysim.OverrideError))
+		}
+	}
+	perfring.RunSubTest(t, ctx, "calls", ops, func(ev notify.Message) error {
+		kpEvent, ok := ev.(*tracing.MsgGenericKprobeUnix)
+		if !ok || kpEvent.Msg.ProcessKey.Pid != mypid {
+			return nil
+		}
+		if len(kpEvent.Args) != 3 {
+			return fmt.Errorf("unexpected kprobe arguments: %+v", kpEvent.Args)
+		}
+		fd, ok0 := kpEvent.Args[0].(tracingapi.MsgGenericKprobeArgInt)
+		offset, ok1 := kpEvent.Args[1].(tracingapi.MsgGenericKprobeArgSize)
+		whence, ok2 := kpEvent.Args[2].(tracingapi.MsgGenericKprobeArgInt)
+		if !ok0 || !ok1 || !ok2 {
+			return fmt.Errorf("unexpected kprobe arguments: %+v", kpEvent.Args)
+		}
+
+		// events of other calls, e.g. the test sensor ones, are ignored
+		call := policysim.LseekCall{Fd: fd.Value, Offset: int64(offset.Value), Whence: whence.Value}
+		if i := slices.Index(c.Calls, call); i >= 0 {
+			got[i].Post = true
+		}
+		return nil
+	})
+	require.Equal(t, c.Expected, got)
+}
+
+// TestKprobeSelectorsConformance runs the conformance cases of the policy
+// simulation against the BPF programs, see TestSimulateConformance in
+// pkg/selectors.
+func TestKprobeSelectorsConformance(t *testing.T) {
+	testutils.CaptureLog(t, logger.GetLogger())
+	ctx, cancel := context.WithTimeout(context.Background(), tus.Conf().CmdWaitTime)
+	defer cancel()
+
+	for _, c := range policysim.Cases {
+		t.Run(c.Name, func(t *testing.T) {
+			runLseekCase(t, ctx, &c)
+		})
+	}
+}
+
+// TestKprobeSelectorsUnsignedGT checks that GT matches uint64 arguments that
+// are greater than its value, as unsigned values. It used to match the lower
+// ones instead.
+func TestKprobeSelectorsUnsignedGT(t *testing.T) {
+	if !config.EnableLargeProgs() {
+		t.Skip("Older kernels do not support GT/LT matching")
+	}
+	testutils.CaptureLog(t, logger.GetLogger())
+	ctx, cancel := context.WithTimeout(context.Background(), tus.Conf().CmdWaitTime)
+	defer cancel()
+
+	runLseekCase(t, ctx, &policysim.Case{
+		Selectors: []v1alpha1.KProbeSelector{{
+			MatchArgs: []v1alpha1.ArgSelector{{Index: 1, Operator: "GT", Values: []string{"100"}}},
+		}},
+		Calls: []policysim.LseekCall{
+			{Fd: -1, Offset: 0, Whence: 4090},
+			{Fd: -1, Offset: 100, Whence: 4090},
+			{Fd: -1, Offset: 101, Whence: 4090},
+			{Fd: -1, Offset: math.MinInt64, Whence: 4090},
+		},
+		Expected: []policysim.Outcome{{}, {}, {Post: true}, {Post: true}},
+	})
+}
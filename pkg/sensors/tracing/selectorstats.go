@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+//go:build !windows
+
+package tracing
+
+import (
+	"github.com/cilium/tetragon/pkg/logger"
+	"github.com/cilium/tetragon/pkg/logger/logfields"
+	"github.com/cilium/tetragon/pkg/selectors"
+	"github.com/cilium/tetragon/pkg/sensors"
+	"github.com/cilium/tetragon/pkg/sensors/program"
+)
+
+// selectorStatsValue is the per-CPU value of the selector_stats map, see
+// struct selector_stats in bpf/process/selector_stats.h.
+type selectorStatsValue struct {
+	Match       uint64
+	Post        uint64
+	Override    uint64
+	Sigkill     uint64
+	Ratelimited uint64
+	LastMatch   uint64
+}
+
+// selectorStatsHook is a hook of a program, as indexed by the program
+// (e->idx in bpf).
+type selectorStatsHook struct {
+	name      string
+	selectors int
+}
+
+// selectorStatsMap is a selector_stats map, where the counters of selector j
+// of hooks[i] are stored at index i*MaxSelectors+j.
+type selectorStatsMap struct {
+	m     *program.Map
+	hooks []selectorStatsHook
+}
+
+func newSelectorStatsHook(name string, state *selectors.KernelSelectorState) selectorStatsHook {
+	hook := selectorStatsHook{name: name}
+	if state != nil {
+		hook.selectors = state.Selectors()
+	}
+	return hook
+}
+
+// selectorStatsMap returns the selector_stats map of prog, and keeps track of
+// it to report the policy selector counters.
+func (pi *policyInfo) selectorStatsMap(prog *program.Program, hooks ...selectorStatsHook) *program.Map {
+	m := program.MapBuilderProgram("selector_stats", prog)
+	if len(hooks) > 1 {
+		m.SetMaxEntries(len(hooks) * selectors.MaxSelectors)
+	}
+	pi.selectorStats = append(pi.selectorStats, selectorStatsMap{m: m, hooks: hooks})
+	return m
+}
+
+// selectorStatsFn returns a function reading the selector counters of the
+// policy's programs, to be used as the sensor's SelectorStatsFn.
+func (pi *policyInfo) selectorStatsFn() func() []sensors.SelectorStats {
+	return func() []sensors.SelectorStats {
+		var ret []sensors.SelectorStats
+		for _, sm := range pi.selectorStats {
+			ret = append(ret, sm.read()...)
+		}
+		return ret
+	}
+}
+
+func (sm *selectorStatsMap) read() []sensors.SelectorStats {
+	var ret []sensors.SelectorStats
+
+	if sm.m.MapHandle == nil {
+		return nil
+	}
+
+	for i, hook := range sm.hooks {
+		for j := range hook.selectors {
+			var values []selectorStatsValue
+
+			key := uint32(i*selectors.MaxSelectors + j)
+			if err := sm.m.MapHandle.Lookup(key, &values); err != nil {
+				logger.GetLogger().Warn("failed to read selector stats",
+					"hook", hook.name, "selector", j, logfields.Error, err)
+				continue
+			}
+			ret = append(ret, sumSelectorStats(hook.name, uint32(j), values))
+		}
+	}
+	return ret
+}
+
+// sumSelectorStats sums the per-CPU counters of a selector.
+func sumSelectorStats(hook string, selector uint32, values []selectorStatsValue) sensors.SelectorStats {
+	stats := sensors.SelectorStats{
+		Hook:     hook,
+		Selector: selector,
+	}
+	for _, v := range values {
+		stats.Match += v.Match
+		stats.Post += v.Post
+		stats.Override += v.Override
+		stats.Sigkill += v.Sigkill
+		stats.Ratelimited += v.Ratelimited
+		stats.LastMatch = max(stats.LastMatch, v.LastMatch)
+	}
+	return stats
+}
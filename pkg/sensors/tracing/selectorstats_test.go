@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+//go:build !windows
+
+package tracing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cilium/tetragon/pkg/selectors"
+	"github.com/cilium/tetragon/pkg/sensors"
+	"github.com/cilium/tetragon/pkg/sensors/program"
+)
+
+func TestSumSelectorStats(t *testing.T) {
+	stats := sumSelectorStats("fd_install", 1, []selectorStatsValue{
+		{Match: 3, Post: 2, Ratelimited: 1, LastMatch: 100},
+		{},
+		{Match: 2, Post: 1, Override: 1, Sigkill: 1, LastMatch: 200},
+	})
+	require.Equal(t, sensors.SelectorStats{
+		Hook:        "fd_install",
+		Selector:    1,
+		Match:       5,
+		Post:        3,
+		Override:    1,
+		Sigkill:     1,
+		Ratelimited: 1,
+		LastMatch:   200,
+	}, stats)
+}
+
+func TestSelectorStatsMapEntries(t *testing.T) {
+	polInfo := &policyInfo{}
+	load := program.Builder("bpf_generic_kprobe.o", "test", "kprobe/generic_kprobe", "test", "generic_kprobe")
+
+	m := polInfo.selectorStatsMap(load, selectorStatsHook{name: "fd_install", selectors: 1})
+	require.False(t, m.Entries.Set)
+
+	m = polInfo.selectorStatsMap(load,
+		selectorStatsHook{name: "fd_install", selectors: 1},
+		selectorStatsHook{name: "security_file_open", selectors: 3})
+	require.Equal(t, uint32(2*selectors.MaxSelectors), m.Entries.Val)
+	require.Len(t, polInfo.selectorStats, 2)
+}
@@ -20,9 +20,11 @@ import (
 	"time"
 
 	"github.com/cilium/ebpf"
+	ebtf "github.com/cilium/ebpf/btf"
 	"github.com/cilium/tetragon/api/v1/tetragon"
 	ec "github.com/cilium/tetragon/api/v1/tetragon/codegen/eventchecker"
 	"github.com/cilium/tetragon/pkg/config"
+	gt "github.com/cilium/tetragon/pkg/generictypes"
 	"github.com/cilium/tetragon/pkg/grpc/tracing"
 	"github.com/cilium/tetragon/pkg/jsonchecker"
 	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
@@ -39,6 +41,7 @@ import (
 	tuo "github.com/cilium/tetragon/pkg/testutils/observer"
 	"github.com/cilium/tetragon/pkg/testutils/perfring"
 	tus "github.com/cilium/tetragon/pkg/testutils/sensors"
+	"github.com/cilium/tetragon/pkg/tracepoint"
 	"github.com/stretchr/testify/require"
 	"golang.org/x/sys/unix"
 
@@ -939,3 +942,48 @@ spec:
 	err = jsonchecker.JsonTestCheck(t, checker)
 	require.NoError(t, err)
 }
+
+func TestTracepointBuildArgsBTF(t *testing.T) {
+	file := &ebtf.Struct{Name: "file", Size: 8}
+	bprm := &ebtf.Struct{
+		Name: "linux_binprm",
+		Size: 16,
+		Members: []ebtf.Member{
+			{Name: "p", Type: &ebtf.Int{Name: "unsigned long", Size: 8}, Offset: 0},
+			{Name: "file", Type: &ebtf.Pointer{Target: file}, Offset: 64},
+		},
+	}
+	// sched_process_exec(void *__data, struct task_struct *p, pid_t old_pid, struct linux_binprm *bprm)
+	proto := &ebtf.FuncProto{
+		Return: &ebtf.Void{},
+		Params: []ebtf.FuncParam{
+			{Name: "__data", Type: &ebtf.Pointer{Target: &ebtf.Void{}}},
+			{Name: "p", Type: &ebtf.Pointer{Target: &ebtf.Struct{Name: "task_struct"}}},
+			{Name: "old_pid", Type: &ebtf.Typedef{Name: "pid_t", Type: &ebtf.Int{Name: "int", Size: 4, Encoding: ebtf.Signed}}},
+			{Name: "bprm", Type: &ebtf.Pointer{Target: bprm}},
+		},
+	}
+	info := &tracepoint.Tracepoint{Subsys: "sched", Event: "sched_process_exec"}
+
+	args, err := buildArgsBTF(info, []v1alpha1.KProbeArg{
+		{Index: 1},
+		{Index: 2, Type: "linux_binprm"},
+		{Index: 1, Type: "uint64"},
+		{Index: 2, Resolve: "file"},
+	}, proto)
+	require.NoError(t, err)
+	require.Len(t, args, 4)
+	require.Equal(t, gt.GenericS32Type, args[0].genericTypeId)
+	require.Equal(t, gt.GenericLinuxBinprmType, args[1].genericTypeId)
+	require.Equal(t, gt.GenericU64Type, args[2].genericTypeId)
+	require.Equal(t, gt.GenericFileType, args[3].genericTypeId)
+	require.Equal(t, 2, args[3].TpIdx)
+	require.Equal(t, uint32(8), args[3].btf[0].Offset)
+	require.Equal(t, uint16(1), args[3].btf[0].IsInitialized)
+
+	_, err = buildArgsBTF(info, []v1alpha1.KProbeArg{{Index: 3}}, proto)
+	require.ErrorContains(t, err, "has 3 arguments")
+
+	_, err = buildArgsBTF(info, []v1alpha1.KProbeArg{{Index: 2, Resolve: "nope"}}, proto)
+	require.Error(t, err)
+}
@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package server
+
+import (
+	"testing"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/lostevents"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type rateLimitedStream struct {
+	tetragon.FineGuidanceSensors_GetEventsServer
+	dropped uint64
+}
+
+func (s *rateLimitedStream) RateLimitDropped() uint64 {
+	ret := s.dropped
+	s.dropped = 0
+	return ret
+}
+
+func TestLostEventsTracker(t *testing.T) {
+	// events lost before the stream starts are not reported
+	lostevents.RingBuffer.Add(100)
+	listener := &getEventsListener{events: make(chan *tetragon.GetEventsResponse, 1)}
+	stream := &rateLimitedStream{}
+	tracker := newLostEventsTracker(listener, stream)
+	assert.Nil(t, tracker.next())
+
+	lostevents.RingBuffer.Add(1)
+	lostevents.EventCache.Add(2)
+	stream.dropped = 3
+	for range 5 {
+		listener.Notify(&tetragon.GetEventsResponse{})
+	}
+	ev := tracker.next()
+	require.NotNil(t, ev)
+	assert.Equal(t, &tetragon.LostEvents{
+		RingBuffer: 1,
+		EventCache: 2,
+		RateLimit:  3,
+		SlowClient: 4,
+	}, ev.GetLostEvents())
+	assert.Nil(t, tracker.next())
+
+	// streams reading from the spool have no listener
+	tracker = newLostEventsTracker(nil, &rateLimitedStream{})
+	lostevents.EventCache.Add(1)
+	ev = tracker.next()
+	require.NotNil(t, ev)
+	assert.Equal(t, &tetragon.LostEvents{EventCache: 1}, ev.GetLostEvents())
+}
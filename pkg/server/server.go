@@ -10,6 +10,8 @@ import (
 	"io"
 	"log/slog"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/cilium/tetragon/api/v1/tetragon"
 	"github.com/cilium/tetragon/pkg/aggregator"
@@ -19,15 +21,25 @@ import (
 	"github.com/cilium/tetragon/pkg/health"
 	"github.com/cilium/tetragon/pkg/logger"
 	"github.com/cilium/tetragon/pkg/logger/logfields"
+	"github.com/cilium/tetragon/pkg/lostevents"
 	"github.com/cilium/tetragon/pkg/metrics/eventmetrics"
 	"github.com/cilium/tetragon/pkg/option"
 	"github.com/cilium/tetragon/pkg/process"
+	"github.com/cilium/tetragon/pkg/reader/node"
+	"github.com/cilium/tetragon/pkg/spool"
 	"github.com/cilium/tetragon/pkg/tracingpolicy"
 	"github.com/cilium/tetragon/pkg/version"
 
 	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// lostEventsInterval is the interval at which the events lost since the
+// previous lost_events message are reported to GetEvents clients.
+const lostEventsInterval = 10 * time.Second
+
 type Listener interface {
 	Notify(res *tetragon.GetEventsResponse)
 }
@@ -63,11 +75,23 @@ type Server struct {
 	notifier     Notifier
 	observer     observer
 	hookRunner   hookRunner
+	spool        *spool.Spool
 	tetragon.UnimplementedFineGuidanceSensorsServer
 }
 
 type getEventsListener struct {
 	events chan *tetragon.GetEventsResponse
+	// dropped counts the events dropped because the events channel was full
+	dropped atomic.Uint64
+}
+
+// RateLimitedStream is implemented by GetEvents streams that drop events
+// because of rate limiting, so that the dropped events are reported in
+// lost_events messages.
+type RateLimitedStream interface {
+	// RateLimitDropped returns the number of events dropped since the
+	// previous call.
+	RateLimitDropped() uint64
 }
 
 func NewServer(ctx context.Context, cleanupWg *sync.WaitGroup, notifier Notifier, observer observer, hookRunner hookRunner) *Server {
@@ -80,6 +104,17 @@ func NewServer(ctx context.Context, cleanupWg *sync.WaitGroup, notifier Notifier
 	}
 }
 
+// SetSpool sets the event spool used to serve GetEvents requests with a
+// since_cursor.
+func (s *Server) SetSpool(sp *spool.Spool) {
+	s.spool = sp
+}
+
+// Spool returns the event spool, or nil if it is not enabled.
+func (s *Server) Spool() *spool.Spool {
+	return s.spool
+}
+
 func newListener() *getEventsListener {
 	var chanSize uint = 10000
 	if option.Config.EventQueueSize > 0 {
@@ -96,6 +131,7 @@ func (l *getEventsListener) Notify(res *tetragon.GetEventsResponse) {
 	default:
 		// events channel is full: drop the event so that we do not block everything
 		eventmetrics.NotifyOverflowedEvents.Inc()
+		l.dropped.Add(1)
 	}
 }
 
@@ -126,7 +162,9 @@ func (s *Server) GetEventsWG(request *tetragon.GetEventsRequest, server tetragon
 		"events.allow_list", request.GetAllowList(),
 		"events.deny_list", request.GetDenyList(),
 		"events.field_filters", request.GetFieldFilters(),
-		"events.aggregation_options", request.GetAggregationOptions())
+		"events.aggregation_options", request.GetAggregationOptions(),
+		"events.sampling_options", request.GetSamplingOptions(),
+		"events.computed_fields", request.GetComputedFields())
 	allowList, err := filters.BuildFilterList(s.ctx, request.AllowList, filters.Filters)
 	if err != nil {
 		if readyWG != nil {
@@ -141,6 +179,26 @@ func (s *Server) GetEventsWG(request *tetragon.GetEventsRequest, server tetragon
 		}
 		return err
 	}
+	sampler, err := filters.NewSampler(request.SamplingOptions)
+	if err != nil {
+		if readyWG != nil {
+			readyWG.Done()
+		}
+		return err
+	}
+	computedFields, err := filters.NewComputedFields(request.ComputedFields)
+	if err != nil {
+		if readyWG != nil {
+			readyWG.Done()
+		}
+		return err
+	}
+	if request.SinceCursor != nil && s.spool == nil {
+		if readyWG != nil {
+			readyWG.Done()
+		}
+		return errors.New("since_cursor requires the event spool to be enabled")
+	}
 	aggregator, err := aggregator.NewAggregator(server, request.AggregationOptions)
 	if err != nil {
 		if readyWG != nil {
@@ -152,9 +210,35 @@ func (s *Server) GetEventsWG(request *tetragon.GetEventsRequest, server tetragon
 		go aggregator.Start()
 	}
 
-	l := newListener()
-	s.notifier.AddListener(l)
-	defer s.removeNotifierAndDrain(l)
+	var events <-chan *tetragon.GetEventsResponse
+	var listener *getEventsListener
+	if request.SinceCursor != nil {
+		ctx, cancel := context.WithCancel(server.Context())
+		defer cancel()
+		events = s.readSpool(ctx, request.SinceCursor.Value)
+	} else {
+		listener = newListener()
+		s.notifier.AddListener(listener)
+		defer s.removeNotifierAndDrain(listener)
+		events = listener.events
+	}
+	lost := newLostEventsTracker(listener, server)
+	lostTicker := time.NewTicker(lostEventsInterval)
+	defer lostTicker.Stop()
+	send := func(event *tetragon.GetEventsResponse) error {
+		if aggregator != nil {
+			// Send event to aggregator.
+			select {
+			case aggregator.GetEventChannel() <- event:
+			default:
+				logger.GetLogger().Warn("Aggregator buffer is full. Consider increasing AggregatorOptions.channel_buffer_size.",
+					"request", request)
+			}
+			return nil
+		}
+		// No need to aggregate. Directly send out the response.
+		return server.Send(event)
+	}
 	if readyWG != nil {
 		readyWG.Done()
 	}
@@ -162,11 +246,27 @@ func (s *Server) GetEventsWG(request *tetragon.GetEventsRequest, server tetragon
 	defer s.ctxCleanupWG.Done()
 	for {
 		select {
-		case event := <-l.events:
+		case event, ok := <-events:
+			if !ok {
+				if closer != nil {
+					closer.Close()
+				}
+				return errors.New("failed to read event spool")
+			}
+			cursor := event.Cursor
 			if !filters.Apply(allowList, denyList, &pkgEvent.Event{Event: event}) {
 				// Event is filtered out. Nothing to do here. Continue.
 				continue
 			}
+			if sampler != nil {
+				var keep bool
+				if event, keep = sampler.Sample(event); !keep {
+					continue
+				}
+			}
+			if computedFields != nil {
+				event = computedFields.Apply(server.Context(), event)
+			}
 
 			// Get field filters
 			filters, err := fieldfilters.FieldFiltersFromGetEventsRequest(request)
@@ -183,18 +283,17 @@ func (s *Server) GetEventsWG(request *tetragon.GetEventsRequest, server tetragon
 				}
 				event = ev
 			}
+			if cursor != 0 {
+				// Keep the cursor, so that the client can resume after this event
+				event.Cursor = cursor
+			}
 
-			if aggregator != nil {
-				// Send event to aggregator.
-				select {
-				case aggregator.GetEventChannel() <- event:
-				default:
-					logger.GetLogger().Warn("Aggregator buffer is full. Consider increasing AggregatorOptions.channel_buffer_size.",
-						"request", request)
-				}
-			} else {
-				// No need to aggregate. Directly send out the response.
-				if err = server.Send(event); err != nil {
+			if err = send(event); err != nil {
+				return err
+			}
+		case <-lostTicker.C:
+			if ev := lost.next(); ev != nil {
+				if err := send(ev); err != nil {
 					return err
 				}
 			}
@@ -212,6 +311,80 @@ func (s *Server) GetEventsWG(request *tetragon.GetEventsRequest, server tetragon
 	}
 }
 
+// lostEventsTracker tracks the events lost since the previous lost_events
+// message of a GetEvents stream.
+type lostEventsTracker struct {
+	last lostevents.Counts
+	// listener is nil if events are read from the spool
+	listener *getEventsListener
+	// stream is nil if the stream is not rate limited
+	stream RateLimitedStream
+}
+
+func newLostEventsTracker(listener *getEventsListener, server tetragon.FineGuidanceSensors_GetEventsServer) *lostEventsTracker {
+	t := &lostEventsTracker{
+		last:     lostevents.Read(),
+		listener: listener,
+	}
+	if stream, ok := server.(RateLimitedStream); ok {
+		t.stream = stream
+	}
+	return t
+}
+
+// next returns a lost_events message with the events lost since the previous
+// call, or nil if no events were lost.
+func (t *lostEventsTracker) next() *tetragon.GetEventsResponse {
+	counts := lostevents.Read()
+	lost := &tetragon.LostEvents{
+		RingBuffer: counts.RingBuffer - t.last.RingBuffer,
+		EventCache: counts.EventCache - t.last.EventCache,
+	}
+	t.last = counts
+	if t.listener != nil {
+		lost.SlowClient = t.listener.dropped.Swap(0)
+	}
+	if t.stream != nil {
+		lost.RateLimit = t.stream.RateLimitDropped()
+	}
+	if lost.RingBuffer == 0 && lost.EventCache == 0 && lost.RateLimit == 0 && lost.SlowClient == 0 {
+		return nil
+	}
+	ev := &tetragon.GetEventsResponse{
+		Event: &tetragon.GetEventsResponse_LostEvents{LostEvents: lost},
+		Time:  timestamppb.Now(),
+	}
+	node.SetCommonFields(ev)
+	return ev
+}
+
+// readSpool returns a channel with the spooled events after cursor. The
+// events stop when ctx is done, and the channel is closed if reading the spool
+// fails.
+func (s *Server) readSpool(ctx context.Context, cursor uint64) <-chan *tetragon.GetEventsResponse {
+	events := make(chan *tetragon.GetEventsResponse)
+	go func() {
+		r := s.spool.NewReader(cursor)
+		defer r.Close()
+		for {
+			ev, err := r.Next(ctx)
+			if err != nil {
+				if ctx.Err() == nil {
+					logger.GetLogger().Warn("Failed to read event spool", logfields.Error, err)
+					close(events)
+				}
+				return
+			}
+			select {
+			case events <- ev:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return events
+}
+
 func (s *Server) GetHealth(_ context.Context, request *tetragon.GetHealthStatusRequest) (*tetragon.GetHealthStatusResponse, error) {
 	logger.GetLogger().Debug("Received a GetHealth request", "request", request)
 	return health.GetHealth()
@@ -359,6 +532,41 @@ func (s *Server) GetDebug(_ context.Context, req *tetragon.GetDebugRequest) (*te
 	}
 }
 
+func (s *Server) GetProcessTree(ctx context.Context, req *tetragon.GetProcessTreeRequest) (*tetragon.GetProcessTreeResponse, error) {
+	logger.GetLogger().Debug("Received a GetProcessTree request", "request", req)
+	allowList, err := filters.BuildFilterList(ctx, req.GetAllowList(), filters.Filters)
+	if err != nil {
+		return nil, err
+	}
+	denyList, err := filters.BuildFilterList(ctx, req.GetDenyList(), filters.Filters)
+	if err != nil {
+		return nil, err
+	}
+	match := func(p, parent *tetragon.Process, ancestors []*tetragon.Process) bool {
+		ev := &tetragon.GetEventsResponse{
+			Event: &tetragon.GetEventsResponse_ProcessExec{ProcessExec: &tetragon.ProcessExec{
+				Process:   p,
+				Parent:    parent,
+				Ancestors: ancestors,
+			}},
+		}
+		node.SetCommonFields(ev)
+		return filters.Apply(allowList, denyList, &pkgEvent.Event{Event: ev})
+	}
+	return &tetragon.GetProcessTreeResponse{
+		Processes: process.GetProcessTree(match, req.GetIncludeExited()),
+	}, nil
+}
+
+func (s *Server) GetProcess(_ context.Context, req *tetragon.GetProcessRequest) (*tetragon.GetProcessResponse, error) {
+	logger.GetLogger().Debug("Received a GetProcess request", "request", req)
+	p, err := process.LookupProcess(req.GetExecId())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "process %q not found", req.GetExecId())
+	}
+	return &tetragon.GetProcessResponse{Process: p}, nil
+}
+
 func (s *Server) SetDebug(_ context.Context, req *tetragon.SetDebugRequest) (*tetragon.SetDebugResponse, error) {
 	switch req.GetFlag() {
 	case tetragon.ConfigFlag_CONFIG_FLAG_LOG_LEVEL:
@@ -0,0 +1,40 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package spool
+
+import (
+	"github.com/cilium/tetragon/pkg/metrics"
+	"github.com/cilium/tetragon/pkg/metrics/consts"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	dropReasonQueueFull  = "queue_full"
+	dropReasonWriteError = "write_error"
+	dropReasonRetention  = "retention"
+)
+
+var (
+	eventsSpooled = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: consts.MetricsNamespace,
+		Name:      "spool_events_total",
+		Help:      "Number of events written to the event spool",
+	})
+
+	eventsDropped = metrics.MustNewCounter(metrics.NewOpts(
+		consts.MetricsNamespace, "", "spool_events_dropped_total",
+		"Number of events dropped by the event spool. Events are dropped when the spool queue is full, when they cannot be written, and when they are removed to keep the spool within its maximum size",
+		nil, []metrics.ConstrainedLabel{{
+			Name:   "reason",
+			Values: []string{dropReasonQueueFull, dropReasonWriteError, dropReasonRetention},
+		}}, nil,
+	), nil)
+)
+
+func RegisterMetrics(group metrics.Group) {
+	group.MustRegister(
+		eventsSpooled,
+		eventsDropped,
+	)
+}
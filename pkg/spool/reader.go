@@ -0,0 +1,161 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package spool
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/logger"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxRecordSize is a sanity limit for the size of a spooled event.
+const maxRecordSize = 64 * 1024 * 1024
+
+var errCorrupted = errors.New("corrupted spool record")
+
+// readRecordHeader reads the header of the record at off, and returns the
+// header and event sizes.
+func readRecordHeader(f *os.File, off int64) (int64, uint64, error) {
+	var hdr [binary.MaxVarintLen64]byte
+	n, err := f.ReadAt(hdr[:], off)
+	if n == 0 {
+		if err == nil {
+			err = io.EOF
+		}
+		return 0, 0, err
+	}
+	size, hdrLen := binary.Uvarint(hdr[:n])
+	if hdrLen <= 0 || size > maxRecordSize {
+		return 0, 0, errCorrupted
+	}
+	return int64(hdrLen), size, nil
+}
+
+// readRecordSize returns the total size of the record at off.
+func readRecordSize(f *os.File, off int64) (int64, error) {
+	hdrLen, size, err := readRecordHeader(f, off)
+	if err != nil {
+		return 0, err
+	}
+	return hdrLen + int64(size), nil
+}
+
+// Reader reads events from the spool, in cursor order.
+type Reader struct {
+	s    *Spool
+	next uint64 // cursor of the next event to read
+
+	file     *os.File
+	fileBase uint64 // base of the segment of file
+	filePos  uint64 // cursor of the record at off
+	off      int64
+	buf      []byte
+}
+
+// NewReader returns a reader for the events after the given cursor. If these
+// events have already been removed from the spool, the reader starts with
+// the oldest event in the spool.
+func (s *Spool) NewReader(after uint64) *Reader {
+	return &Reader{s: s, next: after + 1}
+}
+
+// Close closes the reader.
+func (r *Reader) Close() error {
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+// Next returns the next event, with its Cursor field set. It blocks until an
+// event is available or ctx is done.
+func (r *Reader) Next(ctx context.Context) (*tetragon.GetEventsResponse, error) {
+	for {
+		ev, notify, err := r.tryNext()
+		if errors.Is(err, os.ErrNotExist) {
+			// the segment was removed by retention, try again
+			continue
+		} else if err != nil {
+			return nil, err
+		} else if ev != nil {
+			return ev, nil
+		}
+		select {
+		case <-notify:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// tryNext returns the next event if it is available. Otherwise, it returns a
+// channel that is closed when new events are available.
+func (r *Reader) tryNext() (*tetragon.GetEventsResponse, <-chan struct{}, error) {
+	r.s.mu.Lock()
+	segs := r.s.segments
+	if oldest := segs[0].base; r.next < oldest {
+		logger.GetLogger().Warn("Spooled events were removed before being read",
+			"count", oldest-r.next, "cursor", r.next)
+		r.next = oldest
+	}
+	idx := len(segs) - 1
+	for idx > 0 && segs[idx].base > r.next {
+		idx--
+	}
+	seg := *segs[idx]
+	notify := r.s.notify
+	r.s.mu.Unlock()
+
+	if r.next >= seg.base+seg.count {
+		// no new events in the active segment
+		return nil, notify, nil
+	}
+
+	if r.file == nil || r.fileBase != seg.base || r.filePos > r.next {
+		r.Close()
+		f, err := os.Open(seg.path)
+		if err != nil {
+			return nil, nil, err
+		}
+		r.file, r.fileBase, r.filePos, r.off = f, seg.base, seg.base, 0
+	}
+	for r.filePos < r.next {
+		n, err := readRecordSize(r.file, r.off)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read spool segment %s: %w", seg.path, err)
+		}
+		r.off += n
+		r.filePos++
+	}
+
+	hdrLen, size, err := readRecordHeader(r.file, r.off)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read spool segment %s: %w", seg.path, err)
+	}
+	if uint64(cap(r.buf)) < size {
+		r.buf = make([]byte, size)
+	}
+	r.buf = r.buf[:size]
+	if _, err := r.file.ReadAt(r.buf, r.off+hdrLen); err != nil {
+		return nil, nil, fmt.Errorf("failed to read spool segment %s: %w", seg.path, err)
+	}
+	ev := &tetragon.GetEventsResponse{}
+	if err := proto.Unmarshal(r.buf, ev); err != nil {
+		return nil, nil, fmt.Errorf("failed to read spool segment %s: %w", seg.path, err)
+	}
+	ev.Cursor = r.next
+	r.off += hdrLen + int64(size)
+	r.filePos++
+	r.next++
+	return ev, nil, nil
+}
@@ -0,0 +1,382 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+// Package spool implements a bounded on-disk write-ahead log of events.
+//
+// Events are appended to segment files in the spool directory. Each event is
+// identified by its cursor, a number that increases by one for every spooled
+// event and that persists across restarts. Readers can read events starting
+// from any cursor that is still in the spool, and consumers can store the
+// cursor of the last event they acknowledged as a named checkpoint. When the
+// spool exceeds its maximum size, the oldest segments are removed.
+package spool
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/logger"
+	"github.com/cilium/tetragon/pkg/logger/logfields"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	segmentSuffix  = ".spool"
+	checkpointsDir = "checkpoints"
+
+	// flushInterval is the maximum time an event is buffered in memory
+	// before being written to the active segment.
+	flushInterval = 100 * time.Millisecond
+)
+
+// Config configures the event spool.
+type Config struct {
+	// Dir is the spool directory.
+	Dir string
+	// MaxSize is the maximum size of the spool in bytes. The oldest
+	// segments are removed when the spool grows larger.
+	MaxSize int64
+	// SegmentSize is the size in bytes after which a new segment is started.
+	SegmentSize int64
+	// QueueSize is the number of events buffered in memory before being
+	// spooled. Events are dropped when the queue is full.
+	QueueSize int
+}
+
+type segment struct {
+	base uint64 // cursor of the first event in the segment
+	path string
+	// count and size are only updated for the active segment, and are
+	// only valid for events that have been flushed to the file.
+	count uint64
+	size  int64
+}
+
+// Spool is an on-disk event spool. It implements server.Listener, so it can
+// be registered with the event notifier to spool all events.
+type Spool struct {
+	conf   Config
+	events chan *tetragon.GetEventsResponse
+
+	// active segment, only accessed by the writer goroutine
+	file       *os.File
+	buf        *bufio.Writer
+	fileSize   int64 // including buffered events
+	bufCount   uint64
+	bufSize    int64
+	marshalBuf []byte
+
+	mu       sync.Mutex
+	segments []*segment // sorted, the last one is the active segment
+	size     int64      // total size of all segments
+	// notify is closed (and replaced) when new events are flushed
+	notify chan struct{}
+}
+
+// Open opens the spool in conf.Dir, creating it if needed. Call Start to
+// start spooling events.
+func Open(conf Config) (*Spool, error) {
+	if conf.MaxSize <= 0 {
+		return nil, fmt.Errorf("invalid spool maximum size %d", conf.MaxSize)
+	}
+	if conf.SegmentSize <= 0 || conf.SegmentSize > conf.MaxSize {
+		conf.SegmentSize = conf.MaxSize
+	}
+	if err := os.MkdirAll(filepath.Join(conf.Dir, checkpointsDir), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create spool directory: %w", err)
+	}
+
+	s := &Spool{
+		conf:   conf,
+		events: make(chan *tetragon.GetEventsResponse, conf.QueueSize),
+		notify: make(chan struct{}),
+	}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	next := uint64(1)
+	if n := len(s.segments); n > 0 {
+		last := s.segments[n-1]
+		next = last.base + last.count
+	}
+	if err := s.newSegment(next); err != nil {
+		return nil, err
+	}
+	s.enforceMaxSize()
+	return s, nil
+}
+
+func segmentPath(dir string, base uint64) string {
+	return filepath.Join(dir, fmt.Sprintf("%020d%s", base, segmentSuffix))
+}
+
+// load loads the existing segments of the spool.
+func (s *Spool) load() error {
+	entries, err := os.ReadDir(s.conf.Dir)
+	if err != nil {
+		return fmt.Errorf("failed to read spool directory: %w", err)
+	}
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, segmentSuffix) {
+			continue
+		}
+		base, err := strconv.ParseUint(strings.TrimSuffix(name, segmentSuffix), 10, 64)
+		if err != nil || base == 0 {
+			logger.GetLogger().Warn("Ignoring unexpected file in spool directory", "file", name)
+			continue
+		}
+		s.segments = append(s.segments, &segment{base: base, path: filepath.Join(s.conf.Dir, name)})
+	}
+	sort.Slice(s.segments, func(i, j int) bool { return s.segments[i].base < s.segments[j].base })
+
+	for i, seg := range s.segments {
+		if i < len(s.segments)-1 {
+			seg.count = s.segments[i+1].base - seg.base
+			fi, err := os.Stat(seg.path)
+			if err != nil {
+				return fmt.Errorf("failed to load spool segment: %w", err)
+			}
+			seg.size = fi.Size()
+		} else if err := recoverSegment(seg); err != nil {
+			return err
+		}
+		s.size += seg.size
+	}
+	return nil
+}
+
+// recoverSegment counts the events of the last segment, and truncates a
+// partially written event at its end (e.g., after a crash).
+func recoverSegment(seg *segment) error {
+	f, err := os.OpenFile(seg.path, os.O_RDWR, 0)
+	if err != nil {
+		return fmt.Errorf("failed to open spool segment: %w", err)
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to open spool segment: %w", err)
+	}
+	var off int64
+	for {
+		n, err := readRecordSize(f, off)
+		if err != nil || off+n > fi.Size() {
+			break
+		}
+		off += n
+		seg.count++
+	}
+	if err := f.Truncate(off); err != nil {
+		return fmt.Errorf("failed to truncate spool segment: %w", err)
+	}
+	seg.size = off
+	return nil
+}
+
+// newSegment starts a new active segment. The caller must not hold s.mu.
+func (s *Spool) newSegment(base uint64) error {
+	path := segmentPath(s.conf.Dir, base)
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("failed to create spool segment: %w", err)
+	}
+	if s.file != nil {
+		s.file.Sync()
+		s.file.Close()
+	}
+	s.file = f
+	s.fileSize = 0
+	s.buf = bufio.NewWriterSize(f, 64*1024)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if n := len(s.segments); n > 0 && s.segments[n-1].base == base {
+		// the previous segment is empty, and it was truncated above
+		s.size -= s.segments[n-1].size
+		s.segments = s.segments[:n-1]
+	}
+	s.segments = append(s.segments, &segment{base: base, path: path})
+	return nil
+}
+
+// enforceMaxSize removes the oldest segments while the spool is too large.
+func (s *Spool) enforceMaxSize() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for s.size > s.conf.MaxSize && len(s.segments) > 1 {
+		seg := s.segments[0]
+		if err := os.Remove(seg.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			logger.GetLogger().Warn("Failed to remove spool segment", "file", seg.path, logfields.Error, err)
+		}
+		s.segments = s.segments[1:]
+		s.size -= seg.size
+		eventsDropped.WithLabelValues(dropReasonRetention).Add(float64(seg.count))
+	}
+}
+
+// Notify implements server.Listener. It never blocks: if the queue is full,
+// the event is dropped.
+func (s *Spool) Notify(ev *tetragon.GetEventsResponse) {
+	select {
+	case s.events <- ev:
+	default:
+		eventsDropped.WithLabelValues(dropReasonQueueFull).Inc()
+	}
+}
+
+// Start starts spooling events until ctx is done. Events that are still
+// queued when ctx is done are spooled before wg is marked done.
+func (s *Spool) Start(ctx context.Context, wg *sync.WaitGroup) {
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		s.run(ctx)
+	}()
+}
+
+func (s *Spool) run(ctx context.Context) {
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case ev := <-s.events:
+			s.append(ev)
+		case <-ticker.C:
+			s.flush()
+		case <-ctx.Done():
+			// spool whatever is still queued
+			for {
+				select {
+				case ev := <-s.events:
+					s.append(ev)
+				default:
+					s.flush()
+					s.file.Sync()
+					s.file.Close()
+					return
+				}
+			}
+		}
+	}
+}
+
+func (s *Spool) append(ev *tetragon.GetEventsResponse) {
+	var err error
+	s.marshalBuf, err = proto.MarshalOptions{}.MarshalAppend(s.marshalBuf[:0], ev)
+	if err != nil {
+		logger.GetLogger().Warn("Failed to marshal spooled event", logfields.Error, err)
+		eventsDropped.WithLabelValues(dropReasonWriteError).Inc()
+		return
+	}
+	var hdr [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(hdr[:], uint64(len(s.marshalBuf)))
+	if _, err := s.buf.Write(hdr[:n]); err == nil {
+		_, err = s.buf.Write(s.marshalBuf)
+	}
+	if err != nil {
+		logger.GetLogger().Warn("Failed to write spooled event", logfields.Error, err)
+		eventsDropped.WithLabelValues(dropReasonWriteError).Inc()
+		return
+	}
+	s.bufCount++
+	s.bufSize += int64(n + len(s.marshalBuf))
+	s.fileSize += int64(n + len(s.marshalBuf))
+	eventsSpooled.Inc()
+
+	// flush when the writer is idle, so that readers get events quickly, and
+	// when the segment is full
+	if len(s.events) == 0 || s.bufSize >= int64(s.buf.Size()) || s.fileSize >= s.conf.SegmentSize {
+		s.flush()
+	}
+}
+
+// flush writes buffered events to the active segment and wakes up readers.
+func (s *Spool) flush() {
+	if s.bufCount == 0 {
+		return
+	}
+	if err := s.buf.Flush(); err != nil {
+		// The events of the failed write are lost. Start a new segment,
+		// since the current one might contain a partial event.
+		logger.GetLogger().Warn("Failed to write spool segment", logfields.Error, err)
+		eventsDropped.WithLabelValues(dropReasonWriteError).Add(float64(s.bufCount))
+		s.bufCount, s.bufSize = 0, 0
+		s.mu.Lock()
+		active := s.segments[len(s.segments)-1]
+		next := active.base + active.count
+		s.mu.Unlock()
+		if err := s.newSegment(next); err != nil {
+			logger.GetLogger().Warn("Failed to create spool segment", logfields.Error, err)
+		}
+		return
+	}
+
+	s.mu.Lock()
+	active := s.segments[len(s.segments)-1]
+	active.count += s.bufCount
+	active.size += s.bufSize
+	s.size += s.bufSize
+	activeSize, next := active.size, active.base+active.count
+	close(s.notify)
+	s.notify = make(chan struct{})
+	s.mu.Unlock()
+	s.bufCount, s.bufSize = 0, 0
+
+	if activeSize >= s.conf.SegmentSize {
+		if err := s.newSegment(next); err != nil {
+			logger.GetLogger().Warn("Failed to create spool segment", logfields.Error, err)
+		}
+	}
+	s.enforceMaxSize()
+}
+
+// LastCursor returns the cursor of the last spooled event, or 0 if no events
+// are spooled.
+func (s *Spool) LastCursor() uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	active := s.segments[len(s.segments)-1]
+	return active.base + active.count - 1
+}
+
+// LoadCheckpoint returns the cursor stored in the checkpoint name, and false
+// if the checkpoint does not exist.
+func (s *Spool) LoadCheckpoint(name string) (uint64, bool, error) {
+	data, err := os.ReadFile(s.checkpointPath(name))
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, false, nil
+	} else if err != nil {
+		return 0, false, err
+	}
+	cursor, err := strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid spool checkpoint %q: %w", name, err)
+	}
+	return cursor, true, nil
+}
+
+// SaveCheckpoint atomically stores cursor in the checkpoint name.
+func (s *Spool) SaveCheckpoint(name string, cursor uint64) error {
+	path := s.checkpointPath(name)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(strconv.FormatUint(cursor, 10)+"\n"), 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func (s *Spool) checkpointPath(name string) string {
+	return filepath.Join(s.conf.Dir, checkpointsDir, name)
+}
@@ -0,0 +1,160 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package spool
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func execEvent(pid uint32) *tetragon.GetEventsResponse {
+	return &tetragon.GetEventsResponse{
+		Event: &tetragon.GetEventsResponse_ProcessExec{ProcessExec: &tetragon.ProcessExec{
+			Process: &tetragon.Process{Pid: wrapperspb.UInt32(pid), Binary: "/usr/bin/true"},
+		}},
+		NodeName: "node",
+	}
+}
+
+// startSpool opens and starts a spool, and returns a function that stops it.
+func startSpool(t *testing.T, conf Config) (*Spool, func()) {
+	sp, err := Open(conf)
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	sp.Start(ctx, &wg)
+	return sp, func() {
+		cancel()
+		wg.Wait()
+	}
+}
+
+func readN(t *testing.T, r *Reader, n int) []*tetragon.GetEventsResponse {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	var ret []*tetragon.GetEventsResponse
+	for range n {
+		ev, err := r.Next(ctx)
+		require.NoError(t, err)
+		ret = append(ret, ev)
+	}
+	return ret
+}
+
+func TestSpool(t *testing.T) {
+	dir := t.TempDir()
+	conf := Config{Dir: dir, MaxSize: 1 << 20, SegmentSize: 1 << 10, QueueSize: 100}
+	sp, stop := startSpool(t, conf)
+
+	r := sp.NewReader(0)
+	defer r.Close()
+	for i := range 50 {
+		sp.Notify(execEvent(uint32(i)))
+	}
+	events := readN(t, r, 50)
+	for i, ev := range events {
+		assert.Equal(t, uint64(i+1), ev.Cursor)
+		assert.Equal(t, uint32(i), ev.GetProcessExec().Process.Pid.GetValue())
+	}
+	assert.Equal(t, uint64(50), sp.LastCursor())
+
+	// a reader starting in the middle of the spool
+	r2 := sp.NewReader(42)
+	defer r2.Close()
+	ev := readN(t, r2, 1)[0]
+	assert.Equal(t, uint64(43), ev.Cursor)
+
+	// no more events
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	_, err := r.Next(ctx)
+	cancel()
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	require.NoError(t, sp.SaveCheckpoint("export", 42))
+	stop()
+
+	// reopen the spool: cursors continue, and old events are still there
+	sp, stop = startSpool(t, conf)
+	defer stop()
+	assert.Equal(t, uint64(50), sp.LastCursor())
+	cursor, ok, err := sp.LoadCheckpoint("export")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, uint64(42), cursor)
+	_, ok, err = sp.LoadCheckpoint("other")
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	r3 := sp.NewReader(cursor)
+	defer r3.Close()
+	sp.Notify(execEvent(100))
+	events = readN(t, r3, 9)
+	assert.Equal(t, uint64(43), events[0].Cursor)
+	assert.Equal(t, uint64(51), events[8].Cursor)
+	assert.Equal(t, uint32(100), events[8].GetProcessExec().Process.Pid.GetValue())
+}
+
+func TestSpoolMaxSize(t *testing.T) {
+	dir := t.TempDir()
+	sp, stop := startSpool(t, Config{Dir: dir, MaxSize: 4 << 10, SegmentSize: 1 << 10, QueueSize: 1000})
+	defer stop()
+
+	r := sp.NewReader(0)
+	defer r.Close()
+	for i := range 1000 {
+		sp.Notify(execEvent(uint32(i)))
+	}
+	require.Eventually(t, func() bool { return sp.LastCursor() == 1000 }, 5*time.Second, 10*time.Millisecond)
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	var size int64
+	for _, e := range entries {
+		if fi, err := e.Info(); err == nil && !e.IsDir() {
+			size += fi.Size()
+		}
+	}
+	assert.LessOrEqual(t, size, int64(5<<10))
+
+	// the reader skips the removed events
+	ev := readN(t, r, 1)[0]
+	assert.Greater(t, ev.Cursor, uint64(1))
+	assert.Equal(t, uint32(ev.Cursor-1), ev.GetProcessExec().Process.Pid.GetValue())
+}
+
+func TestSpoolRecover(t *testing.T) {
+	dir := t.TempDir()
+	conf := Config{Dir: dir, MaxSize: 1 << 20, QueueSize: 100}
+	sp, stop := startSpool(t, conf)
+	for i := range 10 {
+		sp.Notify(execEvent(uint32(i)))
+	}
+	require.Eventually(t, func() bool { return sp.LastCursor() == 10 }, 5*time.Second, 10*time.Millisecond)
+	stop()
+
+	// simulate a crash in the middle of writing an event
+	path := segmentPath(dir, 1)
+	fi, err := os.Stat(path)
+	require.NoError(t, err)
+	require.NoError(t, os.Truncate(path, fi.Size()-3))
+
+	sp, stop = startSpool(t, conf)
+	defer stop()
+	assert.Equal(t, uint64(9), sp.LastCursor())
+	sp.Notify(execEvent(42))
+	r := sp.NewReader(8)
+	defer r.Close()
+	events := readN(t, r, 2)
+	assert.Equal(t, uint64(9), events[0].Cursor)
+	assert.Equal(t, uint64(10), events[1].Cursor)
+	assert.Equal(t, uint32(42), events[1].GetProcessExec().Process.Pid.GetValue())
+}
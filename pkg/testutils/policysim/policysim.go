@@ -0,0 +1,225 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+// Package policysim provides the conformance cases of the policy simulation
+// (see selectors.Simulator). The same cases are run against the simulator and
+// against the BPF programs, so that both implementations agree on the
+// semantics of selectors.
+//
+// Each case is a kprobe policy on the lseek syscall, and a series of lseek
+// calls with the outcome expected for each one.
+package policysim
+
+import (
+	"github.com/cilium/tetragon/api/v1/tetragon"
+	"github.com/cilium/tetragon/pkg/k8s/apis/cilium.io/v1alpha1"
+	"golang.org/x/sys/unix"
+)
+
+const (
+	// Call is the function hooked by the cases
+	Call = "sys_lseek"
+	// OverrideError is the error returned by overridden calls
+	OverrideError = -int32(unix.EOPNOTSUPP)
+)
+
+// LseekCall is a call to lseek, whose arguments are the arguments of the
+// kprobe: fd (int), offset (uint64) and whence (int). Calls use negative file
+// descriptors, so that they fail, and whence values in the [4000,4100) range,
+// so that their events can be told apart from the events of other calls.
+type LseekCall struct {
+	Fd     int32
+	Offset int64
+	Whence int32
+}
+
+// Outcome is the outcome of a call
+type Outcome struct {
+	Post     bool
+	Override bool
+}
+
+// Case is a conformance case
+type Case struct {
+	Name      string
+	Selectors []v1alpha1.KProbeSelector
+	Calls     []LseekCall
+	// Expected are the expected outcomes of Calls
+	Expected []Outcome
+}
+
+// Spec returns the policy spec of the case
+func (c *Case) Spec() *v1alpha1.TracingPolicySpec {
+	return &v1alpha1.TracingPolicySpec{
+		KProbes: []v1alpha1.KProbeSpec{{
+			Call:    Call,
+			Syscall: true,
+			Args: []v1alpha1.KProbeArg{
+				{Index: 0, Type: "int"},
+				{Index: 1, Type: "uint64"},
+				{Index: 2, Type: "int"},
+			},
+			Selectors: c.Selectors,
+		}},
+	}
+}
+
+// HasOverride tells whether the case uses the override action
+func (c *Case) HasOverride() bool {
+	for _, o := range c.Expected {
+		if o.Override {
+			return true
+		}
+	}
+	return false
+}
+
+// Event returns the event that the agent reports for the call, for a process
+// running binary. function is the (arch specific) name of the lseek syscall
+// function.
+func Event(call LseekCall, function, binary string) *tetragon.GetEventsResponse {
+	return &tetragon.GetEventsResponse{
+		Event: &tetragon.GetEventsResponse_ProcessKprobe{ProcessKprobe: &tetragon.ProcessKprobe{
+			Process:      &tetragon.Process{Binary: binary},
+			FunctionName: function,
+			Args: []*tetragon.KprobeArgument{
+				{Arg: &tetragon.KprobeArgument_IntArg{IntArg: call.Fd}},
+				{Arg: &tetragon.KprobeArgument_SizeArg{SizeArg: uint64(call.Offset)}},
+				{Arg: &tetragon.KprobeArgument_IntArg{IntArg: call.Whence}},
+			},
+		}},
+	}
+}
+
+var (
+	posted     = Outcome{Post: true}
+	notPosted  = Outcome{}
+	overridden = Outcome{Post: true, Override: true}
+)
+
+func matchArg(index uint32, op string, values ...string) v1alpha1.ArgSelector {
+	return v1alpha1.ArgSelector{Index: index, Operator: op, Values: values}
+}
+
+// Cases are the conformance cases
+var Cases = []Case{
+	{
+		Name:     "no selectors",
+		Calls:    []LseekCall{{-1, 0, 4000}},
+		Expected: []Outcome{posted},
+	},
+	{
+		Name: "int Equal",
+		Selectors: []v1alpha1.KProbeSelector{{
+			MatchArgs: []v1alpha1.ArgSelector{matchArg(2, "Equal", "4001", "4002")},
+		}},
+		Calls:    []LseekCall{{-1, 0, 4001}, {-1, 0, 4002}, {-1, 0, 4003}},
+		Expected: []Outcome{posted, posted, notPosted},
+	},
+	{
+		// NotEqual matches values that differ from any of the values
+		Name: "int NotEqual",
+		Selectors: []v1alpha1.KProbeSelector{{
+			MatchArgs: []v1alpha1.ArgSelector{matchArg(2, "NotEqual", "4001")},
+		}},
+		Calls:    []LseekCall{{-1, 0, 4001}, {-1, 0, 4002}},
+		Expected: []Outcome{notPosted, posted},
+	},
+	{
+		Name: "int GreaterThan is signed",
+		Selectors: []v1alpha1.KProbeSelector{{
+			MatchArgs: []v1alpha1.ArgSelector{matchArg(0, "GreaterThan", "-5")},
+		}},
+		Calls:    []LseekCall{{-1, 0, 4004}, {-10, 0, 4004}},
+		Expected: []Outcome{posted, notPosted},
+	},
+	{
+		Name: "uint64 GreaterThan is unsigned",
+		Selectors: []v1alpha1.KProbeSelector{{
+			MatchArgs: []v1alpha1.ArgSelector{matchArg(1, "GreaterThan", "100")},
+		}},
+		Calls:    []LseekCall{{-1, 200, 4005}, {-1, 50, 4005}, {-1, -1, 4005}},
+		Expected: []Outcome{posted, notPosted, posted},
+	},
+	{
+		Name: "uint64 LessThan is unsigned",
+		Selectors: []v1alpha1.KProbeSelector{{
+			MatchArgs: []v1alpha1.ArgSelector{matchArg(1, "LessThan", "100")},
+		}},
+		Calls:    []LseekCall{{-1, 50, 4006}, {-1, 200, 4006}, {-1, -1, 4006}},
+		Expected: []Outcome{posted, notPosted, notPosted},
+	},
+	{
+		Name: "int Mask",
+		Selectors: []v1alpha1.KProbeSelector{{
+			MatchArgs: []v1alpha1.ArgSelector{matchArg(2, "Mask", "1")},
+		}},
+		Calls:    []LseekCall{{-1, 0, 4007}, {-1, 0, 4008}},
+		Expected: []Outcome{posted, notPosted},
+	},
+	{
+		Name: "int InMap",
+		Selectors: []v1alpha1.KProbeSelector{{
+			MatchArgs: []v1alpha1.ArgSelector{matchArg(2, "InMap", "4010:4020", "4030")},
+		}},
+		Calls:    []LseekCall{{-1, 0, 4015}, {-1, 0, 4030}, {-1, 0, 4021}},
+		Expected: []Outcome{posted, posted, notPosted},
+	},
+	{
+		Name: "int NotInMap",
+		Selectors: []v1alpha1.KProbeSelector{{
+			MatchArgs: []v1alpha1.ArgSelector{matchArg(2, "NotInMap", "4040:4050")},
+		}},
+		Calls:    []LseekCall{{-1, 0, 4045}, {-1, 0, 4051}},
+		Expected: []Outcome{notPosted, posted},
+	},
+	{
+		Name: "matchArgs are and-ed",
+		Selectors: []v1alpha1.KProbeSelector{{
+			MatchArgs: []v1alpha1.ArgSelector{
+				matchArg(0, "Equal", "-1"),
+				matchArg(2, "Equal", "4060"),
+			},
+		}},
+		Calls:    []LseekCall{{-1, 0, 4060}, {-2, 0, 4060}, {-1, 0, 4061}},
+		Expected: []Outcome{posted, notPosted, notPosted},
+	},
+	{
+		Name: "first matching selector applies",
+		Selectors: []v1alpha1.KProbeSelector{
+			{
+				MatchArgs:    []v1alpha1.ArgSelector{matchArg(2, "Equal", "4070")},
+				MatchActions: []v1alpha1.ActionSelector{{Action: "NoPost"}},
+			},
+			{
+				MatchArgs: []v1alpha1.ArgSelector{matchArg(2, "GreaterThan", "4069")},
+			},
+		},
+		Calls:    []LseekCall{{-1, 0, 4070}, {-1, 0, 4071}, {-1, 0, 4069}},
+		Expected: []Outcome{notPosted, posted, notPosted},
+	},
+	{
+		Name: "matchBinaries",
+		Selectors: []v1alpha1.KProbeSelector{
+			{
+				MatchBinaries: []v1alpha1.BinarySelector{{Operator: "In", Values: []string{"/nonexistent/binary"}}},
+				MatchArgs:     []v1alpha1.ArgSelector{matchArg(2, "Equal", "4080")},
+			},
+			{
+				MatchBinaries: []v1alpha1.BinarySelector{{Operator: "NotIn", Values: []string{"/nonexistent/binary"}}},
+				MatchArgs:     []v1alpha1.ArgSelector{matchArg(2, "Equal", "4081")},
+			},
+		},
+		Calls:    []LseekCall{{-1, 0, 4080}, {-1, 0, 4081}},
+		Expected: []Outcome{notPosted, posted},
+	},
+	{
+		Name: "override",
+		Selectors: []v1alpha1.KProbeSelector{{
+			MatchArgs:    []v1alpha1.ArgSelector{matchArg(2, "Equal", "4090")},
+			MatchActions: []v1alpha1.ActionSelector{{Action: "Override", ArgError: OverrideError}},
+		}},
+		Calls:    []LseekCall{{-1, 0, 4090}, {-1, 0, 4091}},
+		Expected: []Outcome{overridden, notPosted},
+	},
+}
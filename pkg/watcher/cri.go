@@ -0,0 +1,360 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package watcher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cilium/tetragon/pkg/logger"
+	"github.com/cilium/tetragon/pkg/logger/logfields"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	criapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+)
+
+const (
+	// criRefreshInterval is the minimum interval between two listings of
+	// the pods of a CRIPodAccessor.
+	criRefreshInterval = time.Second
+	// criTimeout is the timeout of a listing of the pods of a
+	// CRIPodAccessor.
+	criTimeout = 5 * time.Second
+	// criUnknownContainerTTL is the interval during which the pods are not
+	// listed again for a container that was not found by a listing.
+	criUnknownContainerTTL = 10 * time.Second
+	// criKubeletLabelPrefix is the prefix of the labels that the kubelet
+	// adds to the pod sandboxes, in addition to the labels of the pods.
+	criKubeletLabelPrefix = "io.kubernetes."
+)
+
+// CRIPodAccessor is a PodAccessor for nodes without access to the Kubernetes
+// API server. It builds the pods from the pod sandboxes and containers of the
+// CRI. If a kubelet pod list URL is set, the pods of the kubelet are used
+// instead for the pods it lists, since they include what the CRI does not
+// have, such as owner references, probes and security contexts.
+//
+// Pods are listed when a container or pod is not found, at most once per
+// criRefreshInterval, and at most once per criUnknownContainerTTL for a
+// container that was not found by the previous listing. The lookups are not
+// blocked by the listings.
+type CRIPodAccessor struct {
+	client     criapi.RuntimeServiceClient
+	runtime    string
+	kubeletURL string
+	httpClient *http.Client
+
+	// refreshMu serializes the listings, which are done without holding mu
+	refreshMu sync.Mutex
+
+	mu          sync.Mutex
+	pods        []interface{}
+	statuses    map[string]*criapi.ContainerStatus
+	lastRefresh time.Time
+	lastListed  time.Time
+	// unknown holds the time at which a listing did not find a container
+	unknown     map[string]time.Time
+	deletedPods *DeletedPodCache
+}
+
+// NewCRIPodAccessor returns a CRIPodAccessor that uses client, and the kubelet
+// pod list at kubeletURL if it is not empty.
+func NewCRIPodAccessor(ctx context.Context, client criapi.RuntimeServiceClient, kubeletURL string) (*CRIPodAccessor, error) {
+	ctx, cancel := context.WithTimeout(ctx, criTimeout)
+	defer cancel()
+	version, err := client.Version(ctx, &criapi.VersionRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get CRI runtime version: %w", err)
+	}
+	deletedPods, err := NewDeletedPodCache()
+	if err != nil {
+		return nil, err
+	}
+	return &CRIPodAccessor{
+		client: client,
+		// container IDs of pods are prefixed by the runtime name, for
+		// example containerd://<id> or cri-o://<id>
+		runtime:     version.RuntimeName,
+		kubeletURL:  kubeletURL,
+		httpClient:  &http.Client{Timeout: criTimeout},
+		statuses:    map[string]*criapi.ContainerStatus{},
+		unknown:     map[string]time.Time{},
+		deletedPods: deletedPods,
+	}, nil
+}
+
+// FindContainer implements PodAccessor.FindContainer
+func (a *CRIPodAccessor) FindContainer(containerID string) (*corev1.Pod, *corev1.ContainerStatus, bool) {
+	if pod, cont, ok := findContainer(containerID, a.getPods()); ok {
+		return pod, cont, ok
+	}
+	if !a.isUnknown(containerID) && a.refresh() {
+		if pod, cont, ok := findContainer(containerID, a.getPods()); ok {
+			return pod, cont, ok
+		}
+		a.mu.Lock()
+		a.unknown[containerID] = time.Now()
+		a.mu.Unlock()
+	}
+	if len(containerID) > containerIDLen {
+		containerID = containerID[:containerIDLen]
+	}
+	return a.deletedPods.FindContainer(containerID)
+}
+
+// FindPod implements PodAccessor.FindPod
+func (a *CRIPodAccessor) FindPod(podID string) (*corev1.Pod, error) {
+	pods := a.getPods()
+	if pod, ok := findPod(podID, pods); ok {
+		return pod, nil
+	}
+	if a.refresh() {
+		pods = a.getPods()
+		if pod, ok := findPod(podID, pods); ok {
+			return pod, nil
+		}
+	}
+	return nil, fmt.Errorf("unable to find pod with ID %s (all pods=%d)", podID, len(pods))
+}
+
+// FindMirrorPod implements PodAccessor.FindMirrorPod. Without the API server,
+// there are no mirror pods, so it returns the static pod itself.
+func (a *CRIPodAccessor) FindMirrorPod(hash string) (*corev1.Pod, error) {
+	if pod, ok := findStaticPod(hash, a.getPods()); ok {
+		return pod, nil
+	}
+	if a.refresh() {
+		if pod, ok := findStaticPod(hash, a.getPods()); ok {
+			return pod, nil
+		}
+	}
+	return nil, fmt.Errorf("static pod (hash=%s) not found", hash)
+}
+
+func findStaticPod(hash string, pods []interface{}) (*corev1.Pod, bool) {
+	for i := range pods {
+		if pod, ok := pods[i].(*corev1.Pod); ok {
+			if pod.Annotations["kubernetes.io/config.hash"] == hash ||
+				pod.Annotations["kubernetes.io/config.mirror"] == hash {
+				return pod, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// getPods returns the pods of the last listing. The returned slice is replaced,
+// not modified, by the next listing.
+func (a *CRIPodAccessor) getPods() []interface{} {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.pods
+}
+
+// isUnknown returns true if a listing did not find the container less than
+// criUnknownContainerTTL ago.
+func (a *CRIPodAccessor) isUnknown(containerID string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	t, ok := a.unknown[containerID]
+	return ok && time.Since(t) < criUnknownContainerTTL
+}
+
+// refresh lists the pods, unless they were listed less than
+// criRefreshInterval ago. It returns true if the pods were listed, by this
+// call or by another one while it waited for it. The containers of the pods
+// that are not listed anymore are kept in the deleted pods cache. a.mu is only
+// held to read and swap the results, not during the listing.
+func (a *CRIPodAccessor) refresh() bool {
+	called := time.Now()
+	a.refreshMu.Lock()
+	defer a.refreshMu.Unlock()
+
+	a.mu.Lock()
+	if a.lastListed.After(called) {
+		a.mu.Unlock()
+		return true
+	}
+	if time.Since(a.lastRefresh) < criRefreshInterval {
+		a.mu.Unlock()
+		return false
+	}
+	a.lastRefresh = time.Now()
+	// only refresh updates statuses, so it can be read without a.mu
+	prevStatuses := a.statuses
+	a.mu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), criTimeout)
+	defer cancel()
+	pods, statuses, err := a.listPods(ctx, prevStatuses)
+	if err != nil {
+		logger.GetLogger().Warn("failed to list pods from the CRI", logfields.Error, err)
+		return false
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	listed := make(map[types.UID]bool, len(pods))
+	for _, obj := range pods {
+		listed[obj.(*corev1.Pod).UID] = true
+	}
+	for _, obj := range a.pods {
+		if pod := obj.(*corev1.Pod); !listed[pod.UID] {
+			a.deletedPods.addPod(pod)
+		}
+	}
+	a.pods = pods
+	a.statuses = statuses
+	a.lastListed = time.Now()
+	for id, t := range a.unknown {
+		if time.Since(t) >= criUnknownContainerTTL {
+			delete(a.unknown, id)
+		}
+	}
+	return true
+}
+
+// listPods returns the pods of the kubelet pod list, if any, and the pods
+// built from the pod sandboxes of the CRI that are not in the kubelet pod
+// list, with the statuses of their containers. prevStatuses are the statuses
+// of the previous listing.
+func (a *CRIPodAccessor) listPods(ctx context.Context, prevStatuses map[string]*criapi.ContainerStatus) ([]interface{}, map[string]*criapi.ContainerStatus, error) {
+	sandboxes, err := a.client.ListPodSandbox(ctx, &criapi.ListPodSandboxRequest{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list pod sandboxes: %w", err)
+	}
+	containers, err := a.client.ListContainers(ctx, &criapi.ListContainersRequest{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	var ret []interface{}
+	kubeletPods := make(map[string]bool)
+	if a.kubeletURL != "" {
+		pods, err := a.listKubeletPods(ctx)
+		if err != nil {
+			// the pods of the CRI are used instead
+			logger.GetLogger().Warn("failed to list pods from the kubelet", logfields.Error, err)
+		}
+		for i := range pods {
+			kubeletPods[string(pods[i].UID)] = true
+			ret = append(ret, &pods[i])
+		}
+	}
+
+	bySandbox := make(map[string][]*criapi.Container)
+	for _, c := range containers.Containers {
+		bySandbox[c.PodSandboxId] = append(bySandbox[c.PodSandboxId], c)
+	}
+	statuses := make(map[string]*criapi.ContainerStatus, len(containers.Containers))
+	for _, sandbox := range sandboxes.Items {
+		md := sandbox.GetMetadata()
+		if md == nil || kubeletPods[md.Uid] {
+			continue
+		}
+		pod := criPod(sandbox)
+		for _, c := range bySandbox[sandbox.Id] {
+			status := a.containerStatus(ctx, prevStatuses, c)
+			if status != nil {
+				statuses[c.Id] = status
+			}
+			pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{
+				Name: c.GetMetadata().GetName(),
+			})
+			pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, a.criContainerStatus(c, status))
+		}
+		ret = append(ret, pod)
+	}
+	return ret, statuses, nil
+}
+
+// containerStatus returns the status of the container c. The status of a
+// container is only retrieved again if its state changed since prevStatuses.
+func (a *CRIPodAccessor) containerStatus(ctx context.Context, prevStatuses map[string]*criapi.ContainerStatus, c *criapi.Container) *criapi.ContainerStatus {
+	if status, ok := prevStatuses[c.Id]; ok && status.State == c.State {
+		return status
+	}
+	res, err := a.client.ContainerStatus(ctx, &criapi.ContainerStatusRequest{ContainerId: c.Id})
+	if err != nil {
+		logger.GetLogger().Debug("failed to get container status from the CRI", "container_id", c.Id, logfields.Error, err)
+		return nil
+	}
+	return res.GetStatus()
+}
+
+func (a *CRIPodAccessor) listKubeletPods(ctx context.Context) ([]corev1.Pod, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, a.kubeletURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := a.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status from %s: %s", a.kubeletURL, res.Status)
+	}
+	var pods corev1.PodList
+	if err := json.NewDecoder(res.Body).Decode(&pods); err != nil {
+		return nil, fmt.Errorf("failed to decode pods from %s: %w", a.kubeletURL, err)
+	}
+	return pods.Items, nil
+}
+
+// criPod returns a pod without containers for the pod sandbox.
+func criPod(sandbox *criapi.PodSandbox) *corev1.Pod {
+	md := sandbox.GetMetadata()
+	var labels map[string]string
+	for k, v := range sandbox.Labels {
+		if strings.HasPrefix(k, criKubeletLabelPrefix) {
+			continue
+		}
+		if labels == nil {
+			labels = make(map[string]string)
+		}
+		labels[k] = v
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              md.Name,
+			Namespace:         md.Namespace,
+			UID:               types.UID(md.Uid),
+			Labels:            labels,
+			Annotations:       sandbox.Annotations,
+			CreationTimestamp: metav1.NewTime(time.Unix(0, sandbox.CreatedAt)),
+		},
+	}
+}
+
+// criContainerStatus returns the status of the container c of a pod. status
+// is nil if the status of the container could not be retrieved.
+func (a *CRIPodAccessor) criContainerStatus(c *criapi.Container, status *criapi.ContainerStatus) corev1.ContainerStatus {
+	ret := corev1.ContainerStatus{
+		Name:        c.GetMetadata().GetName(),
+		ContainerID: a.runtime + "://" + c.Id,
+		Image:       c.GetImage().GetImage(),
+		ImageID:     c.ImageRef,
+	}
+	if status == nil {
+		return ret
+	}
+	if image := status.GetImage().GetUserSpecifiedImage(); image != "" {
+		ret.Image = image
+	} else if image := status.GetImage().GetImage(); image != "" {
+		ret.Image = image
+	}
+	if status.State == criapi.ContainerState_CONTAINER_RUNNING {
+		ret.State.Running = &corev1.ContainerStateRunning{
+			StartedAt: metav1.NewTime(time.Unix(0, status.StartedAt)),
+		}
+	}
+	return ret
+}
@@ -0,0 +1,296 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright Authors of Tetragon
+
+package watcher
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cilium/tetragon/pkg/cri"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	criapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+)
+
+type fakeRuntime struct {
+	criapi.UnimplementedRuntimeServiceServer
+
+	mu         sync.Mutex
+	sandboxes  []*criapi.PodSandbox
+	containers []*criapi.Container
+	statuses   map[string]*criapi.ContainerStatus
+	// block, if set, blocks the listings of the pod sandboxes until it is
+	// closed
+	block chan struct{}
+}
+
+func (f *fakeRuntime) Version(context.Context, *criapi.VersionRequest) (*criapi.VersionResponse, error) {
+	return &criapi.VersionResponse{RuntimeName: "containerd"}, nil
+}
+
+func (f *fakeRuntime) ListPodSandbox(context.Context, *criapi.ListPodSandboxRequest) (*criapi.ListPodSandboxResponse, error) {
+	f.mu.Lock()
+	block := f.block
+	f.mu.Unlock()
+	if block != nil {
+		<-block
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return &criapi.ListPodSandboxResponse{Items: f.sandboxes}, nil
+}
+
+func (f *fakeRuntime) ListContainers(context.Context, *criapi.ListContainersRequest) (*criapi.ListContainersResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return &criapi.ListContainersResponse{Containers: f.containers}, nil
+}
+
+func (f *fakeRuntime) ContainerStatus(_ context.Context, req *criapi.ContainerStatusRequest) (*criapi.ContainerStatusResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return &criapi.ContainerStatusResponse{Status: f.statuses[req.ContainerId]}, nil
+}
+
+// addPod adds a pod sandbox with a running container to the runtime.
+func (f *fakeRuntime) addPod(uid, name, containerID string, startedAt time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sandboxes = append(f.sandboxes, &criapi.PodSandbox{
+		Id: "sandbox-" + uid,
+		Metadata: &criapi.PodSandboxMetadata{
+			Name:      name,
+			Namespace: "default",
+			Uid:       uid,
+		},
+		State: criapi.PodSandboxState_SANDBOX_READY,
+		Labels: map[string]string{
+			"app":                          name,
+			"io.kubernetes.pod.name":       name,
+			"io.kubernetes.pod.namespace":  "default",
+			"io.kubernetes.pod.uid":        uid,
+			"io.kubernetes.container.name": "POD",
+		},
+		Annotations: map[string]string{"note": name},
+	})
+	f.containers = append(f.containers, &criapi.Container{
+		Id:           containerID,
+		PodSandboxId: "sandbox-" + uid,
+		Metadata:     &criapi.ContainerMetadata{Name: "main"},
+		Image:        &criapi.ImageSpec{Image: "sha256:1234"},
+		ImageRef:     "docker.io/library/nginx@sha256:1234",
+		State:        criapi.ContainerState_CONTAINER_RUNNING,
+	})
+	f.statuses[containerID] = &criapi.ContainerStatus{
+		Id:        containerID,
+		Metadata:  &criapi.ContainerMetadata{Name: "main"},
+		State:     criapi.ContainerState_CONTAINER_RUNNING,
+		StartedAt: startedAt.UnixNano(),
+		Image:     &criapi.ImageSpec{Image: "docker.io/library/nginx:latest"},
+		ImageRef:  "docker.io/library/nginx@sha256:1234",
+	}
+}
+
+// removePods removes all pod sandboxes and containers from the runtime.
+func (f *fakeRuntime) removePods() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sandboxes = nil
+	f.containers = nil
+}
+
+// newFakeRuntime starts a fake CRI server on a Unix socket, and returns a
+// client for it.
+func newFakeRuntime(t *testing.T) (*fakeRuntime, criapi.RuntimeServiceClient) {
+	f := &fakeRuntime{statuses: map[string]*criapi.ContainerStatus{}}
+	path := filepath.Join(t.TempDir(), "cri.sock")
+	lis, err := net.Listen("unix", path)
+	require.NoError(t, err)
+	srv := grpc.NewServer()
+	criapi.RegisterRuntimeServiceServer(srv, f)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	client, err := cri.NewClient(context.Background(), "unix://"+path)
+	require.NoError(t, err)
+	return f, client
+}
+
+const (
+	criTestContainerID  = "0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	criTestContainerID2 = "fedcba9876543210fedcba9876543210fedcba9876543210fedcba9876543210"
+)
+
+func TestCRIPodAccessor(t *testing.T) {
+	f, client := newFakeRuntime(t)
+	startedAt := time.Unix(1700000000, 0)
+	f.addPod("uid-1", "nginx", criTestContainerID, startedAt)
+
+	a, err := NewCRIPodAccessor(context.Background(), client, "")
+	require.NoError(t, err)
+
+	pod, cont, ok := a.FindContainer(criTestContainerID[:31])
+	require.True(t, ok)
+	assert.Equal(t, "nginx", pod.Name)
+	assert.Equal(t, "default", pod.Namespace)
+	assert.Equal(t, map[string]string{"app": "nginx"}, pod.Labels)
+	assert.Equal(t, map[string]string{"note": "nginx"}, pod.Annotations)
+	assert.Equal(t, "main", cont.Name)
+	assert.Equal(t, "containerd://"+criTestContainerID, cont.ContainerID)
+	assert.Equal(t, "docker.io/library/nginx:latest", cont.Image)
+	assert.Equal(t, "docker.io/library/nginx@sha256:1234", cont.ImageID)
+	require.NotNil(t, cont.State.Running)
+	assert.True(t, startedAt.Equal(cont.State.Running.StartedAt.Time))
+
+	pod, err = a.FindPod("uid-1")
+	require.NoError(t, err)
+	assert.Equal(t, "nginx", pod.Name)
+
+	// pods are not listed again before criRefreshInterval
+	f.removePods()
+	f.addPod("uid-2", "redis", criTestContainerID2, startedAt)
+	_, _, ok = a.FindContainer(criTestContainerID2)
+	assert.False(t, ok)
+
+	a.mu.Lock()
+	a.lastRefresh = time.Time{}
+	a.mu.Unlock()
+	pod, _, ok = a.FindContainer(criTestContainerID2)
+	require.True(t, ok)
+	assert.Equal(t, "redis", pod.Name)
+
+	// the containers of removed pods are still found
+	pod, _, ok = a.FindContainer(criTestContainerID)
+	require.True(t, ok)
+	assert.Equal(t, "nginx", pod.Name)
+	_, err = a.FindPod("uid-1")
+	assert.Error(t, err)
+}
+
+func TestCRIPodAccessorKubelet(t *testing.T) {
+	f, client := newFakeRuntime(t)
+	startedAt := time.Unix(1700000000, 0)
+	f.addPod("uid-1", "nginx-5d8f7b9c4d-abcde", criTestContainerID, startedAt)
+	f.addPod("uid-2", "redis", criTestContainerID2, startedAt)
+
+	// the kubelet only lists the first pod
+	kubelet := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		controller := true
+		json.NewEncoder(w).Encode(&corev1.PodList{Items: []corev1.Pod{{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:         "nginx-5d8f7b9c4d-abcde",
+				GenerateName: "nginx-5d8f7b9c4d-",
+				Namespace:    "default",
+				UID:          "uid-1",
+				Labels:       map[string]string{"pod-template-hash": "5d8f7b9c4d"},
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: "apps/v1",
+					Kind:       "ReplicaSet",
+					Name:       "nginx-5d8f7b9c4d",
+					Controller: &controller,
+				}},
+			},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{{
+					Name:        "main",
+					ContainerID: "containerd://" + criTestContainerID,
+				}},
+			},
+		}}})
+	}))
+	defer kubelet.Close()
+
+	a, err := NewCRIPodAccessor(context.Background(), client, kubelet.URL+"/pods")
+	require.NoError(t, err)
+
+	pod, _, ok := a.FindContainer(criTestContainerID)
+	require.True(t, ok)
+	require.Len(t, pod.OwnerReferences, 1)
+	assert.Equal(t, "ReplicaSet", pod.OwnerReferences[0].Kind)
+
+	// pods that the kubelet does not list are built from the CRI
+	pod, _, ok = a.FindContainer(criTestContainerID2)
+	require.True(t, ok)
+	assert.Equal(t, "redis", pod.Name)
+	assert.Equal(t, map[string]string{"app": "redis"}, pod.Labels)
+}
+
+func TestCRIPodAccessorUnknownContainer(t *testing.T) {
+	f, client := newFakeRuntime(t)
+	startedAt := time.Unix(1700000000, 0)
+
+	a, err := NewCRIPodAccessor(context.Background(), client, "")
+	require.NoError(t, err)
+
+	_, _, ok := a.FindContainer(criTestContainerID)
+	assert.False(t, ok)
+
+	// pods are not listed again for an unknown container before
+	// criUnknownContainerTTL
+	f.addPod("uid-1", "nginx", criTestContainerID, startedAt)
+	a.mu.Lock()
+	a.lastRefresh = time.Time{}
+	a.mu.Unlock()
+	_, _, ok = a.FindContainer(criTestContainerID)
+	assert.False(t, ok)
+
+	a.mu.Lock()
+	a.unknown[criTestContainerID] = time.Now().Add(-criUnknownContainerTTL)
+	a.mu.Unlock()
+	pod, _, ok := a.FindContainer(criTestContainerID)
+	require.True(t, ok)
+	assert.Equal(t, "nginx", pod.Name)
+	a.mu.Lock()
+	assert.Empty(t, a.unknown)
+	a.mu.Unlock()
+}
+
+func TestCRIPodAccessorListingNotBlocking(t *testing.T) {
+	f, client := newFakeRuntime(t)
+	startedAt := time.Unix(1700000000, 0)
+	f.addPod("uid-1", "nginx", criTestContainerID, startedAt)
+
+	a, err := NewCRIPodAccessor(context.Background(), client, "")
+	require.NoError(t, err)
+	_, _, ok := a.FindContainer(criTestContainerID)
+	require.True(t, ok)
+
+	block := make(chan struct{})
+	f.mu.Lock()
+	f.block = block
+	f.mu.Unlock()
+	a.mu.Lock()
+	a.lastRefresh = time.Time{}
+	a.mu.Unlock()
+
+	done := make(chan bool)
+	go func() {
+		_, _, ok := a.FindContainer(criTestContainerID2)
+		done <- ok
+	}()
+
+	// known pods are found while the pods are listed
+	require.Eventually(t, func() bool {
+		a.mu.Lock()
+		defer a.mu.Unlock()
+		return !a.lastRefresh.IsZero()
+	}, 5*time.Second, 10*time.Millisecond)
+	pod, err := a.FindPod("uid-1")
+	require.NoError(t, err)
+	assert.Equal(t, "nginx", pod.Name)
+
+	f.addPod("uid-2", "redis", criTestContainerID2, startedAt)
+	close(block)
+	assert.True(t, <-done)
+}
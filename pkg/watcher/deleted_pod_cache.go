@@ -47,32 +47,37 @@ func (c *DeletedPodCache) EventHandler() cache.ResourceEventHandler {
 				return
 			}
 
-			run := func(s []corev1.ContainerStatus) {
-				for i := range s {
-					contStatus := &s[i]
-					contID := contStatus.ContainerID
-					if contID == "" {
-						continue
-					}
+			c.addPod(pod)
+		},
+	}
+}
 
-					key, err := ContainerIDKey(contID)
-					if err != nil {
-						logger.GetLogger().Warn("failed to crate container key for id '%s': %w", contID, err)
-						continue
-					}
+// addPod adds the containers of a deleted pod to the cache.
+func (c *DeletedPodCache) addPod(pod *corev1.Pod) {
+	run := func(s []corev1.ContainerStatus) {
+		for i := range s {
+			contStatus := &s[i]
+			contID := contStatus.ContainerID
+			if contID == "" {
+				continue
+			}
 
-					c.Add(key, deletedPodCacheEntry{
-						pod:        pod,
-						contStatus: contStatus,
-					})
-				}
+			key, err := ContainerIDKey(contID)
+			if err != nil {
+				logger.GetLogger().Warn("failed to crate container key for id '%s': %w", contID, err)
+				continue
 			}
 
-			run(pod.Status.InitContainerStatuses)
-			run(pod.Status.ContainerStatuses)
-			run(pod.Status.EphemeralContainerStatuses)
-		},
+			c.Add(key, deletedPodCacheEntry{
+				pod:        pod,
+				contStatus: contStatus,
+			})
+		}
 	}
+
+	run(pod.Status.InitContainerStatuses)
+	run(pod.Status.ContainerStatuses)
+	run(pod.Status.EphemeralContainerStatuses)
 }
 
 func (c *DeletedPodCache) FindContainer(containerID string) (*corev1.Pod, *corev1.ContainerStatus, bool) {